@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"pingspot/internal/config"
+	notificationUtil "pingspot/internal/domain/notification_service/util"
 	"pingspot/internal/infrastructure/cache"
 	"pingspot/internal/infrastructure/database"
 	"pingspot/internal/migration"
@@ -28,6 +29,10 @@ func main() {
 		panic(fmt.Sprintf("failed to initialize logger: %v", err))
 	}
 
+	if err := notificationUtil.CheckDigestUnsubscribeSecret(env.DigestUnsubscribeSecret()); err != nil {
+		logger.Warn("Notification digests will not be sent and unsubscribe links are rejected", zap.Error(err))
+	}
+
 	postgresConfig := config.LoadPostgresConfig()
 	if err := database.InitPostgres(postgresConfig); err != nil {
 		logger.Error("Failed to initialize PostgreSQL", zap.Error(err))
@@ -91,6 +96,15 @@ func main() {
 		}
 	}()
 
+	schedulerServer := asynqWorker.NewSchedulerServer(redisConfig)
+
+	go func() {
+		logger.Info("Starting Asynq scheduler")
+		if err := schedulerServer.Run(); err != nil {
+			logger.Error("Asynq scheduler error", zap.Error(err))
+		}
+	}()
+
 	server := server.New()
 
 	port, _ := strconv.Atoi(env.Port())
@@ -25,4 +25,19 @@ type ForgotPasswordResetPasswordRequest struct {
 	Password string `json:"password" validate:"required,min=6"`
 	PasswordConfirmation string `json:"passwordConfirmation" validate:"required,eqfield=Password"`
 	Email string `json:"email" validate:"required,email"`
-}
\ No newline at end of file
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+	IPAddress      string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+type EnableTwoFactorRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "pingspot/internal/model"
+
 type LoginResponse struct {
 	AccessToken  string `json:"accessToken"`
 	ExpiresIn    int64  `json:"expiresIn"`
@@ -19,3 +21,27 @@ type VerificationResponse struct {
 type ForgotPasswordLinkVerificationResponse struct {
 	Email string `json:"email"`
 }
+
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+	ExpiresIn         int64  `json:"expiresIn"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauthUri"`
+}
+
+type TwoFactorEnableResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// LoginResult carries either a new session or, when the user has two-factor
+// authentication enabled, the challenge token for the second login step.
+type LoginResult struct {
+	User           *model.User
+	AccessToken    string
+	RefreshToken   string
+	ChallengeToken string
+}
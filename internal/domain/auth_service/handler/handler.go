@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"pingspot/internal/domain/auth_service/dto"
 	"pingspot/internal/domain/auth_service/service"
+	"pingspot/internal/domain/auth_service/util"
 	"pingspot/internal/domain/auth_service/validation"
 	apperror "pingspot/pkg/app_error"
 	"pingspot/pkg/logger"
@@ -133,7 +135,7 @@ func (h *AuthHandler) LoginHandler(c *fiber.Ctx) error {
 	req.IPAddress = userIP
 	req.UserAgent = userAgent
 
-	_, accessToken, refreshToken, err := h.authService.Login(ctx, req)
+	result, err := h.authService.Login(ctx, req)
 	if err != nil {
 		logger.Error("Login failed", zap.Error(err))
 		if appErr, ok := err.(*apperror.AppError); ok {
@@ -142,6 +144,22 @@ func (h *AuthHandler) LoginHandler(c *fiber.Ctx) error {
 		return response.ResponseError(c, 401, "Login gagal", "", err.Error())
 	}
 
+	if result.ChallengeToken != "" {
+		return response.ResponseSuccess(c, 200, "Verifikasi dua langkah diperlukan", "data", dto.TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    result.ChallengeToken,
+			ExpiresIn:         int64(util.TwoFactorChallengeTTL.Seconds()),
+		})
+	}
+
+	setAuthCookies(c, result.AccessToken, result.RefreshToken)
+	return response.ResponseSuccess(c, 200, "Login berhasil", "data", dto.LoginResponse{
+		AccessToken: result.AccessToken,
+		ExpiresIn:   int64(getAccessTokenAge()),
+	})
+}
+
+func setAuthCookies(c *fiber.Ctx, accessToken, refreshToken string) {
 	c.Cookie(&fiber.Cookie{
 		Name:     "access_token",
 		Value:    accessToken,
@@ -163,13 +181,125 @@ func (h *AuthHandler) LoginHandler(c *fiber.Ctx) error {
 		Path:     "/",
 		MaxAge:   getRefreshTokenAge(),
 	})
+}
+
+func (h *AuthHandler) TwoFactorLoginHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req dto.TwoFactorLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatTwoFactorValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	req.IPAddress = mainutils.GetClientIP(c)
+	req.UserAgent = mainutils.GetUserAgent(c)
 
+	result, err := h.authService.VerifyTwoFactorLogin(ctx, req)
+	if err != nil {
+		logger.Error("Two-factor login failed", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 401, "Login gagal", "", err.Error())
+	}
+
+	setAuthCookies(c, result.AccessToken, result.RefreshToken)
 	return response.ResponseSuccess(c, 200, "Login berhasil", "data", dto.LoginResponse{
-		AccessToken: accessToken,
+		AccessToken: result.AccessToken,
 		ExpiresIn:   int64(getAccessTokenAge()),
 	})
 }
 
+func (h *AuthHandler) SetupTwoFactorHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	result, err := h.authService.SetupTwoFactor(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to set up two-factor authentication", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menyiapkan autentikasi dua langkah", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Pindai kode QR lalu masukkan kode verifikasi", "data", result)
+}
+
+func (h *AuthHandler) EnableTwoFactorHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req dto.EnableTwoFactorRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatTwoFactorValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+	sessionID := uint(claims["session_id"].(float64))
+
+	result, err := h.authService.EnableTwoFactor(ctx, userID, sessionID, req)
+	if err != nil {
+		logger.Error("Failed to enable two-factor authentication", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mengaktifkan autentikasi dua langkah", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Autentikasi dua langkah berhasil diaktifkan, simpan kode pemulihan Anda", "data", result)
+}
+
+func (h *AuthHandler) DisableTwoFactorHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req dto.DisableTwoFactorRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatTwoFactorValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	if err := h.authService.DisableTwoFactor(ctx, userID, req); err != nil {
+		logger.Error("Failed to disable two-factor authentication", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menonaktifkan autentikasi dua langkah", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Autentikasi dua langkah berhasil dinonaktifkan", "data", nil)
+}
+
 func (h *AuthHandler) OAuthLoginHandler(provider string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Info("OAUTH LOGIN HANDLER", zap.String("provider", provider))
@@ -205,7 +335,7 @@ func (h *AuthHandler) OAuthCallbackHandler(provider string) http.HandlerFunc {
 		userAgent := mainutils.GetHTTPUserAgent(r)
 
 		ctx := r.Context()
-		accessToken, refreshToken, err := h.authService.HandleOAuthCallback(ctx, email, fullName, nickName, user.Name, providerId, provider, userIP, userAgent)
+		result, err := h.authService.HandleOAuthCallback(ctx, email, fullName, nickName, user.Name, providerId, provider, userIP, userAgent)
 		if err != nil {
 			logger.Error("OAuth callback handler failed", zap.String("provider", provider), zap.Error(err))
 			if appErr, ok := err.(*apperror.AppError); ok {
@@ -216,9 +346,16 @@ func (h *AuthHandler) OAuthCallbackHandler(provider string) http.HandlerFunc {
 			return
 		}
 
+		if result.ChallengeToken != "" {
+			query := url.Values{}
+			query.Set("challengeToken", result.ChallengeToken)
+			http.Redirect(w, r, fmt.Sprintf("%s/auth/two-factor?%s", env.ClientURL(), query.Encode()), http.StatusFound)
+			return
+		}
+
 		http.SetCookie(w, &http.Cookie{
 			Name:     "access_token",
-			Value:    accessToken,
+			Value:    result.AccessToken,
 			HttpOnly: true,
 			Secure:   true,
 			SameSite: http.SameSiteLaxMode,
@@ -228,7 +365,7 @@ func (h *AuthHandler) OAuthCallbackHandler(provider string) http.HandlerFunc {
 
 		http.SetCookie(w, &http.Cookie{
 			Name:     "refresh_token",
-			Value:    refreshToken,
+			Value:    result.RefreshToken,
 			HttpOnly: true,
 			Secure:   true,
 			SameSite: http.SameSiteLaxMode,
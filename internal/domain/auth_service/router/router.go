@@ -1,6 +1,7 @@
 package router
 
 import (
+	"fmt"
 	"pingspot/internal/domain/auth_service/handler"
 	"pingspot/internal/domain/auth_service/service"
 	userRepository "pingspot/internal/domain/user_service/repository"
@@ -8,10 +9,13 @@ import (
 	"pingspot/internal/infrastructure/database"
 	"pingspot/internal/middleware"
 	cacheRepository "pingspot/internal/repository"
+	taskService "pingspot/internal/domain/task_service/service"
+	env "pingspot/pkg/utils/env_util"
 	"time"
 
 	"github.com/gofiber/adaptor/v2"
 	"github.com/gofiber/fiber/v2"
+	"github.com/hibiken/asynq"
 )
 
 func RegisterAuthRoutes(app *fiber.App) {
@@ -21,8 +25,11 @@ func RegisterAuthRoutes(app *fiber.App) {
 	userRepo := userRepository.NewUserRepository(db)
 	userProfileRepo := userRepository.NewUserProfileRepository(db)
 	userSessionRepo := userRepository.NewUserSessionRepository(db)
+	userRecoveryCodeRepo := userRepository.NewUserRecoveryCodeRepository(db)
 	cacheRepo := cacheRepository.NewCacheRepository(&rdb)
-	authService := service.NewAuthService(db, userRepo, userProfileRepo, userSessionRepo, cacheRepo)
+	redisAddress := fmt.Sprintf("%s:%s", env.RedisHost(), env.RedisPort())
+	tasksService := taskService.NewTaskService(asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddress}))
+	authService := service.NewAuthService(db, userRepo, userProfileRepo, userSessionRepo, cacheRepo, tasksService, userRecoveryCodeRepo)
 	authHandler := handler.NewAuthHandler(authService)
 
 	authRoute := app.Group("/pingspot/api/auth")
@@ -57,6 +64,48 @@ func RegisterAuthRoutes(app *fiber.App) {
 	authHandler.LoginHandler,
 	)
 
+	authRoute.Post("/login/two-factor",
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      10 * time.Minute,
+		MaxRequests: 10,
+		KeyPrefix: "login_two_factor",
+	})),
+	authHandler.TwoFactorLoginHandler,
+	)
+
+	twoFactorRoute := authRoute.Group("/two-factor", middleware.ValidateAccessToken())
+
+	twoFactorRoute.Post("/setup",
+	middleware.TimeoutMiddleware(5*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      10 * time.Minute,
+		MaxRequests: 6,
+		KeyPrefix: "two_factor_setup",
+	})),
+	authHandler.SetupTwoFactorHandler,
+	)
+
+	twoFactorRoute.Post("/enable",
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      10 * time.Minute,
+		MaxRequests: 10,
+		KeyPrefix: "two_factor_enable",
+	})),
+	authHandler.EnableTwoFactorHandler,
+	)
+
+	twoFactorRoute.Post("/disable",
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      10 * time.Minute,
+		MaxRequests: 6,
+		KeyPrefix: "two_factor_disable",
+	})),
+	authHandler.DisableTwoFactorHandler,
+	)
+
 	authRoute.Post("/logout", 
 	middleware.ValidateAccessToken(),
 	middleware.TimeoutMiddleware(5*time.Second),
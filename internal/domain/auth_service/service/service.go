@@ -7,14 +7,19 @@ import (
 	"fmt"
 	"pingspot/internal/domain/auth_service/dto"
 	"pingspot/internal/domain/auth_service/util"
+	tasksService "pingspot/internal/domain/task_service/service"
 	userRepo "pingspot/internal/domain/user_service/repository"
 	"pingspot/internal/model"
 	cacheRepo "pingspot/internal/repository"
 	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/i18n"
 	"pingspot/pkg/logger"
+	"pingspot/pkg/mailer"
 	contextutils "pingspot/pkg/utils/context_util"
 	env "pingspot/pkg/utils/env_util"
+	mainutils "pingspot/pkg/utils/main_util"
 	tokenutils "pingspot/pkg/utils/token_util"
+	totputils "pingspot/pkg/utils/totp_util"
 	"strconv"
 	"strings"
 	"time"
@@ -39,6 +44,8 @@ type AuthService struct {
 	userSessionRepo userRepo.UserSessionRepository
 	userProfileRepo userRepo.UserProfileRepository
 	cacheRepo       cacheRepo.CacheRepository
+	tasksService    tasksService.TaskService
+	userRecoveryCodeRepo userRepo.UserRecoveryCodeRepository
 }
 
 func NewAuthService(
@@ -47,6 +54,8 @@ func NewAuthService(
 	userProfileRepo userRepo.UserProfileRepository,
 	userSessionRepo userRepo.UserSessionRepository,
 	cacheRepo cacheRepo.CacheRepository,
+	tasksService tasksService.TaskService,
+	userRecoveryCodeRepo userRepo.UserRecoveryCodeRepository,
 ) *AuthService {
 	return &AuthService{
 		db:              db,
@@ -54,9 +63,19 @@ func NewAuthService(
 		userProfileRepo: userProfileRepo,
 		userSessionRepo: userSessionRepo,
 		cacheRepo:       cacheRepo,
+		tasksService:    tasksService,
+		userRecoveryCodeRepo: userRecoveryCodeRepo,
 	}
 }
 
+func (s *AuthService) queueVerificationEmail(ctx context.Context, user *model.User, verificationLink, code string) error {
+	message, err := util.BuildVerificationEmail(user.Email, user.Username, verificationLink, mailer.NewIdempotencyKey("verification", user.ID, code), i18n.Preferred(user.Language, contextutils.GetLanguage(ctx)))
+	if err != nil {
+		return err
+	}
+	return s.tasksService.SendEmailTask(message)
+}
+
 func (s *AuthService) Register(ctx context.Context, req dto.RegisterRequest, isVerified bool) (*model.User, error) {
 	requestID := contextutils.GetRequestID(ctx)
 	logger.Info("Registering new user",
@@ -103,6 +122,7 @@ func (s *AuthService) Register(ctx context.Context, req dto.RegisterRequest, isV
 		Provider:   model.Provider(req.Provider),
 		ProviderID: req.ProviderID,
 		IsVerified: isVerified,
+		Language:   mainutils.StrPtrOrNil(string(contextutils.GetLanguage(ctx))),
 	}
 
 	createdUser, err := s.userRepo.CreateTX(ctx, tx, &user)
@@ -137,7 +157,7 @@ func (s *AuthService) Register(ctx context.Context, req dto.RegisterRequest, isV
 	return createdUser, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.User, string, string, error) {
+func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResult, error) {
 	requestID := contextutils.GetRequestID(ctx)
 	logger.Info("User login attempt",
 		zap.String("request_id", requestID),
@@ -146,12 +166,12 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 
 	user, err := s.userRepo.GetByEmailOrUsername(ctx, req.EmailOrUsername)
 	if err != nil {
-		return nil, "", "", apperror.New(401, "INVALID_CREDENTIALS", "Email atau password salah", err.Error(), nil)
+		return nil, apperror.New(401, "INVALID_CREDENTIALS", "Email atau password salah", err.Error(), nil)
 	}
 
 	if model.Provider(req.Provider) == model.ProviderEmail {
 		if !tokenutils.CheckHashString(req.Password, *user.Password) {
-			return nil, "", "", apperror.New(401, "INVALID_CREDENTIALS", "Email atau password salah", "", nil)
+			return nil, apperror.New(401, "INVALID_CREDENTIALS", "Email atau password salah", "", nil)
 		}
 	}
 
@@ -162,7 +182,7 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 				zap.String("request_id", requestID),
 				zap.Error(err),
 			)
-			return nil, "", "", apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode acak", err.Error(), nil)
+			return nil, apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode acak", err.Error(), nil)
 		}
 		randomCode2, err := tokenutils.GenerateRandomCode(150)
 		if err != nil {
@@ -170,7 +190,7 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 				zap.String("request_id", requestID),
 				zap.Error(err),
 			)
-			return nil, "", "", apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode acak", err.Error(), nil)
+			return nil, apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode acak", err.Error(), nil)
 		}
 		verificationLink := fmt.Sprintf("%s/auth/verify-account/%s/%d/%s", env.ClientURL(), randomCode1, user.ID, randomCode2)
 
@@ -184,7 +204,7 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 				zap.String("request_id", requestID),
 				zap.Error(err),
 			)
-			return nil, "", "", apperror.New(500, "VERIFICATION_CODE_SAVE_FAILED", "Gagal menyimpan kode verifikasi", err.Error(), nil)
+			return nil, apperror.New(500, "VERIFICATION_CODE_SAVE_FAILED", "Gagal menyimpan kode verifikasi", err.Error(), nil)
 		}
 		redisKey := fmt.Sprintf("link:%d", user.ID)
 		err = s.cacheRepo.Set(context.Background(), redisKey, linkJSON, 5*time.Minute)
@@ -193,12 +213,55 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 				zap.String("request_id", requestID),
 				zap.Error(err),
 			)
-			return nil, "", "", apperror.New(500, "VERIFICATION_CODE_REDIS_FAILED", "Gagal menyimpan kode verifikasi ke Redis", err.Error(), nil)
+			return nil, apperror.New(500, "VERIFICATION_CODE_REDIS_FAILED", "Gagal menyimpan kode verifikasi ke Redis", err.Error(), nil)
+		}
+		if err := s.queueVerificationEmail(ctx, user, verificationLink, randomCode1); err != nil {
+			logger.Error("Failed to queue verification email",
+				zap.String("request_id", requestID),
+				zap.Error(err),
+			)
 		}
-		go util.SendVerificationEmail(user.Email, user.Username, verificationLink)
-		return nil, "", "", apperror.New(403, "ACCOUNT_NOT_VERIFIED", "Akun belum diverifikasi, silakan cek email untuk verifikasi", "", nil)
+		return nil, apperror.New(403, "ACCOUNT_NOT_VERIFIED", "Akun belum diverifikasi, silakan cek email untuk verifikasi", "", nil)
 	}
 
+	if user.TwoFactorEnabled {
+		if err := s.ensureTwoFactorNotLocked(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		challengeToken, err := s.createTwoFactorChallenge(ctx, user.ID)
+		if err != nil {
+			logger.Error("Failed to create two-factor challenge",
+				zap.String("request_id", requestID),
+				zap.Uint("user_id", user.ID),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+		logger.Info("Two-factor challenge issued",
+			zap.String("request_id", requestID),
+			zap.Uint("user_id", user.ID),
+		)
+		return &dto.LoginResult{User: user, ChallengeToken: challengeToken}, nil
+	}
+
+	accessToken, refreshToken, err := s.createSession(ctx, user, req.IPAddress, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("User logged in successfully",
+		zap.String("request_id", requestID),
+		zap.Uint("user_id", user.ID),
+		zap.String("email", user.Email),
+	)
+
+	return &dto.LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// createSession stores a new refresh token session for user and returns the
+// access and refresh tokens for it.
+func (s *AuthService) createSession(ctx context.Context, user *model.User, ipAddress, userAgent string) (string, string, error) {
+	requestID := contextutils.GetRequestID(ctx)
 	refreshTokenID := uuid.New().String()
 	refreshToken := tokenutils.GenerateRefreshToken(user.ID, refreshTokenID)
 	hashedRefreshToken := tokenutils.HashSHA256String(refreshToken)
@@ -210,8 +273,8 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 		IsActive:           true,
 		RefreshTokenID:     refreshTokenID,
 		HashedRefreshToken: hashedRefreshToken,
-		IPAddress:          req.IPAddress,
-		UserAgent:          req.UserAgent,
+		IPAddress:          ipAddress,
+		UserAgent:          userAgent,
 	})
 	if err != nil {
 		tx.Rollback()
@@ -220,7 +283,7 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 			zap.Uint("user_id", user.ID),
 			zap.Error(err),
 		)
-		return nil, "", "", apperror.New(500, "USER_SESSION_CREATE_FAILED", "Gagal membuat sesi user", err.Error(), nil)
+		return "", "", apperror.New(500, "USER_SESSION_CREATE_FAILED", "Gagal membuat sesi user", err.Error(), nil)
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -228,7 +291,7 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 			zap.String("request_id", requestID),
 			zap.Error(err),
 		)
-		return nil, "", "", apperror.New(500, "USER_SESSION_COMMIT_FAILED", "Gagal menyimpan sesi user", err.Error(), nil)
+		return "", "", apperror.New(500, "USER_SESSION_COMMIT_FAILED", "Gagal menyimpan sesi user", err.Error(), nil)
 	}
 
 	refreshKey := fmt.Sprintf("refresh_token:%s", refreshTokenID)
@@ -238,7 +301,7 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 			zap.String("request_id", requestID),
 			zap.Error(err),
 		)
-		return nil, "", "", apperror.New(500, "REFRESH_TOKEN_SAVE_FAILED", "Gagal menyimpan refresh token", err.Error(), nil)
+		return "", "", apperror.New(500, "REFRESH_TOKEN_SAVE_FAILED", "Gagal menyimpan refresh token", err.Error(), nil)
 	}
 
 	userSessionKey := fmt.Sprintf("user_session:%d", user.ID)
@@ -248,7 +311,7 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 			zap.String("request_id", requestID),
 			zap.Error(err),
 		)
-		return nil, "", "", apperror.New(500, "USER_SESSION_SAVE_FAILED", "Gagal menyimpan sesi user", err.Error(), nil)
+		return "", "", apperror.New(500, "USER_SESSION_SAVE_FAILED", "Gagal menyimpan sesi user", err.Error(), nil)
 	}
 
 	sessionKey := fmt.Sprintf("session:%d", userSession.ID)
@@ -258,18 +321,12 @@ func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*model.U
 			zap.String("request_id", requestID),
 			zap.Error(err),
 		)
-		return nil, "", "", apperror.New(500, "SESSION_SAVE_FAILED", "Gagal menyimpan data sesi", err.Error(), nil)
+		return "", "", apperror.New(500, "SESSION_SAVE_FAILED", "Gagal menyimpan data sesi", err.Error(), nil)
 	}
 
-	accessToken := tokenutils.GenerateAccessToken(user.ID, userSession.ID, user.Email, user.Username, user.FullName)
+	accessToken := tokenutils.GenerateAccessToken(user.ID, userSession.ID, user.Email, user.Username, user.FullName, string(i18n.Preferred(user.Language, "")))
 
-	logger.Info("User logged in successfully",
-		zap.String("request_id", requestID),
-		zap.Uint("user_id", user.ID),
-		zap.String("email", user.Email),
-	)
-
-	return user, accessToken, refreshToken, nil
+	return accessToken, refreshToken, nil
 }
 
 func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
@@ -390,6 +447,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (st
 		user.Email,
 		user.Username,
 		user.FullName,
+		string(i18n.Preferred(user.Language, "")),
 	)
 
 	return accessToken, newRefreshToken, nil
@@ -566,7 +624,17 @@ func (s *AuthService) ForgotPasswordEmailVerification(ctx context.Context, req d
 		}
 
 		verificationLink := fmt.Sprintf("%s/auth/forgot-password/verification?code=%s&email=%s", env.ClientURL(), verificationCode, req.Email)
-		go util.SendPasswordResetEmail(req.Email, req.Email, verificationLink)
+		message, err := util.BuildPasswordResetEmail(req.Email, req.Email, verificationLink, mailer.NewIdempotencyKey("password_reset", req.Email, verificationCode), i18n.Preferred(user.Language, contextutils.GetLanguage(ctx)))
+		if err == nil {
+			err = s.tasksService.SendEmailTask(message)
+		}
+		if err != nil {
+			logger.Error("Failed to queue password reset email", zap.Error(err))
+			if delErr := s.cacheRepo.Del(ctx, redisKey); delErr != nil {
+				logger.Error("Failed to clear password reset code", zap.Error(delErr))
+			}
+			return apperror.New(500, "EMAIL_QUEUE_FAILED", "Gagal mengirim email", err.Error(), nil)
+		}
 		return nil
 	}
 	return apperror.New(404, "USER_NOT_FOUND", "User tidak ditemukan", "", nil)
@@ -603,7 +671,10 @@ func (s *AuthService) SendRegistrationVerificationEmail(ctx context.Context, use
 		return apperror.New(500, "REDIS_SAVE_FAILED", "Gagal menyimpan kode verifikasi ke Redis", err.Error(), nil)
 	}
 
-	go util.SendVerificationEmail(user.Email, user.Username, verificationLink)
+	if err := s.queueVerificationEmail(ctx, user, verificationLink, randomCode1); err != nil {
+		logger.Error("Failed to queue verification email", zap.Error(err))
+		return apperror.New(500, "EMAIL_QUEUE_FAILED", "Gagal mengirim email", err.Error(), nil)
+	}
 
 	return nil
 }
@@ -679,7 +750,7 @@ func (s *AuthService) ResetPassword(ctx context.Context, email, newPassword stri
 	return nil
 }
 
-func (s *AuthService) HandleOAuthCallback(ctx context.Context, oauthEmail, oauthFullName, oauthGivenName, oauthName, oauthProviderID, provider, userIP, userAgent string) (string, string, error) {
+func (s *AuthService) HandleOAuthCallback(ctx context.Context, oauthEmail, oauthFullName, oauthGivenName, oauthName, oauthProviderID, provider, userIP, userAgent string) (*dto.LoginResult, error) {
 	requestID := contextutils.GetRequestID(ctx)
 	logger.Info("Processing OAuth callback",
 		zap.String("request_id", requestID),
@@ -690,13 +761,13 @@ func (s *AuthService) HandleOAuthCallback(ctx context.Context, oauthEmail, oauth
 	existingUser, err := s.GetUserByEmail(ctx, oauthEmail)
 	if err != nil {
 		logger.Error("Error retrieving user by email", zap.String("request_id", requestID), zap.Error(err))
-		return "", "", apperror.New(500, "USER_FETCH_FAILED", "Gagal mengambil data pengguna", err.Error(), nil)
+		return nil, apperror.New(500, "USER_FETCH_FAILED", "Gagal mengambil data pengguna", err.Error(), nil)
 	}
 
 	randomCode, err := tokenutils.GenerateRandomCode(5)
 	if err != nil {
 		logger.Error("Error generating random code", zap.String("request_id", requestID), zap.Error(err))
-		return "", "", apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode acak", err.Error(), nil)
+		return nil, apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode acak", err.Error(), nil)
 	}
 
 	if existingUser == nil {
@@ -715,7 +786,7 @@ func (s *AuthService) HandleOAuthCallback(ctx context.Context, oauthEmail, oauth
 		createdUser, err := s.Register(ctx, newUser, true)
 		if err != nil {
 			logger.Error("Error registering new user from OAuth", zap.String("request_id", requestID), zap.String("provider", provider), zap.Error(err))
-			return "", "", apperror.New(500, "USER_REGISTRATION_FAILED", "Gagal mendaftarkan pengguna baru", err.Error(), nil)
+			return nil, apperror.New(500, "USER_REGISTRATION_FAILED", "Gagal mendaftarkan pengguna baru", err.Error(), nil)
 		}
 		logger.Info("New user registered via OAuth", zap.String("request_id", requestID), zap.String("provider", provider), zap.Uint("user_id", createdUser.ID))
 		existingUser = createdUser
@@ -729,13 +800,387 @@ func (s *AuthService) HandleOAuthCallback(ctx context.Context, oauthEmail, oauth
 		Provider:            strings.ToUpper(provider),
 	}
 
-	_, accessToken, refreshToken, err := s.Login(ctx, loginReq)
+	result, err := s.Login(ctx, loginReq)
 	if err != nil {
 		logger.Error("Login failed for OAuth user", zap.String("request_id", requestID), zap.String("provider", provider), zap.Error(err))
-		return "", "", apperror.New(500, "LOGIN_FAILED", "Gagal masuk dengan akun OAuth", err.Error(), nil)
+		return nil, apperror.New(500, "LOGIN_FAILED", "Gagal masuk dengan akun OAuth", err.Error(), nil)
 	}
 
 	logger.Info("OAuth user logged in successfully", zap.String("request_id", requestID), zap.String("provider", provider), zap.Uint("user_id", existingUser.ID))
 
-	return accessToken, refreshToken, nil
+	return result, nil
+}
+
+type twoFactorChallenge struct {
+	UserID uint `json:"userId"`
+}
+
+func (s *AuthService) getTwoFactorUser(ctx context.Context, userID uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "USER_NOT_FOUND", "User tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "USER_FETCH_FAILED", "Gagal mengambil data pengguna", err.Error(), nil)
+	}
+	return user, nil
+}
+
+// SetupTwoFactor starts enrollment with a fresh secret. The secret is only kept
+// in Redis until EnableTwoFactor confirms the user's authenticator produces
+// valid codes for it.
+func (s *AuthService) SetupTwoFactor(ctx context.Context, userID uint) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.getTwoFactorUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, apperror.New(409, "TWO_FACTOR_ALREADY_ENABLED", "Autentikasi dua langkah sudah aktif", "", nil)
+	}
+	if user.Password == nil {
+		return nil, apperror.New(400, "PASSWORD_NOT_SET", "Atur password terlebih dahulu sebelum mengaktifkan autentikasi dua langkah", "", nil)
+	}
+
+	secret, err := totputils.GenerateSecret()
+	if err != nil {
+		logger.Error("Failed to generate two-factor secret", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "TWO_FACTOR_SETUP_FAILED", "Gagal menyiapkan autentikasi dua langkah", err.Error(), nil)
+	}
+	if err := s.cacheRepo.Set(ctx, util.GetTwoFactorSetupKey(userID), secret, util.TwoFactorSetupTTL); err != nil {
+		logger.Error("Failed to save two-factor setup secret", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "TWO_FACTOR_SETUP_FAILED", "Gagal menyiapkan autentikasi dua langkah", err.Error(), nil)
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OtpauthURI: totputils.BuildURI(util.TwoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor verifies the first code from the authenticator and turns
+// two-factor authentication on. The recovery codes are returned only here;
+// just their hashes are stored. Every other session of the user is ended, so
+// a session opened with only the password does not outlive the change.
+func (s *AuthService) EnableTwoFactor(ctx context.Context, userID, currentSessionID uint, req dto.EnableTwoFactorRequest) (*dto.TwoFactorEnableResponse, error) {
+	user, err := s.getTwoFactorUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, apperror.New(409, "TWO_FACTOR_ALREADY_ENABLED", "Autentikasi dua langkah sudah aktif", "", nil)
+	}
+
+	setupKey := util.GetTwoFactorSetupKey(userID)
+	secret, err := s.cacheRepo.Get(ctx, setupKey)
+	if err != nil || secret == "" {
+		return nil, apperror.New(400, "TWO_FACTOR_SETUP_EXPIRED", "Sesi aktivasi autentikasi dua langkah kedaluwarsa, silakan mulai ulang", "", nil)
+	}
+	if _, ok := totputils.ValidateCode(secret, req.Code, time.Now()); !ok {
+		return nil, apperror.New(400, "INVALID_TWO_FACTOR_CODE", "Kode verifikasi tidak valid", "", nil)
+	}
+
+	recoveryCodes, err := util.GenerateRecoveryCodes(util.RecoveryCodeCount)
+	if err != nil {
+		logger.Error("Failed to generate recovery codes", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode pemulihan", err.Error(), nil)
+	}
+	hashedCodes := make([]model.UserRecoveryCode, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hash, err := tokenutils.HashString(code)
+		if err != nil {
+			logger.Error("Failed to hash recovery code", zap.Uint("user_id", userID), zap.Error(err))
+			return nil, apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode pemulihan", err.Error(), nil)
+		}
+		hashedCodes = append(hashedCodes, model.UserRecoveryCode{UserID: userID, CodeHash: hash})
+	}
+
+	activeSessions, err := s.userSessionRepo.GetActiveByUserID(ctx, userID, time.Now().Unix())
+	if err != nil {
+		return nil, apperror.New(500, "USER_SESSION_FETCH_FAILED", "Gagal mengambil sesi user", err.Error(), nil)
+	}
+	otherSessions := make([]model.UserSession, 0, len(activeSessions))
+	for _, userSession := range activeSessions {
+		if userSession.ID != currentSessionID {
+			otherSessions = append(otherSessions, userSession)
+		}
+	}
+
+	encryptedSecret, err := util.EncryptTwoFactorSecret(secret)
+	if err != nil {
+		logger.Error("Failed to encrypt two-factor secret", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "TWO_FACTOR_ENABLE_FAILED", "Gagal mengaktifkan autentikasi dua langkah", err.Error(), nil)
+	}
+
+	tx := s.db.Begin()
+	user.TwoFactorEnabled = true
+	user.TwoFactorSecret = &encryptedSecret
+	if _, err := s.userRepo.UpdateTX(ctx, tx, user); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to enable two-factor authentication", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "TWO_FACTOR_ENABLE_FAILED", "Gagal mengaktifkan autentikasi dua langkah", err.Error(), nil)
+	}
+	if err := s.userRecoveryCodeRepo.ReplaceTX(ctx, tx, userID, hashedCodes); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to save recovery codes", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "TWO_FACTOR_ENABLE_FAILED", "Gagal mengaktifkan autentikasi dua langkah", err.Error(), nil)
+	}
+	for i := range otherSessions {
+		otherSessions[i].IsActive = false
+		if err := s.userSessionRepo.UpdateTX(ctx, tx, &otherSessions[i]); err != nil {
+			tx.Rollback()
+			logger.Error("Failed to end other sessions", zap.Uint("user_id", userID), zap.Error(err))
+			return nil, apperror.New(500, "USER_SESSION_UPDATE_FAILED", "Gagal memperbarui sesi user", err.Error(), nil)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("Failed to commit two-factor enrollment", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "TWO_FACTOR_ENABLE_FAILED", "Gagal mengaktifkan autentikasi dua langkah", err.Error(), nil)
+	}
+
+	if err := s.cacheRepo.Del(ctx, setupKey); err != nil {
+		logger.Warn("Failed to clear two-factor setup secret", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	for _, userSession := range otherSessions {
+		s.clearSessionCache(ctx, userID, userSession)
+	}
+	logger.Info("Two-factor authentication enabled", zap.Uint("user_id", userID), zap.Int("revoked_sessions", len(otherSessions)))
+	return &dto.TwoFactorEnableResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID uint, req dto.DisableTwoFactorRequest) error {
+	user, err := s.getTwoFactorUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return apperror.New(400, "TWO_FACTOR_NOT_ENABLED", "Autentikasi dua langkah belum aktif", "", nil)
+	}
+	if user.Password == nil || !tokenutils.CheckHashString(req.Password, *user.Password) {
+		return apperror.New(401, "INVALID_PASSWORD", "Password salah", "", nil)
+	}
+
+	tx := s.db.Begin()
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = nil
+	if _, err := s.userRepo.UpdateTX(ctx, tx, user); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to disable two-factor authentication", zap.Uint("user_id", userID), zap.Error(err))
+		return apperror.New(500, "TWO_FACTOR_DISABLE_FAILED", "Gagal menonaktifkan autentikasi dua langkah", err.Error(), nil)
+	}
+	if err := s.userRecoveryCodeRepo.DeleteByUserIDTX(ctx, tx, userID); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to delete recovery codes", zap.Uint("user_id", userID), zap.Error(err))
+		return apperror.New(500, "TWO_FACTOR_DISABLE_FAILED", "Gagal menonaktifkan autentikasi dua langkah", err.Error(), nil)
+	}
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("Failed to commit two-factor removal", zap.Uint("user_id", userID), zap.Error(err))
+		return apperror.New(500, "TWO_FACTOR_DISABLE_FAILED", "Gagal menonaktifkan autentikasi dua langkah", err.Error(), nil)
+	}
+
+	logger.Info("Two-factor authentication disabled", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *AuthService) createTwoFactorChallenge(ctx context.Context, userID uint) (string, error) {
+	challengeToken, err := tokenutils.GenerateRandomCode(64)
+	if err != nil {
+		return "", apperror.New(500, "CODE_GENERATION_FAILED", "Gagal membuat kode acak", err.Error(), nil)
+	}
+	challengeJSON, err := json.Marshal(twoFactorChallenge{UserID: userID})
+	if err != nil {
+		return "", apperror.New(500, "TWO_FACTOR_CHALLENGE_FAILED", "Gagal membuat sesi verifikasi dua langkah", err.Error(), nil)
+	}
+	if err := s.cacheRepo.Set(ctx, util.GetTwoFactorChallengeKey(challengeToken), challengeJSON, util.TwoFactorChallengeTTL); err != nil {
+		return "", apperror.New(500, "TWO_FACTOR_CHALLENGE_FAILED", "Gagal membuat sesi verifikasi dua langkah", err.Error(), nil)
+	}
+	return challengeToken, nil
+}
+
+// VerifyTwoFactorLogin completes a login that returned a challenge token. The
+// code is either a current TOTP code or an unused recovery code.
+func (s *AuthService) VerifyTwoFactorLogin(ctx context.Context, req dto.TwoFactorLoginRequest) (*dto.LoginResult, error) {
+	requestID := contextutils.GetRequestID(ctx)
+	challengeKey := util.GetTwoFactorChallengeKey(req.ChallengeToken)
+	challengeData, err := s.cacheRepo.Get(ctx, challengeKey)
+	if err != nil || challengeData == "" {
+		return nil, apperror.New(401, "TWO_FACTOR_CHALLENGE_EXPIRED", "Sesi verifikasi kedaluwarsa, silakan login ulang", "", nil)
+	}
+	var challenge twoFactorChallenge
+	if err := json.Unmarshal([]byte(challengeData), &challenge); err != nil {
+		return nil, apperror.New(401, "TWO_FACTOR_CHALLENGE_EXPIRED", "Sesi verifikasi kedaluwarsa, silakan login ulang", err.Error(), nil)
+	}
+	if err := s.ensureTwoFactorNotLocked(ctx, challenge.UserID); err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.reserveTwoFactorAttempt(ctx, req.ChallengeToken)
+	if err != nil {
+		logger.Error("Failed to record two-factor attempt",
+			zap.String("request_id", requestID),
+			zap.Uint("user_id", challenge.UserID),
+			zap.Error(err),
+		)
+		return nil, apperror.New(500, "TWO_FACTOR_VERIFY_FAILED", "Gagal memverifikasi kode", err.Error(), nil)
+	}
+	if attempts > util.TwoFactorMaxAttempts {
+		s.clearTwoFactorChallenge(ctx, req.ChallengeToken)
+		return nil, apperror.New(401, "TWO_FACTOR_CHALLENGE_EXPIRED", "Sesi verifikasi kedaluwarsa, silakan login ulang", "", nil)
+	}
+
+	user, err := s.getTwoFactorUser(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled || user.TwoFactorSecret == nil {
+		s.clearTwoFactorChallenge(ctx, req.ChallengeToken)
+		return nil, apperror.New(401, "TWO_FACTOR_CHALLENGE_EXPIRED", "Sesi verifikasi kedaluwarsa, silakan login ulang", "", nil)
+	}
+
+	verified, err := s.verifySecondFactor(ctx, user, req.Code)
+	if err != nil {
+		logger.Error("Failed to verify second factor",
+			zap.String("request_id", requestID),
+			zap.Uint("user_id", user.ID),
+			zap.Error(err),
+		)
+		return nil, apperror.New(500, "TWO_FACTOR_VERIFY_FAILED", "Gagal memverifikasi kode", err.Error(), nil)
+	}
+	if !verified {
+		s.recordFailedTwoFactorAttempt(ctx, req.ChallengeToken, attempts, user.ID)
+		return nil, apperror.New(401, "INVALID_TWO_FACTOR_CODE", "Kode verifikasi tidak valid", "", nil)
+	}
+	s.clearTwoFactorChallenge(ctx, req.ChallengeToken)
+	if err := s.cacheRepo.Del(ctx, util.GetTwoFactorFailuresKey(user.ID)); err != nil {
+		logger.Warn("Failed to reset two-factor failures", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	accessToken, refreshToken, err := s.createSession(ctx, user, req.IPAddress, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("User logged in with two-factor authentication",
+		zap.String("request_id", requestID),
+		zap.Uint("user_id", user.ID),
+	)
+	return &dto.LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func (s *AuthService) verifySecondFactor(ctx context.Context, user *model.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == totputils.Digits {
+		secret, err := util.DecryptTwoFactorSecret(*user.TwoFactorSecret)
+		if err != nil {
+			return false, fmt.Errorf("failed to decrypt two-factor secret: %w", err)
+		}
+		step, ok := totputils.ValidateCode(secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		lastStepKey := util.GetTwoFactorLastStepKey(user.ID)
+		if lastStep, err := s.cacheRepo.Get(ctx, lastStepKey); err == nil {
+			if used, err := strconv.ParseInt(lastStep, 10, 64); err == nil && step <= used {
+				return false, nil
+			}
+		}
+		window := time.Duration(2*totputils.SkewSteps+1) * totputils.Period * time.Second
+		if err := s.cacheRepo.Set(ctx, lastStepKey, step, window); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	recoveryCodes, err := s.userRecoveryCodeRepo.GetUnusedByUserID(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	normalized := util.NormalizeRecoveryCode(code)
+	for _, recoveryCode := range recoveryCodes {
+		if !tokenutils.CheckHashString(normalized, recoveryCode.CodeHash) {
+			continue
+		}
+		used, err := s.userRecoveryCodeRepo.MarkUsed(ctx, recoveryCode.ID, time.Now().Unix())
+		if err != nil {
+			return false, err
+		}
+		if used {
+			logger.Info("Recovery code used", zap.Uint("user_id", user.ID), zap.Int("remaining", len(recoveryCodes)-1))
+		}
+		return used, nil
+	}
+	return false, nil
+}
+
+// ensureTwoFactorNotLocked stops new challenges and codes for a user after
+// too many wrong codes across challenges, so logging in again does not reset
+// the guess budget.
+func (s *AuthService) ensureTwoFactorNotLocked(ctx context.Context, userID uint) error {
+	failures, err := s.cacheRepo.Get(ctx, util.GetTwoFactorFailuresKey(userID))
+	if err != nil {
+		return nil
+	}
+	if count, err := strconv.Atoi(failures); err == nil && count >= util.TwoFactorUserMaxFailures {
+		return apperror.New(429, "TWO_FACTOR_LOCKED", "Terlalu banyak kode verifikasi yang salah, coba lagi nanti", "", nil)
+	}
+	return nil
+}
+
+// reserveTwoFactorAttempt counts an attempt before the code is checked, so
+// concurrent requests cannot test more codes than TwoFactorMaxAttempts.
+func (s *AuthService) reserveTwoFactorAttempt(ctx context.Context, challengeToken string) (int64, error) {
+	attemptsKey := util.GetTwoFactorAttemptsKey(challengeToken)
+	attempts, err := s.cacheRepo.Incr(ctx, attemptsKey)
+	if err != nil {
+		return 0, err
+	}
+	if attempts == 1 {
+		if _, err := s.cacheRepo.Expire(ctx, attemptsKey, util.TwoFactorChallengeTTL); err != nil {
+			return 0, err
+		}
+	}
+	return attempts, nil
+}
+
+// recordFailedTwoFactorAttempt invalidates the challenge after too many wrong
+// codes and counts the failure against the user, so a stolen password alone
+// cannot be used to guess the second factor.
+func (s *AuthService) recordFailedTwoFactorAttempt(ctx context.Context, challengeToken string, attempts int64, userID uint) {
+	if attempts >= util.TwoFactorMaxAttempts {
+		s.clearTwoFactorChallenge(ctx, challengeToken)
+	}
+
+	failuresKey := util.GetTwoFactorFailuresKey(userID)
+	failures, err := s.cacheRepo.Incr(ctx, failuresKey)
+	if err != nil {
+		logger.Warn("Failed to record two-factor failure", zap.Uint("user_id", userID), zap.Error(err))
+		return
+	}
+	if failures == 1 {
+		if _, err := s.cacheRepo.Expire(ctx, failuresKey, util.TwoFactorLockoutDuration); err != nil {
+			logger.Warn("Failed to set two-factor failure expiration", zap.Uint("user_id", userID), zap.Error(err))
+		}
+	}
+}
+
+func (s *AuthService) clearTwoFactorChallenge(ctx context.Context, challengeToken string) {
+	if err := s.cacheRepo.Del(ctx, util.GetTwoFactorChallengeKey(challengeToken)); err != nil {
+		logger.Warn("Failed to clear two-factor challenge", zap.Error(err))
+	}
+	if err := s.cacheRepo.Del(ctx, util.GetTwoFactorAttemptsKey(challengeToken)); err != nil {
+		logger.Warn("Failed to clear two-factor attempts", zap.Error(err))
+	}
+}
+
+// clearSessionCache removes the Redis keys that ValidateAccessToken and token
+// refresh check first, so an ended session stops working right away.
+func (s *AuthService) clearSessionCache(ctx context.Context, userID uint, userSession model.UserSession) {
+	if err := s.cacheRepo.Del(ctx, fmt.Sprintf("refresh_token:%s", userSession.RefreshTokenID)); err != nil {
+		logger.Warn("Failed to delete refresh token from Redis", zap.Uint("session_id", userSession.ID), zap.Error(err))
+	}
+	if err := s.cacheRepo.Del(ctx, fmt.Sprintf("session:%d", userSession.ID)); err != nil {
+		logger.Warn("Failed to delete session data from Redis", zap.Uint("session_id", userSession.ID), zap.Error(err))
+	}
+	if err := s.cacheRepo.SRem(ctx, fmt.Sprintf("user_session:%d", userID), userSession.ID); err != nil {
+		logger.Warn("Failed to remove user session ID from Redis set", zap.Uint("session_id", userSession.ID), zap.Error(err))
+	}
 }
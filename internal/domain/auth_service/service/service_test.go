@@ -5,15 +5,21 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"errors"
 	"os"
 	"path/filepath"
 	"pingspot/internal/domain/auth_service/dto"
+	"pingspot/internal/domain/auth_service/util"
 	"pingspot/internal/mocks"
+	taskMocks "pingspot/internal/mocks/task"
 	userMocks "pingspot/internal/mocks/user"
 	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
 	tokenutils "pingspot/pkg/utils/token_util"
+	totputils "pingspot/pkg/utils/totp_util"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -35,6 +41,12 @@ func setupAuthTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
+func newAuthTestTaskService() *taskMocks.MockTaskService {
+	tasksService := new(taskMocks.MockTaskService)
+	tasksService.On("SendEmailTask", mock.Anything).Return(nil).Maybe()
+	return tasksService
+}
+
 func setupTestKeys(t *testing.T) {
 	keysDir := filepath.Join("keys")
 	err := os.MkdirAll(keysDir, 0755)
@@ -83,7 +95,7 @@ func TestNewAuthService(t *testing.T) {
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
 
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		assert.NotNil(t, service)
 		assert.Equal(t, mockUserRepo, service.userRepo)
@@ -100,7 +112,7 @@ func TestAuthService_Register(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		req := dto.RegisterRequest{
@@ -141,7 +153,7 @@ func TestAuthService_Register(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		req := dto.RegisterRequest{
@@ -173,7 +185,7 @@ func TestAuthService_Register(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		req := dto.RegisterRequest{
@@ -200,7 +212,7 @@ func TestAuthService_Register(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		req := dto.RegisterRequest{
@@ -229,7 +241,7 @@ func TestAuthService_Register(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		req := dto.RegisterRequest{
@@ -277,7 +289,7 @@ func TestAuthService_VerifyUser(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		expectedUser := &model.User{
 			ID:         userID,
@@ -307,7 +319,7 @@ func TestAuthService_VerifyUser(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		expectedUser := &model.User{
 			ID:         userID,
@@ -333,7 +345,7 @@ func TestAuthService_VerifyUser(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		mockUserRepo.On("GetByID", ctx, userID).Return(nil, gorm.ErrRecordNotFound)
 
@@ -352,7 +364,7 @@ func TestAuthService_VerifyUser(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		expectedUser := &model.User{
 			ID:         userID,
@@ -383,7 +395,7 @@ func TestAuthService_Login(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		req := dto.LoginRequest{
@@ -394,12 +406,10 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockUserRepo.On("GetByEmailOrUsername", ctx, req.EmailOrUsername).Return(nil, gorm.ErrRecordNotFound)
 
-		user, accessToken, refreshToken, err := service.Login(ctx, req)
+		result, err := service.Login(ctx, req)
 
 		assert.Error(t, err)
-		assert.Nil(t, user)
-		assert.Empty(t, accessToken)
-		assert.Empty(t, refreshToken)
+		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "Email atau password salah")
 		mockUserRepo.AssertExpectations(t)
 	})
@@ -410,7 +420,7 @@ func TestAuthService_Login(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		req := dto.LoginRequest{
@@ -430,12 +440,10 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockUserRepo.On("GetByEmailOrUsername", ctx, req.EmailOrUsername).Return(existingUser, nil)
 
-		user, accessToken, refreshToken, err := service.Login(ctx, req)
+		result, err := service.Login(ctx, req)
 
 		assert.Error(t, err)
-		assert.Nil(t, user)
-		assert.Empty(t, accessToken)
-		assert.Empty(t, refreshToken)
+		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "Email atau password salah")
 		mockUserRepo.AssertExpectations(t)
 	})
@@ -448,7 +456,7 @@ func TestAuthService_Login(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		req := dto.LoginRequest{
@@ -466,12 +474,10 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockUserRepo.On("GetByEmailOrUsername", ctx, req.EmailOrUsername).Return(existingUser, nil)
 
-		user, accessToken, refreshToken, err := service.Login(ctx, req)
+		result, err := service.Login(ctx, req)
 
 		assert.Error(t, err)
-		assert.Nil(t, user)
-		assert.Empty(t, accessToken)
-		assert.Empty(t, refreshToken)
+		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "belum diverifikasi")
 		mockUserRepo.AssertExpectations(t)
 	})
@@ -483,7 +489,7 @@ func TestAuthService_Login(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		password := "password123"
@@ -524,15 +530,16 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockCacheRepo.On("SAdd", mock.Anything, "user_session:1", mock.AnythingOfType("[]interface {}")).Return(nil)
 
-		user, accessToken, refreshToken, err := service.Login(ctx, req)
+		result, err := service.Login(ctx, req)
 
 		require.NoError(t, err)
-		assert.NotNil(t, user)
-		assert.NotEmpty(t, accessToken)
-		assert.NotEmpty(t, refreshToken)
-		assert.Equal(t, existingUser.Email, user.Email)
-		assert.Equal(t, existingUser.ID, user.ID)
-		assert.Equal(t, existingUser.Username, user.Username)
+		require.NotNil(t, result)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.NotEmpty(t, result.RefreshToken)
+		assert.Empty(t, result.ChallengeToken)
+		assert.Equal(t, existingUser.Email, result.User.Email)
+		assert.Equal(t, existingUser.ID, result.User.ID)
+		assert.Equal(t, existingUser.Username, result.User.Username)
 
 		mockUserRepo.AssertExpectations(t)
 		mockSessionRepo.AssertExpectations(t)
@@ -549,7 +556,7 @@ func TestAuthService_Logout(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -584,7 +591,7 @@ func TestAuthService_Logout(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		invalidRefreshToken := "invalid-token"
@@ -601,7 +608,7 @@ func TestAuthService_Logout(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -623,7 +630,7 @@ func TestAuthService_Logout(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -655,7 +662,7 @@ func TestAuthService_Logout(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -690,7 +697,7 @@ func TestAuthService_Logout(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -728,7 +735,7 @@ func TestAuthService_UpdateUserByEmail(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		email := "user@example.com"
@@ -766,7 +773,7 @@ func TestAuthService_UpdateUserByEmail(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		email := "notfound@example.com"
@@ -788,7 +795,7 @@ func TestAuthService_UpdateUserByEmail(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		email := "user@example.com"
@@ -819,7 +826,7 @@ func TestAuthService_GetUserByEmail(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		email := "user@example.com"
@@ -847,7 +854,7 @@ func TestAuthService_GetUserByEmail(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		email := "notfound@example.com"
@@ -867,7 +874,7 @@ func TestAuthService_GetUserByEmail(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		cacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, cacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		email := "user@example.com"
@@ -892,7 +899,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -947,7 +954,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1003,7 +1010,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		invalidRefreshToken := "invalid.token.here"
@@ -1022,7 +1029,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1050,7 +1057,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1087,7 +1094,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1124,7 +1131,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1150,7 +1157,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1187,7 +1194,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1224,7 +1231,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1276,7 +1283,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo := new(userMocks.MockUserSessionRepository)
 		mockCacheRepo := new(mocks.MockCacheRepository)
 		db := setupAuthTestDB(t)
-		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo)
+		service := NewAuthService(db, mockUserRepo, mockProfileRepo, mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -1318,3 +1325,296 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		mockSessionRepo.AssertExpectations(t)
 	})
 }
+
+func setupTwoFactorEncryptionKey(t *testing.T) {
+	t.Setenv("TWO_FACTOR_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+}
+
+func TestAuthService_TwoFactorLogin(t *testing.T) {
+	setupTestKeys(t)
+	setupTwoFactorEncryptionKey(t)
+	password := "password123"
+	hashedPassword, _ := tokenutils.HashString(password)
+	secret, err := totputils.GenerateSecret()
+	require.NoError(t, err)
+	encryptedSecret, err := util.EncryptTwoFactorSecret(secret)
+	require.NoError(t, err)
+
+	newTwoFactorUser := func() *model.User {
+		return &model.User{
+			ID:               1,
+			Email:            "user@example.com",
+			Username:         "testuser",
+			Password:         &hashedPassword,
+			Provider:         model.ProviderEmail,
+			IsVerified:       true,
+			TwoFactorEnabled: true,
+			TwoFactorSecret:  &encryptedSecret,
+		}
+	}
+	expectAttempt := func(mockCacheRepo *mocks.MockCacheRepository, attempts int64) {
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_failures:1").Return("", errors.New("redis: nil"))
+		mockCacheRepo.On("Incr", mock.Anything, "two_factor_attempts:challenge").Return(attempts, nil)
+		mockCacheRepo.On("Expire", mock.Anything, "two_factor_attempts:challenge", util.TwoFactorChallengeTTL).Return(true, nil).Maybe()
+	}
+	expectChallengeCleared := func(mockCacheRepo *mocks.MockCacheRepository) {
+		mockCacheRepo.On("Del", mock.Anything, "two_factor_challenge:challenge").Return(nil)
+		mockCacheRepo.On("Del", mock.Anything, "two_factor_attempts:challenge").Return(nil)
+	}
+	expectFailure := func(mockCacheRepo *mocks.MockCacheRepository) {
+		mockCacheRepo.On("Incr", mock.Anything, "two_factor_failures:1").Return(int64(1), nil)
+		mockCacheRepo.On("Expire", mock.Anything, "two_factor_failures:1", util.TwoFactorLockoutDuration).Return(true, nil)
+	}
+	expectSession := func(mockSessionRepo *userMocks.MockUserSessionRepository, mockCacheRepo *mocks.MockCacheRepository) {
+		mockSessionRepo.On("CreateTX", mock.Anything, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.UserSession")).Return(&model.UserSession{ID: 1, UserID: 1}, nil)
+		mockCacheRepo.On("Set", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "refresh_token:")
+		}), mock.Anything, mock.Anything).Return(nil)
+		mockCacheRepo.On("Set", mock.Anything, "session:1", mock.Anything, mock.Anything).Return(nil)
+		mockCacheRepo.On("SAdd", mock.Anything, "user_session:1", mock.Anything).Return(nil)
+	}
+
+	t.Run("should return challenge token instead of session when two-factor is enabled", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockSessionRepo := new(userMocks.MockUserSessionRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
+
+		ctx := context.Background()
+		req := dto.LoginRequest{EmailOrUsername: "user@example.com", Password: password, Provider: string(model.ProviderEmail)}
+		mockUserRepo.On("GetByEmailOrUsername", ctx, req.EmailOrUsername).Return(newTwoFactorUser(), nil)
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_failures:1").Return("", errors.New("redis: nil"))
+		mockCacheRepo.On("Set", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "two_factor_challenge:")
+		}), mock.Anything, mock.Anything).Return(nil)
+
+		result, err := service.Login(ctx, req)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.ChallengeToken)
+		assert.Empty(t, result.AccessToken)
+		assert.Empty(t, result.RefreshToken)
+		mockSessionRepo.AssertNotCalled(t, "CreateTX", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should create session with valid totp code", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockSessionRepo := new(userMocks.MockUserSessionRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
+
+		ctx := context.Background()
+		code, err := totputils.GenerateCode(secret, time.Now())
+		require.NoError(t, err)
+
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_challenge:challenge").Return(`{"userId":1}`, nil)
+		expectAttempt(mockCacheRepo, 1)
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(newTwoFactorUser(), nil)
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_last_step:1").Return("", errors.New("redis: nil"))
+		mockCacheRepo.On("Set", mock.Anything, "two_factor_last_step:1", mock.Anything, mock.Anything).Return(nil)
+		expectChallengeCleared(mockCacheRepo)
+		mockCacheRepo.On("Del", mock.Anything, "two_factor_failures:1").Return(nil)
+		expectSession(mockSessionRepo, mockCacheRepo)
+
+		result, err := service.VerifyTwoFactorLogin(ctx, dto.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: code})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.NotEmpty(t, result.RefreshToken)
+		mockCacheRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a totp code that was already used", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), new(userMocks.MockUserSessionRepository), mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
+
+		ctx := context.Background()
+		now := time.Now()
+		code, err := totputils.GenerateCode(secret, now)
+		require.NoError(t, err)
+
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_challenge:challenge").Return(`{"userId":1}`, nil)
+		expectAttempt(mockCacheRepo, 1)
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(newTwoFactorUser(), nil)
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_last_step:1").Return(strconv.FormatInt(totputils.GetTimeStep(now)+1, 10), nil)
+		expectFailure(mockCacheRepo)
+
+		result, err := service.VerifyTwoFactorLogin(ctx, dto.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: code})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Kode verifikasi tidak valid")
+		mockCacheRepo.AssertExpectations(t)
+	})
+
+	t.Run("should accept an unused recovery code once", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockSessionRepo := new(userMocks.MockUserSessionRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		mockRecoveryRepo := new(userMocks.MockUserRecoveryCodeRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), mockRecoveryRepo)
+
+		ctx := context.Background()
+		codeHash, err := tokenutils.HashString("abcde-fghjk")
+		require.NoError(t, err)
+
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_challenge:challenge").Return(`{"userId":1}`, nil)
+		expectAttempt(mockCacheRepo, 1)
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(newTwoFactorUser(), nil)
+		mockRecoveryRepo.On("GetUnusedByUserID", ctx, uint(1)).Return([]model.UserRecoveryCode{{ID: 7, UserID: 1, CodeHash: codeHash}}, nil)
+		mockRecoveryRepo.On("MarkUsed", ctx, uint(7), mock.AnythingOfType("int64")).Return(true, nil)
+		expectChallengeCleared(mockCacheRepo)
+		mockCacheRepo.On("Del", mock.Anything, "two_factor_failures:1").Return(nil)
+		expectSession(mockSessionRepo, mockCacheRepo)
+
+		result, err := service.VerifyTwoFactorLogin(ctx, dto.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "ABCDEFGHJK"})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		mockRecoveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should drop the challenge after too many wrong codes", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		mockRecoveryRepo := new(userMocks.MockUserRecoveryCodeRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), new(userMocks.MockUserSessionRepository), mockCacheRepo, newAuthTestTaskService(), mockRecoveryRepo)
+
+		ctx := context.Background()
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_challenge:challenge").Return(`{"userId":1}`, nil)
+		expectAttempt(mockCacheRepo, util.TwoFactorMaxAttempts)
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(newTwoFactorUser(), nil)
+		mockRecoveryRepo.On("GetUnusedByUserID", ctx, uint(1)).Return([]model.UserRecoveryCode{}, nil)
+		expectChallengeCleared(mockCacheRepo)
+		expectFailure(mockCacheRepo)
+
+		result, err := service.VerifyTwoFactorLogin(ctx, dto.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "wrong-code"})
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockCacheRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject attempts beyond the limit without checking the code", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), new(userMocks.MockUserSessionRepository), mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
+
+		ctx := context.Background()
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_challenge:challenge").Return(`{"userId":1}`, nil)
+		expectAttempt(mockCacheRepo, util.TwoFactorMaxAttempts+1)
+		expectChallengeCleared(mockCacheRepo)
+
+		result, err := service.VerifyTwoFactorLogin(ctx, dto.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Sesi verifikasi kedaluwarsa")
+		mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should lock the user out after too many failures across challenges", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), new(userMocks.MockUserSessionRepository), mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
+
+		ctx := context.Background()
+		req := dto.LoginRequest{EmailOrUsername: "user@example.com", Password: password, Provider: string(model.ProviderEmail)}
+		mockUserRepo.On("GetByEmailOrUsername", ctx, req.EmailOrUsername).Return(newTwoFactorUser(), nil)
+		mockCacheRepo.On("Get", mock.Anything, "two_factor_failures:1").Return(strconv.Itoa(util.TwoFactorUserMaxFailures), nil)
+
+		result, err := service.Login(ctx, req)
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 429, appErr.StatusCode)
+		mockCacheRepo.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_EnableTwoFactor(t *testing.T) {
+	setupTwoFactorEncryptionKey(t)
+	password := "password123"
+	hashedPassword, _ := tokenutils.HashString(password)
+
+	t.Run("should enable two-factor and store hashed recovery codes", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockSessionRepo := new(userMocks.MockUserSessionRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		mockRecoveryRepo := new(userMocks.MockUserRecoveryCodeRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), mockSessionRepo, mockCacheRepo, newAuthTestTaskService(), mockRecoveryRepo)
+
+		ctx := context.Background()
+		secret, err := totputils.GenerateSecret()
+		require.NoError(t, err)
+		code, err := totputils.GenerateCode(secret, time.Now())
+		require.NoError(t, err)
+
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Password: &hashedPassword}, nil)
+		mockCacheRepo.On("Get", ctx, "two_factor_setup:1").Return(secret, nil)
+		mockUserRepo.On("UpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(u *model.User) bool {
+			if !u.TwoFactorEnabled || u.TwoFactorSecret == nil || *u.TwoFactorSecret == secret {
+				return false
+			}
+			stored, err := util.DecryptTwoFactorSecret(*u.TwoFactorSecret)
+			return err == nil && stored == secret
+		})).Return(&model.User{}, nil)
+		var storedCodes []model.UserRecoveryCode
+		mockRecoveryRepo.On("ReplaceTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1), mock.Anything).Run(func(args mock.Arguments) {
+			storedCodes = args.Get(3).([]model.UserRecoveryCode)
+		}).Return(nil)
+		mockCacheRepo.On("Del", ctx, "two_factor_setup:1").Return(nil)
+		mockSessionRepo.On("GetActiveByUserID", ctx, uint(1), mock.AnythingOfType("int64")).Return([]model.UserSession{
+			{ID: 1, UserID: 1, RefreshTokenID: "current", IsActive: true},
+			{ID: 2, UserID: 1, RefreshTokenID: "other", IsActive: true},
+		}, nil)
+		mockSessionRepo.On("UpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(userSession *model.UserSession) bool {
+			return userSession.ID == 2 && !userSession.IsActive
+		})).Return(nil).Once()
+		mockCacheRepo.On("Del", ctx, "refresh_token:other").Return(nil)
+		mockCacheRepo.On("Del", ctx, "session:2").Return(nil)
+		mockCacheRepo.On("SRem", ctx, "user_session:1", mock.Anything).Return(nil)
+
+		result, err := service.EnableTwoFactor(ctx, 1, 1, dto.EnableTwoFactorRequest{Code: code})
+
+		require.NoError(t, err)
+		require.Len(t, result.RecoveryCodes, 10)
+		require.Len(t, storedCodes, 10)
+		assert.NotEqual(t, result.RecoveryCodes[0], storedCodes[0].CodeHash)
+		assert.True(t, tokenutils.CheckHashString(result.RecoveryCodes[0], storedCodes[0].CodeHash))
+		mockUserRepo.AssertExpectations(t)
+		mockRecoveryRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+		mockCacheRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid first code", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), new(userMocks.MockUserSessionRepository), mockCacheRepo, newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
+
+		ctx := context.Background()
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Password: &hashedPassword}, nil)
+		mockCacheRepo.On("Get", ctx, "two_factor_setup:1").Return("JBSWY3DPEHPK3PXP", nil)
+
+		result, err := service.EnableTwoFactor(ctx, 1, 1, dto.EnableTwoFactorRequest{Code: "000000"})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Kode verifikasi tidak valid")
+	})
+
+	t.Run("should require the password to disable two-factor", func(t *testing.T) {
+		mockUserRepo := new(userMocks.MockUserRepository)
+		service := NewAuthService(setupAuthTestDB(t), mockUserRepo, new(userMocks.MockUserProfileRepository), new(userMocks.MockUserSessionRepository), new(mocks.MockCacheRepository), newAuthTestTaskService(), new(userMocks.MockUserRecoveryCodeRepository))
+
+		ctx := context.Background()
+		secret := "JBSWY3DPEHPK3PXP"
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Password: &hashedPassword, TwoFactorEnabled: true, TwoFactorSecret: &secret}, nil)
+
+		err := service.DisableTwoFactor(ctx, 1, dto.DisableTwoFactorRequest{Password: "wrongpassword"})
+
+		assert.Contains(t, err.Error(), "Password salah")
+		mockUserRepo.AssertNotCalled(t, "UpdateTX", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	env "pingspot/pkg/utils/env_util"
+	totputils "pingspot/pkg/utils/totp_util"
+	"strings"
+	"time"
+)
+
+const (
+	TwoFactorIssuer       = "PingSpot"
+	TwoFactorSetupTTL     = 10 * time.Minute
+	TwoFactorChallengeTTL = 5 * time.Minute
+	TwoFactorMaxAttempts  = 5
+	RecoveryCodeCount     = 10
+
+	TwoFactorUserMaxFailures = 10
+	TwoFactorLockoutDuration = 15 * time.Minute
+
+	recoveryCodeAlphabet   = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeHalfLength = 5
+)
+
+func GetTwoFactorSetupKey(userID uint) string {
+	return fmt.Sprintf("two_factor_setup:%d", userID)
+}
+
+func GetTwoFactorChallengeKey(challengeToken string) string {
+	return fmt.Sprintf("two_factor_challenge:%s", challengeToken)
+}
+
+func GetTwoFactorAttemptsKey(challengeToken string) string {
+	return fmt.Sprintf("two_factor_attempts:%s", challengeToken)
+}
+
+func GetTwoFactorFailuresKey(userID uint) string {
+	return fmt.Sprintf("two_factor_failures:%d", userID)
+}
+
+func GetTwoFactorLastStepKey(userID uint) string {
+	return fmt.Sprintf("two_factor_last_step:%d", userID)
+}
+
+// EncryptTwoFactorSecret seals a TOTP secret with TWO_FACTOR_ENCRYPTION_KEY
+// before it is stored on the user.
+func EncryptTwoFactorSecret(secret string) (string, error) {
+	key, err := totputils.ParseEncryptionKey(env.TwoFactorEncryptionKey())
+	if err != nil {
+		return "", err
+	}
+	return totputils.EncryptSecret(secret, key)
+}
+
+func DecryptTwoFactorSecret(stored string) (string, error) {
+	key, err := totputils.ParseEncryptionKey(env.TwoFactorEncryptionKey())
+	if err != nil {
+		return "", err
+	}
+	return totputils.DecryptSecret(stored, key)
+}
+
+// GenerateRecoveryCodes returns codes such as "k7m2p-x9qrt". Look-alike
+// characters are left out so the codes can be copied from paper.
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	for len(codes) < count {
+		var code strings.Builder
+		for i := 0; i < recoveryCodeHalfLength*2; i++ {
+			if i == recoveryCodeHalfLength {
+				code.WriteByte('-')
+			}
+			index, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeAlphabet))))
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+			}
+			code.WriteByte(recoveryCodeAlphabet[index.Int64()])
+		}
+		codes = append(codes, code.String())
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode accepts a recovery code typed with or without the dash
+// and in any case.
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != recoveryCodeHalfLength*2 {
+		return code
+	}
+	return code[:recoveryCodeHalfLength] + "-" + code[recoveryCodeHalfLength:]
+}
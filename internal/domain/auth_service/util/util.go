@@ -1,27 +1,33 @@
 package util
 
-import mainutils "pingspot/pkg/utils/main_util"
+import (
+	"pingspot/pkg/i18n"
+	"pingspot/pkg/mailer"
+	mainutils "pingspot/pkg/utils/main_util"
+)
 
-func SendVerificationEmail(to, username, verificationLink string) error {
-	return mainutils.SendEmail(mainutils.EmailData{
+func BuildVerificationEmail(to, username, verificationLink string, idempotencyKey string, language i18n.Language) (mailer.Message, error) {
+	return mainutils.BuildEmail(mainutils.EmailData{
 		To:            to,
-		Subject:       "Verifikasi Akun PingSpot",
+		Subject:       i18n.T(language, "email.verification.subject", nil),
 		RecipientName: username,
 		BodyTempate: getVerificationEmailTemplate(),
 		EmailType:     mainutils.EmailTypeVerification,
 		TemplateData: map[string]interface{}{
 			"VerificationLink": verificationLink,
 		},
+		Language: language,
+		IdempotencyKey: idempotencyKey,
 	})
 }
 
 func getVerificationEmailTemplate() string {
 	return `<!DOCTYPE html>
-<html lang="id">
+<html lang="{{lang}}">
 <head>
 	<meta charset="UTF-8">
 	<meta name="viewport" content="width=device-width, initial-scale=1.0">
-	<title>Verifikasi Akun PingSpot</title>
+	<title>{{t "email.verification.subject"}}</title>
 </head>
 <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif; background-color: #f8fafc; line-height: 1.6;">
 	<table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%" style="background-color: #f8fafc;">
@@ -34,30 +40,30 @@ func getVerificationEmailTemplate() string {
 								PingSpot
 							</h1>
 							<p style="margin: 8px 0 0; color: rgba(255, 255, 255, 0.9); font-size: 16px; font-weight: 400;">
-								Selamat datang di dunia terhubung Anda
+								{{t "email.verification.tagline"}}
 							</p>
 						</td>
 					</tr>
 					<tr>
 						<td style="padding: 50px 40px;">
 							<h2 style="margin: 0 0 20px; color: #1e293b; font-size: 24px; font-weight: 600; text-align: center;">
-								Halo {{.UserName}}! 👋
+								{{t "email.common.greeting" "name" .UserName}} 👋
 							</h2>
 							<p style="margin: 0 0 25px; color: #475569; font-size: 16px; text-align: center; line-height: 1.7;">
-								Terima kasih telah bergabung dengan PingSpot! Untuk mulai menggunakan dan mengamankan akun Anda, silakan verifikasi alamat email Anda.
+								{{t "email.verification.intro"}}
 							</p>
 							<div style="text-align: center; margin: 35px 0;">
 								<a href="{{.VerificationLink}}" 
 								   style="display: inline-block; background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); color: #ffffff; text-decoration: none; padding: 16px 32px; border-radius: 50px; font-weight: 600; font-size: 16px; box-shadow: 0 4px 15px rgba(102, 126, 234, 0.4); transition: all 0.3s ease; text-align: center; min-width: 200px;">
-									Verifikasi Akun Saya
+									{{t "email.verification.button"}}
 								</a>
 							</div>
 							<div style="margin: 30px 0; padding: 20px; background-color: #f1f5f9; border-radius: 12px; border-left: 4px solid #667eea;">
 								<p style="margin: 0 0 10px; color: #475569; font-size: 14px; font-weight: 600;">
-									Tombol tidak berfungsi?
+									{{t "email.common.button_not_working"}}
 								</p>
 								<p style="margin: 0; color: #64748b; font-size: 14px; line-height: 1.5;">
-									Salin dan tempel link ini ke browser Anda:
+									{{t "email.common.copy_link"}}
 								</p>
 								<p style="margin: 8px 0 0; word-break: break-all;">
 									<a href="{{.VerificationLink}}" style="color: #667eea; text-decoration: none; font-size: 14px;">
@@ -67,8 +73,8 @@ func getVerificationEmailTemplate() string {
 							</div>
 							<div style="margin: 30px 0; text-align: center;">
 								<p style="margin: 0; color: #64748b; font-size: 14px; line-height: 1.6;">
-									🔒 Link ini akan kedaluwarsa dalam 5 menit demi keamanan Anda.<br>
-									Jika Anda tidak membuat akun, abaikan email ini.
+									🔒 {{t "email.verification.expiry"}}<br>
+									{{t "email.verification.ignore"}}
 								</p>
 							</div>
 						</td>
@@ -76,10 +82,10 @@ func getVerificationEmailTemplate() string {
 					<tr>
 						<td style="background-color: #f8fafc; padding: 30px 40px; text-align: center; border-top: 1px solid #e2e8f0;">
 							<p style="margin: 0 0 10px; color: #64748b; font-size: 14px;">
-								© 2025 PingSpot. Hak cipta dilindungi undang-undang.
+								{{t "email.common.copyright"}}
 							</p>
 							<p style="margin: 0; color: #94a3b8; font-size: 12px;">
-								Pertanyaan? Hubungi kami di
+								{{t "email.common.questions"}}
 								<a href="mailto:support@pingspot.com" style="color: #667eea; text-decoration: none;">
 									support@pingspot.com
 								</a>
@@ -95,26 +101,28 @@ func getVerificationEmailTemplate() string {
 }
 
 
-func SendPasswordResetEmail(to, username, resetLink string) error {
-	return mainutils.SendEmail(mainutils.EmailData{
+func BuildPasswordResetEmail(to, username, resetLink string, idempotencyKey string, language i18n.Language) (mailer.Message, error) {
+	return mainutils.BuildEmail(mainutils.EmailData{
 		To:            to,
-		Subject:       "Reset Password PingSpot",
+		Subject:       i18n.T(language, "email.password_reset.subject", nil),
 		RecipientName: username,
 		BodyTempate: getPasswordResetEmailTemplate(),
 		EmailType:     mainutils.EmailTypePasswordReset,
 		TemplateData: map[string]interface{}{
 			"ResetLink": resetLink,
 		},
+		Language: language,
+		IdempotencyKey: idempotencyKey,
 	})
 }
 
 func getPasswordResetEmailTemplate() string {
 	return `<!DOCTYPE html>
-<html lang="id">
+<html lang="{{lang}}">
 <head>
 	<meta charset="UTF-8">
 	<meta name="viewport" content="width=device-width, initial-scale=1.0">
-	<title>Reset Password PingSpot</title>
+	<title>{{t "email.password_reset.subject"}}</title>
 </head>
 <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif; background-color: #f8fafc; line-height: 1.6;">
 	<table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%" style="background-color: #f8fafc;">
@@ -127,30 +135,30 @@ func getPasswordResetEmailTemplate() string {
 								PingSpot
 							</h1>
 							<p style="margin: 8px 0 0; color: rgba(255, 255, 255, 0.9); font-size: 16px; font-weight: 400;">
-								Reset Password Anda
+								{{t "email.password_reset.tagline"}}
 							</p>
 						</td>
 					</tr>
 					<tr>
 						<td style="padding: 50px 40px;">
 							<h2 style="margin: 0 0 20px; color: #1e293b; font-size: 24px; font-weight: 600; text-align: center;">
-								Halo {{.UserName}}! 👋
+								{{t "email.common.greeting" "name" .UserName}} 👋
 							</h2>
 							<p style="margin: 0 0 25px; color: #475569; font-size: 16px; text-align: center; line-height: 1.7;">
-								Kami menerima permintaan untuk mereset password akun Anda. Klik tombol di bawah untuk melanjutkan proses reset password.
+								{{t "email.password_reset.intro"}}
 							</p>
 							<div style="text-align: center; margin: 35px 0;">
 								<a href="{{.ResetLink}}" 
 								   style="display: inline-block; background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); color: #ffffff; text-decoration: none; padding: 16px 32px; border-radius: 50px; font-weight: 600; font-size: 16px; box-shadow: 0 4px 15px rgba(102, 126, 234, 0.4); transition: all 0.3s ease; text-align: center; min-width: 200px;">
-									Reset Password
+									{{t "email.password_reset.button"}}
 								</a>
 							</div>
 							<div style="margin: 30px 0; padding: 20px; background-color: #f1f5f9; border-radius: 12px; border-left: 4px solid #667eea;">
 								<p style="margin: 0 0 10px; color: #475569; font-size: 14px; font-weight: 600;">
-									Tombol tidak berfungsi?
+									{{t "email.common.button_not_working"}}
 								</p>
 								<p style="margin: 0; color: #64748b; font-size: 14px; line-height: 1.5;">
-									Salin dan tempel link ini ke browser Anda:
+									{{t "email.common.copy_link"}}
 								</p>
 								<p style="margin: 8px 0 0; word-break: break-all;">
 									<a href="{{.ResetLink}}" style="color: #667eea; text-decoration: none; font-size: 14px;">
@@ -160,8 +168,8 @@ func getPasswordResetEmailTemplate() string {
 							</div>
 							<div style="margin: 30px 0; text-align: center;">
 								<p style="margin: 0; color: #64748b; font-size: 14px; line-height: 1.6;">
-									🔒 Link ini akan kedaluwarsa dalam 15 menit demi keamanan Anda.<br>
-									Jika Anda tidak meminta reset password, abaikan email ini.
+									🔒 {{t "email.password_reset.expiry"}}<br>
+									{{t "email.password_reset.ignore"}}
 								</p>
 							</div>
 						</td>
@@ -169,10 +177,10 @@ func getPasswordResetEmailTemplate() string {
 					<tr>
 						<td style="background-color: #f8fafc; padding: 30px 40px; text-align: center; border-top: 1px solid #e2e8f0;">
 							<p style="margin: 0 0 10px; color: #64748b; font-size: 14px;">
-								© 2025 PingSpot. Hak cipta dilindungi undang-undang.
+								{{t "email.common.copyright"}}
 							</p>
 							<p style="margin: 0; color: #94a3b8; font-size: 12px;">
-								Pertanyaan? Hubungi kami di
+								{{t "email.common.questions"}}
 								<a href="mailto:support@pingspot.com" style="color: #667eea; text-decoration: none;">
 									support@pingspot.com
 								</a>
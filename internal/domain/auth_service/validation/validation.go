@@ -137,4 +137,30 @@ func FormatForgotPasswordResetPasswordValidationErrors(err error) map[string]str
 		}
 	}
 	return errors
-}
\ No newline at end of file
+}
+func FormatTwoFactorValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "ChallengeToken":
+			if e.Tag() == "required" {
+				errors["challengeToken"] = "Token verifikasi wajib diisi"
+			}
+		case "Code":
+			if e.Tag() == "required" {
+				errors["code"] = "Kode verifikasi wajib diisi"
+			}
+			if e.Tag() == "len" || e.Tag() == "numeric" {
+				errors["code"] = "Kode verifikasi harus 6 digit angka"
+			}
+		case "Password":
+			if e.Tag() == "required" {
+				errors["password"] = "Password wajib diisi"
+			}
+		}
+	}
+	return errors
+}
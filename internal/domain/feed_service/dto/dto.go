@@ -0,0 +1,44 @@
+package dto
+
+type FeedSource string
+
+const (
+	FeedSourceFollowedUser FeedSource = "FOLLOWED_USER"
+	FeedSourceNearbyHome   FeedSource = "NEARBY_HOME"
+	FeedSourceTrending     FeedSource = "TRENDING"
+)
+
+type FeedEntry struct {
+	ReportID uint         `json:"reportID"`
+	Score    float64      `json:"score"`
+	Sources  []FeedSource `json:"sources"`
+}
+
+type FeedReport struct {
+	ID                uint               `json:"id"`
+	ReportTitle       string             `json:"reportTitle"`
+	ReportType        string             `json:"reportType"`
+	ReportDescription string             `json:"reportDescription"`
+	ReportStatus      string             `json:"reportStatus"`
+	ReportCreatedAt   int64              `json:"reportCreatedAt"`
+	UserID            uint               `json:"userID"`
+	UserName          string             `json:"userName"`
+	FullName          string             `json:"fullName"`
+	ProfilePicture    *string            `json:"profilePicture"`
+	IsAnonymous       bool               `json:"isAnonymous"`
+	Location          FeedReportLocation `json:"location"`
+	Image1URL         *string            `json:"image1URL"`
+	HotScore          float64            `json:"hotScore"`
+}
+
+type FeedReportLocation struct {
+	DisplayName *string `json:"displayName"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+type FeedItem struct {
+	Report  FeedReport   `json:"report"`
+	Score   float64      `json:"score"`
+	Sources []FeedSource `json:"sources"`
+}
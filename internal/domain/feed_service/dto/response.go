@@ -0,0 +1,6 @@
+package dto
+
+type GetFeedResponse struct {
+	Items      []FeedItem `json:"items"`
+	NextCursor *string    `json:"nextCursor"`
+}
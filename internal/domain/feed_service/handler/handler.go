@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"pingspot/internal/domain/feed_service/service"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	response "pingspot/pkg/utils/response_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type FeedHandler struct {
+	feedService *service.FeedService
+}
+
+func NewFeedHandler(feedService *service.FeedService) *FeedHandler {
+	return &FeedHandler{feedService: feedService}
+}
+
+func (h *FeedHandler) GetFeedHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	feed, err := h.feedService.GetFeed(ctx, userID, c.Query("cursor"))
+	if err != nil {
+		logger.Error("Failed to get feed", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan feed", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan feed", "data", feed)
+}
@@ -0,0 +1,39 @@
+package router
+
+import (
+	"pingspot/internal/domain/feed_service/handler"
+	"pingspot/internal/domain/feed_service/service"
+	reportRepository "pingspot/internal/domain/report_service/repository"
+	socialRepository "pingspot/internal/domain/social_service/repository"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/cache"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	cacheRepository "pingspot/internal/repository"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterFeedRoutes(app *fiber.App) {
+	db := database.GetPostgresDB()
+	rdb := cache.GetRedis()
+	reportRepo := reportRepository.NewReportRepository(db)
+	followRepo := socialRepository.NewFollowRepository(db)
+	userProfileRepo := userRepository.NewUserProfileRepository(db)
+	cacheRepo := cacheRepository.NewCacheRepository(&rdb)
+
+	feedService := service.NewFeedService(reportRepo, followRepo, userProfileRepo, cacheRepo)
+	feedHandler := handler.NewFeedHandler(feedService)
+
+	feedRoute := app.Group("/pingspot/api/feed", middleware.ValidateAccessToken())
+	feedRoute.Get("/",
+		middleware.TimeoutMiddleware(15*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 60,
+			KeyPrefix:   "get_feed",
+		})),
+		feedHandler.GetFeedHandler,
+	)
+}
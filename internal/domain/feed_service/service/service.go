@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"pingspot/internal/domain/feed_service/dto"
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	reportRepository "pingspot/internal/domain/report_service/repository"
+	reportUtil "pingspot/internal/domain/report_service/util"
+	socialRepository "pingspot/internal/domain/social_service/repository"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/model"
+	cacheRepository "pingspot/internal/repository"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	contextutils "pingspot/pkg/utils/context_util"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	feedPageSize          = 10
+	feedSourceLimit       = 100
+	feedCandidateWindow   = 30 * 24 * time.Hour
+	feedHomeRadiusMeters  = 5000
+	feedSnapshotTTL       = 30 * time.Minute
+	feedFollowedUserBoost = 0.6
+	feedNearbyHomeBoost   = 0.4
+)
+
+type FeedService struct {
+	reportRepo      reportRepository.ReportRepository
+	followRepo      socialRepository.FollowRepository
+	userProfileRepo userRepository.UserProfileRepository
+	cacheRepo       cacheRepository.CacheRepository
+}
+
+func NewFeedService(
+	reportRepo reportRepository.ReportRepository,
+	followRepo socialRepository.FollowRepository,
+	userProfileRepo userRepository.UserProfileRepository,
+	cacheRepo cacheRepository.CacheRepository,
+) *FeedService {
+	return &FeedService{
+		reportRepo:      reportRepo,
+		followRepo:      followRepo,
+		userProfileRepo: userProfileRepo,
+		cacheRepo:       cacheRepo,
+	}
+}
+
+func (s *FeedService) GetFeed(ctx context.Context, userID uint, cursor string) (*dto.GetFeedResponse, error) {
+	requestID := contextutils.GetRequestID(ctx)
+	logger.Info("Getting home feed",
+		zap.String("request_id", requestID),
+		zap.Uint("user_id", userID),
+		zap.String("cursor", cursor),
+	)
+
+	var (
+		snapshotID string
+		offset     int
+		entries    []dto.FeedEntry
+	)
+
+	if cursor == "" {
+		builtEntries, err := s.buildFeedEntries(ctx, userID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		entries = builtEntries
+		if len(entries) == 0 {
+			return &dto.GetFeedResponse{Items: []dto.FeedItem{}}, nil
+		}
+
+		snapshotID = strconv.FormatInt(time.Now().UnixNano(), 36)
+		entriesJSON, err := json.Marshal(entries)
+		if err != nil {
+			return nil, apperror.New(500, "FEED_SNAPSHOT_FAILED", "Gagal menyimpan feed", err.Error(), nil)
+		}
+		if err := s.cacheRepo.Set(ctx, feedSnapshotKey(userID, snapshotID), string(entriesJSON), feedSnapshotTTL); err != nil {
+			return nil, apperror.New(500, "FEED_SNAPSHOT_FAILED", "Gagal menyimpan feed", err.Error(), nil)
+		}
+	} else {
+		parsedSnapshotID, parsedOffset, err := parseFeedCursor(cursor)
+		if err != nil {
+			return nil, apperror.New(400, "INVALID_FEED_CURSOR", "Cursor feed tidak valid", err.Error(), nil)
+		}
+		snapshotID, offset = parsedSnapshotID, parsedOffset
+
+		entriesJSON, err := s.cacheRepo.Get(ctx, feedSnapshotKey(userID, snapshotID))
+		if err != nil {
+			logger.Warn("Feed snapshot not found",
+				zap.String("request_id", requestID),
+				zap.String("snapshot_id", snapshotID),
+				zap.Error(err),
+			)
+			return nil, apperror.New(410, "FEED_CURSOR_EXPIRED", "Feed telah kedaluwarsa, silakan muat ulang", "", nil)
+		}
+		if err := json.Unmarshal([]byte(entriesJSON), &entries); err != nil {
+			return nil, apperror.New(500, "FEED_SNAPSHOT_FAILED", "Gagal membaca feed", err.Error(), nil)
+		}
+	}
+
+	if offset >= len(entries) {
+		return &dto.GetFeedResponse{Items: []dto.FeedItem{}}, nil
+	}
+
+	end := min(offset+feedPageSize, len(entries))
+	pageEntries := entries[offset:end]
+
+	reportIDs := make([]uint, 0, len(pageEntries))
+	for _, entry := range pageEntries {
+		reportIDs = append(reportIDs, entry.ReportID)
+	}
+
+	reports, err := s.reportRepo.GetByIDs(ctx, reportIDs)
+	if err != nil {
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
+	}
+	reportsByID := make(map[uint]model.Report, len(reports))
+	for _, report := range reports {
+		reportsByID[report.ID] = report
+	}
+
+	items := make([]dto.FeedItem, 0, len(pageEntries))
+	for _, entry := range pageEntries {
+		report, ok := reportsByID[entry.ReportID]
+		if !ok {
+			continue
+		}
+		items = append(items, dto.FeedItem{
+			Report:  toFeedReport(report, userID),
+			Score:   entry.Score,
+			Sources: entry.Sources,
+		})
+	}
+
+	var nextCursor *string
+	if end < len(entries) {
+		next := fmt.Sprintf("%s:%d", snapshotID, end)
+		nextCursor = &next
+	}
+
+	return &dto.GetFeedResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (s *FeedService) buildFeedEntries(ctx context.Context, userID uint, now time.Time) ([]dto.FeedEntry, error) {
+	since := now.Add(-feedCandidateWindow).Unix()
+	sources := make(map[dto.FeedSource][]reportDTO.FeedCandidate)
+
+	following, err := s.followRepo.GetFollowingByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.New(500, "FOLLOWING_FETCH_FAILED", "Gagal mengambil data pengguna yang diikuti", err.Error(), nil)
+	}
+	if len(following) > 0 {
+		followingIDs := make([]uint, 0, len(following))
+		for _, user := range following {
+			followingIDs = append(followingIDs, user.ID)
+		}
+		candidates, err := s.reportRepo.GetFeedCandidatesByUserIDs(ctx, followingIDs, since, feedSourceLimit)
+		if err != nil {
+			return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan dari pengguna yang diikuti", err.Error(), nil)
+		}
+		sources[dto.FeedSourceFollowedUser] = candidates
+	}
+
+	profile, err := s.userProfileRepo.GetByID(ctx, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.New(500, "PROFILE_FETCH_FAILED", "Gagal mengambil profil", err.Error(), nil)
+	}
+	if profile != nil && profile.HomeLatitude != nil && profile.HomeLongitude != nil {
+		candidates, err := s.reportRepo.GetFeedCandidatesNearby(ctx, *profile.HomeLatitude, *profile.HomeLongitude, feedHomeRadiusMeters, since, feedSourceLimit)
+		if err != nil {
+			return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan di sekitar lokasi rumah", err.Error(), nil)
+		}
+		sources[dto.FeedSourceNearbyHome] = candidates
+	}
+
+	trending, err := s.reportRepo.GetFeedCandidatesTrending(ctx, feedSourceLimit)
+	if err != nil {
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan trending", err.Error(), nil)
+	}
+	sources[dto.FeedSourceTrending] = trending
+
+	return rankFeedEntries(sources, now.Unix()), nil
+}
+
+func rankFeedEntries(sources map[dto.FeedSource][]reportDTO.FeedCandidate, now int64) []dto.FeedEntry {
+	candidates := make(map[uint]reportDTO.FeedCandidate)
+	entrySources := make(map[uint][]dto.FeedSource)
+
+	for _, source := range []dto.FeedSource{dto.FeedSourceFollowedUser, dto.FeedSourceNearbyHome, dto.FeedSourceTrending} {
+		for _, candidate := range sources[source] {
+			candidates[candidate.ReportID] = candidate
+			entrySources[candidate.ReportID] = append(entrySources[candidate.ReportID], source)
+		}
+	}
+
+	entries := make([]dto.FeedEntry, 0, len(candidates))
+	for reportID, candidate := range candidates {
+		ageHours := math.Max(float64(now-candidate.CreatedAt)/3600, 0)
+		freshness := 10 / math.Pow(ageHours+2, 1.5)
+
+		boost := 1.0
+		for _, source := range entrySources[reportID] {
+			switch source {
+			case dto.FeedSourceFollowedUser:
+				boost += feedFollowedUserBoost
+			case dto.FeedSourceNearbyHome:
+				boost += feedNearbyHomeBoost
+			}
+		}
+
+		entries = append(entries, dto.FeedEntry{
+			ReportID: reportID,
+			Score:    math.Round((candidate.HotScore+freshness)*boost*10000) / 10000,
+			Sources:  entrySources[reportID],
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score == entries[j].Score {
+			return entries[i].ReportID > entries[j].ReportID
+		}
+		return entries[i].Score > entries[j].Score
+	})
+	return entries
+}
+
+func toFeedReport(report model.Report, viewerID uint) dto.FeedReport {
+	feedReport := dto.FeedReport{
+		ID:                report.ID,
+		ReportTitle:       report.ReportTitle,
+		ReportType:        string(report.ReportType),
+		ReportDescription: report.ReportDescription,
+		ReportStatus:      string(report.ReportStatus),
+		ReportCreatedAt:   report.CreatedAt,
+		UserID:            report.UserID,
+		UserName:          report.User.Username,
+		FullName:          report.User.FullName,
+		ProfilePicture:    report.User.Profile.ProfilePicture,
+		IsAnonymous:       report.IsAnonymous,
+		HotScore:          report.HotScore,
+	}
+	if reportUtil.ShouldRedactReportOwner(&report, viewerID) {
+		feedReport.UserID = 0
+		feedReport.UserName = reportUtil.AnonymousUsername
+		feedReport.FullName = reportUtil.AnonymousFullName
+		feedReport.ProfilePicture = nil
+	}
+	if report.ReportLocation != nil {
+		feedReport.Location = dto.FeedReportLocation{
+			DisplayName: report.ReportLocation.DisplayName,
+			Latitude:    report.ReportLocation.Latitude,
+			Longitude:   report.ReportLocation.Longitude,
+		}
+	}
+	if report.ReportImages != nil {
+		feedReport.Image1URL = report.ReportImages.Image1URL
+	}
+	return feedReport
+}
+
+func feedSnapshotKey(userID uint, snapshotID string) string {
+	return fmt.Sprintf("feed:%d:%s", userID, snapshotID)
+}
+
+func parseFeedCursor(cursor string) (string, int, error) {
+	snapshotID, rawOffset, found := strings.Cut(cursor, ":")
+	if !found || snapshotID == "" {
+		return "", 0, errors.New("cursor must be in snapshot:offset format")
+	}
+	offset, err := strconv.Atoi(rawOffset)
+	if err != nil || offset < 0 {
+		return "", 0, errors.New("cursor offset must be a non-negative number")
+	}
+	return snapshotID, offset, nil
+}
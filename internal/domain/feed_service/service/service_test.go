@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"pingspot/internal/domain/feed_service/dto"
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	"pingspot/internal/mocks"
+	reportMocks "pingspot/internal/mocks/report"
+	socialMocks "pingspot/internal/mocks/social"
+	userMocks "pingspot/internal/mocks/user"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupMocks() (*reportMocks.MockReportRepository, *socialMocks.MockFollowRepository, *userMocks.MockUserProfileRepository, *mocks.MockCacheRepository, *FeedService) {
+	mockReportRepo := new(reportMocks.MockReportRepository)
+	mockFollowRepo := new(socialMocks.MockFollowRepository)
+	mockUserProfileRepo := new(userMocks.MockUserProfileRepository)
+	mockCacheRepo := new(mocks.MockCacheRepository)
+	service := NewFeedService(mockReportRepo, mockFollowRepo, mockUserProfileRepo, mockCacheRepo)
+	return mockReportRepo, mockFollowRepo, mockUserProfileRepo, mockCacheRepo, service
+}
+
+func TestRankFeedEntries(t *testing.T) {
+	now := int64(1_760_000_000)
+
+	t.Run("should deduplicate reports and merge their sources", func(t *testing.T) {
+		entries := rankFeedEntries(map[dto.FeedSource][]reportDTO.FeedCandidate{
+			dto.FeedSourceFollowedUser: {{ReportID: 1, HotScore: 0.5, CreatedAt: now - 3600}},
+			dto.FeedSourceTrending:     {{ReportID: 1, HotScore: 0.5, CreatedAt: now - 3600}, {ReportID: 2, HotScore: 0.1, CreatedAt: now - 7200}},
+		}, now)
+
+		require.Len(t, entries, 2)
+		assert.Equal(t, uint(1), entries[0].ReportID)
+		assert.Equal(t, []dto.FeedSource{dto.FeedSourceFollowedUser, dto.FeedSourceTrending}, entries[0].Sources)
+		assert.Equal(t, []dto.FeedSource{dto.FeedSourceTrending}, entries[1].Sources)
+	})
+
+	t.Run("should boost reports from followed users and home area", func(t *testing.T) {
+		entries := rankFeedEntries(map[dto.FeedSource][]reportDTO.FeedCandidate{
+			dto.FeedSourceNearbyHome: {{ReportID: 3, HotScore: 0.2, CreatedAt: now - 3600}},
+			dto.FeedSourceTrending:   {{ReportID: 4, HotScore: 0.2, CreatedAt: now - 3600}},
+		}, now)
+
+		require.Len(t, entries, 2)
+		assert.Equal(t, uint(3), entries[0].ReportID)
+		assert.Greater(t, entries[0].Score, entries[1].Score)
+	})
+}
+
+func TestFeedService_GetFeed(t *testing.T) {
+	t.Run("should build a snapshot on the first page", func(t *testing.T) {
+		mockReportRepo, mockFollowRepo, mockUserProfileRepo, mockCacheRepo, service := setupMocks()
+		ctx := context.Background()
+		homeLat, homeLng := -6.2, 106.8
+
+		mockFollowRepo.On("GetFollowingByUserID", ctx, uint(1)).Return([]*model.User{{ID: 7}}, nil)
+		mockReportRepo.On("GetFeedCandidatesByUserIDs", ctx, []uint{7}, mock.Anything, feedSourceLimit).Return([]reportDTO.FeedCandidate{{ReportID: 10, CreatedAt: 1}}, nil)
+		mockUserProfileRepo.On("GetByID", ctx, uint(1)).Return(&model.UserProfile{UserID: 1, HomeLatitude: &homeLat, HomeLongitude: &homeLng}, nil)
+		mockReportRepo.On("GetFeedCandidatesNearby", ctx, homeLat, homeLng, feedHomeRadiusMeters, mock.Anything, feedSourceLimit).Return([]reportDTO.FeedCandidate{{ReportID: 10, CreatedAt: 1}}, nil)
+		mockReportRepo.On("GetFeedCandidatesTrending", ctx, feedSourceLimit).Return([]reportDTO.FeedCandidate{{ReportID: 11, HotScore: 0.3, CreatedAt: 1}}, nil)
+		mockCacheRepo.On("Set", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string"), feedSnapshotTTL).Return(nil)
+		mockReportRepo.On("GetByIDs", ctx, mock.Anything).Return([]model.Report{{ID: 10, ReportTitle: "Banjir"}, {ID: 11, ReportTitle: "Jalan rusak"}}, nil)
+
+		result, err := service.GetFeed(ctx, 1, "")
+
+		require.NoError(t, err)
+		require.Len(t, result.Items, 2)
+		assert.Nil(t, result.NextCursor)
+		mockReportRepo.AssertExpectations(t)
+		mockCacheRepo.AssertExpectations(t)
+	})
+
+	t.Run("should page through an existing snapshot", func(t *testing.T) {
+		mockReportRepo, mockFollowRepo, _, mockCacheRepo, service := setupMocks()
+		ctx := context.Background()
+
+		var entries []dto.FeedEntry
+		for i := 1; i <= feedPageSize+2; i++ {
+			entries = append(entries, dto.FeedEntry{ReportID: uint(i), Sources: []dto.FeedSource{dto.FeedSourceTrending}})
+		}
+		entriesJSON, _ := json.Marshal(entries)
+
+		mockCacheRepo.On("Get", ctx, "feed:1:abc").Return(string(entriesJSON), nil)
+		mockReportRepo.On("GetByIDs", ctx, []uint{11, 12}).Return([]model.Report{{ID: 12}, {ID: 11}}, nil)
+
+		result, err := service.GetFeed(ctx, 1, "abc:10")
+
+		require.NoError(t, err)
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, uint(11), result.Items[0].Report.ID)
+		assert.Nil(t, result.NextCursor)
+		mockFollowRepo.AssertNotCalled(t, "GetFollowingByUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return gone when snapshot expired", func(t *testing.T) {
+		_, _, _, mockCacheRepo, service := setupMocks()
+		ctx := context.Background()
+
+		mockCacheRepo.On("Get", ctx, "feed:1:abc").Return("", errors.New("redis: nil"))
+
+		result, err := service.GetFeed(ctx, 1, "abc:10")
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 410, appErr.StatusCode)
+	})
+
+	t.Run("should reject malformed cursor", func(t *testing.T) {
+		_, _, _, _, service := setupMocks()
+
+		result, err := service.GetFeed(context.Background(), 1, "abc")
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 400, appErr.StatusCode)
+	})
+
+	t.Run("should fall back to trending without follows or home location", func(t *testing.T) {
+		mockReportRepo, mockFollowRepo, mockUserProfileRepo, _, service := setupMocks()
+		ctx := context.Background()
+
+		mockFollowRepo.On("GetFollowingByUserID", ctx, uint(2)).Return([]*model.User{}, nil)
+		mockUserProfileRepo.On("GetByID", ctx, uint(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockReportRepo.On("GetFeedCandidatesTrending", ctx, feedSourceLimit).Return([]reportDTO.FeedCandidate{}, nil)
+
+		result, err := service.GetFeed(ctx, 2, "")
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Items)
+		mockReportRepo.AssertNotCalled(t, "GetFeedCandidatesNearby", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,25 @@
+package dto
+
+type UserActivityCounts struct {
+	Reports         int64
+	ResolvedReports int64
+	Votes           int64
+	HelpfulComments int64
+}
+
+type Badge struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Region      string `json:"region,omitempty"`
+	AwardedAt   int64  `json:"awardedAt"`
+}
+
+type LeaderboardEntry struct {
+	Rank           int64   `json:"rank"`
+	UserID         uint    `json:"userID"`
+	Username       string  `json:"username"`
+	FullName       string  `json:"fullName"`
+	ProfilePicture *string `json:"profilePicture"`
+	Score          float64 `json:"score"`
+}
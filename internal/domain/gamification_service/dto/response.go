@@ -0,0 +1,15 @@
+package dto
+
+type GetLeaderboardResponse struct {
+	Metric      string             `json:"metric"`
+	Period      string             `json:"period"`
+	PeriodKey   string             `json:"periodKey"`
+	Region      string             `json:"region"`
+	Entries     []LeaderboardEntry `json:"entries"`
+	CurrentUser *LeaderboardEntry  `json:"currentUser,omitempty"`
+}
+
+type GetUserBadgesResponse struct {
+	UserID uint    `json:"userID"`
+	Badges []Badge `json:"badges"`
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"pingspot/internal/domain/gamification_service/service"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	response "pingspot/pkg/utils/response_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type GamificationHandler struct {
+	gamificationService *service.GamificationService
+}
+
+func NewGamificationHandler(gamificationService *service.GamificationService) *GamificationHandler {
+	return &GamificationHandler{gamificationService: gamificationService}
+}
+
+func (h *GamificationHandler) GetLeaderboardHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	leaderboard, err := h.gamificationService.GetLeaderboard(ctx, userID, c.Query("metric"), c.Query("period"), c.Query("region"))
+	if err != nil {
+		logger.Error("Failed to get leaderboard", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan papan peringkat", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan papan peringkat", "data", leaderboard)
+}
+
+func (h *GamificationHandler) GetUserBadgesHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID, err := c.ParamsInt("userID")
+	if err != nil || userID <= 0 {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		return response.ResponseError(c, 400, "ID pengguna tidak valid", "", "ID pengguna harus berupa angka")
+	}
+
+	badges, err := h.gamificationService.GetUserBadges(ctx, uint(userID))
+	if err != nil {
+		logger.Error("Failed to get user badges", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan lencana pengguna", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan lencana pengguna", "data", badges)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/domain/gamification_service/dto"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GamificationRepository interface {
+	AwardBadgeTX(ctx context.Context, tx *gorm.DB, badge *model.UserBadge) (bool, error)
+	GetBadgesByUserID(ctx context.Context, userID uint) ([]model.UserBadge, error)
+	GetUserActivityCounts(ctx context.Context, userID uint) (*dto.UserActivityCounts, error)
+}
+
+type gamificationRepository struct {
+	db *gorm.DB
+}
+
+func NewGamificationRepository(db *gorm.DB) GamificationRepository {
+	return &gamificationRepository{db: db}
+}
+
+func (r *gamificationRepository) AwardBadgeTX(ctx context.Context, tx *gorm.DB, badge *model.UserBadge) (bool, error) {
+	result := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(badge)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *gamificationRepository) GetBadgesByUserID(ctx context.Context, userID uint) ([]model.UserBadge, error) {
+	var badges []model.UserBadge
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("awarded_at DESC").
+		Find(&badges).Error; err != nil {
+		return nil, err
+	}
+	return badges, nil
+}
+
+func (r *gamificationRepository) GetUserActivityCounts(ctx context.Context, userID uint) (*dto.UserActivityCounts, error) {
+	var counts dto.UserActivityCounts
+
+	if err := r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("user_id = ? AND merged_into_id IS NULL", userID).
+		Count(&counts.Reports).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("user_id = ? AND report_status = ? AND merged_into_id IS NULL", userID, model.RESOLVED).
+		Count(&counts.ResolvedReports).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&model.ReportVote{}).
+		Where("user_id = ?", userID).
+		Count(&counts.Votes).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&model.ReputationEvent{}).
+		Where("user_id = ? AND event_type = ?", userID, model.ReputationHelpfulComment).
+		Count(&counts.HelpfulComments).Error; err != nil {
+		return nil, err
+	}
+
+	return &counts, nil
+}
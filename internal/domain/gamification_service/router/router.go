@@ -0,0 +1,49 @@
+package router
+
+import (
+	"pingspot/internal/domain/gamification_service/handler"
+	gamificationRepository "pingspot/internal/domain/gamification_service/repository"
+	"pingspot/internal/domain/gamification_service/service"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/cache"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	cacheRepository "pingspot/internal/repository"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterGamificationRoutes(app *fiber.App) {
+	db := database.GetPostgresDB()
+	rdb := cache.GetRedis()
+	gamificationRepo := gamificationRepository.NewGamificationRepository(db)
+	userRepo := userRepository.NewUserRepository(db)
+	cacheRepo := cacheRepository.NewCacheRepository(&rdb)
+	gamificationService := service.NewGamificationService(gamificationRepo, userRepo, cacheRepo)
+	gamificationHandler := handler.NewGamificationHandler(gamificationService)
+
+	gamificationRoute := app.Group("/pingspot/api/gamification", middleware.ValidateAccessToken())
+
+	gamificationRoute.Get(
+		"/leaderboard",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 60,
+			KeyPrefix:   "get_leaderboard",
+		})),
+		gamificationHandler.GetLeaderboardHandler,
+	)
+
+	gamificationRoute.Get(
+		"/badges/:userID",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 60,
+			KeyPrefix:   "get_user_badges",
+		})),
+		gamificationHandler.GetUserBadgesHandler,
+	)
+}
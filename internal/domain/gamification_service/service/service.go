@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/domain/gamification_service/dto"
+	gamificationRepository "pingspot/internal/domain/gamification_service/repository"
+	"pingspot/internal/domain/gamification_service/util"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/model"
+	cacheRepository "pingspot/internal/repository"
+	apperror "pingspot/pkg/app_error"
+	contextutils "pingspot/pkg/utils/context_util"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const leaderboardSize = 50
+
+type GamificationService struct {
+	gamificationRepo gamificationRepository.GamificationRepository
+	userRepo         userRepository.UserRepository
+	cacheRepo        cacheRepository.CacheRepository
+}
+
+func NewGamificationService(gamificationRepo gamificationRepository.GamificationRepository, userRepo userRepository.UserRepository, cacheRepo cacheRepository.CacheRepository) *GamificationService {
+	return &GamificationService{
+		gamificationRepo: gamificationRepo,
+		userRepo:         userRepo,
+		cacheRepo:        cacheRepo,
+	}
+}
+
+func (s *GamificationService) GetLeaderboard(ctx context.Context, userID uint, metric, period, region string) (*dto.GetLeaderboardResponse, error) {
+	leaderboardMetric := util.LeaderboardMetric(metric)
+	if metric == "" {
+		leaderboardMetric = util.LeaderboardPoints
+	}
+	if leaderboardMetric != util.LeaderboardPoints && leaderboardMetric != util.LeaderboardVotes {
+		return nil, apperror.New(400, "INVALID_LEADERBOARD_METRIC", "metrik papan peringkat tidak valid", "", nil)
+	}
+
+	leaderboardPeriod := util.LeaderboardPeriod(period)
+	if period == "" {
+		leaderboardPeriod = util.LeaderboardWeekly
+	}
+	if leaderboardPeriod != util.LeaderboardWeekly && leaderboardPeriod != util.LeaderboardMonthly && leaderboardPeriod != util.LeaderboardAllTime {
+		return nil, apperror.New(400, "INVALID_LEADERBOARD_PERIOD", "periode papan peringkat tidak valid", "", nil)
+	}
+
+	normalizedRegion := util.NormalizeRegion(region)
+	if normalizedRegion == "" {
+		normalizedRegion = util.GlobalRegion
+	}
+
+	periodKey := util.GetPeriodKey(leaderboardPeriod, time.Now())
+	key := util.GetLeaderboardKey(leaderboardMetric, leaderboardPeriod, periodKey, normalizedRegion)
+
+	members, err := s.cacheRepo.ZRevRangeWithScores(ctx, key, 0, leaderboardSize-1)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, apperror.New(500, "LEADERBOARD_FETCH_FAILED", "gagal mengambil papan peringkat", err.Error(), nil)
+	}
+
+	userIDs := make([]uint, 0, len(members)+1)
+	for _, member := range members {
+		memberID, err := strconv.ParseUint(member.Member.(string), 10, 64)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, uint(memberID))
+	}
+
+	var currentUser *dto.LeaderboardEntry
+	rank, err := s.cacheRepo.ZRevRank(ctx, key, strconv.FormatUint(uint64(userID), 10))
+	if err == nil {
+		score, err := s.cacheRepo.ZScore(ctx, key, strconv.FormatUint(uint64(userID), 10))
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, apperror.New(500, "LEADERBOARD_FETCH_FAILED", "gagal mengambil papan peringkat", err.Error(), nil)
+		}
+		currentUser = &dto.LeaderboardEntry{Rank: rank + 1, UserID: userID, Score: score}
+		if rank >= leaderboardSize {
+			userIDs = append(userIDs, userID)
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		return nil, apperror.New(500, "LEADERBOARD_FETCH_FAILED", "gagal mengambil papan peringkat", err.Error(), nil)
+	}
+
+	usersByID := make(map[uint]model.User, len(userIDs))
+	if len(userIDs) > 0 {
+		users, err := s.userRepo.GetByIDs(ctx, userIDs)
+		if err != nil {
+			return nil, apperror.New(500, "USER_FETCH_FAILED", "gagal mengambil data pengguna", err.Error(), nil)
+		}
+		for _, user := range users {
+			usersByID[user.ID] = user
+		}
+	}
+
+	entries := make([]dto.LeaderboardEntry, 0, len(members))
+	for i, member := range members {
+		memberID, err := strconv.ParseUint(member.Member.(string), 10, 64)
+		if err != nil {
+			continue
+		}
+		user, ok := usersByID[uint(memberID)]
+		if !ok {
+			continue
+		}
+		entries = append(entries, mapLeaderboardEntry(int64(i+1), user, member.Score))
+	}
+
+	if currentUser != nil {
+		if user, ok := usersByID[userID]; ok {
+			entry := mapLeaderboardEntry(currentUser.Rank, user, currentUser.Score)
+			currentUser = &entry
+		}
+	}
+
+	return &dto.GetLeaderboardResponse{
+		Metric:      string(leaderboardMetric),
+		Period:      string(leaderboardPeriod),
+		PeriodKey:   periodKey,
+		Region:      normalizedRegion,
+		Entries:     entries,
+		CurrentUser: currentUser,
+	}, nil
+}
+
+func (s *GamificationService) GetUserBadges(ctx context.Context, userID uint) (*dto.GetUserBadgesResponse, error) {
+	badges, err := s.gamificationRepo.GetBadgesByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.New(500, "BADGE_FETCH_FAILED", "gagal mengambil lencana pengguna", err.Error(), nil)
+	}
+
+	badgesDTO := make([]dto.Badge, 0, len(badges))
+	for _, badge := range badges {
+		definition := util.GetBadgeDefinition(contextutils.GetLanguage(ctx), badge.BadgeCode)
+		badgesDTO = append(badgesDTO, dto.Badge{
+			Code:        string(badge.BadgeCode),
+			Name:        definition.Name,
+			Description: definition.Description,
+			Region:      badge.Region,
+			AwardedAt:   badge.AwardedAt,
+		})
+	}
+
+	return &dto.GetUserBadgesResponse{
+		UserID: userID,
+		Badges: badgesDTO,
+	}, nil
+}
+
+func mapLeaderboardEntry(rank int64, user model.User, score float64) dto.LeaderboardEntry {
+	return dto.LeaderboardEntry{
+		Rank:           rank,
+		UserID:         user.ID,
+		Username:       user.Username,
+		FullName:       user.FullName,
+		ProfilePicture: user.Profile.ProfilePicture,
+		Score:          score,
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"pingspot/internal/mocks"
+	gamificationMocks "pingspot/internal/mocks/gamification"
+	userMocks "pingspot/internal/mocks/user"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMocks() (*gamificationMocks.MockGamificationRepository, *userMocks.MockUserRepository, *mocks.MockCacheRepository, *GamificationService) {
+	mockGamificationRepo := new(gamificationMocks.MockGamificationRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+	mockCacheRepo := new(mocks.MockCacheRepository)
+	service := NewGamificationService(mockGamificationRepo, mockUserRepo, mockCacheRepo)
+	return mockGamificationRepo, mockUserRepo, mockCacheRepo, service
+}
+
+func regionKey(region string) any {
+	return mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "leaderboard:points:weekly:") && strings.HasSuffix(key, ":"+region)
+	})
+}
+
+func TestGamificationService_GetLeaderboard(t *testing.T) {
+	t.Run("should return regional leaderboard with current user rank", func(t *testing.T) {
+		_, mockUserRepo, mockCacheRepo, service := setupMocks()
+		ctx := context.Background()
+
+		mockCacheRepo.On("ZRevRangeWithScores", ctx, regionKey("jakarta-selatan"), int64(0), int64(leaderboardSize-1)).Return([]redis.Z{
+			{Member: "4", Score: 30},
+			{Member: "1", Score: 12},
+		}, nil)
+		mockCacheRepo.On("ZRevRank", ctx, regionKey("jakarta-selatan"), "1").Return(int64(1), nil)
+		mockCacheRepo.On("ZScore", ctx, regionKey("jakarta-selatan"), "1").Return(float64(12), nil)
+		mockUserRepo.On("GetByIDs", ctx, []uint{4, 1}).Return([]model.User{
+			{ID: 1, Username: "andi"},
+			{ID: 4, Username: "budi"},
+		}, nil)
+
+		result, err := service.GetLeaderboard(ctx, 1, "", "", "Jakarta Selatan")
+
+		require.NoError(t, err)
+		assert.Equal(t, "jakarta-selatan", result.Region)
+		require.Len(t, result.Entries, 2)
+		assert.Equal(t, "budi", result.Entries[0].Username)
+		assert.Equal(t, int64(1), result.Entries[0].Rank)
+		require.NotNil(t, result.CurrentUser)
+		assert.Equal(t, int64(2), result.CurrentUser.Rank)
+		assert.Equal(t, "andi", result.CurrentUser.Username)
+	})
+
+	t.Run("should default to global leaderboard for unranked user", func(t *testing.T) {
+		_, mockUserRepo, mockCacheRepo, service := setupMocks()
+		ctx := context.Background()
+
+		mockCacheRepo.On("ZRevRangeWithScores", ctx, regionKey("global"), int64(0), int64(leaderboardSize-1)).Return([]redis.Z{}, nil)
+		mockCacheRepo.On("ZRevRank", ctx, regionKey("global"), "2").Return(int64(0), redis.Nil)
+
+		result, err := service.GetLeaderboard(ctx, 2, "points", "weekly", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "global", result.Region)
+		assert.Empty(t, result.Entries)
+		assert.Nil(t, result.CurrentUser)
+		mockUserRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject invalid period", func(t *testing.T) {
+		_, _, _, service := setupMocks()
+
+		result, err := service.GetLeaderboard(context.Background(), 1, "points", "daily", "")
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 400, appErr.StatusCode)
+	})
+}
+
+func TestGamificationService_GetUserBadges(t *testing.T) {
+	t.Run("should map badge definitions", func(t *testing.T) {
+		mockGamificationRepo, _, _, service := setupMocks()
+		ctx := context.Background()
+
+		mockGamificationRepo.On("GetBadgesByUserID", ctx, uint(3)).Return([]model.UserBadge{
+			{UserID: 3, BadgeCode: model.BadgeTopRegionalVoter, Region: "bandung", AwardedAt: 100},
+			{UserID: 3, BadgeCode: model.BadgeFirstReport, AwardedAt: 50},
+		}, nil)
+
+		result, err := service.GetUserBadges(ctx, 3)
+
+		require.NoError(t, err)
+		require.Len(t, result.Badges, 2)
+		assert.Equal(t, "TOP_REGIONAL_VOTER", result.Badges[0].Code)
+		assert.Equal(t, "bandung", result.Badges[0].Region)
+		assert.Equal(t, "Pelapor Pertama", result.Badges[1].Name)
+	})
+}
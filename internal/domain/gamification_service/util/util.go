@@ -0,0 +1,143 @@
+package util
+
+import (
+	"fmt"
+	"pingspot/internal/domain/gamification_service/dto"
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type LeaderboardPeriod string
+
+const (
+	LeaderboardWeekly  LeaderboardPeriod = "weekly"
+	LeaderboardMonthly LeaderboardPeriod = "monthly"
+	LeaderboardAllTime LeaderboardPeriod = "all_time"
+)
+
+type LeaderboardMetric string
+
+const (
+	LeaderboardPoints LeaderboardMetric = "points"
+	LeaderboardVotes  LeaderboardMetric = "votes"
+)
+
+const (
+	GlobalRegion             = "global"
+	TopRegionalVoterMinVotes = 5
+	EventDedupeTTL           = 400 * 24 * time.Hour
+)
+
+var LeaderboardPeriods = []LeaderboardPeriod{LeaderboardWeekly, LeaderboardMonthly, LeaderboardAllTime}
+
+type BadgeDefinition struct {
+	Name        string
+	Description string
+}
+
+func GetBadgeNameKey(code model.BadgeCode) string {
+	return fmt.Sprintf("badge.%s.name", code)
+}
+
+func GetBadgeDescriptionKey(code model.BadgeCode) string {
+	return fmt.Sprintf("badge.%s.description", code)
+}
+
+func GetBadgeDefinition(language i18n.Language, code model.BadgeCode) BadgeDefinition {
+	return BadgeDefinition{
+		Name:        i18n.T(language, GetBadgeNameKey(code), nil),
+		Description: i18n.T(language, GetBadgeDescriptionKey(code), nil),
+	}
+}
+
+var regionSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+func GetActivityPoints(eventType model.GamificationEventType) float64 {
+	switch eventType {
+	case model.GamificationReportCreated:
+		return 5
+	case model.GamificationVoteCast:
+		return 1
+	case model.GamificationReportResolved:
+		return 10
+	case model.GamificationCommentHelpful:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func GetPeriodKey(period LeaderboardPeriod, t time.Time) string {
+	switch period {
+	case LeaderboardWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case LeaderboardMonthly:
+		return t.Format("2006-01")
+	default:
+		return "all"
+	}
+}
+
+func GetPeriodTTL(period LeaderboardPeriod) time.Duration {
+	switch period {
+	case LeaderboardWeekly:
+		return 8 * 7 * 24 * time.Hour
+	case LeaderboardMonthly:
+		return 400 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+func GetLeaderboardKey(metric LeaderboardMetric, period LeaderboardPeriod, periodKey, region string) string {
+	return fmt.Sprintf("leaderboard:%s:%s:%s:%s", metric, period, periodKey, region)
+}
+
+// GetEventDedupeKey identifies one gamification event. Events without an
+// explicit ID count once per user, type and report.
+func GetEventDedupeKey(eventID string, userID uint, eventType model.GamificationEventType, reportID uint) string {
+	if eventID != "" {
+		return fmt.Sprintf("gamification:event:%s", eventID)
+	}
+	return fmt.Sprintf("gamification:event:%d:%s:%d", userID, eventType, reportID)
+}
+
+func NormalizeRegion(region string) string {
+	slug := regionSlugPattern.ReplaceAllString(strings.ToLower(strings.TrimSpace(region)), "-")
+	return strings.Trim(slug, "-")
+}
+
+func GetReportRegion(location *model.ReportLocation) string {
+	if location == nil {
+		return ""
+	}
+	for _, candidate := range []*string{location.County, location.State, location.Region} {
+		if candidate != nil {
+			if region := NormalizeRegion(*candidate); region != "" {
+				return region
+			}
+		}
+	}
+	return ""
+}
+
+func GetEarnedBadges(counts dto.UserActivityCounts) []model.BadgeCode {
+	var badges []model.BadgeCode
+	if counts.Reports >= 1 {
+		badges = append(badges, model.BadgeFirstReport)
+	}
+	if counts.ResolvedReports >= 10 {
+		badges = append(badges, model.BadgeResolvedReports10)
+	}
+	if counts.Votes >= 50 {
+		badges = append(badges, model.BadgeActiveVoter50)
+	}
+	if counts.HelpfulComments >= 5 {
+		badges = append(badges, model.BadgeHelpfulCommenter5)
+	}
+	return badges
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"pingspot/internal/domain/gamification_service/dto"
+	"pingspot/internal/model"
+	mainutils "pingspot/pkg/utils/main_util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPeriodKey(t *testing.T) {
+	date := time.Date(2026, time.October, 18, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2026-W42", GetPeriodKey(LeaderboardWeekly, date))
+	assert.Equal(t, "2026-10", GetPeriodKey(LeaderboardMonthly, date))
+	assert.Equal(t, "all", GetPeriodKey(LeaderboardAllTime, date))
+}
+
+func TestGetReportRegion(t *testing.T) {
+	t.Run("should prefer county over state", func(t *testing.T) {
+		location := &model.ReportLocation{
+			County: mainutils.StrPtrOrNil("Jakarta Selatan"),
+			State:  mainutils.StrPtrOrNil("DKI Jakarta"),
+		}
+		assert.Equal(t, "jakarta-selatan", GetReportRegion(location))
+	})
+
+	t.Run("should fall back to state", func(t *testing.T) {
+		location := &model.ReportLocation{State: mainutils.StrPtrOrNil("Jawa Barat")}
+		assert.Equal(t, "jawa-barat", GetReportRegion(location))
+	})
+
+	t.Run("should return empty without location", func(t *testing.T) {
+		assert.Equal(t, "", GetReportRegion(nil))
+	})
+}
+
+func TestGetEarnedBadges(t *testing.T) {
+	assert.Empty(t, GetEarnedBadges(dto.UserActivityCounts{}))
+	assert.Equal(t, []model.BadgeCode{model.BadgeFirstReport}, GetEarnedBadges(dto.UserActivityCounts{Reports: 3, ResolvedReports: 9, Votes: 49}))
+	assert.Equal(t,
+		[]model.BadgeCode{model.BadgeFirstReport, model.BadgeResolvedReports10, model.BadgeActiveVoter50, model.BadgeHelpfulCommenter5},
+		GetEarnedBadges(dto.UserActivityCounts{Reports: 12, ResolvedReports: 10, Votes: 50, HelpfulComments: 5}),
+	)
+}
+
+func TestGetEventDedupeKey(t *testing.T) {
+	assert.Equal(t, "gamification:event:7:VOTE_CAST:12", GetEventDedupeKey("", 7, model.GamificationVoteCast, 12))
+	assert.Equal(t, "gamification:event:reputation:3", GetEventDedupeKey("reputation:3", 7, model.GamificationCommentHelpful, 12))
+}
@@ -0,0 +1,25 @@
+package dto
+
+type IncidentAlert struct {
+	ID            uint    `json:"id"`
+	ReportType    string  `json:"reportType"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+	RadiusMeters  int     `json:"radiusMeters"`
+	ReportCount   int64   `json:"reportCount"`
+	BaselineCount float64 `json:"baselineCount"`
+	WindowStart   int64   `json:"windowStart"`
+	WindowEnd     int64   `json:"windowEnd"`
+	Status        string  `json:"status"`
+	ResolvedAt    *int64  `json:"resolvedAt,omitempty"`
+	CreatedAt     int64   `json:"createdAt"`
+}
+
+type AreaSubscription struct {
+	ID           uint    `json:"id"`
+	Label        *string `json:"label"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	RadiusMeters int     `json:"radiusMeters"`
+	CreatedAt    int64   `json:"createdAt"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type SubscribeAreaRequest struct {
+	Label        *string `json:"label" validate:"omitempty,max=100"`
+	Latitude     float64 `json:"latitude" validate:"required,latitude"`
+	Longitude    float64 `json:"longitude" validate:"required,longitude"`
+	RadiusMeters int     `json:"radiusMeters" validate:"required,min=100,max=10000"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+type GetIncidentAlertsResponse struct {
+	Alerts []IncidentAlert `json:"alerts"`
+}
+
+type GetAreaSubscriptionsResponse struct {
+	Subscriptions []AreaSubscription `json:"subscriptions"`
+}
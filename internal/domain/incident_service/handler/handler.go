@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"pingspot/internal/domain/incident_service/dto"
+	"pingspot/internal/domain/incident_service/service"
+	"pingspot/internal/domain/incident_service/validation"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	response "pingspot/pkg/utils/response_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type IncidentHandler struct {
+	incidentService *service.IncidentService
+}
+
+func NewIncidentHandler(incidentService *service.IncidentService) *IncidentHandler {
+	return &IncidentHandler{incidentService: incidentService}
+}
+
+func (h *IncidentHandler) GetIncidentAlertsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	cursorID := c.Query("cursorID")
+	status := c.Query("status")
+	cursorIDUint, err := mainutils.StringToUint(cursorID)
+	if err != nil {
+		logger.Error("Invalid cursorID format", zap.String("cursorID", cursorID), zap.Error(err))
+		return response.ResponseError(c, 400, "Format cursorID tidak valid", "", "cursorID harus berupa angka")
+	}
+
+	alerts, err := h.incidentService.GetIncidentAlerts(ctx, userID, cursorIDUint, status)
+	if err != nil {
+		logger.Error("Failed to get incident alerts", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan peringatan insiden", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan peringatan insiden", "data", alerts)
+}
+
+func (h *IncidentHandler) ResolveIncidentAlertHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	alertID, err := c.ParamsInt("alertID")
+	if err != nil {
+		logger.Error("Failed to parse alert ID", zap.Error(err))
+		return response.ResponseError(c, 400, "ID peringatan tidak valid", "", "ID peringatan harus berupa angka")
+	}
+
+	alert, err := h.incidentService.ResolveIncidentAlert(ctx, userID, uint(alertID))
+	if err != nil {
+		logger.Error("Failed to resolve incident alert", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menyelesaikan peringatan insiden", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menyelesaikan peringatan insiden", "data", alert)
+}
+
+func (h *IncidentHandler) SubscribeAreaHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	var req dto.SubscribeAreaRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatSubscribeAreaValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	subscription, err := h.incidentService.SubscribeArea(ctx, userID, req)
+	if err != nil {
+		logger.Error("Failed to subscribe area", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal berlangganan area", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 201, "Berhasil berlangganan area", "data", subscription)
+}
+
+func (h *IncidentHandler) GetAreaSubscriptionsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	subscriptions, err := h.incidentService.GetAreaSubscriptions(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get area subscriptions", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan langganan area", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan langganan area", "data", subscriptions)
+}
+
+func (h *IncidentHandler) DeleteAreaSubscriptionHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	subscriptionID, err := c.ParamsInt("subscriptionID")
+	if err != nil {
+		logger.Error("Failed to parse subscription ID", zap.Error(err))
+		return response.ResponseError(c, 400, "ID langganan tidak valid", "", "ID langganan harus berupa angka")
+	}
+
+	if err := h.incidentService.DeleteAreaSubscription(ctx, userID, uint(subscriptionID)); err != nil {
+		logger.Error("Failed to delete area subscription", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menghapus langganan area", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menghapus langganan area", "data", nil)
+}
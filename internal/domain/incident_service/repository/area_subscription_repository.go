@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type AreaSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *model.AreaSubscription) error
+	GetByID(ctx context.Context, id uint) (*model.AreaSubscription, error)
+	GetByUserID(ctx context.Context, userID uint) ([]model.AreaSubscription, error)
+	CountByUserID(ctx context.Context, userID uint) (int64, error)
+	Delete(ctx context.Context, subscription *model.AreaSubscription) error
+	GetSubscriberIDsNear(ctx context.Context, lat, lng float64) ([]uint, error)
+}
+
+type areaSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewAreaSubscriptionRepository(db *gorm.DB) AreaSubscriptionRepository {
+	return &areaSubscriptionRepository{db: db}
+}
+
+func (r *areaSubscriptionRepository) Create(ctx context.Context, subscription *model.AreaSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *areaSubscriptionRepository) GetByID(ctx context.Context, id uint) (*model.AreaSubscription, error) {
+	var subscription model.AreaSubscription
+	if err := r.db.WithContext(ctx).First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *areaSubscriptionRepository) GetByUserID(ctx context.Context, userID uint) ([]model.AreaSubscription, error) {
+	var subscriptions []model.AreaSubscription
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *areaSubscriptionRepository) CountByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.AreaSubscription{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *areaSubscriptionRepository) Delete(ctx context.Context, subscription *model.AreaSubscription) error {
+	return r.db.WithContext(ctx).Delete(subscription).Error
+}
+
+func (r *areaSubscriptionRepository) GetSubscriberIDsNear(ctx context.Context, lat, lng float64) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.WithContext(ctx).
+		Model(&model.AreaSubscription{}).
+		Distinct("user_id").
+		Where(`
+			ST_DWithin(
+				ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography,
+				ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography,
+				radius_meters
+			)
+		`, lng, lat).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type IncidentAlertRepository interface {
+	CreateTX(ctx context.Context, tx *gorm.DB, alert *model.IncidentAlert) error
+	UpdateTX(ctx context.Context, tx *gorm.DB, alert *model.IncidentAlert) error
+	GetByID(ctx context.Context, id uint) (*model.IncidentAlert, error)
+	GetActiveNearby(ctx context.Context, reportType model.ReportType, lat, lng float64, radiusMeters int, since int64) (*model.IncidentAlert, error)
+	GetActiveNearbyTX(ctx context.Context, tx *gorm.DB, reportType model.ReportType, lat, lng float64, radiusMeters int, since int64) (*model.IncidentAlert, error)
+	LockReportTypeTX(ctx context.Context, tx *gorm.DB, reportType model.ReportType) error
+	GetPaginated(ctx context.Context, limit, cursorID uint, status string) ([]model.IncidentAlert, error)
+}
+
+type incidentAlertRepository struct {
+	db *gorm.DB
+}
+
+func NewIncidentAlertRepository(db *gorm.DB) IncidentAlertRepository {
+	return &incidentAlertRepository{db: db}
+}
+
+func (r *incidentAlertRepository) CreateTX(ctx context.Context, tx *gorm.DB, alert *model.IncidentAlert) error {
+	return tx.WithContext(ctx).Create(alert).Error
+}
+
+func (r *incidentAlertRepository) UpdateTX(ctx context.Context, tx *gorm.DB, alert *model.IncidentAlert) error {
+	return tx.WithContext(ctx).Save(alert).Error
+}
+
+func (r *incidentAlertRepository) GetByID(ctx context.Context, id uint) (*model.IncidentAlert, error) {
+	var alert model.IncidentAlert
+	if err := r.db.WithContext(ctx).First(&alert, id).Error; err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+func (r *incidentAlertRepository) GetActiveNearby(ctx context.Context, reportType model.ReportType, lat, lng float64, radiusMeters int, since int64) (*model.IncidentAlert, error) {
+	return r.GetActiveNearbyTX(ctx, r.db, reportType, lat, lng, radiusMeters, since)
+}
+
+func (r *incidentAlertRepository) GetActiveNearbyTX(ctx context.Context, tx *gorm.DB, reportType model.ReportType, lat, lng float64, radiusMeters int, since int64) (*model.IncidentAlert, error) {
+	var alert model.IncidentAlert
+	err := tx.WithContext(ctx).
+		Where("report_type = ? AND status = ? AND created_at >= ?", reportType, model.IncidentAlertActive, since).
+		Where(`
+			ST_DWithin(
+				ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography,
+				ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography,
+				?
+			)
+		`, lng, lat, radiusMeters).
+		Order("created_at DESC").
+		First(&alert).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// LockReportTypeTX serializes alert creation for one report type until tx
+// ends, so the nearby check and the insert cannot interleave across workers.
+func (r *incidentAlertRepository) LockReportTypeTX(ctx context.Context, tx *gorm.DB, reportType model.ReportType) error {
+	return tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "incident_alert:"+string(reportType)).Error
+}
+
+func (r *incidentAlertRepository) GetPaginated(ctx context.Context, limit, cursorID uint, status string) ([]model.IncidentAlert, error) {
+	var alerts []model.IncidentAlert
+	query := r.db.WithContext(ctx).Model(&model.IncidentAlert{})
+	if status != "" && status != "all" {
+		query = query.Where("status = ?", status)
+	}
+	if cursorID != 0 {
+		query = query.Where("id < ?", cursorID)
+	}
+	if err := query.Order("id DESC").Limit(int(limit)).Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
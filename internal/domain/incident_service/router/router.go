@@ -0,0 +1,79 @@
+package router
+
+import (
+	"pingspot/internal/domain/incident_service/handler"
+	incidentRepository "pingspot/internal/domain/incident_service/repository"
+	"pingspot/internal/domain/incident_service/service"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterIncidentRoutes(app *fiber.App) {
+	db := database.GetPostgresDB()
+	incidentAlertRepo := incidentRepository.NewIncidentAlertRepository(db)
+	areaSubscriptionRepo := incidentRepository.NewAreaSubscriptionRepository(db)
+	userRepo := userRepository.NewUserRepository(db)
+	incidentService := service.NewIncidentService(db, incidentAlertRepo, areaSubscriptionRepo, userRepo)
+	incidentHandler := handler.NewIncidentHandler(incidentService)
+
+	incidentRoute := app.Group("/pingspot/api/incident", middleware.ValidateAccessToken())
+
+	incidentRoute.Get(
+		"/alert",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 60,
+			KeyPrefix:   "get_incident_alerts",
+		})),
+		incidentHandler.GetIncidentAlertsHandler,
+	)
+
+	incidentRoute.Patch(
+		"/alert/:alertID/resolve",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix:   "resolve_incident_alert",
+		})),
+		incidentHandler.ResolveIncidentAlertHandler,
+	)
+
+	incidentRoute.Get(
+		"/subscription",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 60,
+			KeyPrefix:   "get_area_subscriptions",
+		})),
+		incidentHandler.GetAreaSubscriptionsHandler,
+	)
+
+	incidentRoute.Post(
+		"/subscription",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      10 * time.Minute,
+			MaxRequests: 10,
+			KeyPrefix:   "subscribe_area",
+		})),
+		incidentHandler.SubscribeAreaHandler,
+	)
+
+	incidentRoute.Delete(
+		"/subscription/:subscriptionID",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      10 * time.Minute,
+			MaxRequests: 10,
+			KeyPrefix:   "delete_area_subscription",
+		})),
+		incidentHandler.DeleteAreaSubscriptionHandler,
+	)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/domain/incident_service/dto"
+	incidentRepository "pingspot/internal/domain/incident_service/repository"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const maxAreaSubscriptionsPerUser = 5
+
+type IncidentService struct {
+	db                   *gorm.DB
+	incidentAlertRepo    incidentRepository.IncidentAlertRepository
+	areaSubscriptionRepo incidentRepository.AreaSubscriptionRepository
+	userRepo             userRepository.UserRepository
+}
+
+func NewIncidentService(db *gorm.DB, incidentAlertRepo incidentRepository.IncidentAlertRepository, areaSubscriptionRepo incidentRepository.AreaSubscriptionRepository, userRepo userRepository.UserRepository) *IncidentService {
+	return &IncidentService{
+		db:                   db,
+		incidentAlertRepo:    incidentAlertRepo,
+		areaSubscriptionRepo: areaSubscriptionRepo,
+		userRepo:             userRepo,
+	}
+}
+
+func (s *IncidentService) getStaffUser(ctx context.Context, userID uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "USER_NOT_FOUND", "pengguna tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "USER_FETCH_FAILED", "gagal mengambil data pengguna", err.Error(), nil)
+	}
+	if user.Role != model.UserRoleModerator && user.Role != model.UserRoleAgency {
+		return nil, apperror.New(403, "FORBIDDEN", "anda tidak memiliki akses ke fitur ini", "", nil)
+	}
+	return user, nil
+}
+
+func (s *IncidentService) GetIncidentAlerts(ctx context.Context, userID, cursorID uint, status string) (*dto.GetIncidentAlertsResponse, error) {
+	if _, err := s.getStaffUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.incidentAlertRepo.GetPaginated(ctx, 20, cursorID, status)
+	if err != nil {
+		logger.Error("Failed to get incident alerts", zap.Error(err))
+		return nil, apperror.New(500, "INCIDENT_ALERT_FETCH_FAILED", "gagal mengambil data peringatan insiden", err.Error(), nil)
+	}
+
+	alertsDTO := make([]dto.IncidentAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		alertsDTO = append(alertsDTO, mapIncidentAlert(alert))
+	}
+	return &dto.GetIncidentAlertsResponse{Alerts: alertsDTO}, nil
+}
+
+func (s *IncidentService) ResolveIncidentAlert(ctx context.Context, userID, alertID uint) (*dto.IncidentAlert, error) {
+	user, err := s.getStaffUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	alert, err := s.incidentAlertRepo.GetByID(ctx, alertID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "INCIDENT_ALERT_NOT_FOUND", "peringatan insiden tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "INCIDENT_ALERT_FETCH_FAILED", "gagal mengambil data peringatan insiden", err.Error(), nil)
+	}
+	if alert.Status == model.IncidentAlertResolved {
+		return nil, apperror.New(400, "INCIDENT_ALERT_ALREADY_RESOLVED", "peringatan insiden sudah diselesaikan", "", nil)
+	}
+
+	alert.Status = model.IncidentAlertResolved
+	alert.ResolvedAt = mainutils.Int64PtrOrNil(time.Now().Unix())
+	alert.ResolvedByID = &user.ID
+
+	tx := s.db.Begin()
+	if err := s.incidentAlertRepo.UpdateTX(ctx, tx, alert); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to resolve incident alert", zap.Uint("alert_id", alertID), zap.Error(err))
+		return nil, apperror.New(500, "INCIDENT_ALERT_UPDATE_FAILED", "gagal memperbarui peringatan insiden", err.Error(), nil)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal commit transaksi", err.Error(), nil)
+	}
+
+	result := mapIncidentAlert(*alert)
+	return &result, nil
+}
+
+func (s *IncidentService) SubscribeArea(ctx context.Context, userID uint, req dto.SubscribeAreaRequest) (*dto.AreaSubscription, error) {
+	total, err := s.areaSubscriptionRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.New(500, "AREA_SUBSCRIPTION_FETCH_FAILED", "gagal mengambil data langganan area", err.Error(), nil)
+	}
+	if total >= maxAreaSubscriptionsPerUser {
+		return nil, apperror.New(400, "AREA_SUBSCRIPTION_LIMIT_REACHED", "jumlah langganan area sudah mencapai batas maksimum", "", nil)
+	}
+
+	subscription := &model.AreaSubscription{
+		UserID:       userID,
+		Label:        req.Label,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		RadiusMeters: req.RadiusMeters,
+	}
+	if err := s.areaSubscriptionRepo.Create(ctx, subscription); err != nil {
+		logger.Error("Failed to create area subscription", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "AREA_SUBSCRIPTION_CREATE_FAILED", "gagal membuat langganan area", err.Error(), nil)
+	}
+
+	result := mapAreaSubscription(*subscription)
+	return &result, nil
+}
+
+func (s *IncidentService) GetAreaSubscriptions(ctx context.Context, userID uint) (*dto.GetAreaSubscriptionsResponse, error) {
+	subscriptions, err := s.areaSubscriptionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.New(500, "AREA_SUBSCRIPTION_FETCH_FAILED", "gagal mengambil data langganan area", err.Error(), nil)
+	}
+
+	subscriptionsDTO := make([]dto.AreaSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscriptionsDTO = append(subscriptionsDTO, mapAreaSubscription(subscription))
+	}
+	return &dto.GetAreaSubscriptionsResponse{Subscriptions: subscriptionsDTO}, nil
+}
+
+func (s *IncidentService) DeleteAreaSubscription(ctx context.Context, userID, subscriptionID uint) error {
+	subscription, err := s.areaSubscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.New(404, "AREA_SUBSCRIPTION_NOT_FOUND", "langganan area tidak ditemukan", "", nil)
+		}
+		return apperror.New(500, "AREA_SUBSCRIPTION_FETCH_FAILED", "gagal mengambil data langganan area", err.Error(), nil)
+	}
+	if subscription.UserID != userID {
+		return apperror.New(403, "FORBIDDEN", "anda tidak memiliki akses ke langganan area ini", "", nil)
+	}
+	if err := s.areaSubscriptionRepo.Delete(ctx, subscription); err != nil {
+		return apperror.New(500, "AREA_SUBSCRIPTION_DELETE_FAILED", "gagal menghapus langganan area", err.Error(), nil)
+	}
+	return nil
+}
+
+func mapIncidentAlert(alert model.IncidentAlert) dto.IncidentAlert {
+	return dto.IncidentAlert{
+		ID:            alert.ID,
+		ReportType:    string(alert.ReportType),
+		Latitude:      alert.Latitude,
+		Longitude:     alert.Longitude,
+		RadiusMeters:  alert.RadiusMeters,
+		ReportCount:   alert.ReportCount,
+		BaselineCount: alert.BaselineCount,
+		WindowStart:   alert.WindowStart,
+		WindowEnd:     alert.WindowEnd,
+		Status:        string(alert.Status),
+		ResolvedAt:    alert.ResolvedAt,
+		CreatedAt:     alert.CreatedAt,
+	}
+}
+
+func mapAreaSubscription(subscription model.AreaSubscription) dto.AreaSubscription {
+	return dto.AreaSubscription{
+		ID:           subscription.ID,
+		Label:        subscription.Label,
+		Latitude:     subscription.Latitude,
+		Longitude:    subscription.Longitude,
+		RadiusMeters: subscription.RadiusMeters,
+		CreatedAt:    subscription.CreatedAt,
+	}
+}
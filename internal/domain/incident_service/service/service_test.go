@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"pingspot/internal/domain/incident_service/dto"
+	incidentMocks "pingspot/internal/mocks/incident"
+	userMocks "pingspot/internal/mocks/user"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
+
+func setupMocks(t *testing.T) (*incidentMocks.MockIncidentAlertRepository, *incidentMocks.MockAreaSubscriptionRepository, *userMocks.MockUserRepository, *IncidentService) {
+	mockAlertRepo := new(incidentMocks.MockIncidentAlertRepository)
+	mockSubscriptionRepo := new(incidentMocks.MockAreaSubscriptionRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+	service := NewIncidentService(setupTestDB(t), mockAlertRepo, mockSubscriptionRepo, mockUserRepo)
+	return mockAlertRepo, mockSubscriptionRepo, mockUserRepo, service
+}
+
+func TestIncidentService_GetIncidentAlerts(t *testing.T) {
+	t.Run("should get incident alerts for moderator", func(t *testing.T) {
+		mockAlertRepo, _, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleModerator}, nil)
+		mockAlertRepo.On("GetPaginated", ctx, uint(20), uint(0), "ACTIVE").Return([]model.IncidentAlert{
+			{ID: 3, ReportType: model.Water, ReportCount: 15, Status: model.IncidentAlertActive},
+		}, nil)
+
+		result, err := service.GetIncidentAlerts(ctx, 1, 0, "ACTIVE")
+
+		require.NoError(t, err)
+		require.Len(t, result.Alerts, 1)
+		assert.Equal(t, "WATER", result.Alerts[0].ReportType)
+		assert.Equal(t, int64(15), result.Alerts[0].ReportCount)
+		mockAlertRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject regular users", func(t *testing.T) {
+		mockAlertRepo, _, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, uint(2)).Return(&model.User{ID: 2, Role: model.UserRoleUser}, nil)
+
+		result, err := service.GetIncidentAlerts(ctx, 2, 0, "")
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 403, appErr.StatusCode)
+		mockAlertRepo.AssertNotCalled(t, "GetPaginated", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestIncidentService_SubscribeArea(t *testing.T) {
+	t.Run("should create area subscription", func(t *testing.T) {
+		_, mockSubscriptionRepo, _, service := setupMocks(t)
+		ctx := context.Background()
+		req := dto.SubscribeAreaRequest{Latitude: -6.2, Longitude: 106.8, RadiusMeters: 2000}
+
+		mockSubscriptionRepo.On("CountByUserID", ctx, uint(1)).Return(int64(0), nil)
+		mockSubscriptionRepo.On("Create", ctx, mock.AnythingOfType("*model.AreaSubscription")).Return(nil)
+
+		result, err := service.SubscribeArea(ctx, 1, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2000, result.RadiusMeters)
+		mockSubscriptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject when subscription limit reached", func(t *testing.T) {
+		_, mockSubscriptionRepo, _, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockSubscriptionRepo.On("CountByUserID", ctx, uint(1)).Return(int64(maxAreaSubscriptionsPerUser), nil)
+
+		result, err := service.SubscribeArea(ctx, 1, dto.SubscribeAreaRequest{Latitude: -6.2, Longitude: 106.8, RadiusMeters: 500})
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, "AREA_SUBSCRIPTION_LIMIT_REACHED", appErr.Code)
+	})
+}
+
+func TestIncidentService_DeleteAreaSubscription(t *testing.T) {
+	t.Run("should reject deleting another user's subscription", func(t *testing.T) {
+		_, mockSubscriptionRepo, _, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockSubscriptionRepo.On("GetByID", ctx, uint(9)).Return(&model.AreaSubscription{ID: 9, UserID: 5}, nil)
+
+		err := service.DeleteAreaSubscription(ctx, 1, 9)
+
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 403, appErr.StatusCode)
+		mockSubscriptionRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+var Validate *validator.Validate
+
+func init() {
+	Validate = validator.New()
+}
+
+func FormatSubscribeAreaValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Label":
+			if e.Tag() == "max" {
+				errors["label"] = "Label maksimal 100 karakter"
+			}
+		case "Latitude":
+			if e.Tag() == "required" {
+				errors["latitude"] = "Latitude wajib diisi"
+			}
+			if e.Tag() == "latitude" {
+				errors["latitude"] = "Latitude tidak valid"
+			}
+		case "Longitude":
+			if e.Tag() == "required" {
+				errors["longitude"] = "Longitude wajib diisi"
+			}
+			if e.Tag() == "longitude" {
+				errors["longitude"] = "Longitude tidak valid"
+			}
+		case "RadiusMeters":
+			if e.Tag() == "required" {
+				errors["radiusMeters"] = "Radius wajib diisi"
+			}
+			if e.Tag() == "min" {
+				errors["radiusMeters"] = "Radius minimal 100 meter"
+			}
+			if e.Tag() == "max" {
+				errors["radiusMeters"] = "Radius maksimal 10000 meter"
+			}
+		}
+	}
+	return errors
+}
@@ -0,0 +1,50 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/model"
+)
+
+// ErrNoRecipient means the user cannot be reached on the channel at all, e.g.
+// no phone number or push subscription, so the delivery is skipped rather
+// than retried.
+var ErrNoRecipient = errors.New("recipient is not reachable on this channel")
+
+type Message struct {
+	Notification model.Notification
+	Recipient    model.User
+	Setting      *model.NotificationSetting
+	Link         string
+}
+
+// NotificationChannel delivers a stored notification to one medium. Send
+// returns the provider's message id when the provider exposes one.
+type NotificationChannel interface {
+	Channel() model.NotificationChannel
+	MaxAttempts() int
+	Send(ctx context.Context, message Message) (string, error)
+}
+
+type Registry struct {
+	channels map[model.NotificationChannel]NotificationChannel
+}
+
+func NewRegistry(channels ...NotificationChannel) *Registry {
+	registry := &Registry{channels: make(map[model.NotificationChannel]NotificationChannel, len(channels))}
+	for _, channel := range channels {
+		registry.Register(channel)
+	}
+	return registry
+}
+
+// Register replaces any channel already registered under the same name, which
+// is how tests swap a provider for a fake.
+func (r *Registry) Register(channel NotificationChannel) {
+	r.channels[channel.Channel()] = channel
+}
+
+func (r *Registry) Get(channel model.NotificationChannel) (NotificationChannel, bool) {
+	registered, ok := r.channels[channel]
+	return registered, ok
+}
@@ -0,0 +1,170 @@
+package channel_test
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pingspot/internal/domain/notification_service/channel"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	notificationMocks "pingspot/internal/mocks/notification"
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMessage() channel.Message {
+	phoneNumber := "+6281234567890"
+	return channel.Message{
+		Notification: model.Notification{ID: 9, UserID: 1, Title: "Status laporan berubah", Description: "Laporan Anda sedang ditangani"},
+		Recipient:    model.User{ID: 1, Email: "user@example.com", Username: "user"},
+		Setting:      &model.NotificationSetting{UserID: 1, PhoneNumber: &phoneNumber},
+		Link:         "https://app.example.com/main/report/3",
+	}
+}
+
+func newTestVAPIDKeys(t *testing.T) NotificationUtil.VAPIDKeys {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := NotificationUtil.ParseVAPIDKeys(
+		base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()),
+		"mailto:admin@pingspot.id",
+	)
+	require.NoError(t, err)
+	return *keys
+}
+
+func newTestPushSubscription(t *testing.T, id uint, endpoint string) model.PushSubscription {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return model.PushSubscription{
+		ID:       id,
+		UserID:   1,
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString([]byte("0123456789abcdef")),
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := channel.NewRegistry(channel.NewEmailChannel(nil))
+	fake := notificationMocks.NewFakeChannel(model.NotificationChannelEmail)
+	registry.Register(fake)
+
+	registered, ok := registry.Get(model.NotificationChannelEmail)
+	require.True(t, ok)
+	assert.Same(t, fake, registered)
+
+	_, ok = registry.Get(model.NotificationChannelSMS)
+	assert.False(t, ok)
+}
+
+func TestEmailChannel(t *testing.T) {
+	t.Run("should send through the injected sender", func(t *testing.T) {
+		var sentTo, sentLink string
+		emailChannel := channel.NewEmailChannel(func(to, username string, notification model.Notification, link string, language i18n.Language) error {
+			sentTo, sentLink = to, link
+			return nil
+		})
+
+		_, err := emailChannel.Send(context.Background(), newTestMessage())
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", sentTo)
+		assert.Equal(t, "https://app.example.com/main/report/3", sentLink)
+	})
+
+	t.Run("should skip recipient without email", func(t *testing.T) {
+		emailChannel := channel.NewEmailChannel(func(string, string, model.Notification, string, i18n.Language) error {
+			t.Fatal("sender must not be called")
+			return nil
+		})
+		message := newTestMessage()
+		message.Recipient.Email = ""
+
+		_, err := emailChannel.Send(context.Background(), message)
+		assert.ErrorIs(t, err, channel.ErrNoRecipient)
+	})
+}
+
+func TestSMSChannel(t *testing.T) {
+	t.Run("should send to the saved phone number", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"messageId":"sms-7"}`))
+		}))
+		defer server.Close()
+		smsChannel := channel.NewSMSChannel(server.Client(), NotificationUtil.SMSProviderConfig{URL: server.URL, APIKey: "secret"})
+
+		messageID, err := smsChannel.Send(context.Background(), newTestMessage())
+		require.NoError(t, err)
+		assert.Equal(t, "sms-7", messageID)
+	})
+
+	t.Run("should skip recipient without phone number", func(t *testing.T) {
+		smsChannel := channel.NewSMSChannel(http.DefaultClient, NotificationUtil.SMSProviderConfig{URL: "http://127.0.0.1", APIKey: "secret"})
+		message := newTestMessage()
+		message.Setting = nil
+
+		_, err := smsChannel.Send(context.Background(), message)
+		assert.ErrorIs(t, err, channel.ErrNoRecipient)
+	})
+}
+
+func TestWebPushChannel(t *testing.T) {
+	keys := newTestVAPIDKeys(t)
+
+	t.Run("should deliver and drop expired subscriptions", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/gone" {
+				w.WriteHeader(http.StatusGone)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		subscriptionRepo := new(notificationMocks.MockPushSubscriptionRepository)
+		subscriptionRepo.On("GetByUserID", mock.Anything, uint(1)).Return([]model.PushSubscription{
+			newTestPushSubscription(t, 1, server.URL+"/gone"),
+			newTestPushSubscription(t, 2, server.URL+"/ok"),
+		}, nil)
+		subscriptionRepo.On("DeleteByID", mock.Anything, uint(1)).Return(nil)
+
+		_, err := channel.NewWebPushChannel(subscriptionRepo, server.Client(), keys).Send(context.Background(), newTestMessage())
+		require.NoError(t, err)
+		subscriptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("should fail when every push service errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		subscriptionRepo := new(notificationMocks.MockPushSubscriptionRepository)
+		subscriptionRepo.On("GetByUserID", mock.Anything, uint(1)).Return([]model.PushSubscription{
+			newTestPushSubscription(t, 1, server.URL+"/down"),
+		}, nil)
+
+		_, err := channel.NewWebPushChannel(subscriptionRepo, server.Client(), keys).Send(context.Background(), newTestMessage())
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, channel.ErrNoRecipient))
+	})
+
+	t.Run("should skip user without subscriptions", func(t *testing.T) {
+		subscriptionRepo := new(notificationMocks.MockPushSubscriptionRepository)
+		subscriptionRepo.On("GetByUserID", mock.Anything, uint(1)).Return([]model.PushSubscription{}, nil)
+
+		_, err := channel.NewWebPushChannel(subscriptionRepo, http.DefaultClient, keys).Send(context.Background(), newTestMessage())
+		assert.ErrorIs(t, err, channel.ErrNoRecipient)
+	})
+}
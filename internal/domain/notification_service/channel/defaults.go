@@ -0,0 +1,44 @@
+package channel
+
+import (
+	"net/http"
+	notificationRepository "pingspot/internal/domain/notification_service/repository"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/pkg/logger"
+	"pingspot/pkg/safehttp"
+	env "pingspot/pkg/utils/env_util"
+
+	"go.uber.org/zap"
+)
+
+var providerHTTPClient = &http.Client{Timeout: NotificationUtil.DeliveryTimeout}
+
+// webPushHTTPClient posts to user supplied endpoints, so it refuses internal
+// addresses. This also covers subscriptions saved before endpoints were
+// restricted to known push services.
+var webPushHTTPClient = safehttp.NewClient(NotificationUtil.DeliveryTimeout)
+
+// NewDefaultRegistry registers in-app and email unconditionally and the
+// external providers only when their credentials are configured, so a missing
+// provider leaves its deliveries unqueued instead of failing them.
+func NewDefaultRegistry(inApp *InAppChannel, subscriptionRepo notificationRepository.PushSubscriptionRepository) *Registry {
+	registry := NewRegistry(inApp, NewEmailChannel(nil))
+
+	keys, err := NotificationUtil.ParseVAPIDKeys(env.VAPIDPublicKey(), env.VAPIDPrivateKey(), env.VAPIDSubject())
+	if err != nil {
+		logger.Info("Web push channel disabled", zap.Error(err))
+	} else {
+		registry.Register(NewWebPushChannel(subscriptionRepo, webPushHTTPClient, *keys))
+	}
+
+	if env.SMSProviderURL() == "" || env.SMSProviderAPIKey() == "" {
+		logger.Info("SMS channel disabled: provider is not configured")
+	} else {
+		registry.Register(NewSMSChannel(providerHTTPClient, NotificationUtil.SMSProviderConfig{
+			URL:      env.SMSProviderURL(),
+			APIKey:   env.SMSProviderAPIKey(),
+			SenderID: env.SMSSenderID(),
+		}))
+	}
+	return registry
+}
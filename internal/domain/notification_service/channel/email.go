@@ -0,0 +1,43 @@
+package channel
+
+import (
+	"context"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+)
+
+const EmailMaxAttempts = 5
+
+type EmailSender func(to, username string, notification model.Notification, link string, language i18n.Language) error
+
+type EmailChannel struct {
+	send EmailSender
+}
+
+// NewEmailChannel sends through the shared mailer unless another sender is
+// given.
+func NewEmailChannel(send EmailSender) *EmailChannel {
+	if send == nil {
+		send = NotificationUtil.SendNotificationEmail
+	}
+	return &EmailChannel{send: send}
+}
+
+func (c *EmailChannel) Channel() model.NotificationChannel {
+	return model.NotificationChannelEmail
+}
+
+func (c *EmailChannel) MaxAttempts() int {
+	return EmailMaxAttempts
+}
+
+func (c *EmailChannel) Send(ctx context.Context, message Message) (string, error) {
+	if message.Recipient.Email == "" {
+		return "", ErrNoRecipient
+	}
+	if err := c.send(message.Recipient.Email, message.Recipient.Username, message.Notification, message.Link, i18n.Preferred(message.Recipient.Language, i18n.DefaultLanguage)); err != nil {
+		return "", err
+	}
+	return "", nil
+}
@@ -0,0 +1,110 @@
+package channel
+
+import (
+	"context"
+	notificationDTO "pingspot/internal/domain/notification_service/dto"
+	notificationRepository "pingspot/internal/domain/notification_service/repository"
+	realtimeDTO "pingspot/internal/domain/realtime_service/dto"
+	realtimeService "pingspot/internal/domain/realtime_service/service"
+	"pingspot/internal/model"
+	"pingspot/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// InAppChannel delivers the Postgres notification row, which is written by
+// the caller, to the user's open sessions together with the unread counter.
+type InAppChannel struct {
+	notificationRepo  notificationRepository.NotificationRepository
+	unreadCounterRepo notificationRepository.NotificationUnreadCounterRepository
+	publisher         realtimeService.Publisher
+}
+
+func NewInAppChannel(notificationRepo notificationRepository.NotificationRepository, unreadCounterRepo notificationRepository.NotificationUnreadCounterRepository, publisher realtimeService.Publisher) *InAppChannel {
+	return &InAppChannel{
+		notificationRepo:  notificationRepo,
+		unreadCounterRepo: unreadCounterRepo,
+		publisher:         publisher,
+	}
+}
+
+func (c *InAppChannel) Channel() model.NotificationChannel {
+	return model.NotificationChannelInApp
+}
+
+func (c *InAppChannel) MaxAttempts() int {
+	return 1
+}
+
+func (c *InAppChannel) Send(ctx context.Context, message Message) (string, error) {
+	c.Publish(ctx, model.RealtimeNotificationCreated, &message.Notification, 1)
+	return "", nil
+}
+
+// Publish bumps the unread counter by delta before publishing, and omits the
+// unread count event when it cannot be resolved.
+func (c *InAppChannel) Publish(ctx context.Context, eventType model.RealtimeEventType, notification *model.Notification, delta int64) {
+	unreadCount, ok := c.IncrementUnreadCount(ctx, notification.UserID, delta)
+	if !ok {
+		c.publishNotification(ctx, eventType, notification, nil)
+		return
+	}
+	c.publishNotification(ctx, eventType, notification, &unreadCount)
+}
+
+// IncrementUnreadCount must run after the notifications are committed: when
+// the cached counter has expired it is rebuilt from the database, which
+// already includes them. It reports false when neither source is usable.
+func (c *InAppChannel) IncrementUnreadCount(ctx context.Context, userID uint, delta int64) (int64, bool) {
+	count, ok, err := c.unreadCounterRepo.IncrBy(ctx, userID, delta)
+	if err != nil {
+		logger.Error("Failed to increment unread count", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	if ok {
+		return count, true
+	}
+
+	count, err = c.notificationRepo.CountUnreadByUserID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to count unread notifications", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, false
+	}
+	if err := c.unreadCounterRepo.Set(ctx, userID, count); err != nil {
+		logger.Error("Failed to cache unread count", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return count, true
+}
+
+func (c *InAppChannel) publishNotification(ctx context.Context, eventType model.RealtimeEventType, notification *model.Notification, unreadCount *int64) {
+	isRead := false
+	if notification.IsRead != nil {
+		isRead = *notification.IsRead
+	}
+	if err := c.publisher.Publish(ctx, model.RealtimeScopeUser, notification.UserID, eventType, notificationDTO.Notification{
+		ID:          notification.ID,
+		UserID:      notification.UserID,
+		Type:        string(notification.Type),
+		Category:    string(notification.Category),
+		Title:       notification.Title,
+		Description: notification.Description,
+		IsRead:      isRead,
+		ReadAt:      notification.ReadAt,
+		CreatedAt:   notification.CreatedAt,
+		EntityID:    notification.EntityID,
+		EntityType:  (*string)(notification.EntityType),
+		ActorCount:  notification.ActorCount,
+		UpdatedAt:   notification.UpdatedAt,
+	}); err != nil {
+		logger.Error("Failed to publish realtime notification", zap.Uint("notification_id", notification.ID), zap.Error(err))
+		return
+	}
+
+	if unreadCount == nil {
+		return
+	}
+	if err := c.publisher.Publish(ctx, model.RealtimeScopeUser, notification.UserID, model.RealtimeUnreadCount, realtimeDTO.UnreadCountEventData{
+		UnreadCount: *unreadCount,
+	}); err != nil {
+		logger.Error("Failed to publish realtime unread count", zap.Uint("user_id", notification.UserID), zap.Error(err))
+	}
+}
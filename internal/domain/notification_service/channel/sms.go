@@ -0,0 +1,34 @@
+package channel
+
+import (
+	"context"
+	"net/http"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/model"
+)
+
+const SMSMaxAttempts = 3
+
+type SMSChannel struct {
+	client *http.Client
+	config NotificationUtil.SMSProviderConfig
+}
+
+func NewSMSChannel(client *http.Client, config NotificationUtil.SMSProviderConfig) *SMSChannel {
+	return &SMSChannel{client: client, config: config}
+}
+
+func (c *SMSChannel) Channel() model.NotificationChannel {
+	return model.NotificationChannelSMS
+}
+
+func (c *SMSChannel) MaxAttempts() int {
+	return SMSMaxAttempts
+}
+
+func (c *SMSChannel) Send(ctx context.Context, message Message) (string, error) {
+	if message.Setting == nil || message.Setting.PhoneNumber == nil || *message.Setting.PhoneNumber == "" {
+		return "", ErrNoRecipient
+	}
+	return NotificationUtil.SendSMS(ctx, c.client, c.config, *message.Setting.PhoneNumber, NotificationUtil.FormatSMSMessage(message.Notification))
+}
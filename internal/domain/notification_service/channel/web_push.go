@@ -0,0 +1,81 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	notificationRepository "pingspot/internal/domain/notification_service/repository"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/model"
+	"pingspot/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const WebPushMaxAttempts = 3
+
+type WebPushChannel struct {
+	subscriptionRepo notificationRepository.PushSubscriptionRepository
+	client           *http.Client
+	keys             NotificationUtil.VAPIDKeys
+}
+
+func NewWebPushChannel(subscriptionRepo notificationRepository.PushSubscriptionRepository, client *http.Client, keys NotificationUtil.VAPIDKeys) *WebPushChannel {
+	return &WebPushChannel{
+		subscriptionRepo: subscriptionRepo,
+		client:           client,
+		keys:             keys,
+	}
+}
+
+func (c *WebPushChannel) Channel() model.NotificationChannel {
+	return model.NotificationChannelPush
+}
+
+func (c *WebPushChannel) MaxAttempts() int {
+	return WebPushMaxAttempts
+}
+
+// Send pushes to every browser the user subscribed from and succeeds when at
+// least one accepted the message. Subscriptions the push service no longer
+// knows are removed on the way.
+func (c *WebPushChannel) Send(ctx context.Context, message Message) (string, error) {
+	subscriptions, err := c.subscriptionRepo.GetByUserID(ctx, message.Recipient.ID)
+	if err != nil {
+		return "", err
+	}
+	payload, err := NotificationUtil.BuildPushMessage(message.Notification, message.Link)
+	if err != nil {
+		return "", err
+	}
+
+	var messageID string
+	delivered := false
+	var sendErrs []error
+	for _, subscription := range subscriptions {
+		location, err := NotificationUtil.SendWebPush(ctx, c.client, c.keys, subscription, payload)
+		if errors.Is(err, NotificationUtil.ErrPushSubscriptionExpired) {
+			if err := c.subscriptionRepo.DeleteByID(ctx, subscription.ID); err != nil {
+				logger.Error("Failed to delete expired push subscription", zap.Uint("subscription_id", subscription.ID), zap.Error(err))
+			}
+			continue
+		}
+		if err != nil {
+			sendErrs = append(sendErrs, err)
+			continue
+		}
+		if !delivered {
+			messageID = location
+		}
+		delivered = true
+	}
+
+	switch {
+	case delivered:
+		return messageID, nil
+	case len(sendErrs) > 0:
+		return "", errors.Join(sendErrs...)
+	default:
+		return "", ErrNoRecipient
+	}
+}
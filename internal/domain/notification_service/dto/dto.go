@@ -12,4 +12,50 @@ type Notification struct {
 	CreatedAt   int64  `json:"createdAt"`
 	EntityID   *string `json:"entityID,omitempty"`
 	EntityType *string `json:"entityType,omitempty"`
-}
\ No newline at end of file
+	ActorCount int     `json:"actorCount,omitempty"`
+	UpdatedAt  int64   `json:"updatedAt,omitempty"`
+}
+type NotificationPreference struct {
+	Category string `json:"category,omitempty"`
+	Event    string `json:"event,omitempty"`
+	Channel  string `json:"channel"`
+	Enabled  bool   `json:"enabled"`
+}
+
+type QuietHours struct {
+	Start    *string `json:"start"`
+	End      *string `json:"end"`
+	Timezone string  `json:"timezone"`
+}
+
+type NotificationFilter struct {
+	Category   string `validate:"omitempty,oneof=GENERAL REPORT USER INCIDENT"`
+	Type       string `validate:"omitempty,oneof=INFO WARNING ERROR"`
+	IsRead     *bool
+	EntityType string `validate:"omitempty,oneof=REPORT USER COMMENT INCIDENT"`
+	EntityID   string `validate:"omitempty,max=64"`
+}
+
+type NotificationSMSSettings struct {
+	PhoneNumber *string `json:"phoneNumber"`
+}
+
+type NotificationDigestSettings struct {
+	Frequency string `json:"frequency"`
+	Hour      int    `json:"hour"`
+}
+
+type DigestItem struct {
+	Title       string
+	Description string
+	Link        string
+}
+
+type DigestEmail struct {
+	PeriodLabel     string
+	UnreadCount     int64
+	Notifications   []DigestItem
+	WatchedProgress []DigestItem
+	NearbyReports   []DigestItem
+	AppLink         string
+}
@@ -14,4 +14,48 @@ type SaveUserSecurityRequest struct {
 	CurrentPasswordConfirmation string `json:"currentPasswordConfirmation" validate:"required,eqfield=CurrentPassword"`
 	NewPassword          string `json:"newPassword" validate:"required,min=6"`
 	NewPasswordConfirmation   string `json:"newPasswordConfirmation" validate:"required,eqfield=NewPassword"`
-}
\ No newline at end of file
+}
+
+type NotificationPreferenceRequest struct {
+	Category string `json:"category" validate:"omitempty,oneof=GENERAL REPORT USER INCIDENT"`
+	Event    string `json:"event" validate:"omitempty,oneof=VOTE COMMENT REPLY MENTION FOLLOW STATUS_CHANGE REACTION REPORT_MERGED"`
+	Channel  string `json:"channel" validate:"required,oneof=IN_APP EMAIL PUSH SMS"`
+	Enabled  *bool  `json:"enabled" validate:"required"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceRequest `json:"preferences" validate:"required,min=1,max=100,dive"`
+}
+
+type UpdateQuietHoursRequest struct {
+	Start    *string `json:"start" validate:"required_with=End,omitempty,datetime=15:04"`
+	End      *string `json:"end" validate:"required_with=Start,omitempty,datetime=15:04"`
+	Timezone string  `json:"timezone" validate:"omitempty,timezone"`
+}
+
+type MarkNotificationsAsReadRequest struct {
+	NotificationIDs []uint `json:"notificationIDs" validate:"required,min=1,max=100,dive,gt=0"`
+}
+
+type UpdateDigestSettingsRequest struct {
+	Frequency string `json:"frequency" validate:"required,oneof=NONE DAILY WEEKLY"`
+	Hour      *int   `json:"hour" validate:"omitempty,min=0,max=23"`
+}
+
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh" validate:"required,max=255"`
+	Auth   string `json:"auth" validate:"required,max=255"`
+}
+
+type SavePushSubscriptionRequest struct {
+	Endpoint string               `json:"endpoint" validate:"required,url,startswith=https://,max=2048"`
+	Keys     PushSubscriptionKeys `json:"keys" validate:"required"`
+}
+
+type DeletePushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint" validate:"required,max=2048"`
+}
+
+type UpdateSMSSettingsRequest struct {
+	PhoneNumber *string `json:"phoneNumber" validate:"omitempty,e164"`
+}
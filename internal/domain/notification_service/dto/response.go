@@ -1,5 +1,25 @@
 package dto
 
 type GetNotificationsResponse struct {
-	Notifications   []*Notification `json:"notifications"`
-}
\ No newline at end of file
+	Notifications []*Notification `json:"notifications"`
+	NextCursor    *uint           `json:"nextCursor"`
+}
+
+type GetUnreadCountResponse struct {
+	UnreadCount int64 `json:"unreadCount"`
+}
+
+type MarkNotificationsAsReadResponse struct {
+	UpdatedCount int64 `json:"updatedCount"`
+}
+type GetNotificationPreferencesResponse struct {
+	Preferences    []*NotificationPreference `json:"preferences"`
+	QuietHours     QuietHours                `json:"quietHours"`
+	Digest         NotificationDigestSettings `json:"digest"`
+	SMS            NotificationSMSSettings    `json:"sms"`
+	MutedReportIDs []uint                    `json:"mutedReportIDs"`
+}
+
+type GetPushPublicKeyResponse struct {
+	PublicKey string `json:"publicKey"`
+}
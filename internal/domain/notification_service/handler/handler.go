@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"pingspot/internal/domain/notification_service/dto"
 	"pingspot/internal/domain/notification_service/service"
+	"pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/domain/notification_service/validation"
 	apperror "pingspot/pkg/app_error"
 	tokenutils "pingspot/pkg/utils/token_util"
 	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
 	response "pingspot/pkg/utils/response_util"
+	"strings"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -27,7 +32,31 @@ func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
 	}
 	userId := uint(claims["user_id"].(float64))
 
-	notifications, err := h.notificationService.GetNotifications(ctx, userId)
+	cursorID := c.Query("cursorID")
+	cursorIDUint, err := mainutils.StringToUint(cursorID)
+	if err != nil && cursorID != "" {
+		logger.Error("Invalid cursorID format", zap.String("cursorID", cursorID), zap.Error(err))
+		return response.ResponseError(c, 400, "Format cursorID tidak valid", "", "cursorID harus berupa angka")
+	}
+	isRead, err := util.ParseReadState(c.Query("isRead"))
+	if err != nil {
+		logger.Error("Invalid isRead format", zap.Error(err))
+		return response.ResponseError(c, 400, "Format isRead tidak valid", "", "isRead harus bernilai true atau false")
+	}
+	filter := dto.NotificationFilter{
+		Category:   strings.ToUpper(c.Query("category")),
+		Type:       strings.ToUpper(c.Query("type")),
+		IsRead:     isRead,
+		EntityType: strings.ToUpper(c.Query("entityType")),
+		EntityID:   strings.TrimSpace(c.Query("entityID")),
+	}
+	if err := validation.Validate.Struct(filter); err != nil {
+		errors := validation.FormatNotificationFilterValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	notifications, err := h.notificationService.GetNotifications(ctx, userId, filter, cursorIDUint)
 	if err != nil {
 		logger.Error("Failed to get notifications", zap.Error(err))
 		if appErr, ok := err.(*apperror.AppError); ok {
@@ -38,6 +67,57 @@ func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
 	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan notifikasi", "data", notifications)
 }
 
+func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	unreadCount, err := h.notificationService.GetUnreadCount(ctx, userId)
+	if err != nil {
+		logger.Error("Failed to get unread notification count", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan jumlah notifikasi belum dibaca", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan jumlah notifikasi belum dibaca", "data", unreadCount)
+}
+
+func (h *NotificationHandler) MarkNotificationsAsRead(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	var req dto.MarkNotificationsAsReadRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatMarkNotificationsAsReadValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	result, err := h.notificationService.MarkNotificationsAsRead(ctx, userId, req)
+	if err != nil {
+		logger.Error("Failed to mark notifications as read", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menandai notifikasi sebagai dibaca", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menandai notifikasi sebagai dibaca", "data", result)
+}
+
 func (h *NotificationHandler) MarkNotificationAsRead(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	claims, err := tokenutils.GetJWTClaims(c)
@@ -123,4 +203,297 @@ func (h *NotificationHandler) DeleteAllNotifications(c *fiber.Ctx) error {
 		return response.ResponseError(c, 500, "Gagal menghapus semua notifikasi", "", err.Error())
 	}
 	return response.ResponseSuccess(c, 200, "Berhasil menghapus semua notifikasi", "data", nil)
-}
\ No newline at end of file
+}
+func (h *NotificationHandler) GetNotificationPreferences(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	preferences, err := h.notificationService.GetNotificationPreferences(ctx, userId)
+	if err != nil {
+		logger.Error("Failed to get notification preferences", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan preferensi notifikasi", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan preferensi notifikasi", "data", preferences)
+}
+
+func (h *NotificationHandler) UpdateNotificationPreferences(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	var req dto.UpdateNotificationPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	for i := range req.Preferences {
+		req.Preferences[i].Category = strings.ToUpper(strings.TrimSpace(req.Preferences[i].Category))
+		req.Preferences[i].Event = strings.ToUpper(strings.TrimSpace(req.Preferences[i].Event))
+		req.Preferences[i].Channel = strings.ToUpper(strings.TrimSpace(req.Preferences[i].Channel))
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatUpdateNotificationPreferencesValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	preferences, err := h.notificationService.UpdateNotificationPreferences(ctx, userId, req)
+	if err != nil {
+		logger.Error("Failed to update notification preferences", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menyimpan preferensi notifikasi", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menyimpan preferensi notifikasi", "data", preferences)
+}
+
+func (h *NotificationHandler) UpdateQuietHours(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	var req dto.UpdateQuietHoursRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	req.Timezone = strings.TrimSpace(req.Timezone)
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatUpdateQuietHoursValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	quietHours, err := h.notificationService.UpdateQuietHours(ctx, userId, req)
+	if err != nil {
+		logger.Error("Failed to update quiet hours", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menyimpan jam tenang", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menyimpan jam tenang", "data", quietHours)
+}
+
+func (h *NotificationHandler) UpdateDigestSettings(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	var req dto.UpdateDigestSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	req.Frequency = strings.ToUpper(strings.TrimSpace(req.Frequency))
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatUpdateDigestSettingsValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	digest, err := h.notificationService.UpdateDigestSettings(ctx, userId, req)
+	if err != nil {
+		logger.Error("Failed to update digest settings", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menyimpan pengaturan ringkasan email", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menyimpan pengaturan ringkasan email", "data", digest)
+}
+
+func (h *NotificationHandler) UnsubscribeDigest(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID, err := mainutils.StringToUint(c.Query("userID"))
+	if err != nil || userID == 0 {
+		return response.ResponseError(c, 400, "Tautan berhenti berlangganan tidak valid", "", "userID harus berupa angka")
+	}
+	token := c.Query("token")
+	if token == "" {
+		return response.ResponseError(c, 400, "Tautan berhenti berlangganan tidak valid", "", "token wajib diisi")
+	}
+
+	if err := h.notificationService.UnsubscribeDigest(ctx, userID, token); err != nil {
+		logger.Error("Failed to unsubscribe from digest", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal berhenti berlangganan ringkasan email", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Anda telah berhenti berlangganan ringkasan email", "data", nil)
+}
+
+func (h *NotificationHandler) MuteReport(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+	reportID, err := c.ParamsInt("reportID")
+	if err != nil || reportID <= 0 {
+		return response.ResponseError(c, 400, "ID laporan tidak valid", "", "ID laporan harus berupa angka")
+	}
+
+	if err := h.notificationService.MuteReport(ctx, userId, uint(reportID)); err != nil {
+		logger.Error("Failed to mute report notifications", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal membisukan notifikasi laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil membisukan notifikasi laporan", "data", nil)
+}
+
+func (h *NotificationHandler) UnmuteReport(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+	reportID, err := c.ParamsInt("reportID")
+	if err != nil || reportID <= 0 {
+		return response.ResponseError(c, 400, "ID laporan tidak valid", "", "ID laporan harus berupa angka")
+	}
+
+	if err := h.notificationService.UnmuteReport(ctx, userId, uint(reportID)); err != nil {
+		logger.Error("Failed to unmute report notifications", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mengaktifkan kembali notifikasi laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mengaktifkan kembali notifikasi laporan", "data", nil)
+}
+
+func (h *NotificationHandler) UpdateSMSSettings(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	var req dto.UpdateSMSSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	if req.PhoneNumber != nil {
+		phoneNumber := strings.TrimSpace(*req.PhoneNumber)
+		req.PhoneNumber = &phoneNumber
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatUpdateSMSSettingsValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	sms, err := h.notificationService.UpdateSMSSettings(ctx, userId, req)
+	if err != nil {
+		logger.Error("Failed to update SMS settings", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menyimpan nomor telepon notifikasi", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menyimpan nomor telepon notifikasi", "data", sms)
+}
+
+func (h *NotificationHandler) GetPushPublicKey(c *fiber.Ctx) error {
+	publicKey, err := h.notificationService.GetPushPublicKey()
+	if err != nil {
+		logger.Error("Failed to get push public key", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan kunci notifikasi push", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan kunci notifikasi push", "data", publicKey)
+}
+
+func (h *NotificationHandler) SavePushSubscription(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	var req dto.SavePushSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatSavePushSubscriptionValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	if err := h.notificationService.SavePushSubscription(ctx, userId, req, mainutils.GetUserAgent(c)); err != nil {
+		logger.Error("Failed to save push subscription", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menyimpan langganan notifikasi push", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 201, "Berhasil berlangganan notifikasi push", "data", nil)
+}
+
+func (h *NotificationHandler) DeletePushSubscription(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+
+	var req dto.DeletePushSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatDeletePushSubscriptionValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	if err := h.notificationService.DeletePushSubscription(ctx, userId, req.Endpoint); err != nil {
+		logger.Error("Failed to delete push subscription", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menghapus langganan notifikasi push", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil berhenti berlangganan notifikasi push", "data", nil)
+}
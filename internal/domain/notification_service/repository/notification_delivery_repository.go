@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationDeliveryRepository interface {
+	Create(ctx context.Context, delivery *model.NotificationDelivery) (bool, error)
+	GetByID(ctx context.Context, deliveryID uint) (*model.NotificationDelivery, error)
+	Update(ctx context.Context, delivery *model.NotificationDelivery) (*model.NotificationDelivery, error)
+}
+
+type notificationDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationDeliveryRepository(db *gorm.DB) NotificationDeliveryRepository {
+	return &notificationDeliveryRepository{db: db}
+}
+
+// Create reports false when the notification already has a delivery on the
+// channel, so a redelivered task does not send the same message twice.
+func (r *notificationDeliveryRepository) Create(ctx context.Context, delivery *model.NotificationDelivery) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "notification_id"}, {Name: "channel"}},
+			DoNothing: true,
+		}).
+		Create(delivery)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *notificationDeliveryRepository) GetByID(ctx context.Context, deliveryID uint) (*model.NotificationDelivery, error) {
+	var delivery model.NotificationDelivery
+	if err := r.db.WithContext(ctx).
+		Preload("Notification").
+		Preload("User").
+		First(&delivery, deliveryID).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *notificationDeliveryRepository) Update(ctx context.Context, delivery *model.NotificationDelivery) (*model.NotificationDelivery, error) {
+	if err := r.db.WithContext(ctx).Omit("Notification", "User").Save(delivery).Error; err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationPreferenceRepository interface {
+	GetByUserID(ctx context.Context, userID uint) ([]model.NotificationPreference, error)
+	UpsertTX(ctx context.Context, tx *gorm.DB, preferences []model.NotificationPreference) error
+	GetSetting(ctx context.Context, userID uint) (*model.NotificationSetting, error)
+	SaveSetting(ctx context.Context, setting *model.NotificationSetting) error
+	GetDigestSubscribers(ctx context.Context) ([]model.NotificationSetting, error)
+	UpdateDigestSentAt(ctx context.Context, userID uint, sentAt int64) error
+	IsReportMuted(ctx context.Context, userID, reportID uint) (bool, error)
+	GetMutedReportIDs(ctx context.Context, userID uint) ([]uint, error)
+	MuteReport(ctx context.Context, userID, reportID uint) error
+	UnmuteReport(ctx context.Context, userID, reportID uint) error
+}
+
+type notificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceRepository(db *gorm.DB) NotificationPreferenceRepository {
+	return &notificationPreferenceRepository{db: db}
+}
+
+func (r *notificationPreferenceRepository) GetByUserID(ctx context.Context, userID uint) ([]model.NotificationPreference, error) {
+	var preferences []model.NotificationPreference
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("category ASC, event ASC, channel ASC").
+		Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (r *notificationPreferenceRepository) UpsertTX(ctx context.Context, tx *gorm.DB, preferences []model.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "category"}, {Name: "event"}, {Name: "channel"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+		}).
+		Create(&preferences).Error
+}
+
+// GetSetting returns nil without an error when the user has never saved any
+// notification settings.
+func (r *notificationPreferenceRepository) GetSetting(ctx context.Context, userID uint) (*model.NotificationSetting, error) {
+	var setting model.NotificationSetting
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *notificationPreferenceRepository) SaveSetting(ctx context.Context, setting *model.NotificationSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+func (r *notificationPreferenceRepository) GetDigestSubscribers(ctx context.Context) ([]model.NotificationSetting, error) {
+	var settings []model.NotificationSetting
+	if err := r.db.WithContext(ctx).Where("digest_frequency <> ?", model.NotificationDigestNone).Find(&settings).Error; err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (r *notificationPreferenceRepository) UpdateDigestSentAt(ctx context.Context, userID uint, sentAt int64) error {
+	return r.db.WithContext(ctx).Model(&model.NotificationSetting{}).Where("user_id = ?", userID).Update("last_digest_sent_at", sentAt).Error
+}
+
+func (r *notificationPreferenceRepository) IsReportMuted(ctx context.Context, userID, reportID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.NotificationReportMute{}).
+		Where("user_id = ? AND report_id = ?", userID, reportID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *notificationPreferenceRepository) GetMutedReportIDs(ctx context.Context, userID uint) ([]uint, error) {
+	var reportIDs []uint
+	if err := r.db.WithContext(ctx).
+		Model(&model.NotificationReportMute{}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Pluck("report_id", &reportIDs).Error; err != nil {
+		return nil, err
+	}
+	return reportIDs, nil
+}
+
+func (r *notificationPreferenceRepository) MuteReport(ctx context.Context, userID, reportID uint) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.NotificationReportMute{UserID: userID, ReportID: reportID}).Error
+}
+
+func (r *notificationPreferenceRepository) UnmuteReport(ctx context.Context, userID, reportID uint) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND report_id = ?", userID, reportID).
+		Delete(&model.NotificationReportMute{}).Error
+}
@@ -2,17 +2,25 @@ package repository
 
 import (
 	"context"
+	"pingspot/internal/domain/notification_service/dto"
 	"pingspot/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type NotificationRepository interface {
 	GetByID(ctx context.Context, id uint) (*model.Notification, error)
 	GetByUserID(ctx context.Context, userID uint) (*[]model.Notification, error)
+	GetPaginatedByUserID(ctx context.Context, userID uint, filter dto.NotificationFilter, cursorID uint, limit int) ([]model.Notification, error)
+	CountUnreadByUserID(ctx context.Context, userID uint) (int64, error)
 	CreateTX(ctx context.Context, tx *gorm.DB, notification *model.Notification) error
+	CreateGroupedTX(ctx context.Context, tx *gorm.DB, notification *model.Notification) (bool, error)
+	GetByGroupKeyForUpdateTX(ctx context.Context, tx *gorm.DB, userID uint, groupKey string) (*model.Notification, error)
+	AddActorTX(ctx context.Context, tx *gorm.DB, actor *model.NotificationActor) (bool, error)
 	UpdateTX(ctx context.Context, tx *gorm.DB, notification *model.Notification) error
 	MarkAllAsReadTX(ctx context.Context, tx *gorm.DB, userID uint) error
+	MarkAsReadByIDsTX(ctx context.Context, tx *gorm.DB, userID uint, ids []uint, readAt int64) (int64, error)
 	DeleteByIDTX(ctx context.Context, tx *gorm.DB, id uint) error
 	DeleteByUserIDTX(ctx context.Context, tx *gorm.DB, userID uint) error
 }
@@ -27,12 +35,47 @@ func NewNotificationRepository(db *gorm.DB) NotificationRepository {
 
 func (r *notificationRepository) GetByUserID(ctx context.Context, userID uint) (*[]model.Notification, error) {
 	var notifications []model.Notification
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&notifications).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND is_hidden = ?", userID, false).Order("created_at DESC").Find(&notifications).Error; err != nil {
 		return nil, err
 	}
 	return &notifications, nil
 }
 
+func (r *notificationRepository) GetPaginatedByUserID(ctx context.Context, userID uint, filter dto.NotificationFilter, cursorID uint, limit int) ([]model.Notification, error) {
+	var notifications []model.Notification
+	query := r.db.WithContext(ctx).Where("user_id = ? AND is_hidden = ?", userID, false)
+	if cursorID != 0 {
+		query = query.Where("id < ?", cursorID)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.IsRead != nil {
+		query = query.Where("is_read = ?", *filter.IsRead)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if err := query.Order("id DESC").Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnreadByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ? AND is_read = ? AND is_hidden = ?", userID, false, false).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (r *notificationRepository) CreateTX(ctx context.Context, tx *gorm.DB, notification *model.Notification) error {
 	if err := tx.WithContext(ctx).Create(notification).Error; err != nil {
 		return err
@@ -40,6 +83,40 @@ func (r *notificationRepository) CreateTX(ctx context.Context, tx *gorm.DB, noti
 	return nil
 }
 
+func (r *notificationRepository) CreateGroupedTX(ctx context.Context, tx *gorm.DB, notification *model.Notification) (bool, error) {
+	result := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "group_key"}},
+			DoNothing: true,
+		}).
+		Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *notificationRepository) GetByGroupKeyForUpdateTX(ctx context.Context, tx *gorm.DB, userID uint, groupKey string) (*model.Notification, error) {
+	var notification model.Notification
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND group_key = ?", userID, groupKey).
+		First(&notification).Error; err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *notificationRepository) AddActorTX(ctx context.Context, tx *gorm.DB, actor *model.NotificationActor) (bool, error) {
+	result := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(actor)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *notificationRepository) GetByID(ctx context.Context, id uint) (*model.Notification, error) {
 	var notification model.Notification
 	if err := r.db.WithContext(ctx).First(&notification, id).Error; err != nil {
@@ -66,6 +143,17 @@ func (r *notificationRepository) MarkAllAsReadTX(ctx context.Context, tx *gorm.D
 	return nil
 }
 
+func (r *notificationRepository) MarkAsReadByIDsTX(ctx context.Context, tx *gorm.DB, userID uint, ids []uint, readAt int64) (int64, error) {
+	result := tx.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ? AND id IN ? AND is_read = ?", userID, ids, false).Updates(map[string]any{
+		"is_read": true,
+		"read_at": readAt,
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
 func (r *notificationRepository) DeleteByIDTX(ctx context.Context, tx *gorm.DB, id uint) error {
 	if err := tx.WithContext(ctx).Delete(&model.Notification{}, id).Error; err != nil {
 		return err
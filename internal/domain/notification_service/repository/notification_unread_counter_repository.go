@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/domain/notification_service/util"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type NotificationUnreadCounterRepository interface {
+	Get(ctx context.Context, userID uint) (int64, bool, error)
+	Set(ctx context.Context, userID uint, count int64) error
+	IncrBy(ctx context.Context, userID uint, delta int64) (int64, bool, error)
+}
+
+// incrExistingScript only adjusts a counter that is already cached, so a missing
+// key is rebuilt from the database instead of starting from a partial delta.
+var incrExistingScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return false
+end
+local value = redis.call("INCRBY", KEYS[1], ARGV[1])
+if value < 0 then
+	value = 0
+	redis.call("SET", KEYS[1], 0, "KEEPTTL")
+end
+return value
+`)
+
+type notificationUnreadCounterRepository struct {
+	rdb redis.UniversalClient
+}
+
+func NewNotificationUnreadCounterRepository(rdb redis.UniversalClient) NotificationUnreadCounterRepository {
+	return &notificationUnreadCounterRepository{rdb: rdb}
+}
+
+func (r *notificationUnreadCounterRepository) Get(ctx context.Context, userID uint) (int64, bool, error) {
+	count, err := r.rdb.Get(ctx, util.GetUnreadCountKey(userID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return count, true, nil
+}
+
+func (r *notificationUnreadCounterRepository) Set(ctx context.Context, userID uint, count int64) error {
+	return r.rdb.Set(ctx, util.GetUnreadCountKey(userID), count, util.UnreadCountTTL).Err()
+}
+
+func (r *notificationUnreadCounterRepository) IncrBy(ctx context.Context, userID uint, delta int64) (int64, bool, error) {
+	count, err := incrExistingScript.Run(ctx, r.rdb, []string{util.GetUnreadCountKey(userID)}, delta).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return count, true, nil
+}
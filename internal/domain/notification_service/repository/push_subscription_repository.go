@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PushSubscriptionRepository interface {
+	Upsert(ctx context.Context, subscription *model.PushSubscription) error
+	GetByUserID(ctx context.Context, userID uint) ([]model.PushSubscription, error)
+	DeleteByEndpoint(ctx context.Context, userID uint, endpoint string) (int64, error)
+	DeleteByID(ctx context.Context, subscriptionID uint) error
+}
+
+type pushSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewPushSubscriptionRepository(db *gorm.DB) PushSubscriptionRepository {
+	return &pushSubscriptionRepository{db: db}
+}
+
+// Upsert moves an endpoint to the latest user who registered it, since a
+// browser keeps its endpoint across logins.
+func (r *pushSubscriptionRepository) Upsert(ctx context.Context, subscription *model.PushSubscription) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "endpoint"}},
+			DoUpdates: clause.AssignmentColumns([]string{"user_id", "p256dh", "auth", "user_agent", "updated_at"}),
+		}).
+		Create(subscription).Error
+}
+
+func (r *pushSubscriptionRepository) GetByUserID(ctx context.Context, userID uint) ([]model.PushSubscription, error) {
+	var subscriptions []model.PushSubscription
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *pushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID uint, endpoint string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND endpoint = ?", userID, endpoint).
+		Delete(&model.PushSubscription{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *pushSubscriptionRepository) DeleteByID(ctx context.Context, subscriptionID uint) error {
+	return r.db.WithContext(ctx).Delete(&model.PushSubscription{}, subscriptionID).Error
+}
@@ -5,6 +5,7 @@ import (
 	userRepo "pingspot/internal/domain/user_service/repository"
 	notificationRepo "pingspot/internal/domain/notification_service/repository"
 	"pingspot/internal/domain/notification_service/service"
+	"pingspot/internal/infrastructure/cache"
 	"pingspot/internal/infrastructure/database"
 	"pingspot/internal/middleware"
 	"time"
@@ -15,8 +16,11 @@ import (
 func RegisterNotificationRoutes(app *fiber.App) {
 	db := database.GetPostgresDB()
 	userRepo := userRepo.NewUserRepository(db)
+	notificationPreferenceRepo := notificationRepo.NewNotificationPreferenceRepository(db)
+	notificationUnreadCounterRepo := notificationRepo.NewNotificationUnreadCounterRepository(cache.GetRedis())
+	pushSubscriptionRepo := notificationRepo.NewPushSubscriptionRepository(db)
 	notificationRepo := notificationRepo.NewNotificationRepository(db)
-	notificationService := service.NewNotificationService(db, notificationRepo, userRepo)
+	notificationService := service.NewNotificationService(db, notificationRepo, notificationPreferenceRepo, notificationUnreadCounterRepo, pushSubscriptionRepo, userRepo)
 	notificationHandler := handler.NewNotificationHandler(notificationService)
 
 	notificationRoute := app.Group("/pingspot/api/notification", middleware.ValidateAccessToken())
@@ -32,6 +36,127 @@ func RegisterNotificationRoutes(app *fiber.App) {
 		notificationHandler.GetNotifications,
 	)
 
+	notificationRoute.Get(
+		"/unread-count",
+		middleware.TimeoutMiddleware(5*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 200,
+			KeyPrefix: "get_unread_notification_count",
+		})),
+		notificationHandler.GetUnreadCount,
+	)
+
+	notificationRoute.Get(
+		"/preferences",
+		middleware.TimeoutMiddleware(5*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 50,
+			KeyPrefix: "get_notification_preferences",
+		})),
+		notificationHandler.GetNotificationPreferences,
+	)
+
+	notificationRoute.Put(
+		"/preferences",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix: "update_notification_preferences",
+		})),
+		notificationHandler.UpdateNotificationPreferences,
+	)
+
+	notificationRoute.Put(
+		"/preferences/quiet-hours",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix: "update_quiet_hours",
+		})),
+		notificationHandler.UpdateQuietHours,
+	)
+
+	notificationRoute.Put(
+		"/preferences/digest",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix: "update_digest_settings",
+		})),
+		notificationHandler.UpdateDigestSettings,
+	)
+
+	notificationRoute.Put(
+		"/preferences/sms",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 10,
+			KeyPrefix: "update_sms_settings",
+		})),
+		notificationHandler.UpdateSMSSettings,
+	)
+
+	notificationRoute.Get(
+		"/push-subscriptions/public-key",
+		middleware.TimeoutMiddleware(5*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 50,
+			KeyPrefix: "get_push_public_key",
+		})),
+		notificationHandler.GetPushPublicKey,
+	)
+
+	notificationRoute.Post(
+		"/push-subscriptions",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 20,
+			KeyPrefix: "save_push_subscription",
+		})),
+		notificationHandler.SavePushSubscription,
+	)
+
+	notificationRoute.Delete(
+		"/push-subscriptions",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 20,
+			KeyPrefix: "delete_push_subscription",
+		})),
+		notificationHandler.DeletePushSubscription,
+	)
+
+	notificationRoute.Post(
+		"/preferences/mute/:reportID",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 50,
+			KeyPrefix: "mute_report_notifications",
+		})),
+		notificationHandler.MuteReport,
+	)
+
+	notificationRoute.Delete(
+		"/preferences/mute/:reportID",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 50,
+			KeyPrefix: "unmute_report_notifications",
+		})),
+		notificationHandler.UnmuteReport,
+	)
+
 	notificationRoute.Patch(
 		"/read",
 		middleware.TimeoutMiddleware(10 *time.Second),
@@ -43,6 +168,17 @@ func RegisterNotificationRoutes(app *fiber.App) {
 		notificationHandler.MarkAllNotificationsAsRead,
 	)
 
+	notificationRoute.Patch(
+		"/read/batch",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 50,
+			KeyPrefix: "mark_notifications_read",
+		})),
+		notificationHandler.MarkNotificationsAsRead,
+	)
+
 	notificationRoute.Patch(
 		"/:notificationID/read",
 		middleware.TimeoutMiddleware(10*time.Second),
@@ -75,4 +211,28 @@ func RegisterNotificationRoutes(app *fiber.App) {
 		})),
 		notificationHandler.DeleteAllNotifications,
 	)
+
+	publicNotificationRoute := app.Group("/pingspot/api/public/notification")
+
+	publicNotificationRoute.Get(
+		"/digest/unsubscribe",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 20,
+			KeyPrefix: "unsubscribe_digest",
+		})),
+		notificationHandler.UnsubscribeDigest,
+	)
+
+	publicNotificationRoute.Post(
+		"/digest/unsubscribe",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 20,
+			KeyPrefix: "unsubscribe_digest_one_click",
+		})),
+		notificationHandler.UnsubscribeDigest,
+	)
 }
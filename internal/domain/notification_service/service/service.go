@@ -4,9 +4,13 @@ import (
 	"context"
 	"pingspot/internal/domain/notification_service/dto"
 	notificationRepo "pingspot/internal/domain/notification_service/repository"
+	"pingspot/internal/domain/notification_service/util"
 	userRepo "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/model"
 	apperror "pingspot/pkg/app_error"
 	"pingspot/pkg/logger"
+	contextutils "pingspot/pkg/utils/context_util"
+	env "pingspot/pkg/utils/env_util"
 	"pingspot/pkg/utils/main_util"
 	"time"
 
@@ -16,19 +20,25 @@ import (
 
 type NotificationService struct {
 	notificationRepo notificationRepo.NotificationRepository
+	preferenceRepo   notificationRepo.NotificationPreferenceRepository
+	unreadCounterRepo notificationRepo.NotificationUnreadCounterRepository
+	pushSubscriptionRepo notificationRepo.PushSubscriptionRepository
 	userRepo           userRepo.UserRepository
 	db               *gorm.DB
 }
 
-func NewNotificationService(db *gorm.DB, notificationRepo notificationRepo.NotificationRepository, userRepo userRepo.UserRepository) *NotificationService {
+func NewNotificationService(db *gorm.DB, notificationRepo notificationRepo.NotificationRepository, preferenceRepo notificationRepo.NotificationPreferenceRepository, unreadCounterRepo notificationRepo.NotificationUnreadCounterRepository, pushSubscriptionRepo notificationRepo.PushSubscriptionRepository, userRepo userRepo.UserRepository) *NotificationService {
 	return &NotificationService{
 		db:               db,
 		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		unreadCounterRepo: unreadCounterRepo,
+		pushSubscriptionRepo: pushSubscriptionRepo,
 		userRepo:           userRepo,
 	}
 }
 
-func (s *NotificationService) GetNotifications(ctx context.Context, userID uint) (*dto.GetNotificationsResponse, error) {
+func (s *NotificationService) GetNotifications(ctx context.Context, userID uint, filter dto.NotificationFilter, cursorID uint) (*dto.GetNotificationsResponse, error) {
 	existingUser, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		logger.Error("Failed to get user by ID", zap.Error(err))
@@ -37,13 +47,15 @@ func (s *NotificationService) GetNotifications(ctx context.Context, userID uint)
 	if existingUser == nil {
 		return nil, apperror.New(404, "USER_NOT_FOUND", "pengguna tidak ditemukan", "Pengguna dengan ID tersebut tidak ada", nil)
 	}
-	notifications, err := s.notificationRepo.GetByUserID(ctx, userID)
+	notifications, err := s.notificationRepo.GetPaginatedByUserID(ctx, userID, filter, cursorID, util.NotificationPageSize)
 	if err != nil {
 		logger.Error("Failed to get notifications", zap.Error(err))
 		return nil, apperror.New(500, "NOTIFICATION_FETCH_FAILED", "gagal mendapatkan notifikasi", err.Error(), nil)
 	}
-	var notificationsDTO []*dto.Notification
-	for _, notification := range *notifications {
+	language := contextutils.GetLanguage(ctx)
+	notificationsDTO := make([]*dto.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		notification = util.LocalizeNotification(language, notification)
 		notificationsDTO = append(notificationsDTO, &dto.Notification{
 			ID:          notification.ID,
 			UserID:      notification.UserID,
@@ -51,16 +63,79 @@ func (s *NotificationService) GetNotifications(ctx context.Context, userID uint)
 			Title:       notification.Title,
 			Description: notification.Description,
 			Category:    string(notification.Category),
-			IsRead:      *notification.IsRead,
+			IsRead:      notification.IsRead != nil && *notification.IsRead,
 			ReadAt:      notification.ReadAt,
 			CreatedAt:   notification.CreatedAt,
 			EntityID:    notification.EntityID,
 			EntityType:  (*string)(notification.EntityType),
+			ActorCount:  notification.ActorCount,
+			UpdatedAt:   notification.UpdatedAt,
 		})
 	}
-	return &dto.GetNotificationsResponse{
-		Notifications: notificationsDTO,
-	}, nil
+	response := dto.GetNotificationsResponse{Notifications: notificationsDTO}
+	if len(notifications) == util.NotificationPageSize {
+		nextCursor := notifications[len(notifications)-1].ID
+		response.NextCursor = &nextCursor
+	}
+	return &response, nil
+}
+
+// GetUnreadCount serves the cached counter and only falls back to counting rows
+// when the counter is missing or Redis is unavailable.
+func (s *NotificationService) GetUnreadCount(ctx context.Context, userID uint) (*dto.GetUnreadCountResponse, error) {
+	count, ok, err := s.unreadCounterRepo.Get(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get cached unread count", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	if ok {
+		return &dto.GetUnreadCountResponse{UnreadCount: count}, nil
+	}
+
+	count, err = s.notificationRepo.CountUnreadByUserID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to count unread notifications", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_COUNT_FAILED", "gagal menghitung notifikasi belum dibaca", err.Error(), nil)
+	}
+	s.setUnreadCount(ctx, userID, count)
+	return &dto.GetUnreadCountResponse{UnreadCount: count}, nil
+}
+
+func (s *NotificationService) MarkNotificationsAsRead(ctx context.Context, userID uint, req dto.MarkNotificationsAsReadRequest) (*dto.MarkNotificationsAsReadResponse, error) {
+	ids := util.UniqueNotificationIDs(req.NotificationIDs)
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	updatedCount, err := s.notificationRepo.MarkAsReadByIDsTX(ctx, tx, userID, ids, time.Now().Unix())
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Failed to mark notifications as read", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_MARK_AS_READ_FAILED", "gagal menandai notifikasi sebagai dibaca", err.Error(), nil)
+	}
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyelesaikan transaksi", err.Error(), nil)
+	}
+
+	if updatedCount > 0 {
+		s.adjustUnreadCount(ctx, userID, -updatedCount)
+	}
+	return &dto.MarkNotificationsAsReadResponse{UpdatedCount: updatedCount}, nil
+}
+
+func (s *NotificationService) setUnreadCount(ctx context.Context, userID uint, count int64) {
+	if err := s.unreadCounterRepo.Set(ctx, userID, count); err != nil {
+		logger.Error("Failed to cache unread count", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+func (s *NotificationService) adjustUnreadCount(ctx context.Context, userID uint, delta int64) {
+	if _, _, err := s.unreadCounterRepo.IncrBy(ctx, userID, delta); err != nil {
+		logger.Error("Failed to adjust unread count", zap.Uint("user_id", userID), zap.Int64("delta", delta), zap.Error(err))
+	}
 }
 
 func (s *NotificationService) MarkNotificationAsRead(ctx context.Context, userID uint, notificationID uint) error {
@@ -86,6 +161,9 @@ func (s *NotificationService) MarkNotificationAsRead(ctx context.Context, userID
 		return apperror.New(403, "NOTIFICATION_FORBIDDEN", "notifikasi tidak untuk pengguna ini", "Anda tidak memiliki izin untuk menandai notifikasi ini sebagai dibaca", nil)
 	}
 	
+	if notification.IsRead != nil && *notification.IsRead {
+		return nil
+	}
 	notification.IsRead = main_util.BoolPtrOrNil(true)
 	notification.ReadAt = main_util.Int64PtrOrNil(time.Now().Unix())
 
@@ -105,6 +183,7 @@ func (s *NotificationService) MarkNotificationAsRead(ctx context.Context, userID
 		logger.Error("Failed to commit transaction", zap.Error(err))
 		return apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyelesaikan transaksi", err.Error(), nil)
 	}
+	s.adjustUnreadCount(ctx, userID, -1)
 	return nil
 }
 
@@ -134,6 +213,7 @@ func (s *NotificationService) MarkAllNotificationsAsRead(ctx context.Context, us
 		logger.Error("Failed to commit transaction", zap.Error(err))
 		return apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyelesaikan transaksi", err.Error(), nil)
 	}
+	s.setUnreadCount(ctx, userID, 0)
 	return nil
 }
 
@@ -176,6 +256,9 @@ func (s *NotificationService) DeleteNotification(ctx context.Context, userID uin
 		logger.Error("Failed to commit transaction", zap.Error(err))
 		return apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyelesaikan transaksi", err.Error(), nil)
 	}
+	if notification.IsRead == nil || !*notification.IsRead {
+		s.adjustUnreadCount(ctx, userID, -1)
+	}
 	return nil
 }
 
@@ -206,5 +289,251 @@ func (s *NotificationService) DeleteAllNotifications(ctx context.Context, userID
 		logger.Error("Failed to commit transaction", zap.Error(err))
 		return apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyelesaikan transaksi", err.Error(), nil)
 	}
+	s.setUnreadCount(ctx, userID, 0)
 	return nil
-}
\ No newline at end of file
+}
+func (s *NotificationService) GetNotificationPreferences(ctx context.Context, userID uint) (*dto.GetNotificationPreferencesResponse, error) {
+	preferences, err := s.preferenceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get notification preferences", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_FETCH_FAILED", "gagal mendapatkan preferensi notifikasi", err.Error(), nil)
+	}
+	setting, err := s.preferenceRepo.GetSetting(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get notification setting", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_FETCH_FAILED", "gagal mendapatkan preferensi notifikasi", err.Error(), nil)
+	}
+	mutedReportIDs, err := s.preferenceRepo.GetMutedReportIDs(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get muted reports", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_FETCH_FAILED", "gagal mendapatkan preferensi notifikasi", err.Error(), nil)
+	}
+
+	preferencesDTO := make([]*dto.NotificationPreference, 0, len(preferences))
+	for _, preference := range preferences {
+		preferencesDTO = append(preferencesDTO, &dto.NotificationPreference{
+			Category: string(preference.Category),
+			Event:    string(preference.Event),
+			Channel:  string(preference.Channel),
+			Enabled:  preference.Enabled,
+		})
+	}
+	quietHours := dto.QuietHours{Timezone: util.DefaultTimezone}
+	digest := dto.NotificationDigestSettings{Frequency: string(model.NotificationDigestNone), Hour: util.DefaultDigestHour}
+	sms := dto.NotificationSMSSettings{}
+	if setting != nil {
+		quietHours = dto.QuietHours{
+			Start:    setting.QuietHoursStart,
+			End:      setting.QuietHoursEnd,
+			Timezone: setting.Timezone,
+		}
+		digest = dto.NotificationDigestSettings{
+			Frequency: string(setting.DigestFrequency),
+			Hour:      setting.DigestHour,
+		}
+		sms = dto.NotificationSMSSettings{PhoneNumber: setting.PhoneNumber}
+	}
+	if mutedReportIDs == nil {
+		mutedReportIDs = []uint{}
+	}
+	return &dto.GetNotificationPreferencesResponse{
+		Preferences:    preferencesDTO,
+		QuietHours:     quietHours,
+		Digest:         digest,
+		SMS:            sms,
+		MutedReportIDs: mutedReportIDs,
+	}, nil
+}
+
+func (s *NotificationService) UpdateNotificationPreferences(ctx context.Context, userID uint, req dto.UpdateNotificationPreferencesRequest) (*dto.GetNotificationPreferencesResponse, error) {
+	preferences := make([]model.NotificationPreference, 0, len(req.Preferences))
+	for _, preference := range req.Preferences {
+		if (preference.Category == "") == (preference.Event == "") {
+			return nil, apperror.New(400, "INVALID_NOTIFICATION_PREFERENCE", "preferensi harus memilih kategori atau jenis event", "Isi salah satu dari category atau event untuk setiap preferensi", nil)
+		}
+		preferences = append(preferences, model.NotificationPreference{
+			UserID:   userID,
+			Category: model.NotificationCategory(preference.Category),
+			Event:    model.NotificationEvent(preference.Event),
+			Channel:  model.NotificationChannel(preference.Channel),
+			Enabled:  *preference.Enabled,
+		})
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := s.preferenceRepo.UpsertTX(ctx, tx, preferences); err != nil {
+		tx.Rollback()
+		logger.Error("Failed to save notification preferences", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_UPDATE_FAILED", "gagal menyimpan preferensi notifikasi", err.Error(), nil)
+	}
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyelesaikan transaksi", err.Error(), nil)
+	}
+	return s.GetNotificationPreferences(ctx, userID)
+}
+
+func (s *NotificationService) UpdateQuietHours(ctx context.Context, userID uint, req dto.UpdateQuietHoursRequest) (*dto.QuietHours, error) {
+	setting, err := s.preferenceRepo.GetSetting(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get notification setting", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_FETCH_FAILED", "gagal mendapatkan preferensi notifikasi", err.Error(), nil)
+	}
+	if setting == nil {
+		setting = newNotificationSetting(userID)
+	}
+
+	setting.QuietHoursStart = req.Start
+	setting.QuietHoursEnd = req.End
+	if req.Timezone != "" {
+		setting.Timezone = req.Timezone
+	}
+	if err := s.preferenceRepo.SaveSetting(ctx, setting); err != nil {
+		logger.Error("Failed to save notification setting", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_UPDATE_FAILED", "gagal menyimpan jam tenang", err.Error(), nil)
+	}
+	return &dto.QuietHours{
+		Start:    setting.QuietHoursStart,
+		End:      setting.QuietHoursEnd,
+		Timezone: setting.Timezone,
+	}, nil
+}
+
+func (s *NotificationService) UpdateDigestSettings(ctx context.Context, userID uint, req dto.UpdateDigestSettingsRequest) (*dto.NotificationDigestSettings, error) {
+	setting, err := s.preferenceRepo.GetSetting(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get notification setting", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_FETCH_FAILED", "gagal mendapatkan preferensi notifikasi", err.Error(), nil)
+	}
+	if setting == nil {
+		setting = newNotificationSetting(userID)
+	}
+
+	setting.DigestFrequency = model.NotificationDigestFrequency(req.Frequency)
+	if req.Hour != nil {
+		setting.DigestHour = *req.Hour
+	}
+	if err := s.preferenceRepo.SaveSetting(ctx, setting); err != nil {
+		logger.Error("Failed to save notification setting", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_UPDATE_FAILED", "gagal menyimpan pengaturan ringkasan email", err.Error(), nil)
+	}
+	return &dto.NotificationDigestSettings{
+		Frequency: string(setting.DigestFrequency),
+		Hour:      setting.DigestHour,
+	}, nil
+}
+
+// UnsubscribeDigest backs the one-click link in digest emails, so it is
+// authenticated by the signed token instead of a session.
+func (s *NotificationService) UnsubscribeDigest(ctx context.Context, userID uint, token string) error {
+	if err := util.CheckDigestUnsubscribeSecret(env.DigestUnsubscribeSecret()); err != nil {
+		logger.Error("Digest unsubscribe secret is not configured", zap.Error(err))
+		return apperror.New(503, "DIGEST_UNSUBSCRIBE_UNAVAILABLE", "layanan berhenti berlangganan sedang tidak tersedia", err.Error(), nil)
+	}
+	if !util.VerifyDigestUnsubscribeToken(env.DigestUnsubscribeSecret(), userID, token, time.Now()) {
+		return apperror.New(401, "INVALID_UNSUBSCRIBE_TOKEN", "tautan berhenti berlangganan tidak valid", "Token berhenti berlangganan tidak cocok atau sudah kedaluwarsa", nil)
+	}
+	setting, err := s.preferenceRepo.GetSetting(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get notification setting", zap.Error(err))
+		return apperror.New(500, "NOTIFICATION_PREFERENCE_FETCH_FAILED", "gagal mendapatkan preferensi notifikasi", err.Error(), nil)
+	}
+	if setting == nil || setting.DigestFrequency == model.NotificationDigestNone {
+		return nil
+	}
+	setting.DigestFrequency = model.NotificationDigestNone
+	if err := s.preferenceRepo.SaveSetting(ctx, setting); err != nil {
+		logger.Error("Failed to save notification setting", zap.Error(err))
+		return apperror.New(500, "NOTIFICATION_PREFERENCE_UPDATE_FAILED", "gagal berhenti berlangganan ringkasan email", err.Error(), nil)
+	}
+	return nil
+}
+
+func (s *NotificationService) UpdateSMSSettings(ctx context.Context, userID uint, req dto.UpdateSMSSettingsRequest) (*dto.NotificationSMSSettings, error) {
+	setting, err := s.preferenceRepo.GetSetting(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get notification setting", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_FETCH_FAILED", "gagal mendapatkan preferensi notifikasi", err.Error(), nil)
+	}
+	if setting == nil {
+		setting = newNotificationSetting(userID)
+	}
+
+	setting.PhoneNumber = nil
+	if req.PhoneNumber != nil {
+		setting.PhoneNumber = main_util.StrPtrOrNil(*req.PhoneNumber)
+	}
+	if err := s.preferenceRepo.SaveSetting(ctx, setting); err != nil {
+		logger.Error("Failed to save notification setting", zap.Error(err))
+		return nil, apperror.New(500, "NOTIFICATION_PREFERENCE_UPDATE_FAILED", "gagal menyimpan nomor telepon notifikasi", err.Error(), nil)
+	}
+	return &dto.NotificationSMSSettings{PhoneNumber: setting.PhoneNumber}, nil
+}
+
+func (s *NotificationService) GetPushPublicKey() (*dto.GetPushPublicKeyResponse, error) {
+	publicKey := env.VAPIDPublicKey()
+	if publicKey == "" {
+		return nil, apperror.New(503, "PUSH_NOT_CONFIGURED", "notifikasi push belum tersedia", "VAPID public key is not configured", nil)
+	}
+	return &dto.GetPushPublicKeyResponse{PublicKey: publicKey}, nil
+}
+
+func (s *NotificationService) SavePushSubscription(ctx context.Context, userID uint, req dto.SavePushSubscriptionRequest, userAgent string) error {
+	if !util.IsAllowedPushEndpoint(req.Endpoint) {
+		return apperror.New(400, "PUSH_ENDPOINT_NOT_ALLOWED", "Endpoint langganan notifikasi push tidak didukung", "Endpoint is not a supported push service", nil)
+	}
+	subscription := &model.PushSubscription{
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: main_util.StrPtrOrNil(userAgent),
+	}
+	if err := s.pushSubscriptionRepo.Upsert(ctx, subscription); err != nil {
+		logger.Error("Failed to save push subscription", zap.Error(err))
+		return apperror.New(500, "PUSH_SUBSCRIPTION_SAVE_FAILED", "gagal menyimpan langganan notifikasi push", err.Error(), nil)
+	}
+	return nil
+}
+
+func (s *NotificationService) DeletePushSubscription(ctx context.Context, userID uint, endpoint string) error {
+	deleted, err := s.pushSubscriptionRepo.DeleteByEndpoint(ctx, userID, endpoint)
+	if err != nil {
+		logger.Error("Failed to delete push subscription", zap.Error(err))
+		return apperror.New(500, "PUSH_SUBSCRIPTION_DELETE_FAILED", "gagal menghapus langganan notifikasi push", err.Error(), nil)
+	}
+	if deleted == 0 {
+		return apperror.New(404, "PUSH_SUBSCRIPTION_NOT_FOUND", "langganan notifikasi push tidak ditemukan", "Push subscription not found", nil)
+	}
+	return nil
+}
+
+func newNotificationSetting(userID uint) *model.NotificationSetting {
+	return &model.NotificationSetting{
+		UserID:          userID,
+		Timezone:        util.DefaultTimezone,
+		DigestFrequency: model.NotificationDigestNone,
+		DigestHour:      util.DefaultDigestHour,
+	}
+}
+
+func (s *NotificationService) MuteReport(ctx context.Context, userID, reportID uint) error {
+	if err := s.preferenceRepo.MuteReport(ctx, userID, reportID); err != nil {
+		logger.Error("Failed to mute report notifications", zap.Error(err))
+		return apperror.New(500, "REPORT_MUTE_FAILED", "gagal membisukan notifikasi laporan", err.Error(), nil)
+	}
+	return nil
+}
+
+func (s *NotificationService) UnmuteReport(ctx context.Context, userID, reportID uint) error {
+	if err := s.preferenceRepo.UnmuteReport(ctx, userID, reportID); err != nil {
+		logger.Error("Failed to unmute report notifications", zap.Error(err))
+		return apperror.New(500, "REPORT_UNMUTE_FAILED", "gagal mengaktifkan kembali notifikasi laporan", err.Error(), nil)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	"pingspot/pkg/mailer"
+	mainutils "pingspot/pkg/utils/main_util"
+	"strings"
+	"time"
+)
+
+const (
+	BaseDeliveryRetryDelay = time.Minute
+	MaxDeliveryRetryDelay  = 2 * time.Hour
+	DeliveryTimeout        = 10 * time.Second
+	MaxSMSLength           = 160
+)
+
+type SMSProviderConfig struct {
+	URL      string
+	APIKey   string
+	SenderID string
+}
+
+type PushMessage struct {
+	NotificationID uint   `json:"notificationId"`
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	URL            string `json:"url"`
+}
+
+type smsRequest struct {
+	To      string `json:"to"`
+	From    string `json:"from,omitempty"`
+	Message string `json:"message"`
+}
+
+type smsResponse struct {
+	ID        string `json:"id"`
+	MessageID string `json:"messageId"`
+}
+
+// GetNotificationLink points at the report a notification is about and falls
+// back to the app itself for every other entity.
+func GetNotificationLink(clientURL string, notification model.Notification) string {
+	if notification.EntityType != nil && notification.EntityID != nil && *notification.EntityType == model.EntityTypeReport {
+		return fmt.Sprintf("%s/main/report/%s", clientURL, *notification.EntityID)
+	}
+	return clientURL
+}
+
+func BuildPushMessage(notification model.Notification, link string) ([]byte, error) {
+	return json.Marshal(PushMessage{
+		NotificationID: notification.ID,
+		Title:          notification.Title,
+		Body:           notification.Description,
+		URL:            link,
+	})
+}
+
+func FormatSMSMessage(notification model.Notification) string {
+	message := fmt.Sprintf("PingSpot: %s", notification.Description)
+	runes := []rune(message)
+	if len(runes) <= MaxSMSLength {
+		return message
+	}
+	return string(runes[:MaxSMSLength-3]) + "..."
+}
+
+// SendSMS posts to a generic JSON SMS gateway authenticated with a bearer key
+// and returns the provider's message id when the response carries one.
+func SendSMS(ctx context.Context, client *http.Client, config SMSProviderConfig, to, message string) (string, error) {
+	if config.URL == "" || config.APIKey == "" {
+		return "", fmt.Errorf("sms provider is not configured")
+	}
+	body, err := json.Marshal(smsRequest{To: to, From: config.SenderID, Message: message})
+	if err != nil {
+		return "", fmt.Errorf("failed to build sms request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build sms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+config.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 2000))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("sms provider responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	var parsed smsResponse
+	if err := json.Unmarshal(responseBody, &parsed); err != nil {
+		return "", nil
+	}
+	if parsed.ID != "" {
+		return parsed.ID, nil
+	}
+	return parsed.MessageID, nil
+}
+
+func SendNotificationEmail(to, username string, notification model.Notification, link string, language i18n.Language) error {
+	return mainutils.SendEmail(mainutils.EmailData{
+		To:            to,
+		Subject:       notification.Title,
+		RecipientName: username,
+		EmailType:     mainutils.EmailTypeNotification,
+		TemplateData: map[string]any{
+			"Title":       notification.Title,
+			"Description": notification.Description,
+			"Link":        link,
+		},
+		BodyTempate: getNotificationEmailTemplate(),
+		Language:    language,
+		IdempotencyKey: mailer.NewIdempotencyKey("notification", notification.ID),
+	})
+}
+
+func getNotificationEmailTemplate() string {
+	return `<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>{{.Title}}</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif; background-color: #f8fafc; line-height: 1.6;">
+	<table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%" style="background-color: #f8fafc;">
+		<tr>
+			<td align="center" style="padding: 40px 20px;">
+				<table role="presentation" cellspacing="0" cellpadding="0" border="0" width="600" style="max-width: 600px; background-color: #ffffff; border-radius: 16px; box-shadow: 0 10px 25px rgba(0, 0, 0, 0.1); overflow: hidden;">
+					<tr>
+						<td style="background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); padding: 40px 40px 30px; text-align: center;">
+							<h1 style="margin: 0; color: #ffffff; font-size: 28px; font-weight: 700; letter-spacing: -0.5px;">
+								PingSpot
+							</h1>
+						</td>
+					</tr>
+					<tr>
+						<td style="padding: 40px;">
+							<h2 style="margin: 0 0 20px; color: #1e293b; font-size: 22px; font-weight: 600;">
+								{{t "email.common.greeting" "name" .UserName}}
+							</h2>
+							<div style="margin: 0 0 12px; padding: 14px 16px; background-color: #f1f5f9; border-radius: 12px; border-left: 4px solid #667eea;">
+								<p style="margin: 0; color: #1e293b; font-size: 15px; font-weight: 600;">{{.Title}}</p>
+								<p style="margin: 4px 0 0; color: #475569; font-size: 14px;">{{.Description}}</p>
+							</div>
+							{{if .Link}}
+							<div style="text-align: center; margin: 35px 0 10px;">
+								<a href="{{.Link}}"
+								   style="display: inline-block; background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); color: #ffffff; text-decoration: none; padding: 14px 28px; border-radius: 50px; font-weight: 600; font-size: 16px; min-width: 200px;">
+									{{t "email.notification.view"}}
+								</a>
+							</div>
+							{{end}}
+						</td>
+					</tr>
+					<tr>
+						<td style="padding: 24px 40px; background-color: #f8fafc; text-align: center;">
+							<p style="margin: 0; color: #94a3b8; font-size: 12px;">
+								{{t "email.notification.footer"}}
+							</p>
+						</td>
+					</tr>
+				</table>
+			</td>
+		</tr>
+	</table>
+</body>
+</html>`
+}
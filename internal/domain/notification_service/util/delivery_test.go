@@ -0,0 +1,64 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pingspot/internal/model"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotificationLink(t *testing.T) {
+	reportID := "12"
+	reportType := model.EntityTypeReport
+	userType := model.EntityTypeUser
+
+	assert.Equal(t, "https://app.example.com/main/report/12", GetNotificationLink("https://app.example.com", model.Notification{EntityID: &reportID, EntityType: &reportType}))
+	assert.Equal(t, "https://app.example.com", GetNotificationLink("https://app.example.com", model.Notification{EntityID: &reportID, EntityType: &userType}))
+	assert.Equal(t, "https://app.example.com", GetNotificationLink("https://app.example.com", model.Notification{}))
+}
+
+func TestFormatSMSMessage(t *testing.T) {
+	assert.Equal(t, "PingSpot: Laporan Anda selesai", FormatSMSMessage(model.Notification{Description: "Laporan Anda selesai"}))
+
+	message := FormatSMSMessage(model.Notification{Description: strings.Repeat("a", 300)})
+	assert.Len(t, []rune(message), MaxSMSLength)
+	assert.True(t, strings.HasSuffix(message, "..."))
+}
+
+func TestSendSMS(t *testing.T) {
+	t.Run("should post message with bearer key", func(t *testing.T) {
+		var received smsRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.Write([]byte(`{"id":"msg-1"}`))
+		}))
+		defer server.Close()
+
+		messageID, err := SendSMS(context.Background(), server.Client(), SMSProviderConfig{URL: server.URL, APIKey: "secret", SenderID: "PINGSPOT"}, "+6281234567890", "halo")
+		require.NoError(t, err)
+		assert.Equal(t, "msg-1", messageID)
+		assert.Equal(t, smsRequest{To: "+6281234567890", From: "PINGSPOT", Message: "halo"}, received)
+	})
+
+	t.Run("should fail on provider error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		_, err := SendSMS(context.Background(), server.Client(), SMSProviderConfig{URL: server.URL, APIKey: "secret"}, "+6281234567890", "halo")
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail when provider is not configured", func(t *testing.T) {
+		_, err := SendSMS(context.Background(), http.DefaultClient, SMSProviderConfig{}, "+6281234567890", "halo")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,224 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"pingspot/internal/domain/notification_service/dto"
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	"pingspot/pkg/mailer"
+	mainutils "pingspot/pkg/utils/main_util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDigestHour     = 8
+	DigestItemLimit       = 10
+	DigestNearbyPerArea   = 5
+	digestUnsubscribePath = "/pingspot/api/public/notification/digest/unsubscribe"
+
+	// DigestUnsubscribeTTL keeps links in older digests working for a while
+	// without making a leaked link valid forever.
+	DigestUnsubscribeTTL       = 60 * 24 * time.Hour
+	MinDigestUnsubscribeSecret = 32
+)
+
+var ErrDigestUnsubscribeSecretMissing = errors.New("DIGEST_UNSUBSCRIBE_SECRET is not set or too short")
+
+func CheckDigestUnsubscribeSecret(secret string) error {
+	if len(secret) < MinDigestUnsubscribeSecret {
+		return ErrDigestUnsubscribeSecretMissing
+	}
+	return nil
+}
+
+func GetDigestPeriod(frequency model.NotificationDigestFrequency) time.Duration {
+	if frequency == model.NotificationDigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// IsDigestDue checks the user's local clock, so a digest scheduled for 08:00 is
+// sent at 08:00 in the user's own timezone. Weekly digests go out on Mondays.
+// The last-sent guard keeps an hourly scheduler from sending twice.
+func IsDigestDue(setting model.NotificationSetting, now time.Time) bool {
+	if setting.DigestFrequency != model.NotificationDigestDaily && setting.DigestFrequency != model.NotificationDigestWeekly {
+		return false
+	}
+	localNow := now.In(LoadTimezone(setting.Timezone))
+	if localNow.Hour() != setting.DigestHour {
+		return false
+	}
+	if setting.DigestFrequency == model.NotificationDigestWeekly && localNow.Weekday() != time.Monday {
+		return false
+	}
+	if setting.LastDigestSentAt == nil {
+		return true
+	}
+	return now.Sub(time.Unix(*setting.LastDigestSentAt, 0)) >= GetDigestPeriod(setting.DigestFrequency)-time.Hour
+}
+
+func GetDigestSince(setting model.NotificationSetting, now time.Time) int64 {
+	if setting.LastDigestSentAt != nil {
+		return *setting.LastDigestSentAt
+	}
+	return now.Add(-GetDigestPeriod(setting.DigestFrequency)).Unix()
+}
+
+func GetDigestPeriodLabel(language i18n.Language, frequency model.NotificationDigestFrequency) string {
+	if frequency == model.NotificationDigestWeekly {
+		return i18n.T(language, "email.digest.period_weekly", nil)
+	}
+	return i18n.T(language, "email.digest.period_daily", nil)
+}
+
+func IsDigestEmpty(digest dto.DigestEmail) bool {
+	return digest.UnreadCount == 0 && len(digest.WatchedProgress) == 0 && len(digest.NearbyReports) == 0
+}
+
+func signDigestUnsubscribe(secret string, userID uint, expiresAt int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("notification-digest-unsubscribe:"))
+	mac.Write([]byte(strconv.FormatUint(uint64(userID), 10)))
+	mac.Write([]byte(":"))
+	mac.Write([]byte(strconv.FormatInt(expiresAt, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignDigestUnsubscribeToken returns "<expiresAt>.<signature>". It refuses to
+// sign with a missing or short secret, since anyone could forge those tokens.
+func SignDigestUnsubscribeToken(secret string, userID uint, now time.Time) (string, error) {
+	if err := CheckDigestUnsubscribeSecret(secret); err != nil {
+		return "", err
+	}
+	expiresAt := now.Add(DigestUnsubscribeTTL).Unix()
+	return strconv.FormatInt(expiresAt, 10) + "." + signDigestUnsubscribe(secret, userID, expiresAt), nil
+}
+
+func VerifyDigestUnsubscribeToken(secret string, userID uint, token string, now time.Time) bool {
+	if CheckDigestUnsubscribeSecret(secret) != nil {
+		return false
+	}
+	expiresAtStr, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expiresAtStr, 10, 64)
+	if err != nil || now.Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(signDigestUnsubscribe(secret, userID, expiresAt)), []byte(signature))
+}
+
+func BuildDigestUnsubscribeLink(serverURL, secret string, userID uint, now time.Time) (string, error) {
+	token, err := SignDigestUnsubscribeToken(secret, userID, now)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	query.Set("userID", strconv.FormatUint(uint64(userID), 10))
+	query.Set("token", token)
+	return fmt.Sprintf("%s%s?%s", serverURL, digestUnsubscribePath, query.Encode()), nil
+}
+
+func BuildDigestEmail(to, username string, digest dto.DigestEmail, unsubscribeLink string, idempotencyKey string, language i18n.Language) (mailer.Message, error) {
+	return mainutils.BuildEmail(mainutils.EmailData{
+		To:            to,
+		Subject:       i18n.T(language, "email.digest.subject", map[string]string{"period": digest.PeriodLabel}),
+		RecipientName: username,
+		EmailType:     mainutils.EmailTypeNotificationDigest,
+		TemplateData: map[string]any{
+			"Digest":          digest,
+			"UnsubscribeLink": unsubscribeLink,
+		},
+		BodyTempate: getDigestEmailTemplate(),
+		Language:    language,
+		IdempotencyKey: idempotencyKey,
+	})
+}
+
+func getDigestEmailTemplate() string {
+	return `<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>{{t "email.digest.title"}}</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif; background-color: #f8fafc; line-height: 1.6;">
+	<table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%" style="background-color: #f8fafc;">
+		<tr>
+			<td align="center" style="padding: 40px 20px;">
+				<table role="presentation" cellspacing="0" cellpadding="0" border="0" width="600" style="max-width: 600px; background-color: #ffffff; border-radius: 16px; box-shadow: 0 10px 25px rgba(0, 0, 0, 0.1); overflow: hidden;">
+					<tr>
+						<td style="background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); padding: 40px 40px 30px; text-align: center;">
+							<h1 style="margin: 0; color: #ffffff; font-size: 28px; font-weight: 700; letter-spacing: -0.5px;">
+								PingSpot
+							</h1>
+							<p style="margin: 8px 0 0; color: rgba(255, 255, 255, 0.9); font-size: 16px; font-weight: 400;">
+								{{t "email.digest.tagline" "period" .Digest.PeriodLabel}}
+							</p>
+						</td>
+					</tr>
+					<tr>
+						<td style="padding: 40px;">
+							<h2 style="margin: 0 0 20px; color: #1e293b; font-size: 22px; font-weight: 600;">
+								{{t "email.common.greeting" "name" .UserName}}
+							</h2>
+							{{if .Digest.UnreadCount}}
+							<h3 style="margin: 0 0 12px; color: #1e293b; font-size: 18px;">{{t "email.digest.unread" "count" .Digest.UnreadCount}}</h3>
+							{{range .Digest.Notifications}}
+							<div style="margin: 0 0 12px; padding: 14px 16px; background-color: #f1f5f9; border-radius: 12px; border-left: 4px solid #667eea;">
+								<p style="margin: 0; color: #1e293b; font-size: 15px; font-weight: 600;">{{.Title}}</p>
+								<p style="margin: 4px 0 0; color: #475569; font-size: 14px;">{{.Description}}</p>
+							</div>
+							{{end}}
+							{{end}}
+							{{if .Digest.WatchedProgress}}
+							<h3 style="margin: 28px 0 12px; color: #1e293b; font-size: 18px;">{{t "email.digest.watched"}}</h3>
+							{{range .Digest.WatchedProgress}}
+							<div style="margin: 0 0 12px; padding: 14px 16px; background-color: #f1f5f9; border-radius: 12px; border-left: 4px solid #10b981;">
+								<a href="{{.Link}}" style="margin: 0; color: #1e293b; font-size: 15px; font-weight: 600; text-decoration: none;">{{.Title}}</a>
+								<p style="margin: 4px 0 0; color: #475569; font-size: 14px;">{{.Description}}</p>
+							</div>
+							{{end}}
+							{{end}}
+							{{if .Digest.NearbyReports}}
+							<h3 style="margin: 28px 0 12px; color: #1e293b; font-size: 18px;">{{t "email.digest.nearby"}}</h3>
+							{{range .Digest.NearbyReports}}
+							<div style="margin: 0 0 12px; padding: 14px 16px; background-color: #f1f5f9; border-radius: 12px; border-left: 4px solid #f59e0b;">
+								<a href="{{.Link}}" style="margin: 0; color: #1e293b; font-size: 15px; font-weight: 600; text-decoration: none;">{{.Title}}</a>
+								<p style="margin: 4px 0 0; color: #475569; font-size: 14px;">{{.Description}}</p>
+							</div>
+							{{end}}
+							{{end}}
+							<div style="text-align: center; margin: 35px 0 10px;">
+								<a href="{{.Digest.AppLink}}"
+								   style="display: inline-block; background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); color: #ffffff; text-decoration: none; padding: 14px 28px; border-radius: 50px; font-weight: 600; font-size: 16px; min-width: 200px;">
+									{{t "email.digest.open_app"}}
+								</a>
+							</div>
+						</td>
+					</tr>
+					<tr>
+						<td style="padding: 24px 40px; background-color: #f8fafc; text-align: center;">
+							<p style="margin: 0; color: #94a3b8; font-size: 12px;">
+								{{t "email.digest.footer"}}
+								<a href="{{.UnsubscribeLink}}" style="color: #667eea;">{{t "email.digest.unsubscribe"}}</a>
+							</p>
+						</td>
+					</tr>
+				</table>
+			</td>
+		</tr>
+	</table>
+</body>
+</html>`
+}
@@ -0,0 +1,108 @@
+package util
+
+import (
+	"pingspot/internal/domain/notification_service/dto"
+	"pingspot/internal/model"
+	mainutils "pingspot/pkg/utils/main_util"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDigestDue(t *testing.T) {
+	jakarta := LoadTimezone("Asia/Jakarta")
+	mondayMorning := time.Date(2026, 10, 19, 8, 0, 0, 0, jakarta)
+	tuesdayMorning := mondayMorning.Add(24 * time.Hour)
+
+	daily := model.NotificationSetting{DigestFrequency: model.NotificationDigestDaily, DigestHour: 8, Timezone: "Asia/Jakarta"}
+	assert.True(t, IsDigestDue(daily, mondayMorning))
+	assert.True(t, IsDigestDue(daily, mondayMorning.UTC()), "hour is evaluated in the user's timezone")
+	assert.False(t, IsDigestDue(daily, mondayMorning.Add(time.Hour)))
+
+	sentAt := mondayMorning.Add(-10 * time.Minute).Unix()
+	daily.LastDigestSentAt = &sentAt
+	assert.False(t, IsDigestDue(daily, mondayMorning), "already sent this period")
+	assert.True(t, IsDigestDue(daily, tuesdayMorning))
+
+	weekly := model.NotificationSetting{DigestFrequency: model.NotificationDigestWeekly, DigestHour: 8, Timezone: "Asia/Jakarta"}
+	assert.True(t, IsDigestDue(weekly, mondayMorning))
+	assert.False(t, IsDigestDue(weekly, tuesdayMorning))
+
+	none := model.NotificationSetting{DigestFrequency: model.NotificationDigestNone, DigestHour: 8, Timezone: "Asia/Jakarta"}
+	assert.False(t, IsDigestDue(none, mondayMorning))
+}
+
+func TestGetDigestSince(t *testing.T) {
+	now := time.Date(2026, 10, 19, 1, 0, 0, 0, time.UTC)
+	weekly := model.NotificationSetting{DigestFrequency: model.NotificationDigestWeekly}
+	assert.Equal(t, now.Add(-7*24*time.Hour).Unix(), GetDigestSince(weekly, now))
+
+	sentAt := now.Add(-3 * time.Hour).Unix()
+	weekly.LastDigestSentAt = &sentAt
+	assert.Equal(t, sentAt, GetDigestSince(weekly, now))
+}
+
+func TestDigestUnsubscribeToken(t *testing.T) {
+	secret := strings.Repeat("s", MinDigestUnsubscribeSecret)
+	now := time.Unix(1700000000, 0)
+
+	token, err := SignDigestUnsubscribeToken(secret, 42, now)
+	require.NoError(t, err)
+	assert.True(t, VerifyDigestUnsubscribeToken(secret, 42, token, now))
+	assert.True(t, VerifyDigestUnsubscribeToken(secret, 42, token, now.Add(DigestUnsubscribeTTL)))
+	assert.False(t, VerifyDigestUnsubscribeToken(secret, 42, token, now.Add(DigestUnsubscribeTTL+time.Second)))
+	assert.False(t, VerifyDigestUnsubscribeToken(secret, 43, token, now))
+	assert.False(t, VerifyDigestUnsubscribeToken(strings.Repeat("o", MinDigestUnsubscribeSecret), 42, token, now))
+	assert.False(t, VerifyDigestUnsubscribeToken(secret, 42, "", now))
+
+	_, signature, _ := strings.Cut(token, ".")
+	assert.False(t, VerifyDigestUnsubscribeToken(secret, 42, "9999999999."+signature, now))
+
+	link, err := BuildDigestUnsubscribeLink("https://api.example.com", secret, 42, now)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/pingspot/api/public/notification/digest/unsubscribe?token="+token+"&userID=42", link)
+}
+
+func TestDigestUnsubscribeToken_MissingSecret(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	_, err := SignDigestUnsubscribeToken("", 42, now)
+	assert.ErrorIs(t, err, ErrDigestUnsubscribeSecretMissing)
+	_, err = BuildDigestUnsubscribeLink("https://api.example.com", "short", 42, now)
+	assert.ErrorIs(t, err, ErrDigestUnsubscribeSecretMissing)
+
+	forged := strconv.FormatInt(now.Add(time.Hour).Unix(), 10) + "." + signDigestUnsubscribe("", 42, now.Add(time.Hour).Unix())
+	assert.False(t, VerifyDigestUnsubscribeToken("", 42, forged, now))
+}
+
+func TestDigestEmailTemplate(t *testing.T) {
+	digest := dto.DigestEmail{
+		PeriodLabel:     "minggu ini",
+		UnreadCount:     3,
+		Notifications:   []dto.DigestItem{{Title: "Suara baru", Description: "budi dan 2 lainnya memberikan suara"}},
+		WatchedProgress: []dto.DigestItem{{Title: "Jalan berlubang", Description: "Status: ON_PROGRESS", Link: "https://app.example.com/main/report/7"}},
+		AppLink:         "https://app.example.com",
+	}
+	assert.False(t, IsDigestEmpty(digest))
+	assert.True(t, IsDigestEmpty(dto.DigestEmail{}))
+
+	html, err := mainutils.RenderEmailTemplate(mainutils.EmailData{
+		RecipientName: "sari",
+		EmailType:     mainutils.EmailTypeNotificationDigest,
+		BodyTempate:   getDigestEmailTemplate(),
+		TemplateData: map[string]any{
+			"Digest":          digest,
+			"UnsubscribeLink": "https://api.example.com/unsubscribe",
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, html, "Halo sari!")
+	assert.Contains(t, html, "3 notifikasi belum dibaca")
+	assert.Contains(t, html, "Jalan berlubang")
+	assert.NotContains(t, html, "Laporan baru di sekitar Anda")
+	assert.Contains(t, html, "https://api.example.com/unsubscribe")
+}
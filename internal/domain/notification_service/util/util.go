@@ -0,0 +1,235 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	"strconv"
+	"time"
+)
+
+const DefaultTimezone = "Asia/Jakarta"
+
+const (
+	NotificationPageSize = 20
+	UnreadCountKeyPrefix = "notification:unread"
+	// UnreadCountTTL bounds how long a drifted counter can survive before it is
+	// rebuilt from the database.
+	UnreadCountTTL = 24 * time.Hour
+	// GroupingWindow is the fixed bucket in which similar notifications collapse
+	// into a single grouped item.
+	GroupingWindow = 6 * time.Hour
+)
+
+// groupTemplates maps a groupable event to the catalog key prefix of its
+// single and grouped messages.
+var groupTemplates = map[model.NotificationEvent]string{
+	model.NotificationEventVote:     "notification.vote",
+	model.NotificationEventReaction: "notification.reaction",
+	model.NotificationEventFollow:   "notification.follow",
+}
+
+type DeliveryChannels struct {
+	InApp bool
+	Email bool
+	Push  bool
+	SMS   bool
+}
+
+func (c DeliveryChannels) Any() bool {
+	return c.InApp || c.Email || c.Push || c.SMS
+}
+
+// IsChannelEnabled treats every channel as enabled unless the user disabled it
+// for the notification's category or for its event kind.
+func IsChannelEnabled(preferences []model.NotificationPreference, category model.NotificationCategory, event model.NotificationEvent, channel model.NotificationChannel) bool {
+	for _, preference := range preferences {
+		if preference.Channel != channel || preference.Enabled {
+			continue
+		}
+		if preference.Event == "" && preference.Category != "" && preference.Category == category {
+			return false
+		}
+		if preference.Category == "" && preference.Event != "" && preference.Event == event {
+			return false
+		}
+	}
+	return true
+}
+
+// IsChannelOptedIn is the stricter rule for costly channels such as SMS: the
+// user must enable the channel for the category or the event, and a disable
+// rule on either scope still wins.
+func IsChannelOptedIn(preferences []model.NotificationPreference, category model.NotificationCategory, event model.NotificationEvent, channel model.NotificationChannel) bool {
+	optedIn := false
+	for _, preference := range preferences {
+		if preference.Channel != channel {
+			continue
+		}
+		matches := (preference.Event == "" && preference.Category != "" && preference.Category == category) ||
+			(preference.Category == "" && preference.Event != "" && preference.Event == event)
+		if !matches {
+			continue
+		}
+		if !preference.Enabled {
+			return false
+		}
+		optedIn = true
+	}
+	return optedIn
+}
+
+func ParseClock(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock value %q: %w", value, err)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func LoadTimezone(name string) *time.Location {
+	if name == "" {
+		name = DefaultTimezone
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.FixedZone(DefaultTimezone, 7*60*60)
+	}
+	return location
+}
+
+// IsWithinQuietHours supports windows that wrap past midnight, e.g. 22:00-06:00.
+func IsWithinQuietHours(setting *model.NotificationSetting, now time.Time) bool {
+	if setting == nil || setting.QuietHoursStart == nil || setting.QuietHoursEnd == nil {
+		return false
+	}
+	start, err := ParseClock(*setting.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	end, err := ParseClock(*setting.QuietHoursEnd)
+	if err != nil || start == end {
+		return false
+	}
+
+	localNow := now.In(LoadTimezone(setting.Timezone))
+	minute := localNow.Hour()*60 + localNow.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// ResolveDeliveryChannels decides where a notification may be delivered. A
+// muted report silences every channel. During quiet hours the interruptive
+// channels are turned off for that notification, which is not sent later; the
+// in-app inbox is unaffected.
+func ResolveDeliveryChannels(preferences []model.NotificationPreference, setting *model.NotificationSetting, reportMuted bool, category model.NotificationCategory, event model.NotificationEvent, now time.Time) DeliveryChannels {
+	if reportMuted {
+		return DeliveryChannels{}
+	}
+	quiet := IsWithinQuietHours(setting, now)
+	return DeliveryChannels{
+		InApp: IsChannelEnabled(preferences, category, event, model.NotificationChannelInApp),
+		Email: !quiet && IsChannelEnabled(preferences, category, event, model.NotificationChannelEmail),
+		Push:  !quiet && IsChannelEnabled(preferences, category, event, model.NotificationChannelPush),
+		SMS:   !quiet && IsChannelOptedIn(preferences, category, event, model.NotificationChannelSMS),
+	}
+}
+
+func GetUnreadCountKey(userID uint) string {
+	return fmt.Sprintf("%s:%d", UnreadCountKeyPrefix, userID)
+}
+
+func ParseReadState(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	isRead, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid read state %q: %w", value, err)
+	}
+	return &isRead, nil
+}
+
+func UniqueNotificationIDs(ids []uint) []uint {
+	seen := make(map[uint]struct{}, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func IsGroupableEvent(event model.NotificationEvent) bool {
+	_, ok := groupTemplates[event]
+	return ok
+}
+
+// GetGroupKey buckets notifications by event, entity and time window. Follows
+// are grouped per recipient only, since their entity is the individual follower.
+func GetGroupKey(event model.NotificationEvent, entityType model.EntityType, entityID string, now time.Time) string {
+	bucket := now.Unix() / int64(GroupingWindow/time.Second)
+	if event == model.NotificationEventFollow {
+		return fmt.Sprintf("%s:%d", event, bucket)
+	}
+	return fmt.Sprintf("%s:%s:%s:%d", event, entityType, entityID, bucket)
+}
+
+func FormatGroupedNotification(event model.NotificationEvent, latestActor string, actorCount int) (string, map[string]string) {
+	key := groupTemplates[event]
+	if actorCount <= 1 {
+		return key + ".single", map[string]string{"actor": latestActor}
+	}
+	return key + ".grouped", map[string]string{"actor": latestActor, "others": strconv.Itoa(actorCount - 1)}
+}
+
+func EncodeMessageParams(params map[string]string) *string {
+	if len(params) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+	value := string(encoded)
+	return &value
+}
+
+func DecodeMessageParams(value *string) map[string]string {
+	params := map[string]string{}
+	if value == nil || *value == "" {
+		return params
+	}
+	if err := json.Unmarshal([]byte(*value), &params); err != nil {
+		return map[string]string{}
+	}
+	return params
+}
+
+// SetNotificationMessage stores the message key and params on a notification
+// and renders its title and description in the default language, which is what
+// older clients and rows without a key fall back to.
+func SetNotificationMessage(notification *model.Notification, key string, params map[string]string) {
+	notification.MessageKey = &key
+	notification.MessageParams = EncodeMessageParams(params)
+	notification.Title = i18n.T(i18n.DefaultLanguage, key+".title", params)
+	notification.Description = i18n.T(i18n.DefaultLanguage, key+".description", params)
+}
+
+// LocalizeNotification renders a notification in the reader's language.
+// Notifications stored before message keys existed keep their original text.
+func LocalizeNotification(language i18n.Language, notification model.Notification) model.Notification {
+	if notification.MessageKey == nil || *notification.MessageKey == "" {
+		return notification
+	}
+	params := DecodeMessageParams(notification.MessageParams)
+	notification.Title = i18n.T(language, *notification.MessageKey+".title", params)
+	notification.Description = i18n.T(language, *notification.MessageKey+".description", params)
+	return notification
+}
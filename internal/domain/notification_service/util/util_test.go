@@ -0,0 +1,173 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(value string) *string {
+	return &value
+}
+
+func TestIsChannelEnabled(t *testing.T) {
+	preferences := []model.NotificationPreference{
+		{Event: model.NotificationEventVote, Channel: model.NotificationChannelInApp, Enabled: false},
+		{Category: model.IncidentNotificationCategory, Channel: model.NotificationChannelEmail, Enabled: false},
+		{Event: model.NotificationEventComment, Channel: model.NotificationChannelInApp, Enabled: true},
+	}
+
+	assert.False(t, IsChannelEnabled(preferences, model.ReportNotificationCategory, model.NotificationEventVote, model.NotificationChannelInApp))
+	assert.True(t, IsChannelEnabled(preferences, model.ReportNotificationCategory, model.NotificationEventVote, model.NotificationChannelEmail))
+	assert.True(t, IsChannelEnabled(preferences, model.ReportNotificationCategory, model.NotificationEventComment, model.NotificationChannelInApp))
+	assert.False(t, IsChannelEnabled(preferences, model.IncidentNotificationCategory, "", model.NotificationChannelEmail))
+	assert.True(t, IsChannelEnabled(preferences, model.IncidentNotificationCategory, "", model.NotificationChannelInApp))
+	assert.True(t, IsChannelEnabled(nil, model.UserNotificationCategory, model.NotificationEventFollow, model.NotificationChannelPush))
+}
+
+func TestParseClock(t *testing.T) {
+	minutes, err := ParseClock("22:30")
+	require.NoError(t, err)
+	assert.Equal(t, 22*60+30, minutes)
+
+	_, err = ParseClock("25:00")
+	assert.Error(t, err)
+}
+
+func TestIsWithinQuietHours(t *testing.T) {
+	jakarta := LoadTimezone("Asia/Jakarta")
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 18, hour, minute, 0, 0, jakarta)
+	}
+
+	overnight := &model.NotificationSetting{QuietHoursStart: strPtr("22:00"), QuietHoursEnd: strPtr("06:00"), Timezone: "Asia/Jakarta"}
+	assert.True(t, IsWithinQuietHours(overnight, at(23, 15)))
+	assert.True(t, IsWithinQuietHours(overnight, at(5, 59)))
+	assert.False(t, IsWithinQuietHours(overnight, at(6, 0)))
+	assert.False(t, IsWithinQuietHours(overnight, at(12, 0)))
+
+	daytime := &model.NotificationSetting{QuietHoursStart: strPtr("09:00"), QuietHoursEnd: strPtr("17:00"), Timezone: "Asia/Jakarta"}
+	assert.True(t, IsWithinQuietHours(daytime, at(9, 0)))
+	assert.False(t, IsWithinQuietHours(daytime, at(17, 0)))
+
+	assert.True(t, IsWithinQuietHours(overnight, time.Date(2026, 10, 18, 16, 0, 0, 0, time.UTC)), "16:00 UTC is 23:00 in Jakarta")
+	assert.False(t, IsWithinQuietHours(nil, at(23, 0)))
+	assert.False(t, IsWithinQuietHours(&model.NotificationSetting{QuietHoursStart: strPtr("22:00")}, at(23, 0)))
+}
+
+func TestResolveDeliveryChannels(t *testing.T) {
+	jakarta := LoadTimezone("Asia/Jakarta")
+	night := time.Date(2026, 10, 18, 23, 0, 0, 0, jakarta)
+	noon := time.Date(2026, 10, 18, 12, 0, 0, 0, jakarta)
+	setting := &model.NotificationSetting{QuietHoursStart: strPtr("22:00"), QuietHoursEnd: strPtr("06:00"), Timezone: "Asia/Jakarta"}
+	preferences := []model.NotificationPreference{
+		{Event: model.NotificationEventVote, Channel: model.NotificationChannelPush, Enabled: false},
+	}
+
+	assert.Equal(t, DeliveryChannels{InApp: true, Email: true, Push: false}, ResolveDeliveryChannels(preferences, setting, false, model.ReportNotificationCategory, model.NotificationEventVote, noon))
+	assert.Equal(t, DeliveryChannels{InApp: true, Email: false, Push: false}, ResolveDeliveryChannels(preferences, setting, false, model.ReportNotificationCategory, model.NotificationEventComment, night))
+	assert.Equal(t, DeliveryChannels{}, ResolveDeliveryChannels(nil, nil, true, model.ReportNotificationCategory, model.NotificationEventComment, noon))
+
+	smsPreferences := []model.NotificationPreference{
+		{Category: model.ReportNotificationCategory, Channel: model.NotificationChannelSMS, Enabled: true},
+	}
+	assert.Equal(t, DeliveryChannels{InApp: true, Email: true, Push: true, SMS: true}, ResolveDeliveryChannels(smsPreferences, setting, false, model.ReportNotificationCategory, model.NotificationEventComment, noon))
+	assert.Equal(t, DeliveryChannels{InApp: true}, ResolveDeliveryChannels(smsPreferences, setting, false, model.ReportNotificationCategory, model.NotificationEventComment, night))
+
+	emailOnly := []model.NotificationPreference{
+		{Category: model.ReportNotificationCategory, Channel: model.NotificationChannelInApp, Enabled: false},
+		{Category: model.ReportNotificationCategory, Channel: model.NotificationChannelPush, Enabled: false},
+	}
+	channels := ResolveDeliveryChannels(emailOnly, nil, false, model.ReportNotificationCategory, model.NotificationEventComment, noon)
+	assert.Equal(t, DeliveryChannels{Email: true}, channels)
+	assert.True(t, channels.Any())
+	assert.False(t, DeliveryChannels{}.Any())
+}
+
+func TestIsChannelOptedIn(t *testing.T) {
+	preferences := []model.NotificationPreference{
+		{Category: model.ReportNotificationCategory, Channel: model.NotificationChannelSMS, Enabled: true},
+		{Event: model.NotificationEventVote, Channel: model.NotificationChannelSMS, Enabled: false},
+	}
+
+	assert.False(t, IsChannelOptedIn(nil, model.ReportNotificationCategory, model.NotificationEventComment, model.NotificationChannelSMS))
+	assert.True(t, IsChannelOptedIn(preferences, model.ReportNotificationCategory, model.NotificationEventComment, model.NotificationChannelSMS))
+	assert.False(t, IsChannelOptedIn(preferences, model.ReportNotificationCategory, model.NotificationEventVote, model.NotificationChannelSMS))
+	assert.False(t, IsChannelOptedIn(preferences, model.UserNotificationCategory, model.NotificationEventFollow, model.NotificationChannelSMS))
+}
+
+func TestGetUnreadCountKey(t *testing.T) {
+	assert.Equal(t, "notification:unread:42", GetUnreadCountKey(42))
+}
+
+func TestParseReadState(t *testing.T) {
+	state, err := ParseReadState("")
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	state, err = ParseReadState("false")
+	assert.NoError(t, err)
+	if assert.NotNil(t, state) {
+		assert.False(t, *state)
+	}
+
+	_, err = ParseReadState("maybe")
+	assert.Error(t, err)
+}
+
+func TestUniqueNotificationIDs(t *testing.T) {
+	assert.Equal(t, []uint{3, 1, 2}, UniqueNotificationIDs([]uint{3, 1, 3, 2, 1}))
+	assert.Empty(t, UniqueNotificationIDs(nil))
+}
+
+func TestGetGroupKey(t *testing.T) {
+	start := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	voteKey := GetGroupKey(model.NotificationEventVote, model.EntityTypeReport, "12", start)
+	assert.Equal(t, voteKey, GetGroupKey(model.NotificationEventVote, model.EntityTypeReport, "12", start.Add(GroupingWindow-time.Second)))
+	assert.NotEqual(t, voteKey, GetGroupKey(model.NotificationEventVote, model.EntityTypeReport, "12", start.Add(GroupingWindow)))
+	assert.NotEqual(t, voteKey, GetGroupKey(model.NotificationEventVote, model.EntityTypeReport, "13", start))
+	assert.NotEqual(t, voteKey, GetGroupKey(model.NotificationEventReaction, model.EntityTypeReport, "12", start))
+
+	assert.Equal(t,
+		GetGroupKey(model.NotificationEventFollow, model.EntityTypeUser, "3", start),
+		GetGroupKey(model.NotificationEventFollow, model.EntityTypeUser, "4", start),
+	)
+}
+
+func TestFormatGroupedNotification(t *testing.T) {
+	var notification model.Notification
+	key, params := FormatGroupedNotification(model.NotificationEventVote, "budi", 1)
+	SetNotificationMessage(&notification, key, params)
+	assert.Equal(t, "Seseorang memberikan suara pada laporan Anda", notification.Title)
+	assert.Equal(t, "Pengguna budi memberikan suara pada laporan Anda", notification.Description)
+
+	key, params = FormatGroupedNotification(model.NotificationEventVote, "budi", 13)
+	SetNotificationMessage(&notification, key, params)
+	assert.Equal(t, "budi dan 12 lainnya memberikan suara pada laporan Anda", notification.Description)
+
+	key, params = FormatGroupedNotification(model.NotificationEventFollow, "sari", 2)
+	SetNotificationMessage(&notification, key, params)
+	assert.Equal(t, "sari dan 1 lainnya mulai mengikuti Anda", notification.Description)
+
+	assert.True(t, IsGroupableEvent(model.NotificationEventReaction))
+	assert.False(t, IsGroupableEvent(model.NotificationEventComment))
+}
+
+func TestLocalizeNotification(t *testing.T) {
+	var notification model.Notification
+	SetNotificationMessage(&notification, "notification.comment", map[string]string{"actor": "budi"})
+
+	english := LocalizeNotification(i18n.LanguageEnglish, notification)
+	assert.Equal(t, "Someone commented on your report", english.Title)
+	assert.Equal(t, "budi commented on your report", english.Description)
+	assert.Equal(t, "Pengguna budi mengomentari laporan Anda", notification.Description)
+
+	legacy := model.Notification{Title: "Judul lama", Description: "Deskripsi lama"}
+	assert.Equal(t, legacy, LocalizeNotification(i18n.LanguageEnglish, legacy))
+}
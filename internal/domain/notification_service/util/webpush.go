@@ -0,0 +1,255 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"pingspot/internal/model"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	PushRecordSize     = 4096
+	PushTTL            = 24 * time.Hour
+	VAPIDTokenLifetime = 12 * time.Hour
+	// MaxPushPayloadSize leaves room for the padding delimiter and the AEAD tag
+	// inside a single aes128gcm record.
+	MaxPushPayloadSize = PushRecordSize - 17
+)
+
+// ErrPushSubscriptionExpired is returned when the push service reports that
+// the subscription no longer exists and should be forgotten.
+var ErrPushSubscriptionExpired = errors.New("push subscription expired")
+
+// pushServiceHosts are the push services browsers hand out subscription
+// endpoints for. Endpoints are user supplied and the server posts to them, so
+// anything else is refused instead of being treated as a push service.
+var pushServiceHosts = []string{
+	"fcm.googleapis.com",
+	"android.googleapis.com",
+	"push.services.mozilla.com",
+	"push.apple.com",
+	"notify.windows.com",
+}
+
+// IsAllowedPushEndpoint reports whether endpoint is an https URL on one of the
+// known push services or their subdomains, on the default port.
+func IsAllowedPushEndpoint(endpoint string) bool {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Scheme != "https" || parsed.User != nil {
+		return false
+	}
+	if port := parsed.Port(); port != "" && port != "443" {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, serviceHost := range pushServiceHosts {
+		if host == serviceHost || strings.HasSuffix(host, "."+serviceHost) {
+			return true
+		}
+	}
+	return false
+}
+
+type VAPIDKeys struct {
+	PublicKey  string
+	PrivateKey *ecdsa.PrivateKey
+	Subject    string
+}
+
+// ParseVAPIDKeys expects the raw base64url keys produced by the usual web push
+// tooling: an uncompressed P-256 point and its 32 byte private scalar.
+func ParseVAPIDKeys(publicKey, privateKey, subject string) (*VAPIDKeys, error) {
+	if publicKey == "" || privateKey == "" || subject == "" {
+		return nil, fmt.Errorf("vapid keys are not configured")
+	}
+	publicBytes, err := decodeBase64URL(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid public key: %w", err)
+	}
+	privateBytes, err := decodeBase64URL(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+
+	ecdhKey, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	if !bytes.Equal(ecdhKey.PublicKey().Bytes(), publicBytes) {
+		return nil, fmt.Errorf("vapid public key does not match private key")
+	}
+
+	return &VAPIDKeys{
+		PublicKey: base64.RawURLEncoding.EncodeToString(publicBytes),
+		PrivateKey: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(publicBytes[1:33]),
+				Y:     new(big.Int).SetBytes(publicBytes[33:65]),
+			},
+			D: new(big.Int).SetBytes(privateBytes),
+		},
+		Subject: subject,
+	}, nil
+}
+
+// BuildVAPIDAuthorization signs a token for the push service that owns the
+// endpoint, as required by RFC 8292.
+func BuildVAPIDAuthorization(keys VAPIDKeys, endpoint string, now time.Time) (string, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("invalid push endpoint %q", endpoint)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": parsed.Scheme + "://" + parsed.Host,
+		"exp": now.Add(VAPIDTokenLifetime).Unix(),
+		"sub": keys.Subject,
+	})
+	signed, err := token.SignedString(keys.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign vapid token: %w", err)
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", signed, keys.PublicKey), nil
+}
+
+// EncryptPushPayload encrypts a message for one subscription using the
+// aes128gcm content coding of RFC 8291 with a single record.
+func EncryptPushPayload(p256dh, auth string, plaintext []byte) ([]byte, error) {
+	if len(plaintext) > MaxPushPayloadSize {
+		return nil, fmt.Errorf("push payload too large: %d bytes", len(plaintext))
+	}
+	uaPublicBytes, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription auth secret: %w", err)
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate push key: %w", err)
+	}
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate push salt: %w", err)
+	}
+
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push secret: %w", err)
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+	contentKey, nonce, err := derivePushKeys(sharedSecret, authSecret, salt, uaPublicBytes, asPublicBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push cipher: %w", err)
+	}
+
+	header := make([]byte, 0, 21+len(asPublicBytes))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, PushRecordSize)
+	header = append(header, byte(len(asPublicBytes)))
+	header = append(header, asPublicBytes...)
+
+	record := append(append([]byte{}, plaintext...), 0x02)
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+func derivePushKeys(sharedSecret, authSecret, salt, uaPublic, asPublic []byte) ([]byte, []byte, error) {
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, string(keyInfo), 32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	contentKey, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive push nonce: %w", err)
+	}
+	return contentKey, nonce, nil
+}
+
+// SendWebPush returns the push service message location when it provides one.
+func SendWebPush(ctx context.Context, client *http.Client, keys VAPIDKeys, subscription model.PushSubscription, payload []byte) (string, error) {
+	body, err := EncryptPushPayload(subscription.P256dh, subscription.Auth, payload)
+	if err != nil {
+		return "", err
+	}
+	authorization, err := BuildVAPIDAuthorization(keys, subscription.Endpoint, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(PushTTL/time.Second)))
+	req.Header.Set("Urgency", "normal")
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send push notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return "", ErrPushSubscriptionExpired
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 500))
+		return "", fmt.Errorf("push service responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+// decodeBase64URL accepts both padded and unpadded input, since browsers and
+// key generators disagree on padding.
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
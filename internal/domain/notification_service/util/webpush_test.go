@@ -0,0 +1,182 @@
+package util
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pingspot/internal/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVAPIDKeys(t *testing.T) *VAPIDKeys {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := ParseVAPIDKeys(
+		base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()),
+		"mailto:admin@pingspot.id",
+	)
+	require.NoError(t, err)
+	return keys
+}
+
+func newTestSubscription(t *testing.T, endpoint string) (model.PushSubscription, *ecdh.PrivateKey, []byte) {
+	t.Helper()
+	uaKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+	return model.PushSubscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes()),
+		Auth:     base64.URLEncoding.EncodeToString(authSecret),
+	}, uaKey, authSecret
+}
+
+// decryptPushPayload plays the browser's side of RFC 8291.
+func decryptPushPayload(t *testing.T, uaKey *ecdh.PrivateKey, authSecret, body []byte) []byte {
+	t.Helper()
+	require.Greater(t, len(body), 21)
+	salt := body[:16]
+	assert.Equal(t, uint32(PushRecordSize), binary.BigEndian.Uint32(body[16:20]))
+	keyIDLength := int(body[20])
+	asPublicBytes := body[21 : 21+keyIDLength]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	require.NoError(t, err)
+	sharedSecret, err := uaKey.ECDH(asPublic)
+	require.NoError(t, err)
+	contentKey, nonce, err := derivePushKeys(sharedSecret, authSecret, salt, uaKey.PublicKey().Bytes(), asPublicBytes)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(contentKey)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	record, err := gcm.Open(nil, nonce, body[21+keyIDLength:], nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), record[len(record)-1])
+	return record[:len(record)-1]
+}
+
+func TestParseVAPIDKeys(t *testing.T) {
+	keys := newTestVAPIDKeys(t)
+	assert.NotNil(t, keys.PrivateKey)
+
+	other, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = ParseVAPIDKeys(keys.PublicKey, base64.RawURLEncoding.EncodeToString(other.Bytes()), "mailto:admin@pingspot.id")
+	assert.Error(t, err)
+
+	_, err = ParseVAPIDKeys("", "", "")
+	assert.Error(t, err)
+}
+
+func TestBuildVAPIDAuthorization(t *testing.T) {
+	keys := newTestVAPIDKeys(t)
+	now := time.Now()
+
+	header, err := BuildVAPIDAuthorization(*keys, "https://push.example.com/send/abc", now)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(header, "vapid t="))
+	parts := strings.SplitN(strings.TrimPrefix(header, "vapid t="), ", k=", 2)
+	require.Len(t, parts, 2)
+	assert.Equal(t, keys.PublicKey, parts[1])
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(parts[0], claims, func(token *jwt.Token) (any, error) {
+		return &keys.PrivateKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	require.NoError(t, err)
+	assert.Equal(t, "https://push.example.com", claims["aud"])
+	assert.Equal(t, "mailto:admin@pingspot.id", claims["sub"])
+
+	_, err = BuildVAPIDAuthorization(*keys, "not-a-url", now)
+	assert.Error(t, err)
+}
+
+func TestEncryptPushPayload(t *testing.T) {
+	subscription, uaKey, authSecret := newTestSubscription(t, "https://push.example.com/send/abc")
+
+	body, err := EncryptPushPayload(subscription.P256dh, subscription.Auth, []byte(`{"title":"Halo"}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"title":"Halo"}`, string(decryptPushPayload(t, uaKey, authSecret, body)))
+
+	_, err = EncryptPushPayload(subscription.P256dh, subscription.Auth, make([]byte, MaxPushPayloadSize+1))
+	assert.Error(t, err)
+}
+
+func TestSendWebPush(t *testing.T) {
+	keys := newTestVAPIDKeys(t)
+
+	t.Run("should send encrypted payload", func(t *testing.T) {
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "vapid t="))
+			body, _ = io.ReadAll(r.Body)
+			w.Header().Set("Location", "https://push.example.com/message/1")
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+		subscription, uaKey, authSecret := newTestSubscription(t, server.URL+"/send/abc")
+
+		messageID, err := SendWebPush(context.Background(), server.Client(), *keys, subscription, []byte("halo"))
+		require.NoError(t, err)
+		assert.Equal(t, "https://push.example.com/message/1", messageID)
+		assert.Equal(t, "halo", string(decryptPushPayload(t, uaKey, authSecret, body)))
+	})
+
+	t.Run("should report expired subscription", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
+		subscription, _, _ := newTestSubscription(t, server.URL+"/send/abc")
+
+		_, err := SendWebPush(context.Background(), server.Client(), *keys, subscription, []byte("halo"))
+		assert.ErrorIs(t, err, ErrPushSubscriptionExpired)
+	})
+}
+
+func TestIsAllowedPushEndpoint(t *testing.T) {
+	allowed := []string{
+		"https://fcm.googleapis.com/fcm/send/abc",
+		"https://updates.push.services.mozilla.com/wpush/v2/abc",
+		"https://web.push.apple.com/abc",
+		"https://db5p.notify.windows.com/w/?token=abc",
+		"https://fcm.googleapis.com:443/fcm/send/abc",
+	}
+	for _, endpoint := range allowed {
+		assert.True(t, IsAllowedPushEndpoint(endpoint), endpoint)
+	}
+
+	refused := []string{
+		"http://fcm.googleapis.com/fcm/send/abc",
+		"https://fcm.googleapis.com:8443/fcm/send/abc",
+		"https://evilfcm.googleapis.com.example.com/abc",
+		"https://fcm.googleapis.com.example.com/abc",
+		"https://user@fcm.googleapis.com/abc",
+		"https://169.254.169.254/latest/meta-data",
+		"https://127.0.0.1/send",
+		"not a url",
+	}
+	for _, endpoint := range refused {
+		assert.False(t, IsAllowedPushEndpoint(endpoint), endpoint)
+	}
+}
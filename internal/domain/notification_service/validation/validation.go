@@ -0,0 +1,173 @@
+package validation
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+var Validate *validator.Validate
+
+func init() {
+	Validate = validator.New()
+}
+
+func FormatUpdateNotificationPreferencesValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Preferences":
+			if e.Tag() == "max" {
+				errors["preferences"] = "Maksimal 100 preferensi dalam satu permintaan"
+			} else {
+				errors["preferences"] = "Minimal satu preferensi wajib diisi"
+			}
+		case "Category":
+			errors["category"] = "Kategori notifikasi tidak didukung"
+		case "Event":
+			errors["event"] = "Jenis event notifikasi tidak didukung"
+		case "Channel":
+			if e.Tag() == "required" {
+				errors["channel"] = "Kanal notifikasi wajib diisi"
+			} else {
+				errors["channel"] = "Kanal notifikasi harus IN_APP, EMAIL, PUSH, atau SMS"
+			}
+		case "Enabled":
+			errors["enabled"] = "Status aktif preferensi wajib diisi"
+		}
+	}
+	return errors
+}
+
+func FormatUpdateQuietHoursValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Start":
+			if e.Tag() == "required_with" {
+				errors["start"] = "Jam mulai wajib diisi bersama jam selesai"
+			} else {
+				errors["start"] = "Format jam mulai harus HH:MM"
+			}
+		case "End":
+			if e.Tag() == "required_with" {
+				errors["end"] = "Jam selesai wajib diisi bersama jam mulai"
+			} else {
+				errors["end"] = "Format jam selesai harus HH:MM"
+			}
+		case "Timezone":
+			errors["timezone"] = "Zona waktu tidak valid"
+		}
+	}
+	return errors
+}
+
+func FormatNotificationFilterValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Category":
+			errors["category"] = "Kategori notifikasi tidak didukung"
+		case "Type":
+			errors["type"] = "Tipe notifikasi harus INFO, WARNING, atau ERROR"
+		case "EntityType":
+			errors["entityType"] = "Tipe entitas notifikasi tidak didukung"
+		case "EntityID":
+			errors["entityID"] = "ID entitas maksimal 64 karakter"
+		}
+	}
+	return errors
+}
+
+func FormatMarkNotificationsAsReadValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Tag() {
+		case "max":
+			errors["notificationIDs"] = "Maksimal 100 notifikasi dalam satu permintaan"
+		case "gt":
+			errors["notificationIDs"] = "ID notifikasi harus berupa angka positif"
+		default:
+			errors["notificationIDs"] = "Minimal satu ID notifikasi wajib diisi"
+		}
+	}
+	return errors
+}
+
+func FormatUpdateDigestSettingsValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Frequency":
+			if e.Tag() == "required" {
+				errors["frequency"] = "Frekuensi ringkasan wajib diisi"
+			} else {
+				errors["frequency"] = "Frekuensi ringkasan harus NONE, DAILY, atau WEEKLY"
+			}
+		case "Hour":
+			errors["hour"] = "Jam pengiriman harus antara 0 dan 23"
+		}
+	}
+	return errors
+}
+
+func FormatSavePushSubscriptionValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Endpoint":
+			if e.Tag() == "required" {
+				errors["endpoint"] = "Endpoint langganan push wajib diisi"
+			} else {
+				errors["endpoint"] = "Endpoint langganan push harus berupa URL HTTPS yang valid"
+			}
+		case "P256dh":
+			errors["keys.p256dh"] = "Kunci p256dh wajib diisi"
+		case "Auth":
+			errors["keys.auth"] = "Kunci auth wajib diisi"
+		}
+	}
+	return errors
+}
+
+func FormatDeletePushSubscriptionValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		if e.Field() == "Endpoint" {
+			errors["endpoint"] = "Endpoint langganan push wajib diisi"
+		}
+	}
+	return errors
+}
+
+func FormatUpdateSMSSettingsValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		if e.Field() == "PhoneNumber" {
+			errors["phoneNumber"] = "Nomor telepon harus dalam format internasional, contoh +6281234567890"
+		}
+	}
+	return errors
+}
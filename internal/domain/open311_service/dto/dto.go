@@ -0,0 +1,69 @@
+package dto
+
+import "encoding/xml"
+
+type Service struct {
+	ServiceCode string `json:"service_code" xml:"service_code"`
+	ServiceName string `json:"service_name" xml:"service_name"`
+	Description string `json:"description" xml:"description"`
+	Metadata    bool   `json:"metadata" xml:"metadata"`
+	Type        string `json:"type" xml:"type"`
+	Keywords    string `json:"keywords" xml:"keywords"`
+	Group       string `json:"group" xml:"group"`
+}
+
+type ServiceList struct {
+	XMLName  xml.Name  `xml:"services"`
+	Services []Service `xml:"service"`
+}
+
+type ServiceRequest struct {
+	ServiceRequestID  string  `json:"service_request_id" xml:"service_request_id"`
+	Status            string  `json:"status" xml:"status"`
+	StatusNotes       *string `json:"status_notes" xml:"status_notes"`
+	ServiceName       string  `json:"service_name" xml:"service_name"`
+	ServiceCode       string  `json:"service_code" xml:"service_code"`
+	Description       string  `json:"description" xml:"description"`
+	AgencyResponsible string  `json:"agency_responsible" xml:"agency_responsible"`
+	ServiceNotice     string  `json:"service_notice" xml:"service_notice"`
+	RequestedDatetime string  `json:"requested_datetime" xml:"requested_datetime"`
+	UpdatedDatetime   string  `json:"updated_datetime" xml:"updated_datetime"`
+	Address           string  `json:"address" xml:"address"`
+	Zipcode           *string `json:"zipcode" xml:"zipcode"`
+	Lat               float64 `json:"lat" xml:"lat"`
+	Long              float64 `json:"long" xml:"long"`
+	MediaURL          *string `json:"media_url" xml:"media_url"`
+}
+
+type ServiceRequestList struct {
+	XMLName  xml.Name         `xml:"service_requests"`
+	Requests []ServiceRequest `xml:"request"`
+}
+
+type CreatedServiceRequest struct {
+	ServiceRequestID string `json:"service_request_id" xml:"service_request_id"`
+	ServiceNotice    string `json:"service_notice" xml:"service_notice"`
+}
+
+type CreatedServiceRequestList struct {
+	XMLName  xml.Name                `xml:"service_requests"`
+	Requests []CreatedServiceRequest `xml:"request"`
+}
+
+type Error struct {
+	Code        int    `json:"code" xml:"code"`
+	Description string `json:"description" xml:"description"`
+}
+
+type ErrorList struct {
+	XMLName xml.Name `xml:"errors"`
+	Errors  []Error  `xml:"error"`
+}
+
+type APIKey struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name"`
+	KeyPrefix  string `json:"keyPrefix"`
+	LastUsedAt *int64 `json:"lastUsedAt"`
+	CreatedAt  int64  `json:"createdAt"`
+}
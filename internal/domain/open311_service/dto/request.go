@@ -0,0 +1,21 @@
+package dto
+
+type GetServiceRequestsRequest struct {
+	ServiceRequestIDs []uint
+	ServiceCode       string
+	Status            string
+	StartDate         *int64
+	EndDate           *int64
+}
+
+type CreateServiceRequestRequest struct {
+	ServiceCode   string   `validate:"required"`
+	Lat           *float64 `validate:"required,latitude"`
+	Long          *float64 `validate:"required,longitude"`
+	AddressString string   `validate:"omitempty,max=255"`
+	Description   string   `validate:"required,max=4000"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type CreateAPIKeyResponse struct {
+	APIKey APIKey `json:"apiKey"`
+	Key    string `json:"key"`
+}
+
+type GetAPIKeysResponse struct {
+	APIKeys []APIKey `json:"apiKeys"`
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"pingspot/internal/domain/open311_service/dto"
+	"pingspot/internal/domain/open311_service/service"
+	"pingspot/internal/domain/open311_service/util"
+	"pingspot/internal/domain/open311_service/validation"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	response "pingspot/pkg/utils/response_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	formatJSON = "json"
+	formatXML  = "xml"
+)
+
+type Open311Handler struct {
+	open311Service *service.Open311Service
+}
+
+func NewOpen311Handler(open311Service *service.Open311Service) *Open311Handler {
+	return &Open311Handler{open311Service: open311Service}
+}
+
+func (h *Open311Handler) RequireAPIKey(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	apiKey := c.Query("api_key")
+	if apiKey == "" {
+		apiKey = c.FormValue("api_key")
+	}
+
+	userID, err := h.open311Service.Authenticate(ctx, apiKey)
+	if err != nil {
+		logger.Warn("Open311 API key rejected", zap.String("ip", mainutils.GetClientIP(c)), zap.Error(err))
+		return h.respondError(c, err)
+	}
+	c.Locals("userID", userID)
+	return c.Next()
+}
+
+func (h *Open311Handler) GetServicesHandler(c *fiber.Ctx) error {
+	if err := validateFormat(c); err != nil {
+		return h.respondError(c, err)
+	}
+	services := h.open311Service.GetServices()
+	return respond(c, 200, services, dto.ServiceList{Services: services})
+}
+
+func (h *Open311Handler) GetServiceRequestsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	if err := validateFormat(c); err != nil {
+		return h.respondError(c, err)
+	}
+	userID := c.Locals("userID").(uint)
+
+	req := dto.GetServiceRequestsRequest{
+		ServiceCode: c.Query("service_code"),
+		Status:      c.Query("status"),
+	}
+	if req.Status != "" && req.Status != util.ServiceRequestStatusOpen && req.Status != util.ServiceRequestStatusClosed {
+		return h.respondError(c, apperror.New(400, "INVALID_STATUS", "status harus bernilai open atau closed", "", nil))
+	}
+	if req.ServiceCode != "" {
+		if _, ok := util.GetServiceName(req.ServiceCode); !ok {
+			return h.respondError(c, apperror.New(400, "SERVICE_CODE_NOT_FOUND", "service_code tidak ditemukan", "", nil))
+		}
+	}
+
+	if serviceRequestIDs := c.Query("service_request_id"); serviceRequestIDs != "" {
+		for _, rawID := range strings.Split(serviceRequestIDs, ",") {
+			reportID, err := mainutils.StringToUint(strings.TrimSpace(rawID))
+			if err != nil {
+				return h.respondError(c, apperror.New(400, "INVALID_SERVICE_REQUEST_ID", "service_request_id harus berupa angka", "", nil))
+			}
+			req.ServiceRequestIDs = append(req.ServiceRequestIDs, reportID)
+		}
+		if len(req.ServiceRequestIDs) > util.MaxServiceRequests {
+			req.ServiceRequestIDs = req.ServiceRequestIDs[:util.MaxServiceRequests]
+		}
+	}
+
+	for param, target := range map[string]**int64{"start_date": &req.StartDate, "end_date": &req.EndDate} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return h.respondError(c, apperror.New(400, "INVALID_DATE", param+" harus menggunakan format ISO 8601", "", nil))
+		}
+		unix := parsed.Unix()
+		*target = &unix
+	}
+
+	serviceRequests, err := h.open311Service.GetServiceRequests(ctx, userID, req)
+	if err != nil {
+		logger.Error("Failed to get Open311 service requests", zap.Uint("user_id", userID), zap.Error(err))
+		return h.respondError(c, err)
+	}
+	return respond(c, 200, serviceRequests, dto.ServiceRequestList{Requests: serviceRequests})
+}
+
+func (h *Open311Handler) GetServiceRequestHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	if err := validateFormat(c); err != nil {
+		return h.respondError(c, err)
+	}
+	userID := c.Locals("userID").(uint)
+
+	reportID, err := mainutils.StringToUint(c.Params("requestID"))
+	if err != nil {
+		return h.respondError(c, apperror.New(400, "INVALID_SERVICE_REQUEST_ID", "service_request_id harus berupa angka", "", nil))
+	}
+
+	serviceRequest, err := h.open311Service.GetServiceRequest(ctx, userID, reportID)
+	if err != nil {
+		logger.Error("Failed to get Open311 service request", zap.Uint("report_id", reportID), zap.Error(err))
+		return h.respondError(c, err)
+	}
+	serviceRequests := []dto.ServiceRequest{*serviceRequest}
+	return respond(c, 200, serviceRequests, dto.ServiceRequestList{Requests: serviceRequests})
+}
+
+func (h *Open311Handler) CreateServiceRequestHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	if err := validateFormat(c); err != nil {
+		return h.respondError(c, err)
+	}
+	userID := c.Locals("userID").(uint)
+
+	req := dto.CreateServiceRequestRequest{
+		ServiceCode:   c.FormValue("service_code"),
+		AddressString: strings.TrimSpace(c.FormValue("address_string")),
+		Description:   strings.TrimSpace(c.FormValue("description")),
+	}
+	for param, target := range map[string]**float64{"lat": &req.Lat, "long": &req.Long} {
+		value := c.FormValue(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := mainutils.StringToFloat64(value)
+		if err != nil {
+			return h.respondError(c, apperror.New(400, "INVALID_LOCATION", param+" harus berupa angka desimal", "", nil))
+		}
+		*target = &parsed
+	}
+
+	if err := validation.Validate.Struct(req); err != nil {
+		logger.Error("Open311 validation failed", zap.Error(err))
+		validationErrors := validation.FormatCreateServiceRequestValidationErrors(err)
+		fields := make([]string, 0, len(validationErrors))
+		for field := range validationErrors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		errs := make([]dto.Error, 0, len(fields))
+		for _, field := range fields {
+			errs = append(errs, dto.Error{Code: 400, Description: validationErrors[field]})
+		}
+		return respond(c, 400, errs, dto.ErrorList{Errors: errs})
+	}
+
+	created, err := h.open311Service.CreateServiceRequest(ctx, userID, req)
+	if err != nil {
+		logger.Error("Failed to create Open311 service request", zap.Uint("user_id", userID), zap.Error(err))
+		return h.respondError(c, err)
+	}
+	createdRequests := []dto.CreatedServiceRequest{*created}
+	return respond(c, 201, createdRequests, dto.CreatedServiceRequestList{Requests: createdRequests})
+}
+
+func (h *Open311Handler) CreateAPIKeyHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format request tidak valid", "", err.Error())
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatCreateAPIKeyValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	apiKey, err := h.open311Service.CreateAPIKey(ctx, userID, req)
+	if err != nil {
+		logger.Error("Failed to create Open311 API key", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal membuat API key", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 201, "Berhasil membuat API key", "data", apiKey)
+}
+
+func (h *Open311Handler) GetAPIKeysHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	apiKeys, err := h.open311Service.GetAPIKeys(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get Open311 API keys", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mengambil API key", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mengambil API key", "data", apiKeys)
+}
+
+func (h *Open311Handler) RevokeAPIKeyHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	keyID, err := c.ParamsInt("keyID")
+	if err != nil || keyID <= 0 {
+		return response.ResponseError(c, 400, "ID API key tidak valid", "", "ID API key harus berupa angka")
+	}
+
+	if err := h.open311Service.RevokeAPIKey(ctx, userID, uint(keyID)); err != nil {
+		logger.Error("Failed to revoke Open311 API key", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mencabut API key", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mencabut API key", "", nil)
+}
+
+func (h *Open311Handler) respondError(c *fiber.Ctx, err error) error {
+	status, description := 500, "Terjadi kesalahan pada server"
+	if appErr, ok := err.(*apperror.AppError); ok {
+		status, description = appErr.StatusCode, appErr.Message
+	}
+	errs := []dto.Error{{Code: status, Description: description}}
+	return respond(c, status, errs, dto.ErrorList{Errors: errs})
+}
+
+func validateFormat(c *fiber.Ctx) error {
+	format := c.Params("format")
+	if format != formatJSON && format != formatXML {
+		return apperror.New(400, "INVALID_FORMAT", "Format harus json atau xml", "", nil)
+	}
+	return nil
+}
+
+func respond(c *fiber.Ctx, status int, jsonBody, xmlBody any) error {
+	if c.Params("format") == formatXML {
+		return c.Status(status).XML(xmlBody)
+	}
+	return c.Status(status).JSON(jsonBody)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *model.Open311APIKey) error
+	GetActiveByHashedKey(ctx context.Context, hashedKey string) (*model.Open311APIKey, error)
+	GetActiveByUserID(ctx context.Context, userID uint) ([]model.Open311APIKey, error)
+	CountActiveByUserID(ctx context.Context, userID uint) (int64, error)
+	UpdateLastUsedAt(ctx context.Context, keyID uint, usedAt int64) error
+	Revoke(ctx context.Context, keyID, userID uint, revokedAt int64) (int64, error)
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, apiKey *model.Open311APIKey) error {
+	return r.db.WithContext(ctx).Create(apiKey).Error
+}
+
+func (r *apiKeyRepository) GetActiveByHashedKey(ctx context.Context, hashedKey string) (*model.Open311APIKey, error) {
+	var apiKey model.Open311APIKey
+	if err := r.db.WithContext(ctx).
+		Where("hashed_key = ? AND revoked_at IS NULL", hashedKey).
+		First(&apiKey).Error; err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) GetActiveByUserID(ctx context.Context, userID uint) ([]model.Open311APIKey, error) {
+	var apiKeys []model.Open311APIKey
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&apiKeys).Error; err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+func (r *apiKeyRepository) CountActiveByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.Open311APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsedAt(ctx context.Context, keyID uint, usedAt int64) error {
+	return r.db.WithContext(ctx).
+		Model(&model.Open311APIKey{}).
+		Where("id = ?", keyID).
+		Update("last_used_at", usedAt).Error
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, keyID, userID uint, revokedAt int64) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&model.Open311APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", keyID, userID).
+		Update("revoked_at", revokedAt)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,108 @@
+package router
+
+import (
+	"fmt"
+	"pingspot/internal/domain/open311_service/handler"
+	open311Repository "pingspot/internal/domain/open311_service/repository"
+	"pingspot/internal/domain/open311_service/service"
+	reportRepository "pingspot/internal/domain/report_service/repository"
+	reportService "pingspot/internal/domain/report_service/service"
+	tasksService "pingspot/internal/domain/task_service/service"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/cache"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	cacheRepository "pingspot/internal/repository"
+	env "pingspot/pkg/utils/env_util"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hibiken/asynq"
+)
+
+func RegisterOpen311Routes(app *fiber.App) {
+	postgreDB := database.GetPostgresDB()
+	mongoDB := database.GetMongoDB()
+
+	redisAddress := fmt.Sprintf("%s:%s", env.RedisHost(), env.RedisPort())
+	client := asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddress})
+	rdb := cache.GetRedis()
+
+	reportSvc := reportService.NewreportService(
+		postgreDB,
+		mongoDB,
+		reportRepository.NewReportRepository(postgreDB),
+		reportRepository.NewReportLocationRepository(postgreDB),
+		reportRepository.NewReportReactionRepository(postgreDB),
+		reportRepository.NewReportImageRepository(postgreDB),
+		userRepository.NewUserRepository(postgreDB),
+		userRepository.NewUserProfileRepository(postgreDB),
+		reportRepository.NewReportProgressRepository(postgreDB),
+		reportRepository.NewReportVoteRepository(postgreDB),
+		tasksService.NewTaskService(client),
+		reportRepository.NewReportCommentRepository(mongoDB),
+		reportRepository.NewReportDraftRepository(postgreDB),
+		cacheRepository.NewCacheRepository(&rdb),
+	)
+	apiKeyRepo := open311Repository.NewAPIKeyRepository(postgreDB)
+
+	open311Service := service.NewOpen311Service(apiKeyRepo, reportSvc)
+	open311Handler := handler.NewOpen311Handler(open311Service)
+
+	keyRoute := app.Group("/pingspot/api/open311/keys", middleware.ValidateAccessToken())
+	keyRoute.Post("/",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Hour,
+			MaxRequests: 10,
+			KeyPrefix:   "create_open311_api_key",
+		})),
+		open311Handler.CreateAPIKeyHandler,
+	)
+	keyRoute.Get("/",
+		middleware.TimeoutMiddleware(10*time.Second),
+		open311Handler.GetAPIKeysHandler,
+	)
+	keyRoute.Delete("/:keyID",
+		middleware.TimeoutMiddleware(10*time.Second),
+		open311Handler.RevokeAPIKeyHandler,
+	)
+
+	open311Route := app.Group("/pingspot/api/open311/v2", open311Handler.RequireAPIKey)
+	open311Route.Get("/services.:format",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 60,
+			KeyPrefix:   "open311_get_services",
+		})),
+		open311Handler.GetServicesHandler,
+	)
+	open311Route.Get("/requests.:format",
+		middleware.TimeoutMiddleware(40*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix:   "open311_get_requests",
+		})),
+		open311Handler.GetServiceRequestsHandler,
+	)
+	open311Route.Post("/requests.:format",
+		middleware.TimeoutMiddleware(20*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      10 * time.Minute,
+			MaxRequests: 8,
+			KeyPrefix:   "create_report",
+		})),
+		open311Handler.CreateServiceRequestHandler,
+	)
+	open311Route.Get("/requests/:requestID.:format",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 120,
+			KeyPrefix:   "open311_get_request",
+		})),
+		open311Handler.GetServiceRequestHandler,
+	)
+}
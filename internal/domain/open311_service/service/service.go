@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pingspot/internal/domain/open311_service/dto"
+	"pingspot/internal/domain/open311_service/repository"
+	"pingspot/internal/domain/open311_service/util"
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	"pingspot/internal/domain/report_service/validation"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	tokenutils "pingspot/pkg/utils/token_util"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ReportService interface {
+	CreateReport(ctx context.Context, userID uint, req reportDTO.CreateReportRequest) (*reportDTO.CreateReportResponse, error)
+	GetAllReport(ctx context.Context, userID, cursorID uint, reportType, status, sortBy, hasProgress string, distance reportDTO.Distance) (*reportDTO.GetReportsResponse, error)
+	GetReportByID(ctx context.Context, userID, reportID uint) (*reportDTO.GetReportResponse, error)
+}
+
+type Open311Service struct {
+	apiKeyRepo    repository.APIKeyRepository
+	reportService ReportService
+}
+
+func NewOpen311Service(apiKeyRepo repository.APIKeyRepository, reportService ReportService) *Open311Service {
+	return &Open311Service{
+		apiKeyRepo:    apiKeyRepo,
+		reportService: reportService,
+	}
+}
+
+func (s *Open311Service) Authenticate(ctx context.Context, rawKey string) (uint, error) {
+	if rawKey == "" {
+		return 0, apperror.New(401, "API_KEY_REQUIRED", "API key wajib diisi", "", nil)
+	}
+
+	apiKey, err := s.apiKeyRepo.GetActiveByHashedKey(ctx, tokenutils.HashSHA256String(rawKey))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, apperror.New(401, "INVALID_API_KEY", "API key tidak valid", "", nil)
+		}
+		return 0, apperror.New(500, "API_KEY_FETCH_FAILED", "Gagal memverifikasi API key", err.Error(), nil)
+	}
+
+	if err := s.apiKeyRepo.UpdateLastUsedAt(ctx, apiKey.ID, time.Now().Unix()); err != nil {
+		logger.Warn("Failed to update API key last used time", zap.Uint("api_key_id", apiKey.ID), zap.Error(err))
+	}
+	return apiKey.UserID, nil
+}
+
+func (s *Open311Service) CreateAPIKey(ctx context.Context, userID uint, req dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	totalKeys, err := s.apiKeyRepo.CountActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.New(500, "API_KEY_FETCH_FAILED", "Gagal mengambil API key", err.Error(), nil)
+	}
+	if totalKeys >= util.MaxAPIKeysPerUser {
+		return nil, apperror.New(400, "API_KEY_LIMIT_REACHED", fmt.Sprintf("Maksimal %d API key aktif", util.MaxAPIKeysPerUser), "", nil)
+	}
+
+	rawKey, err := util.GenerateAPIKey()
+	if err != nil {
+		return nil, apperror.New(500, "API_KEY_GENERATE_FAILED", "Gagal membuat API key", err.Error(), nil)
+	}
+
+	apiKey := model.Open311APIKey{
+		UserID:    userID,
+		Name:      req.Name,
+		KeyPrefix: rawKey[:len(util.APIKeyPrefix)+4],
+		HashedKey: tokenutils.HashSHA256String(rawKey),
+	}
+	if err := s.apiKeyRepo.Create(ctx, &apiKey); err != nil {
+		return nil, apperror.New(500, "API_KEY_CREATE_FAILED", "Gagal membuat API key", err.Error(), nil)
+	}
+
+	return &dto.CreateAPIKeyResponse{
+		APIKey: util.ToAPIKeyDTO(apiKey),
+		Key:    rawKey,
+	}, nil
+}
+
+func (s *Open311Service) GetAPIKeys(ctx context.Context, userID uint) (*dto.GetAPIKeysResponse, error) {
+	apiKeys, err := s.apiKeyRepo.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.New(500, "API_KEY_FETCH_FAILED", "Gagal mengambil API key", err.Error(), nil)
+	}
+
+	response := dto.GetAPIKeysResponse{APIKeys: make([]dto.APIKey, 0, len(apiKeys))}
+	for _, apiKey := range apiKeys {
+		response.APIKeys = append(response.APIKeys, util.ToAPIKeyDTO(apiKey))
+	}
+	return &response, nil
+}
+
+func (s *Open311Service) RevokeAPIKey(ctx context.Context, userID, keyID uint) error {
+	revoked, err := s.apiKeyRepo.Revoke(ctx, keyID, userID, time.Now().Unix())
+	if err != nil {
+		return apperror.New(500, "API_KEY_REVOKE_FAILED", "Gagal mencabut API key", err.Error(), nil)
+	}
+	if revoked == 0 {
+		return apperror.New(404, "API_KEY_NOT_FOUND", "API key tidak ditemukan", "", nil)
+	}
+	return nil
+}
+
+func (s *Open311Service) GetServices() []dto.Service {
+	return util.GetServices()
+}
+
+func (s *Open311Service) GetServiceRequest(ctx context.Context, userID, reportID uint) (*dto.ServiceRequest, error) {
+	report, err := s.reportService.GetReportByID(ctx, userID, reportID)
+	if err != nil {
+		return nil, err
+	}
+	serviceRequest := util.ToServiceRequest(report.Report)
+	return &serviceRequest, nil
+}
+
+func (s *Open311Service) GetServiceRequests(ctx context.Context, userID uint, req dto.GetServiceRequestsRequest) ([]dto.ServiceRequest, error) {
+	serviceRequests := []dto.ServiceRequest{}
+
+	if len(req.ServiceRequestIDs) > 0 {
+		for _, reportID := range req.ServiceRequestIDs {
+			serviceRequest, err := s.GetServiceRequest(ctx, userID, reportID)
+			if err != nil {
+				if appErr, ok := err.(*apperror.AppError); ok && appErr.StatusCode == 404 {
+					continue
+				}
+				return nil, err
+			}
+			serviceRequests = append(serviceRequests, *serviceRequest)
+		}
+		return serviceRequests, nil
+	}
+
+	endDate := time.Now().Unix()
+	if req.EndDate != nil {
+		endDate = *req.EndDate
+	}
+	startDate := endDate - int64(util.DefaultRequestsWindow.Seconds())
+	if req.StartDate != nil {
+		startDate = *req.StartDate
+	}
+
+	var cursorID uint
+	for page := 0; page < util.MaxServiceRequestPages; page++ {
+		reports, err := s.reportService.GetAllReport(ctx, userID, cursorID, req.ServiceCode, "", "latest", "", reportDTO.Distance{})
+		if err != nil {
+			if appErr, ok := err.(*apperror.AppError); ok && appErr.StatusCode == 404 {
+				break
+			}
+			return nil, err
+		}
+		if len(reports.Reports) == 0 {
+			break
+		}
+
+		for _, report := range reports.Reports {
+			if report.ReportCreatedAt > endDate {
+				continue
+			}
+			if report.ReportCreatedAt < startDate {
+				return serviceRequests, nil
+			}
+			serviceRequest := util.ToServiceRequest(report)
+			if req.Status != "" && serviceRequest.Status != req.Status {
+				continue
+			}
+			serviceRequests = append(serviceRequests, serviceRequest)
+			if len(serviceRequests) == util.MaxServiceRequests {
+				return serviceRequests, nil
+			}
+		}
+		cursorID = reports.Reports[len(reports.Reports)-1].ID
+	}
+	return serviceRequests, nil
+}
+
+func (s *Open311Service) CreateServiceRequest(ctx context.Context, userID uint, req dto.CreateServiceRequestRequest) (*dto.CreatedServiceRequest, error) {
+	if _, ok := util.GetServiceName(req.ServiceCode); !ok {
+		return nil, apperror.New(400, "SERVICE_CODE_NOT_FOUND", "service_code tidak ditemukan", "", nil)
+	}
+
+	detailLocation := req.AddressString
+	if detailLocation == "" {
+		detailLocation = fmt.Sprintf("%.6f, %.6f", *req.Lat, *req.Long)
+	}
+
+	createReportRequest := reportDTO.CreateReportRequest{
+		ReportTitle:       util.BuildReportTitle(req.Description),
+		ReportType:        req.ServiceCode,
+		ReportDescription: req.Description,
+		DetailLocation:    detailLocation,
+		Latitude:          *req.Lat,
+		Longitude:         *req.Long,
+	}
+	if err := validation.Validate.Struct(createReportRequest); err != nil {
+		return nil, apperror.New(400, "VALIDATION_FAILED", "Validasi gagal", err.Error(), nil)
+	}
+
+	report, err := s.reportService.CreateReport(ctx, userID, createReportRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CreatedServiceRequest{
+		ServiceRequestID: strconv.FormatUint(uint64(report.Report.ID), 10),
+	}, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pingspot/internal/domain/open311_service/dto"
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	open311Mocks "pingspot/internal/mocks/open311"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+	tokenutils "pingspot/pkg/utils/token_util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupMocks() (*open311Mocks.MockAPIKeyRepository, *open311Mocks.MockReportService, *Open311Service) {
+	mockAPIKeyRepo := new(open311Mocks.MockAPIKeyRepository)
+	mockReportService := new(open311Mocks.MockReportService)
+	service := NewOpen311Service(mockAPIKeyRepo, mockReportService)
+	return mockAPIKeyRepo, mockReportService, service
+}
+
+func TestOpen311Service_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return owner of active api key", func(t *testing.T) {
+		mockAPIKeyRepo, _, service := setupMocks()
+		mockAPIKeyRepo.On("GetActiveByHashedKey", ctx, tokenutils.HashSHA256String("ps311_secret")).Return(&model.Open311APIKey{ID: 3, UserID: 9}, nil)
+		mockAPIKeyRepo.On("UpdateLastUsedAt", ctx, uint(3), mock.AnythingOfType("int64")).Return(nil)
+
+		userID, err := service.Authenticate(ctx, "ps311_secret")
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(9), userID)
+	})
+
+	t.Run("should reject unknown api key", func(t *testing.T) {
+		mockAPIKeyRepo, _, service := setupMocks()
+		mockAPIKeyRepo.On("GetActiveByHashedKey", ctx, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := service.Authenticate(ctx, "ps311_unknown")
+
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 401, appErr.StatusCode)
+	})
+
+	t.Run("should reject missing api key", func(t *testing.T) {
+		mockAPIKeyRepo, _, service := setupMocks()
+
+		_, err := service.Authenticate(ctx, "")
+
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, "API_KEY_REQUIRED", appErr.Code)
+		mockAPIKeyRepo.AssertNotCalled(t, "GetActiveByHashedKey", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOpen311Service_CreateAPIKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store only the hash of the generated key", func(t *testing.T) {
+		mockAPIKeyRepo, _, service := setupMocks()
+		mockAPIKeyRepo.On("CountActiveByUserID", ctx, uint(1)).Return(int64(0), nil)
+		mockAPIKeyRepo.On("Create", ctx, mock.AnythingOfType("*model.Open311APIKey")).Return(nil)
+
+		result, err := service.CreateAPIKey(ctx, 1, dto.CreateAPIKeyRequest{Name: "CRM Kota"})
+
+		require.NoError(t, err)
+		stored := mockAPIKeyRepo.Calls[1].Arguments.Get(1).(*model.Open311APIKey)
+		assert.Equal(t, tokenutils.HashSHA256String(result.Key), stored.HashedKey)
+		assert.NotContains(t, stored.HashedKey, result.Key)
+		assert.True(t, len(result.Key) > len(result.APIKey.KeyPrefix))
+		assert.Equal(t, result.Key[:len(result.APIKey.KeyPrefix)], result.APIKey.KeyPrefix)
+	})
+
+	t.Run("should reject when limit is reached", func(t *testing.T) {
+		mockAPIKeyRepo, _, service := setupMocks()
+		mockAPIKeyRepo.On("CountActiveByUserID", ctx, uint(1)).Return(int64(5), nil)
+
+		result, err := service.CreateAPIKey(ctx, 1, dto.CreateAPIKeyRequest{Name: "CRM Kota"})
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, "API_KEY_LIMIT_REACHED", appErr.Code)
+	})
+}
+
+func TestOpen311Service_RevokeAPIKey(t *testing.T) {
+	ctx := context.Background()
+	mockAPIKeyRepo, _, service := setupMocks()
+	mockAPIKeyRepo.On("Revoke", ctx, uint(2), uint(1), mock.AnythingOfType("int64")).Return(int64(0), nil)
+
+	err := service.RevokeAPIKey(ctx, 1, 2)
+
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, 404, appErr.StatusCode)
+}
+
+func TestOpen311Service_GetServiceRequests(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	t.Run("should filter by status and stop at start date", func(t *testing.T) {
+		_, mockReportService, service := setupMocks()
+		mockReportService.On("GetAllReport", ctx, uint(1), uint(0), "WATER", "", "latest", "", reportDTO.Distance{}).Return(&reportDTO.GetReportsResponse{
+			Reports: []reportDTO.Report{
+				{ID: 4, ReportType: "WATER", ReportStatus: "WAITING", ReportCreatedAt: now - 60},
+				{ID: 3, ReportType: "WATER", ReportStatus: "RESOLVED", ReportCreatedAt: now - 120},
+			},
+		}, nil)
+		mockReportService.On("GetAllReport", ctx, uint(1), uint(3), "WATER", "", "latest", "", reportDTO.Distance{}).Return(&reportDTO.GetReportsResponse{
+			Reports: []reportDTO.Report{
+				{ID: 2, ReportType: "WATER", ReportStatus: "ON_PROGRESS", ReportCreatedAt: now - 180},
+				{ID: 1, ReportType: "WATER", ReportStatus: "WAITING", ReportCreatedAt: now - 100*24*3600},
+			},
+		}, nil)
+
+		result, err := service.GetServiceRequests(ctx, 1, dto.GetServiceRequestsRequest{ServiceCode: "WATER", Status: "open"})
+
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "4", result[0].ServiceRequestID)
+		assert.Equal(t, "2", result[1].ServiceRequestID)
+		assert.Equal(t, "Air", result[0].ServiceName)
+	})
+
+	t.Run("should skip missing ids", func(t *testing.T) {
+		_, mockReportService, service := setupMocks()
+		mockReportService.On("GetReportByID", ctx, uint(1), uint(5)).Return(&reportDTO.GetReportResponse{
+			Report: reportDTO.Report{ID: 5, ReportType: "WASTE", ReportStatus: "RESOLVED"},
+		}, nil)
+		mockReportService.On("GetReportByID", ctx, uint(1), uint(6)).Return(nil, apperror.New(404, "REPORT_NOT_FOUND", "Laporan tidak ditemukan", "", nil))
+
+		result, err := service.GetServiceRequests(ctx, 1, dto.GetServiceRequestsRequest{ServiceRequestIDs: []uint{5, 6}})
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "closed", result[0].Status)
+	})
+}
+
+func TestOpen311Service_CreateServiceRequest(t *testing.T) {
+	ctx := context.Background()
+	lat, long := -6.2, 106.8
+
+	t.Run("should create report from service request", func(t *testing.T) {
+		_, mockReportService, service := setupMocks()
+		mockReportService.On("CreateReport", ctx, uint(1), mock.MatchedBy(func(req reportDTO.CreateReportRequest) bool {
+			return req.ReportType == "WASTE" && req.ReportTitle == "Sampah menumpuk" && req.DetailLocation == "-6.200000, 106.800000"
+		})).Return(&reportDTO.CreateReportResponse{Report: model.Report{ID: 12}}, nil)
+
+		result, err := service.CreateServiceRequest(ctx, 1, dto.CreateServiceRequestRequest{
+			ServiceCode: "WASTE",
+			Lat:         &lat,
+			Long:        &long,
+			Description: "Sampah menumpuk",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "12", result.ServiceRequestID)
+	})
+
+	t.Run("should reject unknown service code", func(t *testing.T) {
+		_, mockReportService, service := setupMocks()
+
+		result, err := service.CreateServiceRequest(ctx, 1, dto.CreateServiceRequestRequest{
+			ServiceCode: "POTHOLE",
+			Lat:         &lat,
+			Long:        &long,
+			Description: "Jalan berlubang",
+		})
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, "SERVICE_CODE_NOT_FOUND", appErr.Code)
+		mockReportService.AssertNotCalled(t, "CreateReport", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,143 @@
+package util
+
+import (
+	"fmt"
+	"pingspot/internal/domain/open311_service/dto"
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	"pingspot/internal/model"
+	env "pingspot/pkg/utils/env_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	APIKeyPrefix               = "ps311_"
+	APIKeyLength               = 40
+	MaxAPIKeysPerUser          = 5
+	MaxServiceRequests         = 50
+	MaxServiceRequestPages     = 20
+	DefaultRequestsWindow      = 90 * 24 * time.Hour
+	MaxReportTitleLength       = 100
+	ServiceRequestStatusOpen   = "open"
+	ServiceRequestStatusClosed = "closed"
+)
+
+var serviceNames = []struct {
+	Code model.ReportType
+	Name string
+}{
+	{model.Infrastructure, "Infrastruktur"},
+	{model.Environment, "Lingkungan"},
+	{model.Safety, "Keamanan"},
+	{model.Traffic, "Lalu Lintas"},
+	{model.PublicFacility, "Fasilitas Umum"},
+	{model.Waste, "Sampah"},
+	{model.Water, "Air"},
+	{model.Electricity, "Listrik"},
+	{model.Health, "Kesehatan"},
+	{model.Social, "Sosial"},
+	{model.Education, "Pendidikan"},
+	{model.Administrative, "Administrasi"},
+	{model.Disaster, "Bencana"},
+	{model.Other, "Lainnya"},
+}
+
+func GetServices() []dto.Service {
+	services := make([]dto.Service, 0, len(serviceNames))
+	for _, service := range serviceNames {
+		services = append(services, dto.Service{
+			ServiceCode: string(service.Code),
+			ServiceName: service.Name,
+			Description: fmt.Sprintf("Laporan %s", strings.ToLower(service.Name)),
+			Metadata:    false,
+			Type:        "realtime",
+		})
+	}
+	return services
+}
+
+func GetServiceName(serviceCode string) (string, bool) {
+	for _, service := range serviceNames {
+		if string(service.Code) == serviceCode {
+			return service.Name, true
+		}
+	}
+	return "", false
+}
+
+func ToServiceRequestStatus(reportStatus string) string {
+	switch model.ReportStatus(reportStatus) {
+	case model.RESOLVED, model.EXPIRED:
+		return ServiceRequestStatusClosed
+	default:
+		return ServiceRequestStatusOpen
+	}
+}
+
+func FormatDatetime(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+func BuildMediaURL(imageName *string) *string {
+	if imageName == nil || *imageName == "" {
+		return nil
+	}
+	mediaURL := strings.TrimRight(env.ServerURL(), "/") + "/main/report/" + *imageName
+	return &mediaURL
+}
+
+func BuildReportTitle(description string) string {
+	title := strings.Join(strings.Fields(description), " ")
+	runes := []rune(title)
+	if len(runes) <= MaxReportTitleLength {
+		return title
+	}
+	return strings.TrimSpace(string(runes[:MaxReportTitleLength-3])) + "..."
+}
+
+func ToServiceRequest(report reportDTO.Report) dto.ServiceRequest {
+	serviceName, _ := GetServiceName(report.ReportType)
+	var statusNotes *string
+	var latestProgressAt int64
+	for _, progress := range report.ReportProgress {
+		if statusNotes == nil || progress.CreatedAt > latestProgressAt {
+			statusNotes = progress.Notes
+			latestProgressAt = progress.CreatedAt
+		}
+	}
+	return dto.ServiceRequest{
+		ServiceRequestID:  strconv.FormatUint(uint64(report.ID), 10),
+		Status:            ToServiceRequestStatus(report.ReportStatus),
+		StatusNotes:       statusNotes,
+		ServiceName:       serviceName,
+		ServiceCode:       report.ReportType,
+		Description:       report.ReportDescription,
+		RequestedDatetime: FormatDatetime(report.ReportCreatedAt),
+		UpdatedDatetime:   FormatDatetime(report.ReportUpdatedAt),
+		Address:           report.Location.DetailLocation,
+		Zipcode:           report.Location.PostCode,
+		Lat:               report.Location.Latitude,
+		Long:              report.Location.Longitude,
+		MediaURL:          BuildMediaURL(report.Images.Image1URL),
+	}
+}
+
+func GenerateAPIKey() (string, error) {
+	code, err := tokenutils.GenerateRandomCode(APIKeyLength)
+	if err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + code, nil
+}
+
+func ToAPIKeyDTO(apiKey model.Open311APIKey) dto.APIKey {
+	return dto.APIKey{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		KeyPrefix:  apiKey.KeyPrefix,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
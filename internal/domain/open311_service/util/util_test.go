@@ -0,0 +1,67 @@
+package util
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"pingspot/internal/domain/open311_service/dto"
+	reportDTO "pingspot/internal/domain/report_service/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetServices(t *testing.T) {
+	services := GetServices()
+
+	require.Len(t, services, 14)
+	assert.Equal(t, "INFRASTRUCTURE", services[0].ServiceCode)
+	assert.Equal(t, "realtime", services[0].Type)
+}
+
+func TestToServiceRequestStatus(t *testing.T) {
+	assert.Equal(t, "open", ToServiceRequestStatus("WAITING"))
+	assert.Equal(t, "open", ToServiceRequestStatus("WAITING_CONFIRMATION"))
+	assert.Equal(t, "closed", ToServiceRequestStatus("RESOLVED"))
+	assert.Equal(t, "closed", ToServiceRequestStatus("EXPIRED"))
+}
+
+func TestBuildReportTitle(t *testing.T) {
+	assert.Equal(t, "Lampu jalan mati", BuildReportTitle("  Lampu jalan\n mati "))
+
+	title := BuildReportTitle(strings.Repeat("a", 150))
+	assert.Len(t, []rune(title), MaxReportTitleLength)
+	assert.True(t, strings.HasSuffix(title, "..."))
+}
+
+func TestToServiceRequest(t *testing.T) {
+	firstNotes, latestNotes := "Diterima", "Sedang diperbaiki"
+	image := "123.jpg"
+	serviceRequest := ToServiceRequest(reportDTO.Report{
+		ID:                7,
+		ReportType:        "ELECTRICITY",
+		ReportStatus:      "ON_PROGRESS",
+		ReportDescription: "Lampu jalan mati",
+		ReportCreatedAt:   0,
+		Location:          reportDTO.ReportLocation{DetailLocation: "Jl. Merdeka", Latitude: -6.2, Longitude: 106.8},
+		Images:            reportDTO.ReportImage{Image1URL: &image},
+		ReportProgress: []reportDTO.GetProgressReportResponse{
+			{Notes: &latestNotes, CreatedAt: 200},
+			{Notes: &firstNotes, CreatedAt: 100},
+		},
+	})
+
+	assert.Equal(t, "7", serviceRequest.ServiceRequestID)
+	assert.Equal(t, "open", serviceRequest.Status)
+	assert.Equal(t, "Listrik", serviceRequest.ServiceName)
+	assert.Equal(t, "1970-01-01T00:00:00Z", serviceRequest.RequestedDatetime)
+	require.NotNil(t, serviceRequest.StatusNotes)
+	assert.Equal(t, latestNotes, *serviceRequest.StatusNotes)
+	require.NotNil(t, serviceRequest.MediaURL)
+	assert.True(t, strings.HasSuffix(*serviceRequest.MediaURL, "/main/report/123.jpg"))
+
+	body, err := xml.Marshal(dto.ServiceRequestList{Requests: []dto.ServiceRequest{serviceRequest}})
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "<service_requests><request><service_request_id>7</service_request_id>")
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+var Validate *validator.Validate
+
+func init() {
+	Validate = validator.New()
+}
+
+func FormatCreateAPIKeyValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Name":
+			if e.Tag() == "required" {
+				errors["name"] = "Nama API key wajib diisi"
+			}
+			if e.Tag() == "max" {
+				errors["name"] = "Nama API key maksimal 100 karakter"
+			}
+		}
+	}
+	return errors
+}
+
+func FormatCreateServiceRequestValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "ServiceCode":
+			errors["service_code"] = "service_code wajib diisi"
+		case "Lat":
+			if e.Tag() == "required" {
+				errors["lat"] = "lat wajib diisi"
+			}
+			if e.Tag() == "latitude" {
+				errors["lat"] = "lat tidak valid"
+			}
+		case "Long":
+			if e.Tag() == "required" {
+				errors["long"] = "long wajib diisi"
+			}
+			if e.Tag() == "longitude" {
+				errors["long"] = "long tidak valid"
+			}
+		case "AddressString":
+			errors["address_string"] = "address_string maksimal 255 karakter"
+		case "Description":
+			if e.Tag() == "required" {
+				errors["description"] = "description wajib diisi"
+			}
+			if e.Tag() == "max" {
+				errors["description"] = "description maksimal 4000 karakter"
+			}
+		}
+	}
+	return errors
+}
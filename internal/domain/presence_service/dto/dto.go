@@ -0,0 +1,11 @@
+package dto
+
+type UserPresence struct {
+	UserID     uint   `json:"userID"`
+	Status     string `json:"status"`
+	LastSeenAt *int64 `json:"lastSeenAt"`
+}
+
+type PresenceSettings struct {
+	Visibility string `json:"visibility"`
+}
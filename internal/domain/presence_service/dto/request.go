@@ -0,0 +1,5 @@
+package dto
+
+type UpdatePresenceSettingsRequest struct {
+	Visibility string `json:"visibility" validate:"required,oneof=EVERYONE CONNECTIONS NOBODY"`
+}
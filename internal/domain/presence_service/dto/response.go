@@ -0,0 +1,5 @@
+package dto
+
+type GetPresencesResponse struct {
+	Presences []*UserPresence `json:"presences"`
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"pingspot/internal/domain/presence_service/dto"
+	"pingspot/internal/domain/presence_service/service"
+	"pingspot/internal/domain/presence_service/util"
+	"pingspot/internal/domain/presence_service/validation"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	response "pingspot/pkg/utils/response_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type PresenceHandler struct {
+	presenceService *service.PresenceService
+}
+
+func NewPresenceHandler(presenceService *service.PresenceService) *PresenceHandler {
+	return &PresenceHandler{presenceService: presenceService}
+}
+
+func (h *PresenceHandler) HeartbeatHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	if err := h.presenceService.Heartbeat(ctx, userID); err != nil {
+		logger.Error("Failed to record presence heartbeat", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal memperbarui status kehadiran", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil memperbarui status kehadiran", "", nil)
+}
+
+func (h *PresenceHandler) GetPresencesHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	userIDs, err := util.ParseUserIDs(c.Query("userIDs"))
+	if err != nil {
+		logger.Error("Invalid userIDs format", zap.String("userIDs", c.Query("userIDs")), zap.Error(err))
+		return response.ResponseError(c, 400, "Format userIDs tidak valid", "", err.Error())
+	}
+
+	presences, err := h.presenceService.GetPresences(ctx, userID, userIDs)
+	if err != nil {
+		logger.Error("Failed to get presences", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan status kehadiran", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan status kehadiran", "data", presences)
+}
+
+func (h *PresenceHandler) GetPresenceSettingsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	settings, err := h.presenceService.GetPresenceSettings(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get presence settings", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan pengaturan status kehadiran", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan pengaturan status kehadiran", "data", settings)
+}
+
+func (h *PresenceHandler) UpdatePresenceSettingsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	var req dto.UpdatePresenceSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	req.Visibility = strings.ToUpper(strings.TrimSpace(req.Visibility))
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatUpdatePresenceSettingsValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	settings, err := h.presenceService.UpdatePresenceSettings(ctx, userID, req)
+	if err != nil {
+		logger.Error("Failed to update presence settings", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal memperbarui pengaturan status kehadiran", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil memperbarui pengaturan status kehadiran", "data", settings)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/domain/presence_service/util"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type PresenceRepository interface {
+	Touch(ctx context.Context, userID uint, seenAt int64) error
+	GetLastSeen(ctx context.Context, userIDs []uint) (map[uint]int64, error)
+}
+
+type presenceRepository struct {
+	rdb redis.UniversalClient
+}
+
+func NewPresenceRepository(rdb redis.UniversalClient) PresenceRepository {
+	return &presenceRepository{rdb: rdb}
+}
+
+func (r *presenceRepository) Touch(ctx context.Context, userID uint, seenAt int64) error {
+	return r.rdb.Set(ctx, util.GetPresenceKey(userID), seenAt, util.LastSeenRetention).Err()
+}
+
+func (r *presenceRepository) GetLastSeen(ctx context.Context, userIDs []uint) (map[uint]int64, error) {
+	lastSeen := make(map[uint]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return lastSeen, nil
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = util.GetPresenceKey(userID)
+	}
+	values, err := r.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		seenAt, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			continue
+		}
+		lastSeen[userIDs[i]] = seenAt
+	}
+	return lastSeen, nil
+}
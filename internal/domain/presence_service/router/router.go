@@ -0,0 +1,60 @@
+package router
+
+import (
+	"pingspot/internal/domain/presence_service/handler"
+	presenceRepository "pingspot/internal/domain/presence_service/repository"
+	"pingspot/internal/domain/presence_service/service"
+	socialRepository "pingspot/internal/domain/social_service/repository"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/cache"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterPresenceRoutes(app *fiber.App) {
+	db := database.GetPostgresDB()
+	presenceRepo := presenceRepository.NewPresenceRepository(cache.GetRedis())
+	userRepo := userRepository.NewUserRepository(db)
+	userProfileRepo := userRepository.NewUserProfileRepository(db)
+	followRepo := socialRepository.NewFollowRepository(db)
+
+	presenceService := service.NewPresenceService(presenceRepo, userRepo, userProfileRepo, followRepo)
+	presenceHandler := handler.NewPresenceHandler(presenceService)
+
+	presenceRoute := app.Group("/pingspot/api/presence", middleware.ValidateAccessToken())
+
+	presenceRoute.Get("/",
+		middleware.TimeoutMiddleware(5*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 100,
+			KeyPrefix:   "get_presences",
+		})),
+		presenceHandler.GetPresencesHandler,
+	)
+	presenceRoute.Post("/heartbeat",
+		middleware.TimeoutMiddleware(5*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix:   "presence_heartbeat",
+		})),
+		presenceHandler.HeartbeatHandler,
+	)
+	presenceRoute.Get("/settings",
+		middleware.TimeoutMiddleware(5*time.Second),
+		presenceHandler.GetPresenceSettingsHandler,
+	)
+	presenceRoute.Put("/settings",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 20,
+			KeyPrefix:   "update_presence_settings",
+		})),
+		presenceHandler.UpdatePresenceSettingsHandler,
+	)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/domain/presence_service/dto"
+	presenceRepository "pingspot/internal/domain/presence_service/repository"
+	"pingspot/internal/domain/presence_service/util"
+	socialRepository "pingspot/internal/domain/social_service/repository"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PresenceService struct {
+	presenceRepo    presenceRepository.PresenceRepository
+	userRepo        userRepository.UserRepository
+	userProfileRepo userRepository.UserProfileRepository
+	followRepo      socialRepository.FollowRepository
+}
+
+func NewPresenceService(presenceRepo presenceRepository.PresenceRepository, userRepo userRepository.UserRepository, userProfileRepo userRepository.UserProfileRepository, followRepo socialRepository.FollowRepository) *PresenceService {
+	return &PresenceService{
+		presenceRepo:    presenceRepo,
+		userRepo:        userRepo,
+		userProfileRepo: userProfileRepo,
+		followRepo:      followRepo,
+	}
+}
+
+func (s *PresenceService) Heartbeat(ctx context.Context, userID uint) error {
+	if err := s.presenceRepo.Touch(ctx, userID, time.Now().Unix()); err != nil {
+		return apperror.New(500, "PRESENCE_UPDATE_FAILED", "gagal memperbarui status kehadiran", err.Error(), nil)
+	}
+	return nil
+}
+
+func (s *PresenceService) GetPresences(ctx context.Context, viewerID uint, userIDs []uint) (*dto.GetPresencesResponse, error) {
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, apperror.New(500, "USER_FETCH_FAILED", "gagal mengambil data pengguna", err.Error(), nil)
+	}
+	userPtrs := make([]*model.User, 0, len(users))
+	for i := range users {
+		userPtrs = append(userPtrs, &users[i])
+	}
+
+	presences, err := s.ResolvePresences(ctx, viewerID, userPtrs)
+	if err != nil {
+		return nil, err
+	}
+
+	presencesDTO := make([]*dto.UserPresence, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if presence, ok := presences[userID]; ok {
+			presencesDTO = append(presencesDTO, presence)
+		}
+	}
+	return &dto.GetPresencesResponse{Presences: presencesDTO}, nil
+}
+
+// ResolvePresences loads presence for the given users in a single Redis round
+// trip, hiding it from viewers the user's visibility setting excludes.
+func (s *PresenceService) ResolvePresences(ctx context.Context, viewerID uint, users []*model.User) (map[uint]*dto.UserPresence, error) {
+	var connectionCandidateIDs []uint
+	for _, user := range users {
+		if user.ID != viewerID && util.GetVisibility(user.Profile) == model.PresenceVisibleConnections {
+			connectionCandidateIDs = append(connectionCandidateIDs, user.ID)
+		}
+	}
+	connected := map[uint]bool{}
+	if len(connectionCandidateIDs) > 0 {
+		connectedIDs, err := s.followRepo.GetConnectedUserIDs(ctx, viewerID, connectionCandidateIDs)
+		if err != nil {
+			return nil, apperror.New(500, "USER_CONNECTIONS_FETCH_FAILED", "gagal mendapatkan koneksi pengguna", err.Error(), nil)
+		}
+		for _, connectedID := range connectedIDs {
+			connected[connectedID] = true
+		}
+	}
+
+	var visibleIDs []uint
+	for _, user := range users {
+		if util.CanViewPresence(util.GetVisibility(user.Profile), viewerID, user.ID, connected[user.ID]) {
+			visibleIDs = append(visibleIDs, user.ID)
+		}
+	}
+	lastSeen, err := s.presenceRepo.GetLastSeen(ctx, visibleIDs)
+	if err != nil {
+		return nil, apperror.New(500, "PRESENCE_FETCH_FAILED", "gagal mendapatkan status kehadiran", err.Error(), nil)
+	}
+
+	now := time.Now()
+	presences := make(map[uint]*dto.UserPresence, len(users))
+	for _, user := range users {
+		presence := &dto.UserPresence{
+			UserID: user.ID,
+			Status: string(model.PresenceOffline),
+		}
+		if seenAt, ok := lastSeen[user.ID]; ok {
+			presence.LastSeenAt = &seenAt
+			presence.Status = string(util.ResolveStatus(&seenAt, now))
+		}
+		presences[user.ID] = presence
+	}
+	return presences, nil
+}
+
+func (s *PresenceService) GetPresenceSettings(ctx context.Context, userID uint) (*dto.PresenceSettings, error) {
+	profile, err := s.userProfileRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &dto.PresenceSettings{Visibility: string(model.PresenceVisibleEveryone)}, nil
+		}
+		return nil, apperror.New(500, "USER_PROFILE_FETCH_FAILED", "gagal mengambil profil pengguna", err.Error(), nil)
+	}
+	return &dto.PresenceSettings{Visibility: string(util.GetVisibility(*profile))}, nil
+}
+
+func (s *PresenceService) UpdatePresenceSettings(ctx context.Context, userID uint, req dto.UpdatePresenceSettingsRequest) (*dto.PresenceSettings, error) {
+	profile, err := s.userProfileRepo.GetByID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(500, "USER_PROFILE_FETCH_FAILED", "gagal mengambil profil pengguna", err.Error(), nil)
+		}
+		profile = &model.UserProfile{UserID: userID}
+	}
+
+	profile.PresenceVisibility = model.PresenceVisibility(req.Visibility)
+	if _, err := s.userProfileRepo.SaveByID(ctx, userID, profile); err != nil {
+		return nil, apperror.New(500, "PRESENCE_SETTINGS_UPDATE_FAILED", "gagal memperbarui pengaturan status kehadiran", err.Error(), nil)
+	}
+	return &dto.PresenceSettings{Visibility: string(profile.PresenceVisibility)}, nil
+}
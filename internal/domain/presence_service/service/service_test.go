@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pingspot/internal/domain/presence_service/dto"
+	presenceMocks "pingspot/internal/mocks/presence"
+	socialMocks "pingspot/internal/mocks/social"
+	userMocks "pingspot/internal/mocks/user"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupMocks() (*presenceMocks.MockPresenceRepository, *userMocks.MockUserRepository, *userMocks.MockUserProfileRepository, *socialMocks.MockFollowRepository, *PresenceService) {
+	mockPresenceRepo := new(presenceMocks.MockPresenceRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+	mockUserProfileRepo := new(userMocks.MockUserProfileRepository)
+	mockFollowRepo := new(socialMocks.MockFollowRepository)
+	service := NewPresenceService(mockPresenceRepo, mockUserRepo, mockUserProfileRepo, mockFollowRepo)
+	return mockPresenceRepo, mockUserRepo, mockUserProfileRepo, mockFollowRepo, service
+}
+
+func TestPresenceService_Heartbeat(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should record last seen", func(t *testing.T) {
+		mockPresenceRepo, _, _, _, service := setupMocks()
+		mockPresenceRepo.On("Touch", ctx, uint(1), mock.AnythingOfType("int64")).Return(nil)
+
+		err := service.Heartbeat(ctx, 1)
+
+		require.NoError(t, err)
+		mockPresenceRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when redis fails", func(t *testing.T) {
+		mockPresenceRepo, _, _, _, service := setupMocks()
+		mockPresenceRepo.On("Touch", ctx, uint(1), mock.AnythingOfType("int64")).Return(errors.New("redis down"))
+
+		err := service.Heartbeat(ctx, 1)
+
+		require.Error(t, err)
+		assert.Equal(t, "PRESENCE_UPDATE_FAILED", err.(*apperror.AppError).Code)
+	})
+}
+
+func TestPresenceService_GetPresences(t *testing.T) {
+	ctx := context.Background()
+	users := []model.User{
+		{ID: 2, Profile: model.UserProfile{PresenceVisibility: model.PresenceVisibleEveryone}},
+		{ID: 3, Profile: model.UserProfile{PresenceVisibility: model.PresenceVisibleConnections}},
+		{ID: 4, Profile: model.UserProfile{PresenceVisibility: model.PresenceVisibleConnections}},
+		{ID: 5, Profile: model.UserProfile{PresenceVisibility: model.PresenceVisibleNobody}},
+	}
+
+	t.Run("should respect visibility and fetch presence in one batch", func(t *testing.T) {
+		mockPresenceRepo, mockUserRepo, _, mockFollowRepo, service := setupMocks()
+		mockUserRepo.On("GetByIDs", ctx, []uint{5, 4, 3, 2, 99}).Return(users, nil)
+		mockFollowRepo.On("GetConnectedUserIDs", ctx, uint(1), []uint{3, 4}).Return([]uint{3}, nil)
+		seenAt := int64(1700000000)
+		mockPresenceRepo.On("GetLastSeen", ctx, []uint{2, 3}).Return(map[uint]int64{2: seenAt}, nil).Once()
+
+		result, err := service.GetPresences(ctx, 1, []uint{5, 4, 3, 2, 99})
+
+		require.NoError(t, err)
+		require.Len(t, result.Presences, 4)
+		assert.Equal(t, []uint{5, 4, 3, 2}, []uint{result.Presences[0].UserID, result.Presences[1].UserID, result.Presences[2].UserID, result.Presences[3].UserID})
+		assert.Equal(t, &dto.UserPresence{UserID: 5, Status: string(model.PresenceOffline)}, result.Presences[0])
+		assert.Equal(t, &dto.UserPresence{UserID: 4, Status: string(model.PresenceOffline)}, result.Presences[1])
+		assert.Equal(t, &dto.UserPresence{UserID: 3, Status: string(model.PresenceOffline)}, result.Presences[2])
+		assert.Equal(t, &seenAt, result.Presences[3].LastSeenAt)
+		mockPresenceRepo.AssertExpectations(t)
+		mockFollowRepo.AssertExpectations(t)
+	})
+
+	t.Run("should always show own presence", func(t *testing.T) {
+		mockPresenceRepo, mockUserRepo, _, _, service := setupMocks()
+		mockUserRepo.On("GetByIDs", ctx, []uint{5}).Return([]model.User{users[3]}, nil)
+		mockPresenceRepo.On("GetLastSeen", ctx, []uint{5}).Return(map[uint]int64{}, nil)
+
+		result, err := service.GetPresences(ctx, 5, []uint{5})
+
+		require.NoError(t, err)
+		require.Len(t, result.Presences, 1)
+		mockPresenceRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when presence lookup fails", func(t *testing.T) {
+		mockPresenceRepo, mockUserRepo, _, _, service := setupMocks()
+		mockUserRepo.On("GetByIDs", ctx, []uint{2}).Return([]model.User{users[0]}, nil)
+		mockPresenceRepo.On("GetLastSeen", ctx, []uint{2}).Return(nil, errors.New("redis down"))
+
+		result, err := service.GetPresences(ctx, 1, []uint{2})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "PRESENCE_FETCH_FAILED", err.(*apperror.AppError).Code)
+	})
+}
+
+func TestPresenceService_UpdatePresenceSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should update existing profile", func(t *testing.T) {
+		_, _, mockUserProfileRepo, _, service := setupMocks()
+		profile := &model.UserProfile{ID: 7, UserID: 1}
+		mockUserProfileRepo.On("GetByID", ctx, uint(1)).Return(profile, nil)
+		mockUserProfileRepo.On("SaveByID", ctx, uint(1), profile).Return(profile, nil)
+
+		result, err := service.UpdatePresenceSettings(ctx, 1, dto.UpdatePresenceSettingsRequest{Visibility: "NOBODY"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "NOBODY", result.Visibility)
+		assert.Equal(t, model.PresenceVisibleNobody, profile.PresenceVisibility)
+	})
+
+	t.Run("should create profile when missing", func(t *testing.T) {
+		_, _, mockUserProfileRepo, _, service := setupMocks()
+		mockUserProfileRepo.On("GetByID", ctx, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockUserProfileRepo.On("SaveByID", ctx, uint(1), mock.MatchedBy(func(profile *model.UserProfile) bool {
+			return profile.UserID == 1 && profile.PresenceVisibility == model.PresenceVisibleConnections
+		})).Return(&model.UserProfile{}, nil)
+
+		result, err := service.UpdatePresenceSettings(ctx, 1, dto.UpdatePresenceSettingsRequest{Visibility: "CONNECTIONS"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "CONNECTIONS", result.Visibility)
+	})
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"fmt"
+	"pingspot/internal/model"
+	mainutils "pingspot/pkg/utils/main_util"
+	"strings"
+	"time"
+)
+
+const (
+	KeyPrefix         = "presence"
+	OnlineWindow      = 2 * time.Minute
+	IdleWindow        = 10 * time.Minute
+	LastSeenRetention = 30 * 24 * time.Hour
+	MaxBatchSize      = 200
+)
+
+func GetPresenceKey(userID uint) string {
+	return fmt.Sprintf("%s:user:%d", KeyPrefix, userID)
+}
+
+func ResolveStatus(lastSeenAt *int64, now time.Time) model.PresenceStatus {
+	if lastSeenAt == nil {
+		return model.PresenceOffline
+	}
+	elapsed := now.Sub(time.Unix(*lastSeenAt, 0))
+	switch {
+	case elapsed < OnlineWindow:
+		return model.PresenceOnline
+	case elapsed < IdleWindow:
+		return model.PresenceIdle
+	default:
+		return model.PresenceOffline
+	}
+}
+
+func GetVisibility(profile model.UserProfile) model.PresenceVisibility {
+	if profile.PresenceVisibility == "" {
+		return model.PresenceVisibleEveryone
+	}
+	return profile.PresenceVisibility
+}
+
+func CanViewPresence(visibility model.PresenceVisibility, viewerID, targetID uint, isConnected bool) bool {
+	if viewerID == targetID {
+		return true
+	}
+	switch visibility {
+	case model.PresenceVisibleNobody:
+		return false
+	case model.PresenceVisibleConnections:
+		return isConnected
+	default:
+		return true
+	}
+}
+
+func ParseUserIDs(raw string) ([]uint, error) {
+	seen := map[uint]bool{}
+	var userIDs []uint
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		userID, err := mainutils.StringToUint(part)
+		if err != nil || userID == 0 {
+			return nil, fmt.Errorf("invalid user ID %q", part)
+		}
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+	}
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("at least one user ID is required")
+	}
+	if len(userIDs) > MaxBatchSize {
+		return nil, fmt.Errorf("at most %d user IDs are allowed", MaxBatchSize)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,66 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPresenceKey(t *testing.T) {
+	assert.Equal(t, "presence:user:42", GetPresenceKey(42))
+}
+
+func TestResolveStatus(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	at := func(ago time.Duration) *int64 {
+		value := now.Add(-ago).Unix()
+		return &value
+	}
+
+	assert.Equal(t, model.PresenceOffline, ResolveStatus(nil, now))
+	assert.Equal(t, model.PresenceOnline, ResolveStatus(at(30*time.Second), now))
+	assert.Equal(t, model.PresenceIdle, ResolveStatus(at(OnlineWindow), now))
+	assert.Equal(t, model.PresenceIdle, ResolveStatus(at(9*time.Minute), now))
+	assert.Equal(t, model.PresenceOffline, ResolveStatus(at(IdleWindow), now))
+}
+
+func TestGetVisibility(t *testing.T) {
+	assert.Equal(t, model.PresenceVisibleEveryone, GetVisibility(model.UserProfile{}))
+	assert.Equal(t, model.PresenceVisibleNobody, GetVisibility(model.UserProfile{PresenceVisibility: model.PresenceVisibleNobody}))
+}
+
+func TestCanViewPresence(t *testing.T) {
+	assert.True(t, CanViewPresence(model.PresenceVisibleEveryone, 1, 2, false))
+	assert.True(t, CanViewPresence(model.PresenceVisibleConnections, 1, 2, true))
+	assert.False(t, CanViewPresence(model.PresenceVisibleConnections, 1, 2, false))
+	assert.False(t, CanViewPresence(model.PresenceVisibleNobody, 1, 2, true))
+	assert.True(t, CanViewPresence(model.PresenceVisibleNobody, 2, 2, false))
+}
+
+func TestParseUserIDs(t *testing.T) {
+	userIDs, err := ParseUserIDs(" 3,1, 3,,2 ")
+	require.NoError(t, err)
+	assert.Equal(t, []uint{3, 1, 2}, userIDs)
+
+	_, err = ParseUserIDs("")
+	assert.Error(t, err)
+
+	_, err = ParseUserIDs("1,abc")
+	assert.Error(t, err)
+
+	_, err = ParseUserIDs("0")
+	assert.Error(t, err)
+
+	ids := make([]string, 0, MaxBatchSize+1)
+	for i := 1; i <= MaxBatchSize+1; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+	_, err = ParseUserIDs(strings.Join(ids, ","))
+	assert.Error(t, err)
+}
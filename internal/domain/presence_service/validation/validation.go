@@ -0,0 +1,29 @@
+package validation
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+var Validate *validator.Validate
+
+func init() {
+	Validate = validator.New()
+}
+
+func FormatUpdatePresenceSettingsValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		if e.Field() == "Visibility" {
+			if e.Tag() == "required" {
+				errors["visibility"] = "Visibilitas status wajib diisi"
+			}
+			if e.Tag() == "oneof" {
+				errors["visibility"] = "Visibilitas status harus EVERYONE, CONNECTIONS, atau NOBODY"
+			}
+		}
+	}
+	return errors
+}
@@ -0,0 +1,45 @@
+package dto
+
+import reportDTO "pingspot/internal/domain/report_service/dto"
+
+type PublicReporter struct {
+	Username       string  `json:"username"`
+	ProfilePicture *string `json:"profilePicture"`
+}
+
+type PublicReportLocation struct {
+	DetailLocation *string `json:"detailLocation,omitempty"`
+	DisplayName    *string `json:"displayName,omitempty"`
+	Road           *string `json:"road,omitempty"`
+	PostCode       *string `json:"postCode,omitempty"`
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	IsApproximate  bool    `json:"isApproximate"`
+	Village        *string `json:"village"`
+	Suburb         *string `json:"suburb"`
+	County         *string `json:"county"`
+	State          *string `json:"state"`
+	Region         *string `json:"region"`
+	Country        *string `json:"country"`
+	CountryCode    *string `json:"countryCode"`
+}
+
+type PublicReport struct {
+	ID                    uint                  `json:"id"`
+	ReportTitle           string                `json:"reportTitle"`
+	ReportType            string                `json:"reportType"`
+	ReportDescription     string                `json:"reportDescription"`
+	ReportStatus          string                `json:"reportStatus"`
+	HasProgress           *bool                 `json:"hasProgress"`
+	IsAnonymous           bool                  `json:"isAnonymous"`
+	Reporter              *PublicReporter       `json:"reporter"`
+	Location              PublicReportLocation  `json:"location"`
+	Images                reportDTO.ReportImage `json:"images"`
+	TotalLikeReactions    int64                 `json:"totalLikeReactions"`
+	TotalDislikeReactions int64                 `json:"totalDislikeReactions"`
+	TotalResolvedVotes    int64                 `json:"totalResolvedVotes"`
+	TotalOnProgressVotes  int64                 `json:"totalOnProgressVotes"`
+	ViewCount             int64                 `json:"viewCount"`
+	ReportCreatedAt       int64                 `json:"reportCreatedAt"`
+	ReportUpdatedAt       int64                 `json:"reportUpdatedAt"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type GetPublicReportsResponse struct {
+	Reports    []PublicReport `json:"reports"`
+	NextCursor *uint          `json:"nextCursor"`
+}
+
+type GetPublicReportResponse struct {
+	Report           PublicReport `json:"report"`
+	RedirectedFromID *uint        `json:"redirectedFromID,omitempty"`
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"pingspot/internal/domain/public_service/service"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	response "pingspot/pkg/utils/response_util"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type PublicHandler struct {
+	publicService *service.PublicService
+}
+
+func NewPublicHandler(publicService *service.PublicService) *PublicHandler {
+	return &PublicHandler{publicService: publicService}
+}
+
+func (h *PublicHandler) GetReportsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	cursorID := c.Query("cursorID")
+	cursorIDUint, err := mainutils.StringToUint(cursorID)
+	if err != nil && cursorID != "" {
+		logger.Error("Invalid cursorID format", zap.String("cursorID", cursorID), zap.Error(err))
+		return response.ResponseError(c, 400, "Format cursorID tidak valid", "", "cursorID harus berupa angka")
+	}
+
+	reports, err := h.publicService.GetReports(ctx, cursorIDUint, c.Query("reportType"), c.Query("status"), c.Query("sortBy"), c.Query("hasProgress"))
+	if err != nil {
+		logger.Error("Failed to get public reports", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Get public reports success", "data", reports)
+}
+
+func (h *PublicHandler) GetReportHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	reportID, err := mainutils.StringToUint(c.Params("reportID"))
+	if err != nil {
+		logger.Error("Invalid reportID format", zap.String("reportID", c.Params("reportID")), zap.Error(err))
+		return response.ResponseError(c, 400, "Format reportID tidak valid", "", "reportID harus berupa angka")
+	}
+
+	report, err := h.publicService.GetReport(ctx, reportID)
+	if err != nil {
+		logger.Error("Failed to get public report", zap.Uint("reportID", reportID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Get public report success", "data", report)
+}
+
+func (h *PublicHandler) GetReportProgressHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	reportID, err := mainutils.StringToUint(c.Params("reportID"))
+	if err != nil {
+		logger.Error("Invalid reportID format", zap.String("reportID", c.Params("reportID")), zap.Error(err))
+		return response.ResponseError(c, 400, "Format reportID tidak valid", "", "reportID harus berupa angka")
+	}
+
+	progress, err := h.publicService.GetReportProgress(ctx, reportID)
+	if err != nil {
+		logger.Error("Failed to get public report progress", zap.Uint("reportID", reportID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan progres laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Get public report progress success", "data", progress)
+}
+
+func (h *PublicHandler) GetStatisticsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	statistics, err := h.publicService.GetStatistics(ctx)
+	if err != nil {
+		logger.Error("Failed to get public report statistics", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan statistik laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Get public report statistics success", "data", statistics)
+}
@@ -0,0 +1,59 @@
+package router
+
+import (
+	"pingspot/internal/domain/public_service/handler"
+	"pingspot/internal/domain/public_service/service"
+	reportRepository "pingspot/internal/domain/report_service/repository"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterPublicRoutes(app *fiber.App) {
+	db := database.GetPostgresDB()
+	reportRepo := reportRepository.NewReportRepository(db)
+	reportProgressRepo := reportRepository.NewReportProgressRepository(db)
+
+	publicService := service.NewPublicService(reportRepo, reportProgressRepo)
+	publicHandler := handler.NewPublicHandler(publicService)
+
+	publicRoute := app.Group("/pingspot/api/public")
+	publicRoute.Get("/report",
+		middleware.TimeoutMiddleware(15*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 60,
+			KeyPrefix:   "public_get_reports",
+		})),
+		publicHandler.GetReportsHandler,
+	)
+	publicRoute.Get("/report/statistics",
+		middleware.TimeoutMiddleware(15*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix:   "public_get_report_statistics",
+		})),
+		publicHandler.GetStatisticsHandler,
+	)
+	publicRoute.Get("/report/:reportID",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 120,
+			KeyPrefix:   "public_get_report",
+		})),
+		publicHandler.GetReportHandler,
+	)
+	publicRoute.Get("/report/:reportID/progress",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 120,
+			KeyPrefix:   "public_get_report_progress",
+		})),
+		publicHandler.GetReportProgressHandler,
+	)
+}
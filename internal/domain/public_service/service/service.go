@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/domain/public_service/dto"
+	"pingspot/internal/domain/public_service/util"
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	reportRepository "pingspot/internal/domain/report_service/repository"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"gorm.io/gorm"
+)
+
+type PublicService struct {
+	reportRepo         reportRepository.ReportRepository
+	reportProgressRepo reportRepository.ReportProgressRepository
+}
+
+func NewPublicService(reportRepo reportRepository.ReportRepository, reportProgressRepo reportRepository.ReportProgressRepository) *PublicService {
+	return &PublicService{
+		reportRepo:         reportRepo,
+		reportProgressRepo: reportProgressRepo,
+	}
+}
+
+func (s *PublicService) GetReports(ctx context.Context, cursorID uint, reportType, status, sortBy, hasProgress string) (*dto.GetPublicReportsResponse, error) {
+	reports, err := s.reportRepo.GetByIsDeletedPaginated(ctx, util.PublicReportPageSize, cursorID, reportType, status, sortBy, hasProgress, reportDTO.Distance{}, false)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &dto.GetPublicReportsResponse{Reports: []dto.PublicReport{}}, nil
+		}
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
+	}
+
+	fuzzMeters := util.GetLocationFuzzMeters()
+	publicReports := make([]dto.PublicReport, 0, len(*reports))
+	for i := range *reports {
+		publicReports = append(publicReports, util.ToPublicReport(&(*reports)[i], fuzzMeters))
+	}
+
+	var nextCursor *uint
+	if len(publicReports) > 0 {
+		lastID := publicReports[len(publicReports)-1].ID
+		nextCursor = &lastID
+	}
+
+	return &dto.GetPublicReportsResponse{
+		Reports:    publicReports,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (s *PublicService) GetReport(ctx context.Context, reportID uint) (*dto.GetPublicReportResponse, error) {
+	report, err := s.getPublicReport(ctx, reportID)
+	if err != nil {
+		if appErr, ok := err.(*apperror.AppError); ok && appErr.StatusCode == 404 {
+			if mergedIntoID, mergedErr := s.reportRepo.GetMergedIntoID(ctx, reportID); mergedErr == nil && mergedIntoID != nil {
+				canonicalReport, err := s.getPublicReport(ctx, *mergedIntoID)
+				if err != nil {
+					return nil, err
+				}
+				return &dto.GetPublicReportResponse{
+					Report:           util.ToPublicReport(canonicalReport, util.GetLocationFuzzMeters()),
+					RedirectedFromID: &reportID,
+				}, nil
+			}
+		}
+		return nil, err
+	}
+
+	return &dto.GetPublicReportResponse{
+		Report: util.ToPublicReport(report, util.GetLocationFuzzMeters()),
+	}, nil
+}
+
+func (s *PublicService) GetReportProgress(ctx context.Context, reportID uint) ([]reportDTO.GetProgressReportResponse, error) {
+	if _, err := s.getPublicReport(ctx, reportID); err != nil {
+		return nil, err
+	}
+
+	reportProgresses, err := s.reportProgressRepo.GetByReportID(ctx, reportID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.New(500, "PROGRESS_FETCH_FAILED", "gagal mengambil progres laporan", err.Error(), nil)
+	}
+
+	response := make([]reportDTO.GetProgressReportResponse, 0, len(reportProgresses))
+	for _, progress := range reportProgresses {
+		notes := progress.Notes
+		response = append(response, reportDTO.GetProgressReportResponse{
+			ReportID:    progress.ReportID,
+			Status:      string(progress.Status),
+			Notes:       &notes,
+			Attachment1: progress.Attachment1,
+			Attachment2: progress.Attachment2,
+			CreatedAt:   progress.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func (s *PublicService) GetStatistics(ctx context.Context) (*reportDTO.GetReportStatisticsResponse, error) {
+	totalReports, err := s.reportRepo.GetByReportTypeCount(ctx)
+	if err != nil {
+		return nil, apperror.New(500, "TOTAL_REPORTS_FETCH_FAILED", "Gagal mengambil total laporan", err.Error(), nil)
+	}
+
+	reportsByStatus, err := s.reportRepo.GetByReportStatusCount(ctx, string(model.WAITING), string(model.ON_PROGRESS), string(model.WAITING_CONFIRMATION), string(model.RESOLVED), string(model.EXPIRED))
+	if err != nil {
+		return nil, apperror.New(500, "RESOLVED_REPORTS_FETCH_FAILED", "Gagal mengambil laporan yang diselesaikan", err.Error(), nil)
+	}
+
+	monthlyReports, err := s.reportRepo.GetMonthlyReportCount(ctx)
+	if err != nil {
+		return nil, apperror.New(500, "MONTHLY_REPORTS_FETCH_FAILED", "Gagal mengambil laporan bulanan", err.Error(), nil)
+	}
+
+	return &reportDTO.GetReportStatisticsResponse{
+		TotalReports:        totalReports.TotalReports,
+		ReportsByStatus:     reportsByStatus,
+		MonthlyReportCounts: monthlyReports,
+	}, nil
+}
+
+func (s *PublicService) getPublicReport(ctx context.Context, reportID uint) (*model.Report, error) {
+	report, err := s.reportRepo.GetByIDIsDeleted(ctx, reportID, false)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "REPORT_NOT_FOUND", "Laporan tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
+	}
+	return report, nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	reportMocks "pingspot/internal/mocks/report"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupMocks() (*reportMocks.MockReportRepository, *reportMocks.MockReportProgressRepository, *PublicService) {
+	mockReportRepo := new(reportMocks.MockReportRepository)
+	mockReportProgressRepo := new(reportMocks.MockReportProgressRepository)
+	service := NewPublicService(mockReportRepo, mockReportProgressRepo)
+	return mockReportRepo, mockReportProgressRepo, service
+}
+
+func TestPublicService_GetReports(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return reports without reporter of anonymous reports", func(t *testing.T) {
+		mockReportRepo, _, service := setupMocks()
+		reports := []model.Report{
+			{ID: 3, User: model.User{Username: "budi", Email: "budi@example.com"}},
+			{ID: 2, IsAnonymous: true, User: model.User{Username: "siti"}},
+		}
+		mockReportRepo.On("GetByIsDeletedPaginated", ctx, uint(20), uint(0), "", "", "", "", reportDTO.Distance{}, false).Return(&reports, nil)
+
+		result, err := service.GetReports(ctx, 0, "", "", "", "")
+
+		require.NoError(t, err)
+		require.Len(t, result.Reports, 2)
+		require.NotNil(t, result.Reports[0].Reporter)
+		assert.Equal(t, "budi", result.Reports[0].Reporter.Username)
+		assert.Nil(t, result.Reports[1].Reporter)
+		require.NotNil(t, result.NextCursor)
+		assert.Equal(t, uint(2), *result.NextCursor)
+	})
+
+	t.Run("should return error when fetching reports fails", func(t *testing.T) {
+		mockReportRepo, _, service := setupMocks()
+		mockReportRepo.On("GetByIsDeletedPaginated", ctx, uint(20), uint(0), "", "", "", "", reportDTO.Distance{}, false).Return(nil, errors.New("db error"))
+
+		result, err := service.GetReports(ctx, 0, "", "", "", "")
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, "REPORT_FETCH_FAILED", appErr.Code)
+	})
+}
+
+func TestPublicService_GetReport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return report", func(t *testing.T) {
+		mockReportRepo, _, service := setupMocks()
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(&model.Report{ID: 1}, nil)
+
+		result, err := service.GetReport(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), result.Report.ID)
+		assert.Nil(t, result.RedirectedFromID)
+		mockReportRepo.AssertNotCalled(t, "IncrementViewCount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should redirect merged report to canonical report", func(t *testing.T) {
+		mockReportRepo, _, service := setupMocks()
+		canonicalID := uint(5)
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(nil, gorm.ErrRecordNotFound)
+		mockReportRepo.On("GetMergedIntoID", ctx, uint(1)).Return(&canonicalID, nil)
+		mockReportRepo.On("GetByIDIsDeleted", ctx, canonicalID, false).Return(&model.Report{ID: canonicalID}, nil)
+
+		result, err := service.GetReport(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, canonicalID, result.Report.ID)
+		require.NotNil(t, result.RedirectedFromID)
+		assert.Equal(t, uint(1), *result.RedirectedFromID)
+	})
+
+	t.Run("should return not found when report does not exist", func(t *testing.T) {
+		mockReportRepo, _, service := setupMocks()
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(nil, gorm.ErrRecordNotFound)
+		mockReportRepo.On("GetMergedIntoID", ctx, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.GetReport(ctx, 1)
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 404, appErr.StatusCode)
+	})
+}
+
+func TestPublicService_GetReportProgress(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return progress of existing report", func(t *testing.T) {
+		mockReportRepo, mockReportProgressRepo, service := setupMocks()
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(&model.Report{ID: 1}, nil)
+		mockReportProgressRepo.On("GetByReportID", ctx, uint(1)).Return([]model.ReportProgress{
+			{ReportID: 1, Status: model.ON_PROGRESS, Notes: "Sedang diperbaiki"},
+		}, nil)
+
+		result, err := service.GetReportProgress(ctx, 1)
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "Sedang diperbaiki", *result[0].Notes)
+	})
+
+	t.Run("should not return progress of missing report", func(t *testing.T) {
+		mockReportRepo, mockReportProgressRepo, service := setupMocks()
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.GetReportProgress(ctx, 1)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockReportProgressRepo.AssertNotCalled(t, "GetByReportID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPublicService_GetStatistics(t *testing.T) {
+	ctx := context.Background()
+	mockReportRepo, _, service := setupMocks()
+	mockReportRepo.On("GetByReportTypeCount", ctx).Return(&reportDTO.TotalReportCount{TotalReports: 4}, nil)
+	mockReportRepo.On("GetByReportStatusCount", ctx, mock.Anything).Return(map[string]int64{"WAITING": 4}, nil)
+	mockReportRepo.On("GetMonthlyReportCount", ctx).Return(map[string]int64{"2026-10": 4}, nil)
+
+	result, err := service.GetStatistics(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), result.TotalReports)
+	assert.Equal(t, int64(4), result.ReportsByStatus["WAITING"])
+}
@@ -0,0 +1,120 @@
+package util
+
+import (
+	"math"
+	"pingspot/internal/domain/public_service/dto"
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	"pingspot/internal/model"
+	env "pingspot/pkg/utils/env_util"
+	"strconv"
+)
+
+const (
+	PublicReportPageSize = 20
+	metersPerDegree      = 111320.0
+)
+
+func GetLocationFuzzMeters() int {
+	if v, err := strconv.Atoi(env.PublicLocationFuzzMeters()); err == nil && v > 0 {
+		return v
+	}
+	return 0
+}
+
+func FuzzCoordinates(lat, lng float64, meters int) (float64, float64) {
+	if meters <= 0 {
+		return lat, lng
+	}
+	latStep := float64(meters) / metersPerDegree
+	fuzzedLat := (math.Floor(lat/latStep) + 0.5) * latStep
+	fuzzedLat = math.Max(math.Min(fuzzedLat, 90), -90)
+
+	cosLat := math.Cos(fuzzedLat * math.Pi / 180)
+	fuzzedLng := lng
+	if cosLat > 1e-6 {
+		lngStep := float64(meters) / (metersPerDegree * cosLat)
+		fuzzedLng = (math.Floor(lng/lngStep) + 0.5) * lngStep
+		fuzzedLng = math.Max(math.Min(fuzzedLng, 180), -180)
+	}
+	return math.Round(fuzzedLat*1e6) / 1e6, math.Round(fuzzedLng*1e6) / 1e6
+}
+
+func ToPublicReport(report *model.Report, fuzzMeters int) dto.PublicReport {
+	publicReport := dto.PublicReport{
+		ID:                report.ID,
+		ReportTitle:       report.ReportTitle,
+		ReportType:        string(report.ReportType),
+		ReportDescription: report.ReportDescription,
+		ReportStatus:      string(report.ReportStatus),
+		HasProgress:       report.HasProgress,
+		IsAnonymous:       report.IsAnonymous,
+		ViewCount:         report.ViewCount,
+		ReportCreatedAt:   report.CreatedAt,
+		ReportUpdatedAt:   report.UpdatedAt,
+	}
+
+	if !report.IsAnonymous && report.User.Username != "" {
+		publicReport.Reporter = &dto.PublicReporter{
+			Username:       report.User.Username,
+			ProfilePicture: report.User.Profile.ProfilePicture,
+		}
+	}
+
+	if location := report.ReportLocation; location != nil {
+		publicReport.Location = dto.PublicReportLocation{
+			Latitude:    location.Latitude,
+			Longitude:   location.Longitude,
+			Village:     location.Village,
+			Suburb:      location.Suburb,
+			County:      location.County,
+			State:       location.State,
+			Region:      location.Region,
+			Country:     location.Country,
+			CountryCode: location.CountryCode,
+		}
+		if fuzzMeters > 0 {
+			publicReport.Location.Latitude, publicReport.Location.Longitude = FuzzCoordinates(location.Latitude, location.Longitude, fuzzMeters)
+			publicReport.Location.IsApproximate = true
+		} else {
+			detailLocation := location.DetailLocation
+			publicReport.Location.DetailLocation = &detailLocation
+			publicReport.Location.DisplayName = location.DisplayName
+			publicReport.Location.Road = location.Road
+			publicReport.Location.PostCode = location.PostCode
+		}
+	}
+
+	if images := report.ReportImages; images != nil {
+		publicReport.Images = reportDTO.ReportImage{
+			Image1URL: images.Image1URL,
+			Image2URL: images.Image2URL,
+			Image3URL: images.Image3URL,
+			Image4URL: images.Image4URL,
+			Image5URL: images.Image5URL,
+		}
+	}
+
+	if report.ReportReactions != nil {
+		for _, reaction := range *report.ReportReactions {
+			switch reaction.Type {
+			case model.Like:
+				publicReport.TotalLikeReactions++
+			case model.Dislike:
+				publicReport.TotalDislikeReactions++
+			}
+		}
+	}
+
+	if report.ReportVotes != nil {
+		for _, vote := range *report.ReportVotes {
+			switch vote.VoteType {
+			case model.RESOLVED:
+				publicReport.TotalResolvedVotes++
+			case model.ON_PROGRESS:
+				publicReport.TotalOnProgressVotes++
+			}
+		}
+	}
+
+	return publicReport
+}
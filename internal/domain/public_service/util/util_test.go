@@ -0,0 +1,89 @@
+package util
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPublicTestReport(isAnonymous bool) *model.Report {
+	road := "Jl. Sudirman No. 1"
+	return &model.Report{
+		ID:          1,
+		ReportTitle: "Jalan berlubang",
+		ReportType:  model.Infrastructure,
+		IsAnonymous: isAnonymous,
+		User: model.User{
+			ID:       7,
+			Username: "budi",
+			Email:    "budi@example.com",
+		},
+		ReportLocation: &model.ReportLocation{
+			DetailLocation: "Depan rumah nomor 1",
+			Latitude:       -6.208763,
+			Longitude:      106.845599,
+			Road:           &road,
+		},
+		ReportReactions: &[]model.ReportReaction{{Type: model.Like}, {Type: model.Like}, {Type: model.Dislike}},
+		ReportVotes:     &[]model.ReportVote{{VoteType: model.RESOLVED}},
+	}
+}
+
+func TestFuzzCoordinates(t *testing.T) {
+	t.Run("should return the original coordinates when disabled", func(t *testing.T) {
+		lat, lng := FuzzCoordinates(-6.208763, 106.845599, 0)
+		assert.Equal(t, -6.208763, lat)
+		assert.Equal(t, 106.845599, lng)
+	})
+
+	t.Run("should snap nearby coordinates to the same cell", func(t *testing.T) {
+		lat1, lng1 := FuzzCoordinates(-6.208763, 106.845599, 500)
+		lat2, lng2 := FuzzCoordinates(-6.208770, 106.845610, 500)
+		assert.Equal(t, lat1, lat2)
+		assert.Equal(t, lng1, lng2)
+	})
+
+	t.Run("should stay within the configured radius", func(t *testing.T) {
+		lat, lng := FuzzCoordinates(-6.208763, 106.845599, 500)
+		assert.LessOrEqual(t, math.Abs(lat+6.208763)*metersPerDegree, 500.0)
+		assert.LessOrEqual(t, math.Abs(lng-106.845599)*metersPerDegree*math.Cos(lat*math.Pi/180), 500.0)
+	})
+}
+
+func TestToPublicReport(t *testing.T) {
+	t.Run("should expose the reporter without personal data", func(t *testing.T) {
+		publicReport := ToPublicReport(newPublicTestReport(false), 0)
+
+		require.NotNil(t, publicReport.Reporter)
+		assert.Equal(t, "budi", publicReport.Reporter.Username)
+		assert.Equal(t, int64(2), publicReport.TotalLikeReactions)
+		assert.Equal(t, int64(1), publicReport.TotalDislikeReactions)
+		assert.Equal(t, int64(1), publicReport.TotalResolvedVotes)
+		require.NotNil(t, publicReport.Location.DetailLocation)
+		assert.False(t, publicReport.Location.IsApproximate)
+
+		body, err := json.Marshal(publicReport)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), "budi@example.com")
+	})
+
+	t.Run("should hide the reporter of anonymous reports", func(t *testing.T) {
+		publicReport := ToPublicReport(newPublicTestReport(true), 0)
+		assert.Nil(t, publicReport.Reporter)
+		assert.True(t, publicReport.IsAnonymous)
+	})
+
+	t.Run("should hide exact location details when fuzzing", func(t *testing.T) {
+		publicReport := ToPublicReport(newPublicTestReport(false), 500)
+
+		assert.True(t, publicReport.Location.IsApproximate)
+		assert.Nil(t, publicReport.Location.DetailLocation)
+		assert.Nil(t, publicReport.Location.Road)
+		assert.NotEqual(t, -6.208763, publicReport.Location.Latitude)
+	})
+}
@@ -0,0 +1,51 @@
+package dto
+
+import "encoding/json"
+
+type Event struct {
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt int64           `json:"createdAt"`
+}
+
+type ReadyEventData struct {
+	UserID      uint  `json:"userID"`
+	ReportID    *uint `json:"reportID"`
+	UnreadCount int64 `json:"unreadCount"`
+}
+
+type UnreadCountEventData struct {
+	UnreadCount int64 `json:"unreadCount"`
+}
+
+type CommentEventData struct {
+	CommentID       string  `json:"commentID"`
+	ReportID        uint    `json:"reportID"`
+	UserID          *uint   `json:"userID"`
+	Username        string  `json:"username"`
+	ParentCommentID *string `json:"parentCommentID"`
+	ThreadRootID    *string `json:"threadRootID"`
+	Content         *string `json:"content"`
+	CreatedAt       int64   `json:"createdAt"`
+}
+
+type ReportVoteEventData struct {
+	ReportID             uint    `json:"reportID"`
+	ReportStatus         string  `json:"reportStatus"`
+	ResolvedVoteCount    int64   `json:"resolvedVoteCount"`
+	OnProgressVoteCount  int64   `json:"onProgressVoteCount"`
+	ResolvedVoteWeight   float64 `json:"resolvedVoteWeight"`
+	OnProgressVoteWeight float64 `json:"onProgressVoteWeight"`
+}
+
+type ReportStatusEventData struct {
+	ReportID       uint   `json:"reportID"`
+	PreviousStatus string `json:"previousStatus"`
+	ReportStatus   string `json:"reportStatus"`
+	UpdatedBy      string `json:"updatedBy"`
+	UpdatedAt      int64  `json:"updatedAt"`
+}
+
+type SessionRevokedEventData struct {
+	SessionIDs []uint `json:"sessionIDs"`
+}
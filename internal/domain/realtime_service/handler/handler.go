@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"pingspot/internal/domain/realtime_service/dto"
+	"pingspot/internal/domain/realtime_service/service"
+	"pingspot/internal/domain/realtime_service/util"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	response "pingspot/pkg/utils/response_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type RealtimeHandler struct {
+	realtimeService *service.RealtimeService
+}
+
+func NewRealtimeHandler(realtimeService *service.RealtimeService) *RealtimeHandler {
+	return &RealtimeHandler{realtimeService: realtimeService}
+}
+
+func (h *RealtimeHandler) StreamHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+	sessionIDFloat, ok := claims["session_id"].(float64)
+	if !ok {
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Session ID tidak ditemukan pada token")
+	}
+	sessionID := uint(sessionIDFloat)
+
+	var reportID *uint
+	if rawReportID := c.Query("reportID"); rawReportID != "" {
+		parsedReportID, err := mainutils.StringToUint(rawReportID)
+		if err != nil || parsedReportID == 0 {
+			return response.ResponseError(c, 400, "Format reportID tidak valid", "", "reportID harus berupa angka")
+		}
+		if err := h.realtimeService.EnsureReportExists(ctx, parsedReportID); err != nil {
+			if appErr, ok := err.(*apperror.AppError); ok {
+				return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+			}
+			return response.ResponseError(c, 500, "Gagal membuka koneksi realtime", "", err.Error())
+		}
+		reportID = &parsedReportID
+	}
+
+	unreadCount, err := h.realtimeService.GetUnreadCount(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get unread notification count", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal membuka koneksi realtime", "", err.Error())
+	}
+
+	pubsub, err := h.realtimeService.Subscribe(context.Background(), userID, reportID)
+	if err != nil {
+		logger.Error("Failed to subscribe to realtime channels", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal membuka koneksi realtime", "", err.Error())
+	}
+
+	readyEvent, err := util.BuildEvent(util.EventReady, dto.ReadyEventData{
+		UserID:      userID,
+		ReportID:    reportID,
+		UnreadCount: unreadCount,
+	}, time.Now().Unix())
+	if err != nil {
+		pubsub.Close()
+		return response.ResponseError(c, 500, "Gagal membuka koneksi realtime", "", err.Error())
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer pubsub.Close()
+		logger.Info("Realtime stream opened", zap.Uint("user_id", userID))
+
+		heartbeat := time.NewTicker(util.HeartbeatInterval)
+		defer heartbeat.Stop()
+		deadline := time.NewTimer(util.MaxStreamDuration)
+		defer deadline.Stop()
+
+		h.touchPresence(userID)
+		w.Write(util.FormatSSERetry())
+		w.Write(util.FormatSSE(*readyEvent))
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event dto.Event
+				if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+					logger.Warn("Skipping malformed realtime event", zap.String("channel", message.Channel), zap.Error(err))
+					continue
+				}
+				if event.Type == string(model.RealtimeSessionRevoked) {
+					if !util.IsSessionRevoked(event, sessionID) {
+						continue
+					}
+					w.Write(util.FormatSSE(event))
+					w.Flush()
+					logger.Info("Realtime stream closed after session revocation", zap.Uint("user_id", userID), zap.Uint("session_id", sessionID))
+					return
+				}
+				w.Write(util.FormatSSE(event))
+			case <-heartbeat.C:
+				if !h.isSessionActive(userID, sessionID) {
+					logger.Info("Realtime stream closed after session revocation", zap.Uint("user_id", userID), zap.Uint("session_id", sessionID))
+					return
+				}
+				h.touchPresence(userID)
+				w.Write(util.FormatSSEHeartbeat())
+			case <-deadline.C:
+				return
+			}
+			if err := w.Flush(); err != nil {
+				logger.Info("Realtime stream closed", zap.Uint("user_id", userID))
+				return
+			}
+		}
+	})
+	return nil
+}
+
+func (h *RealtimeHandler) touchPresence(userID uint) {
+	if err := h.realtimeService.TouchPresence(context.Background(), userID); err != nil {
+		logger.Warn("Failed to record realtime presence", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+func (h *RealtimeHandler) isSessionActive(userID, sessionID uint) bool {
+	active, err := h.realtimeService.IsSessionActive(context.Background(), userID, sessionID)
+	if err != nil {
+		logger.Warn("Failed to check realtime session", zap.Uint("session_id", sessionID), zap.Error(err))
+		return true
+	}
+	return active
+}
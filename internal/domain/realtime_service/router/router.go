@@ -0,0 +1,39 @@
+package router
+
+import (
+	notificationRepository "pingspot/internal/domain/notification_service/repository"
+	presenceRepository "pingspot/internal/domain/presence_service/repository"
+	"pingspot/internal/domain/realtime_service/handler"
+	"pingspot/internal/domain/realtime_service/service"
+	reportRepository "pingspot/internal/domain/report_service/repository"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/cache"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterRealtimeRoutes(app *fiber.App) {
+	db := database.GetPostgresDB()
+	notificationRepo := notificationRepository.NewNotificationRepository(db)
+
+	reportRepo := reportRepository.NewReportRepository(db)
+	userSessionRepo := userRepository.NewUserSessionRepository(db)
+
+	presenceRepo := presenceRepository.NewPresenceRepository(cache.GetRedis())
+
+	realtimeService := service.NewRealtimeService(cache.GetRedis(), notificationRepo, presenceRepo, reportRepo, userSessionRepo)
+	realtimeHandler := handler.NewRealtimeHandler(realtimeService)
+
+	realtimeRoute := app.Group("/pingspot/api/realtime", middleware.ValidateAccessToken())
+	realtimeRoute.Get("/stream",
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix:   "realtime_stream",
+		})),
+		realtimeHandler.StreamHandler,
+	)
+}
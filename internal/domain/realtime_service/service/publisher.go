@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pingspot/internal/domain/realtime_service/util"
+	"pingspot/internal/model"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, scope model.RealtimeScope, scopeID uint, eventType model.RealtimeEventType, data any) error
+}
+
+type redisPublisher struct {
+	rdb redis.UniversalClient
+}
+
+func NewPublisher(rdb redis.UniversalClient) Publisher {
+	return &redisPublisher{rdb: rdb}
+}
+
+func (p *redisPublisher) Publish(ctx context.Context, scope model.RealtimeScope, scopeID uint, eventType model.RealtimeEventType, data any) error {
+	event, err := util.BuildEvent(string(eventType), data, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal realtime event: %w", err)
+	}
+	if err := p.rdb.Publish(ctx, util.GetChannel(scope, scopeID), body).Err(); err != nil {
+		return fmt.Errorf("failed to publish realtime event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	notificationRepository "pingspot/internal/domain/notification_service/repository"
+	presenceRepository "pingspot/internal/domain/presence_service/repository"
+	"pingspot/internal/domain/realtime_service/util"
+	reportRepository "pingspot/internal/domain/report_service/repository"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	apperror "pingspot/pkg/app_error"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type RealtimeService struct {
+	rdb              redis.UniversalClient
+	notificationRepo notificationRepository.NotificationRepository
+	presenceRepo     presenceRepository.PresenceRepository
+	reportRepo       reportRepository.ReportRepository
+	userSessionRepo  userRepository.UserSessionRepository
+}
+
+func NewRealtimeService(rdb redis.UniversalClient, notificationRepo notificationRepository.NotificationRepository, presenceRepo presenceRepository.PresenceRepository, reportRepo reportRepository.ReportRepository, userSessionRepo userRepository.UserSessionRepository) *RealtimeService {
+	return &RealtimeService{
+		rdb:              rdb,
+		notificationRepo: notificationRepo,
+		presenceRepo:     presenceRepo,
+		reportRepo:       reportRepo,
+		userSessionRepo:  userSessionRepo,
+	}
+}
+
+func (s *RealtimeService) TouchPresence(ctx context.Context, userID uint) error {
+	return s.presenceRepo.Touch(ctx, userID, time.Now().Unix())
+}
+
+func (s *RealtimeService) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	unreadCount, err := s.notificationRepo.CountUnreadByUserID(ctx, userID)
+	if err != nil {
+		return 0, apperror.New(500, "NOTIFICATION_COUNT_FAILED", "gagal menghitung notifikasi yang belum dibaca", err.Error(), nil)
+	}
+	return unreadCount, nil
+}
+
+func (s *RealtimeService) EnsureReportExists(ctx context.Context, reportID uint) error {
+	exists, err := s.reportRepo.Exists(ctx, reportID)
+	if err != nil {
+		return apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
+	}
+	if !exists {
+		return apperror.New(404, "REPORT_NOT_FOUND", "Laporan tidak ditemukan", "", nil)
+	}
+	return nil
+}
+
+// IsSessionActive mirrors the access token middleware: the Redis session key is
+// checked first and the session row is the fallback when the key is missing.
+// An error means the state could not be read and the caller should not treat
+// the session as revoked.
+func (s *RealtimeService) IsSessionActive(ctx context.Context, userID, sessionID uint) (bool, error) {
+	storedUserID, err := s.rdb.Get(ctx, fmt.Sprintf("session:%d", sessionID)).Result()
+	if err == nil {
+		return storedUserID == fmt.Sprintf("%d", userID), nil
+	}
+
+	userSession, err := s.userSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return userSession.IsActive && userSession.UserID == userID && userSession.ExpiresAt >= time.Now().Unix(), nil
+}
+
+// Subscribe opens a Redis subscription for the user's channel and, when given,
+// the channel of the report being viewed. The caller must close it.
+func (s *RealtimeService) Subscribe(ctx context.Context, userID uint, reportID *uint) (*redis.PubSub, error) {
+	pubsub := s.rdb.Subscribe(ctx, util.GetSubscriptionChannels(userID, reportID)...)
+
+	receiveCtx, cancel := context.WithTimeout(ctx, util.SubscribeTimeout)
+	defer cancel()
+	if _, err := pubsub.Receive(receiveCtx); err != nil {
+		pubsub.Close()
+		return nil, apperror.New(503, "REALTIME_UNAVAILABLE", "layanan realtime sedang tidak tersedia", err.Error(), nil)
+	}
+	return pubsub, nil
+}
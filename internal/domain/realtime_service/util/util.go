@@ -0,0 +1,76 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"pingspot/internal/domain/realtime_service/dto"
+	"pingspot/internal/model"
+	"time"
+)
+
+const (
+	ChannelPrefix     = "realtime"
+	EventReady        = "ready"
+	HeartbeatInterval = 25 * time.Second
+	MaxStreamDuration = 30 * time.Minute
+	ReconnectDelayMs  = 3000
+	SubscribeTimeout  = 5 * time.Second
+)
+
+func GetChannel(scope model.RealtimeScope, scopeID uint) string {
+	return fmt.Sprintf("%s:%s:%d", ChannelPrefix, scope, scopeID)
+}
+
+func GetSubscriptionChannels(userID uint, reportID *uint) []string {
+	channels := []string{GetChannel(model.RealtimeScopeUser, userID)}
+	if reportID != nil {
+		channels = append(channels, GetChannel(model.RealtimeScopeReport, *reportID))
+	}
+	return channels
+}
+
+func BuildEvent(eventType string, data any, createdAt int64) (*dto.Event, error) {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal realtime event data: %w", err)
+	}
+	return &dto.Event{
+		Type:      eventType,
+		Data:      rawData,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+func FormatSSE(event dto.Event) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("event: ")
+	buf.WriteString(event.Type)
+	buf.WriteString("\ndata: ")
+	body, _ := json.Marshal(event)
+	buf.Write(body)
+	buf.WriteString("\n\n")
+	return buf.Bytes()
+}
+
+func FormatSSERetry() []byte {
+	return []byte(fmt.Sprintf("retry: %d\n\n", ReconnectDelayMs))
+}
+
+func FormatSSEHeartbeat() []byte {
+	return []byte(": ping\n\n")
+}
+
+// IsSessionRevoked reports whether a session.revoked event names sessionID.
+func IsSessionRevoked(event dto.Event, sessionID uint) bool {
+	var data dto.SessionRevokedEventData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return false
+	}
+	for _, revokedID := range data.SessionIDs {
+		if revokedID == sessionID {
+			return true
+		}
+	}
+	return false
+}
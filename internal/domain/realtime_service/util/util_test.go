@@ -0,0 +1,60 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"pingspot/internal/domain/realtime_service/dto"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChannel(t *testing.T) {
+	assert.Equal(t, "realtime:user:7", GetChannel(model.RealtimeScopeUser, 7))
+	assert.Equal(t, "realtime:report:12", GetChannel(model.RealtimeScopeReport, 12))
+}
+
+func TestGetSubscriptionChannels(t *testing.T) {
+	assert.Equal(t, []string{"realtime:user:7"}, GetSubscriptionChannels(7, nil))
+
+	reportID := uint(12)
+	assert.Equal(t, []string{"realtime:user:7", "realtime:report:12"}, GetSubscriptionChannels(7, &reportID))
+}
+
+func TestBuildEvent(t *testing.T) {
+	event, err := BuildEvent(string(model.RealtimeUnreadCount), dto.UnreadCountEventData{UnreadCount: 3}, 1700000000)
+	require.NoError(t, err)
+
+	assert.Equal(t, "notification.unread_count", event.Type)
+	assert.JSONEq(t, `{"unreadCount":3}`, string(event.Data))
+	assert.Equal(t, int64(1700000000), event.CreatedAt)
+
+	rawEvent, err := BuildEvent(string(model.RealtimeCommentCreated), json.RawMessage(`{"commentID":"abc"}`), 1700000000)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"commentID":"abc"}`, string(rawEvent.Data))
+}
+
+func TestFormatSSE(t *testing.T) {
+	event := dto.Event{
+		Type:      "report.vote_changed",
+		Data:      json.RawMessage(`{"reportID":12}`),
+		CreatedAt: 1700000000,
+	}
+
+	assert.Equal(t,
+		"event: report.vote_changed\ndata: {\"type\":\"report.vote_changed\",\"data\":{\"reportID\":12},\"createdAt\":1700000000}\n\n",
+		string(FormatSSE(event)),
+	)
+	assert.Equal(t, "retry: 3000\n\n", string(FormatSSERetry()))
+	assert.Equal(t, ": ping\n\n", string(FormatSSEHeartbeat()))
+}
+
+func TestIsSessionRevoked(t *testing.T) {
+	event, err := BuildEvent(string(model.RealtimeSessionRevoked), dto.SessionRevokedEventData{SessionIDs: []uint{3, 5}}, 1700000000)
+	require.NoError(t, err)
+
+	assert.True(t, IsSessionRevoked(*event, 5))
+	assert.False(t, IsSessionRevoked(*event, 4))
+}
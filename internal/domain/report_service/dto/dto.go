@@ -31,6 +31,7 @@ type Report struct {
 	ReportCreatedAt            int64                       `json:"reportCreatedAt"`
 	ReportStatus               string                      `json:"reportStatus"`
 	HasProgress                *bool                       `json:"hasProgress"`
+	IsAnonymous                bool                        `json:"isAnonymous"`
 	UserID                     uint                        `json:"userID"`
 	UserName                   string                      `json:"userName"`
 	FullName                   string                      `json:"fullName"`
@@ -56,6 +57,24 @@ type Report struct {
 	LastUpdatedBy              *string                     `json:"lastUpdatedBy,omitempty"`
 	LastUpdatedProgressAt      *int64                      `json:"lastUpdatedProgressAt,omitempty"`
 	ReportUpdatedAt            int64                       `json:"reportUpdatedAt"`
+	PriorityScore              float64                     `json:"priorityScore"`
+	HotScore                   float64                     `json:"hotScore"`
+	ViewCount                  int64                       `json:"viewCount"`
+	WeightedResolvedVotes      float64                     `json:"weightedResolvedVotes"`
+	WeightedOnProgressVotes    float64                     `json:"weightedOnProgressVotes"`
+}
+
+type FeedCandidate struct {
+	ReportID  uint
+	HotScore  float64
+	CreatedAt int64
+}
+
+type ReportCluster struct {
+	ReportType string  `json:"reportType"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Total      int64   `json:"total"`
 }
 
 type Distance struct {
@@ -102,6 +121,7 @@ type Comment struct {
 	ThreadRootID    *string               `json:"threadRootID,omitempty"`
 	ParentCommentID *string               `json:"parentCommentID,omitempty"`
 	TotalReplies    int64                 `json:"totalReplies"`
+	IsHelpful       bool                  `json:"isHelpful"`
 	CreatedAt       int64                 `json:"createdAt"`
 	UpdatedAt       *int64                `json:"updatedAt,omitempty"`
 }
@@ -116,6 +136,34 @@ type CommentReply struct {
 	ReplyTo         *userDTO.UserProfile  `json:"replyTo,omitempty"`
 	ThreadRootID    *string               `json:"threadRootID"`
 	ParentCommentID *string               `json:"parentCommentID"`
+	IsHelpful       bool                  `json:"isHelpful"`
 	CreatedAt       int64                 `json:"createdAt"`
 	UpdatedAt       *int64                `json:"updatedAt,omitempty"`
-}
\ No newline at end of file
+}
+type ReportDraft struct {
+	ID                uint     `json:"id"`
+	ReportTitle       *string  `json:"reportTitle"`
+	ReportType        *string  `json:"reportType"`
+	ReportDescription *string  `json:"reportDescription"`
+	DetailLocation    *string  `json:"detailLocation"`
+	HasProgress       *bool    `json:"hasProgress"`
+	IsAnonymous       *bool    `json:"isAnonymous"`
+	MapZoom           *int     `json:"mapZoom"`
+	Latitude          *float64 `json:"latitude"`
+	Longitude         *float64 `json:"longitude"`
+	DisplayName       *string  `json:"displayName"`
+	AddressType       *string  `json:"addressType"`
+	Country           *string  `json:"country"`
+	CountryCode       *string  `json:"countryCode"`
+	Region            *string  `json:"region"`
+	PostCode          *string  `json:"postCode"`
+	County            *string  `json:"county"`
+	State             *string  `json:"state"`
+	Road              *string  `json:"road"`
+	Village           *string  `json:"village"`
+	Suburb            *string  `json:"suburb"`
+	Images            []string `json:"images"`
+	ExpiresAt         int64    `json:"expiresAt"`
+	CreatedAt         int64    `json:"createdAt"`
+	UpdatedAt         int64    `json:"updatedAt"`
+}
@@ -6,6 +6,7 @@ type CreateReportRequest struct {
 	ReportDescription string  `json:"reportDescription" validate:"required"`
 	DetailLocation    string  `json:"detailLocation" validate:"required"`
 	HasProgress       *bool   `json:"hasProgress" validate:"omitempty"`
+	IsAnonymous       bool    `json:"isAnonymous"`
 	MapZoom           *int    `json:"mapZoom" validate:"omitempty,min=0,max=21"`
 	Latitude          float64 `json:"latitude" validate:"required"`
 	Longitude         float64 `json:"longitude" validate:"required"`
@@ -59,7 +60,13 @@ type ReactionReportRequest struct {
 }
 
 type VoteReportRequest struct {
-	VoteType string `json:"voteType" validate:"required,oneof=RESOLVED ON_PROGRESS NOT_RESOLVED"`
+	VoteType  string   `json:"voteType" validate:"required,oneof=RESOLVED ON_PROGRESS NOT_RESOLVED"`
+	Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+}
+
+type MergeReportsRequest struct {
+	DuplicateReportIDs []uint `json:"duplicateReportIDs" validate:"required,min=1,max=10,dive,required"`
 }
 
 type UploadProgressReportRequest struct {
@@ -78,4 +85,28 @@ type CreateReportCommentRequest struct {
 	Mentions        []uint  `json:"mentions" validate:"omitempty,dive,gt=0"`
 	ThreadRootID    *string `json:"threadRootID" validate:"omitempty,len=24"`
 	ParentCommentID *string `json:"parentCommentID" validate:"omitempty,len=24"`
-}
\ No newline at end of file
+}
+type SaveReportDraftRequest struct {
+	ReportTitle       *string  `json:"reportTitle"`
+	ReportType        *string  `json:"reportType"`
+	ReportDescription *string  `json:"reportDescription"`
+	DetailLocation    *string  `json:"detailLocation"`
+	HasProgress       *bool    `json:"hasProgress"`
+	IsAnonymous       *bool    `json:"isAnonymous"`
+	MapZoom           *int     `json:"mapZoom"`
+	Latitude          *float64 `json:"latitude"`
+	Longitude         *float64 `json:"longitude"`
+	DisplayName       *string  `json:"displayName"`
+	AddressType       *string  `json:"addressType"`
+	Country           *string  `json:"country"`
+	CountryCode       *string  `json:"countryCode"`
+	Region            *string  `json:"region"`
+	PostCode          *string  `json:"postCode"`
+	County            *string  `json:"county"`
+	State             *string  `json:"state"`
+	Road              *string  `json:"road"`
+	Village           *string  `json:"village"`
+	Suburb            *string  `json:"suburb"`
+	ExistingImages    []string `json:"existingImages"`
+	NewImages         []string `json:"-"`
+}
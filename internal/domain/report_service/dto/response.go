@@ -23,7 +23,8 @@ type GetReportsResponse struct {
 }
 
 type GetReportResponse struct {
-	Report Report `json:"report"`
+	Report           Report `json:"report"`
+	RedirectedFromID *uint  `json:"redirectedFromID,omitempty"`
 }
 
 type ReactReportResponse struct {
@@ -50,12 +51,21 @@ type GetVoteReportResponse struct {
 	ReportStatus          model.ReportStatus `json:"reportStatus"`
 	UserID                uint               `json:"userID"`
 	VoteType              model.ReportStatus `json:"voteType"`
+	Weight                float64            `json:"weight"`
 	CreatedAt             int64              `json:"createdAt"`
 	UpdatedAt             int64              `json:"updatedAt"`
 	LastUpdatedBy         *string            `json:"lastUpdatedBy,omitempty"`
 	LastUpdatedProgressAt *int64             `json:"lastUpdatedProgressAt,omitempty"`
 }
 
+type MergeReportsResponse struct {
+	CanonicalReportID uint               `json:"canonicalReportID"`
+	MergedReportIDs   []uint             `json:"mergedReportIDs"`
+	MovedVotes        int64              `json:"movedVotes"`
+	MovedReactions    int64              `json:"movedReactions"`
+	ReportStatus      model.ReportStatus `json:"reportStatus"`
+}
+
 type GetProgressReportResponse struct {
 	ID          uint    `json:"id"`
 	ReportID    uint    `json:"reportID"`
@@ -96,3 +106,19 @@ type GetReportStatisticsResponse struct {
 	ReportsByStatus     map[string]int64 `json:"reportsByStatus"`
 	MonthlyReportCounts map[string]int64 `json:"monthlyReportCounts"`
 }
+
+type MarkCommentHelpfulResponse struct {
+	CommentID string `json:"commentID"`
+	ReportID  uint   `json:"reportID"`
+	UserID    uint   `json:"userID"`
+	IsHelpful bool   `json:"isHelpful"`
+}
+
+type SaveReportDraftResponse struct {
+	Draft         ReportDraft `json:"draft"`
+	RemovedImages []string    `json:"-"`
+}
+
+type GetReportDraftsResponse struct {
+	Drafts []ReportDraft `json:"drafts"`
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"pingspot/internal/domain/report_service/dto"
 	"pingspot/internal/domain/report_service/service"
+	reportUtil "pingspot/internal/domain/report_service/util"
 	"pingspot/internal/domain/report_service/validation"
 	apperror "pingspot/pkg/app_error"
 	"pingspot/pkg/logger"
@@ -31,158 +32,27 @@ func NewReportHandler(reportService *service.ReportService) *ReportHandler {
 
 func (h *ReportHandler) CreateReportHandler(c *fiber.Ctx) error {
 	ctx := c.UserContext()
-	form, err := c.MultipartForm()
-	if err != nil {
-		logger.Error("Failed to parse multipart form", zap.Error(err))
-		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
-	}
-
-	reportTitle := c.FormValue("reportTitle")
-	reportDescription := c.FormValue("reportDescription")
-	reportType := c.FormValue("reportType")
-	detailLocation := c.FormValue("detailLocation")
-	hasProgressStr := c.FormValue("hasProgress")
-	latitude := c.FormValue("latitude")
-	longitude := c.FormValue("longitude")
-	displayName := c.FormValue("displayName")
-	addressType := c.FormValue("addressType")
-	road := c.FormValue("road")
-	state := c.FormValue("state")
-	country := c.FormValue("country")
-	postCode := c.FormValue("postCode")
-	mapZoom := c.FormValue("mapZoom")
-	region := c.FormValue("region")
-	countryCode := c.FormValue("countryCode")
-	county := c.FormValue("county")
-	village := c.FormValue("village")
-	suburb := c.FormValue("suburb")
-	totalImageSize := int64(0)
-	var images map[int]string = make(map[int]string)
-
-	mapZoomInt, err := mainutils.StringToInt(mapZoom)
-	if err != nil && mapZoom != "" {
-		logger.Error("Invalid mapZoom format", zap.String("mapZoom", mapZoom), zap.Error(err))
-	}
-
-	files := form.File["reportImages"]
-	if len(files) > 5 {
-		logger.Error("Too many report images", zap.Int("count", len(files)))
-		return response.ResponseError(c, 400, "Terlalu banyak gambar", "", "Maksimal 5 gambar")
-	}
-	const maxFileSize = 2 * 1024 * 1024
-	const maxTotalSize = 10 * 1024 * 1024
-
-	for _, file := range files {
-		if file.Size > maxFileSize {
-			logger.Error("Report image file size too large",
-				zap.Int64("size", file.Size),
-			)
-			return response.ResponseError(
-				c,
-				400,
-				"Ukuran salah satu gambar terlalu besar",
-				"",
-				fmt.Sprintf("Maksimal ukuran gambar %dMB per gambar", maxFileSize/(1024*1024)),
-			)
-		}
-		totalImageSize += file.Size
-	}
-
-	if totalImageSize > maxTotalSize {
-		logger.Error("Total report images size too large",
-			zap.Int64("total_size", totalImageSize),
-		)
-		return response.ResponseError(
-			c,
-			400,
-			"Total ukuran semua gambar terlalu besar",
-			"",
-			fmt.Sprintf("Maksimal total ukuran gambar %dMB", maxTotalSize/(1024*1024)),
-		)
+	formReq, files, appErr := parseReportForm(c)
+	if appErr != nil {
+		return response.ResponseError(c, appErr.StatusCode, appErr.Message, "", appErr.Details)
 	}
 
-	validExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".webp": true,
-	}
-
-	validMimeTypes := map[string]bool{
-		"image/jpeg": true,
-		"image/jpg":  true,
-		"image/png":  true,
-		"image/webp": true,
-	}
-
-	for i, file := range files {
-		ext := strings.ToLower(filepath.Ext(file.Filename))
-		if !validExtensions[ext] {
-			logger.Error("Unsupported image extension", zap.String("extension", ext))
-			return response.ResponseError(c, 400, "Format file tidak didukung", "", "Gunakan JPG atau PNG")
-		}
-
-		contentType := file.Header.Get("Content-Type")
-		if !validMimeTypes[contentType] {
-			logger.Error("Invalid content type", zap.String("mime", contentType))
-			return response.ResponseError(c, 400, "Format file tidak didukung", "", "Gunakan JPG atau PNG")
-		}
-
-		fileName := fmt.Sprintf("%d%s", time.Now().UnixNano(), ext)
-		images[i] = fileName
-		savePath := filepath.Join("uploads/main/report", fileName)
-		if err := c.SaveFile(file, savePath); err != nil {
-			for j := range i {
-				os.Remove(filepath.Join("uploads/main/report", images[j]))
-			}
-			logger.Error("Failed to save image", zap.Error(err))
-			return response.ResponseError(c, 500, "Gagal menyimpan gambar", "", err.Error())
-		}
-	}
-
-	floatLatitude, err := mainutils.StringToFloat64(latitude)
-	if err != nil {
-		logger.Error("Invalid latitude format", zap.String("latitude", latitude), zap.Error(err))
+	if formReq.Latitude == nil {
+		logger.Error("Missing latitude")
 		return response.ResponseError(c, 400, "Format latitude tidak valid", "", "Latitude harus berupa angka desimal")
 	}
-
-	floatLongitude, err := mainutils.StringToFloat64(longitude)
-	if err != nil {
-		logger.Error("Invalid longitude format", zap.String("longitude", longitude), zap.Error(err))
+	if formReq.Longitude == nil {
+		logger.Error("Missing longitude")
 		return response.ResponseError(c, 400, "Format longitude tidak valid", "", "Longitude harus berupa angka desimal")
 	}
 
-	hasProgress, err := mainutils.StringToBool(hasProgressStr)
-	if err != nil && hasProgressStr != "" {
-		logger.Error("Invalid hasProgress format", zap.String("hasProgress", hasProgressStr), zap.Error(err))
+	images, err := saveReportImages(c, reportUtil.ReportImageDir, files)
+	if err != nil {
+		logger.Error("Failed to save image", zap.Error(err))
+		return response.ResponseError(c, 500, "Gagal menyimpan gambar", "", err.Error())
 	}
 
-	req := dto.CreateReportRequest{
-		ReportTitle:       reportTitle,
-		ReportType:        reportType,
-		ReportDescription: reportDescription,
-		DetailLocation:    detailLocation,
-		HasProgress:       hasProgress,
-		Latitude:          floatLatitude,
-		Longitude:         floatLongitude,
-		DisplayName:       mainutils.StrPtrOrNil(displayName),
-		AddressType:       mainutils.StrPtrOrNil(addressType),
-		Country:           mainutils.StrPtrOrNil(country),
-		CountryCode:       mainutils.StrPtrOrNil(countryCode),
-		MapZoom:           &mapZoomInt,
-		Region:            mainutils.StrPtrOrNil(region),
-		PostCode:          mainutils.StrPtrOrNil(postCode),
-		County:            mainutils.StrPtrOrNil(county),
-		State:             mainutils.StrPtrOrNil(state),
-		Road:              mainutils.StrPtrOrNil(road),
-		Village:           mainutils.StrPtrOrNil(village),
-		Suburb:            mainutils.StrPtrOrNil(suburb),
-		Image1URL:         mainutils.StrPtrOrNil(images[0]),
-		Image2URL:         mainutils.StrPtrOrNil(images[1]),
-		Image3URL:         mainutils.StrPtrOrNil(images[2]),
-		Image4URL:         mainutils.StrPtrOrNil(images[3]),
-		Image5URL:         mainutils.StrPtrOrNil(images[4]),
-	}
+	req := reportUtil.ConvertReportDraftRequestToCreateRequest(*formReq, images)
 
 	if err := validation.Validate.Struct(req); err != nil {
 		errors := validation.FormatCreateReportValidationErrors(err)
@@ -199,9 +69,7 @@ func (h *ReportHandler) CreateReportHandler(c *fiber.Ctx) error {
 
 	result, err := h.reportService.CreateReport(ctx, userID, req)
 	if err != nil {
-		for i := range files {
-			os.Remove(filepath.Join("uploads/main/report", images[i]))
-		}
+		reportUtil.RemoveReportImages(images)
 		logger.Error("Failed to create report", zap.Error(err))
 		if appErr, ok := err.(*apperror.AppError); ok {
 			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
@@ -476,7 +344,7 @@ func (h *ReportHandler) GetReportHandler(c *fiber.Ctx) error {
 			return response.ResponseError(c, 400, "Format reportID tidak valid", "", "reportID harus berupa angka")
 		}
 
-		report, err := h.reportService.GetReportByID(ctx, userID, uintReportID)
+		report, err := h.reportService.ViewReportByID(ctx, userID, uintReportID)
 		if err != nil {
 			logger.Error("Failed to get report by ID", zap.Uint("reportID", uintReportID), zap.Error(err))
 			if appErr, ok := err.(*apperror.AppError); ok {
@@ -553,7 +421,7 @@ func (h *ReportHandler) VoteReportHandler(c *fiber.Ctx) error {
 		logger.Error("Validation failed", zap.Error(err))
 		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
 	}
-	vote, err := h.reportService.VoteToReport(ctx, userID, uintReportID, req.VoteType)
+	vote, err := h.reportService.VoteToReport(ctx, userID, uintReportID, req.VoteType, req.Latitude, req.Longitude)
 	if err != nil {
 		logger.Error("Failed to vote to report", zap.Uint("reportID", uintReportID), zap.Uint("userID", userID), zap.Error(err))
 		if appErr, ok := err.(*apperror.AppError); ok {
@@ -564,6 +432,42 @@ func (h *ReportHandler) VoteReportHandler(c *fiber.Ctx) error {
 	return response.ResponseSuccess(c, 200, "Vote laporan berhasil", "", vote)
 }
 
+func (h *ReportHandler) MergeReportsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	reportIDParam := c.Params("reportID")
+	uintReportID, err := mainutils.StringToUint(reportIDParam)
+	if err != nil {
+		logger.Error("Invalid reportID format", zap.String("reportID", reportIDParam), zap.Error(err))
+		return response.ResponseError(c, 400, "Format reportID tidak valid", "", "reportID harus berupa angka")
+	}
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	var req dto.MergeReportsRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatMergeReportsValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+	result, err := h.reportService.MergeReports(ctx, userID, uintReportID, req)
+	if err != nil {
+		logger.Error("Failed to merge reports", zap.Uint("reportID", uintReportID), zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menggabungkan laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Laporan berhasil digabungkan", "data", result)
+}
+
 func (h *ReportHandler) UploadProgressReportHandler(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	reportIDParam := c.Params("reportID")
@@ -910,3 +814,386 @@ func (h *ReportHandler) GetReportCommentRepliesHandler(c *fiber.Ctx) error {
 	}
 	return response.ResponseSuccess(c, 200, "Sukses mengambil balasan komentar laporan", "data", mappedData)
 }
+
+func (h *ReportHandler) MarkCommentHelpfulHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	commentIDParam := c.Params("commentID")
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	result, err := h.reportService.MarkCommentHelpful(ctx, userID, commentIDParam)
+	if err != nil {
+		logger.Error("Failed to mark comment as helpful", zap.String("commentID", commentIDParam), zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menandai komentar sebagai membantu", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Komentar berhasil ditandai sebagai membantu", "data", result)
+}
+
+func (h *ReportHandler) CreateReportDraftHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	req, files, appErr := parseReportForm(c)
+	if appErr != nil {
+		return response.ResponseError(c, appErr.StatusCode, appErr.Message, "", appErr.Details)
+	}
+
+	newImages, err := saveReportImages(c, reportUtil.ReportDraftImageDir, files)
+	if err != nil {
+		logger.Error("Failed to save draft image", zap.Error(err))
+		return response.ResponseError(c, 500, "Gagal menyimpan gambar", "", err.Error())
+	}
+	req.NewImages = newImages
+
+	result, err := h.reportService.CreateReportDraft(ctx, userID, *req)
+	if err != nil {
+		reportUtil.RemoveReportDraftImages(newImages)
+		logger.Error("Failed to create report draft", zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menyimpan draf laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Draf laporan berhasil disimpan", "data", result)
+}
+
+func (h *ReportHandler) UpdateReportDraftHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	draftIDParam := c.Params("draftID")
+	draftID, err := mainutils.StringToUint(draftIDParam)
+	if err != nil {
+		logger.Error("Invalid draftID format", zap.String("draftID", draftIDParam), zap.Error(err))
+		return response.ResponseError(c, 400, "Format draftID tidak valid", "", "draftID harus berupa angka")
+	}
+
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	req, files, appErr := parseReportForm(c)
+	if appErr != nil {
+		return response.ResponseError(c, appErr.StatusCode, appErr.Message, "", appErr.Details)
+	}
+
+	newImages, err := saveReportImages(c, reportUtil.ReportDraftImageDir, files)
+	if err != nil {
+		logger.Error("Failed to save draft image", zap.Error(err))
+		return response.ResponseError(c, 500, "Gagal menyimpan gambar", "", err.Error())
+	}
+	req.NewImages = newImages
+
+	result, err := h.reportService.UpdateReportDraft(ctx, userID, draftID, *req)
+	if err != nil {
+		reportUtil.RemoveReportDraftImages(newImages)
+		logger.Error("Failed to update report draft", zap.Uint("draftID", draftID), zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal memperbarui draf laporan", "", err.Error())
+	}
+	reportUtil.RemoveReportDraftImages(result.RemovedImages)
+	return response.ResponseSuccess(c, 200, "Draf laporan berhasil diperbarui", "data", result)
+}
+
+func (h *ReportHandler) GetReportDraftsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	result, err := h.reportService.GetReportDrafts(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get report drafts", zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mengambil draf laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Draf laporan berhasil diambil", "data", result)
+}
+
+func (h *ReportHandler) GetReportDraftHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	draftIDParam := c.Params("draftID")
+	draftID, err := mainutils.StringToUint(draftIDParam)
+	if err != nil {
+		logger.Error("Invalid draftID format", zap.String("draftID", draftIDParam), zap.Error(err))
+		return response.ResponseError(c, 400, "Format draftID tidak valid", "", "draftID harus berupa angka")
+	}
+
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	result, err := h.reportService.GetReportDraft(ctx, userID, draftID)
+	if err != nil {
+		logger.Error("Failed to get report draft", zap.Uint("draftID", draftID), zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mengambil draf laporan", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Draf laporan berhasil diambil", "data", result)
+}
+
+func (h *ReportHandler) GetReportDraftImageHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	draftIDParam := c.Params("draftID")
+	draftID, err := mainutils.StringToUint(draftIDParam)
+	if err != nil {
+		logger.Error("Invalid draftID format", zap.String("draftID", draftIDParam), zap.Error(err))
+		return response.ResponseError(c, 400, "Format draftID tidak valid", "", "draftID harus berupa angka")
+	}
+
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	imagePath, err := h.reportService.GetReportDraftImagePath(ctx, userID, draftID, c.Params("image"))
+	if err != nil {
+		logger.Error("Failed to get report draft image", zap.Uint("draftID", draftID), zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mengambil gambar draf laporan", "", err.Error())
+	}
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	return c.SendFile(imagePath)
+}
+
+func (h *ReportHandler) DeleteReportDraftHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	draftIDParam := c.Params("draftID")
+	draftID, err := mainutils.StringToUint(draftIDParam)
+	if err != nil {
+		logger.Error("Invalid draftID format", zap.String("draftID", draftIDParam), zap.Error(err))
+		return response.ResponseError(c, 400, "Format draftID tidak valid", "", "draftID harus berupa angka")
+	}
+
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	images, err := h.reportService.DeleteReportDraft(ctx, userID, draftID)
+	if err != nil {
+		logger.Error("Failed to delete report draft", zap.Uint("draftID", draftID), zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menghapus draf laporan", "", err.Error())
+	}
+	reportUtil.RemoveReportDraftImages(images)
+	return response.ResponseSuccess(c, 200, "Draf laporan berhasil dihapus", "data", nil)
+}
+
+func (h *ReportHandler) PublishReportDraftHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	draftIDParam := c.Params("draftID")
+	draftID, err := mainutils.StringToUint(draftIDParam)
+	if err != nil {
+		logger.Error("Invalid draftID format", zap.String("draftID", draftIDParam), zap.Error(err))
+		return response.ResponseError(c, 400, "Format draftID tidak valid", "", "draftID harus berupa angka")
+	}
+
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	result, err := h.reportService.PublishReportDraft(ctx, userID, draftID)
+	if err != nil {
+		logger.Error("Failed to publish report draft", zap.Uint("draftID", draftID), zap.Uint("userID", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			if appErr.ErrorData != nil {
+				return response.ResponseError(c, appErr.StatusCode, appErr.Message, "errors", appErr.ErrorData)
+			}
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mempublikasikan draf laporan", "", err.Error())
+	}
+
+	logger.Info("Report draft published successfully", zap.Uint("draft_id", draftID), zap.Uint("report_id", result.Report.ID))
+	return response.ResponseSuccess(c, 200, "Laporan berhasil dibuat", "data", result)
+}
+
+// parseReportForm reads and validates the multipart form shared by report
+// creation and drafts. Every field is optional here; CreateReportHandler and
+// the service enforce what a published report needs.
+func parseReportForm(c *fiber.Ctx) (*dto.SaveReportDraftRequest, []*multipart.FileHeader, *apperror.AppError) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		logger.Error("Failed to parse multipart form", zap.Error(err))
+		return nil, nil, apperror.New(400, "INVALID_REQUEST", "Format body request tidak valid", err.Error(), nil)
+	}
+
+	latitude := c.FormValue("latitude")
+	longitude := c.FormValue("longitude")
+	mapZoom := c.FormValue("mapZoom")
+	hasProgressStr := c.FormValue("hasProgress")
+	existingImagesSTR := c.FormValue("existingImages")
+
+	req := dto.SaveReportDraftRequest{
+		ReportTitle:       mainutils.StrPtrOrNil(c.FormValue("reportTitle")),
+		ReportType:        mainutils.StrPtrOrNil(c.FormValue("reportType")),
+		ReportDescription: mainutils.StrPtrOrNil(c.FormValue("reportDescription")),
+		DetailLocation:    mainutils.StrPtrOrNil(c.FormValue("detailLocation")),
+		DisplayName:       mainutils.StrPtrOrNil(c.FormValue("displayName")),
+		AddressType:       mainutils.StrPtrOrNil(c.FormValue("addressType")),
+		Country:           mainutils.StrPtrOrNil(c.FormValue("country")),
+		CountryCode:       mainutils.StrPtrOrNil(c.FormValue("countryCode")),
+		Region:            mainutils.StrPtrOrNil(c.FormValue("region")),
+		PostCode:          mainutils.StrPtrOrNil(c.FormValue("postCode")),
+		County:            mainutils.StrPtrOrNil(c.FormValue("county")),
+		State:             mainutils.StrPtrOrNil(c.FormValue("state")),
+		Road:              mainutils.StrPtrOrNil(c.FormValue("road")),
+		Village:           mainutils.StrPtrOrNil(c.FormValue("village")),
+		Suburb:            mainutils.StrPtrOrNil(c.FormValue("suburb")),
+	}
+
+	if latitude != "" {
+		floatLatitude, err := mainutils.StringToFloat64(latitude)
+		if err != nil {
+			logger.Error("Invalid latitude format", zap.String("latitude", latitude), zap.Error(err))
+			return nil, nil, apperror.New(400, "INVALID_LATITUDE", "Format latitude tidak valid", "Latitude harus berupa angka desimal", nil)
+		}
+		req.Latitude = &floatLatitude
+	}
+
+	if longitude != "" {
+		floatLongitude, err := mainutils.StringToFloat64(longitude)
+		if err != nil {
+			logger.Error("Invalid longitude format", zap.String("longitude", longitude), zap.Error(err))
+			return nil, nil, apperror.New(400, "INVALID_LONGITUDE", "Format longitude tidak valid", "Longitude harus berupa angka desimal", nil)
+		}
+		req.Longitude = &floatLongitude
+	}
+
+	if mapZoom != "" {
+		mapZoomInt, err := mainutils.StringToInt(mapZoom)
+		if err != nil {
+			logger.Error("Invalid mapZoom format", zap.String("mapZoom", mapZoom), zap.Error(err))
+		} else {
+			req.MapZoom = &mapZoomInt
+		}
+	}
+
+	hasProgress, err := mainutils.StringToBool(hasProgressStr)
+	if err != nil && hasProgressStr != "" {
+		logger.Error("Invalid hasProgress format", zap.String("hasProgress", hasProgressStr), zap.Error(err))
+	}
+	req.HasProgress = hasProgress
+
+	isAnonymousStr := c.FormValue("isAnonymous")
+	isAnonymous, err := mainutils.StringToBool(isAnonymousStr)
+	if err != nil {
+		logger.Error("Invalid isAnonymous format", zap.String("isAnonymous", isAnonymousStr), zap.Error(err))
+		return nil, nil, apperror.New(400, "INVALID_IS_ANONYMOUS", "Format isAnonymous tidak valid", "isAnonymous harus berupa boolean", nil)
+	}
+	req.IsAnonymous = isAnonymous
+
+	if existingImagesSTR != "" {
+		if err := json.Unmarshal([]byte(existingImagesSTR), &req.ExistingImages); err != nil {
+			logger.Error("Failed to unmarshal existingImages", zap.String("existingImages", existingImagesSTR), zap.Error(err))
+			return nil, nil, apperror.New(400, "INVALID_EXISTING_IMAGES", "Format existingImages tidak valid", "existingImages harus berupa array of string", nil)
+		}
+	}
+
+	files := form.File["reportImages"]
+	if len(files)+len(req.ExistingImages) > 5 {
+		logger.Error("Too many report images", zap.Int("count", len(files)+len(req.ExistingImages)))
+		return nil, nil, apperror.New(400, "TOO_MANY_IMAGES", "Terlalu banyak gambar", "Maksimal 5 gambar", nil)
+	}
+
+	const maxFileSize = 2 * 1024 * 1024
+	const maxTotalSize = 10 * 1024 * 1024
+
+	validExtensions := map[string]bool{
+		".jpg":  true,
+		".jpeg": true,
+		".png":  true,
+		".webp": true,
+	}
+
+	validMimeTypes := map[string]bool{
+		"image/jpeg": true,
+		"image/jpg":  true,
+		"image/png":  true,
+		"image/webp": true,
+	}
+
+	totalImageSize := int64(0)
+	for _, file := range files {
+		if file.Size > maxFileSize {
+			logger.Error("Report image file size too large", zap.Int64("size", file.Size))
+			return nil, nil, apperror.New(400, "IMAGE_TOO_LARGE", "Ukuran salah satu gambar terlalu besar", fmt.Sprintf("Maksimal ukuran gambar %dMB per gambar", maxFileSize/(1024*1024)), nil)
+		}
+		totalImageSize += file.Size
+
+		ext := strings.ToLower(filepath.Ext(file.Filename))
+		if !validExtensions[ext] {
+			logger.Error("Unsupported image extension", zap.String("extension", ext))
+			return nil, nil, apperror.New(400, "UNSUPPORTED_IMAGE_FORMAT", "Format file tidak didukung", "Gunakan JPG atau PNG", nil)
+		}
+
+		contentType := file.Header.Get("Content-Type")
+		if !validMimeTypes[contentType] {
+			logger.Error("Invalid content type", zap.String("mime", contentType))
+			return nil, nil, apperror.New(400, "UNSUPPORTED_IMAGE_FORMAT", "Format file tidak didukung", "Gunakan JPG atau PNG", nil)
+		}
+	}
+
+	if totalImageSize > maxTotalSize {
+		logger.Error("Total report images size too large", zap.Int64("total_size", totalImageSize))
+		return nil, nil, apperror.New(400, "IMAGES_TOO_LARGE", "Total ukuran semua gambar terlalu besar", fmt.Sprintf("Maksimal total ukuran gambar %dMB", maxTotalSize/(1024*1024)), nil)
+	}
+
+	return &req, files, nil
+}
+
+func saveReportImages(c *fiber.Ctx, dir string, files []*multipart.FileHeader) ([]string, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	images := make([]string, 0, len(files))
+	for i, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Filename))
+		fileName := fmt.Sprintf("%d%d%s", time.Now().UnixNano(), i, ext)
+		if err := c.SaveFile(file, filepath.Join(dir, fileName)); err != nil {
+			for _, image := range images {
+				os.Remove(filepath.Join(dir, image))
+			}
+			return nil, err
+		}
+		images = append(images, fileName)
+	}
+	return images, nil
+}
@@ -19,6 +19,8 @@ type ReportCommentRepository interface {
 	GetCountsByRootID(ctx context.Context, rootID primitive.ObjectID) (int64, error)
 	GetPaginatedRootByReportID(ctx context.Context, reportID uint, cursorID *primitive.ObjectID, limit int) ([]*model.ReportComment, error)
 	GetPaginatedRepliesByRootID(ctx context.Context, rootID primitive.ObjectID, cursorID *primitive.ObjectID, limit int) ([]*model.ReportComment, error)
+	MoveToReport(ctx context.Context, fromReportID, toReportID uint) (int64, error)
+	MarkHelpful(ctx context.Context, commentID primitive.ObjectID) error
 }
 
 type reportCommentRepository struct {
@@ -168,3 +170,22 @@ func (r *reportCommentRepository) GetPaginatedRepliesByRootID(ctx context.Contex
 	}
 	return comments, nil
 }
+
+func (r *reportCommentRepository) MoveToReport(ctx context.Context, fromReportID, toReportID uint) (int64, error) {
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"report_id": fromReportID},
+		bson.M{"$set": bson.M{"report_id": toReportID}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *reportCommentRepository) MarkHelpful(ctx context.Context, commentID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": commentID},
+		bson.M{"$set": bson.M{"is_helpful": true}},
+	)
+	return err
+}
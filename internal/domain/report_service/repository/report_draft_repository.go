@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type ReportDraftRepository interface {
+	Create(ctx context.Context, draft *model.ReportDraft) error
+	Update(ctx context.Context, draft *model.ReportDraft) (*model.ReportDraft, error)
+	GetActiveByIDAndUserID(ctx context.Context, draftID, userID uint, now int64) (*model.ReportDraft, error)
+	GetActiveByUserID(ctx context.Context, userID uint, now int64) ([]model.ReportDraft, error)
+	CountActiveByUserID(ctx context.Context, userID uint, now int64) (int64, error)
+	GetExpired(ctx context.Context, now int64) ([]model.ReportDraft, error)
+	Delete(ctx context.Context, draftID uint) error
+	DeleteTX(ctx context.Context, tx *gorm.DB, draftID uint) (bool, error)
+}
+
+type reportDraftRepository struct {
+	db *gorm.DB
+}
+
+func NewReportDraftRepository(db *gorm.DB) ReportDraftRepository {
+	return &reportDraftRepository{db: db}
+}
+
+func (r *reportDraftRepository) Create(ctx context.Context, draft *model.ReportDraft) error {
+	return r.db.WithContext(ctx).Create(draft).Error
+}
+
+func (r *reportDraftRepository) Update(ctx context.Context, draft *model.ReportDraft) (*model.ReportDraft, error) {
+	if err := r.db.WithContext(ctx).Save(draft).Error; err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (r *reportDraftRepository) GetActiveByIDAndUserID(ctx context.Context, draftID, userID uint, now int64) (*model.ReportDraft, error) {
+	var draft model.ReportDraft
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ? AND expires_at > ?", draftID, userID, now).
+		First(&draft).Error; err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (r *reportDraftRepository) GetActiveByUserID(ctx context.Context, userID uint, now int64) ([]model.ReportDraft, error) {
+	var drafts []model.ReportDraft
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Order("updated_at DESC").
+		Find(&drafts).Error; err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+func (r *reportDraftRepository) CountActiveByUserID(ctx context.Context, userID uint, now int64) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.ReportDraft{}).
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *reportDraftRepository) GetExpired(ctx context.Context, now int64) ([]model.ReportDraft, error) {
+	var drafts []model.ReportDraft
+	if err := r.db.WithContext(ctx).Where("expires_at <= ?", now).Find(&drafts).Error; err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+func (r *reportDraftRepository) Delete(ctx context.Context, draftID uint) error {
+	return r.db.WithContext(ctx).Delete(&model.ReportDraft{}, draftID).Error
+}
+
+// DeleteTX reports whether this call removed the draft, so callers can tell a
+// draft they own from one another transaction already deleted.
+func (r *reportDraftRepository) DeleteTX(ctx context.Context, tx *gorm.DB, draftID uint) (bool, error) {
+	result := tx.WithContext(ctx).Delete(&model.ReportDraft{}, draftID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -11,6 +11,7 @@ type ReportImageRepository interface {
 	Create(ctx context.Context, images *model.ReportImage, tx *gorm.DB) error
 	UpdateTX(ctx context.Context, tx *gorm.DB, images *model.ReportImage) (*model.ReportImage, error)
 	GetByReportID(ctx context.Context, reportID uint) (*model.ReportImage, error)
+	IsImageInUse(ctx context.Context, image string) (bool, error)
 }
 
 type reportImageRepository struct {
@@ -42,3 +43,14 @@ func (r *reportImageRepository) GetByReportID(ctx context.Context, reportID uint
 	}
 	return &images, nil
 }
+
+func (r *reportImageRepository) IsImageInUse(ctx context.Context, image string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.ReportImage{}).
+		Where("image1_url = ? OR image2_url = ? OR image3_url = ? OR image4_url = ? OR image5_url = ?", image, image, image, image, image).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
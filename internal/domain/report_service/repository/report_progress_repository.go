@@ -11,6 +11,8 @@ type ReportProgressRepository interface {
 	Create(ctx context.Context, progress *model.ReportProgress) (*model.ReportProgress, error)
 	CreateTX(ctx context.Context, tx *gorm.DB, progress *model.ReportProgress) (*model.ReportProgress, error)
 	GetByReportID(ctx context.Context, reportID uint) ([]model.ReportProgress, error)
+	MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID uint) error
+	GetWatchedSince(ctx context.Context, userID uint, since int64, limit int) ([]model.ReportProgress, error)
 }
 
 type reporProgressRepository struct {
@@ -42,3 +44,29 @@ func (r *reporProgressRepository) GetByReportID(ctx context.Context, reportID ui
 	}
 	return progresses, nil
 }
+
+func (r *reporProgressRepository) MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID uint) error {
+	return tx.WithContext(ctx).
+		Model(&model.ReportProgress{}).
+		Where("report_id = ?", fromReportID).
+		UpdateColumn("report_id", toReportID).Error
+}
+
+// GetWatchedSince returns progress posted by others on reports the user created
+// or voted on.
+func (r *reporProgressRepository) GetWatchedSince(ctx context.Context, userID uint, since int64, limit int) ([]model.ReportProgress, error) {
+	var progresses []model.ReportProgress
+	if err := r.db.WithContext(ctx).
+		Preload("Report").
+		Where("created_at >= ? AND user_id <> ?", since, userID).
+		Where("(report_id IN (?) OR report_id IN (?))",
+			r.db.Model(&model.Report{}).Select("id").Where("user_id = ? AND is_deleted = ?", userID, false),
+			r.db.Model(&model.ReportVote{}).Select("report_id").Where("user_id = ?", userID),
+		).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&progresses).Error; err != nil {
+		return nil, err
+	}
+	return progresses, nil
+}
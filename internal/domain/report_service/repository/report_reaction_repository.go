@@ -15,6 +15,8 @@ type ReportReactionRepository interface {
 	DeleteTX(ctx context.Context, tx *gorm.DB, reaction *model.ReportReaction) error
 	GetLikeReactionCount(ctx context.Context, reportID uint) (int64, error)
 	GetDislikeReactionCount(ctx context.Context, reportID uint) (int64, error)
+	GetCountSince(ctx context.Context, reportID uint, since int64) (int64, error)
+	MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID uint) (int64, error)
 }
 
 type reportReactionRepository struct {
@@ -81,3 +83,28 @@ func (r *reportReactionRepository) GetDislikeReactionCount(ctx context.Context,
 	}
 	return count, nil
 }
+
+func (r *reportReactionRepository) GetCountSince(ctx context.Context, reportID uint, since int64) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.ReportReaction{}).
+		Where("report_id = ? AND created_at >= ?", reportID, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *reportReactionRepository) MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID uint) (int64, error) {
+	existingReactors := tx.Model(&model.ReportReaction{}).Select("user_id").Where("report_id = ?", toReportID)
+	if err := tx.WithContext(ctx).
+		Where("report_id = ? AND user_id IN (?)", fromReportID, existingReactors).
+		Delete(&model.ReportReaction{}).Error; err != nil {
+		return 0, err
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&model.ReportReaction{}).
+		Where("report_id = ?", fromReportID).
+		UpdateColumn("report_id", toReportID)
+	return result.RowsAffected, result.Error
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReportRepository interface {
@@ -27,6 +28,24 @@ type ReportRepository interface {
 	GetMonthlyReportCount(ctx context.Context) (map[string]int64, error)
 	FullTextSearchReport(ctx context.Context, searchQuery string, limit int) (*[]model.Report, error)
 	FullTextSearchReportPaginated(ctx context.Context, searchQuery string, limit int, cursorID uint) (*[]model.Report, error)
+	GetClustersSince(ctx context.Context, since int64, gridSize float64, minCount int64) ([]dto.ReportCluster, error)
+	GetCountInAreaBetween(ctx context.Context, reportType string, lat, lng float64, radiusMeters int, from, to int64) (int64, error)
+	GetNearbyDuplicateCount(ctx context.Context, reportID uint, reportType string, lat, lng float64, radiusMeters int) (int64, error)
+	GetIDsByReportStatus(ctx context.Context, status ...string) ([]uint, error)
+	UpdatePriorityScore(ctx context.Context, reportID uint, score float64, updatedAt int64) error
+	UpdateHotScore(ctx context.Context, reportID uint, score float64) error
+	ResetHotScoreBefore(ctx context.Context, createdBefore int64) error
+	GetIDsCreatedSince(ctx context.Context, since int64) ([]uint, error)
+	IncrementViewCount(ctx context.Context, reportID uint) error
+	GetFeedCandidatesByUserIDs(ctx context.Context, userIDs []uint, since int64, limit int) ([]dto.FeedCandidate, error)
+	GetFeedCandidatesNearby(ctx context.Context, lat, lng float64, radiusMeters int, since int64, limit int) ([]dto.FeedCandidate, error)
+	GetFeedCandidatesTrending(ctx context.Context, limit int) ([]dto.FeedCandidate, error)
+	GetByIDs(ctx context.Context, reportIDs []uint) ([]model.Report, error)
+	MarkMergedTX(ctx context.Context, tx *gorm.DB, reportIDs []uint, canonicalReportID uint, mergedAt int64) error
+	LockByIDsForUpdateTX(ctx context.Context, tx *gorm.DB, reportIDs []uint) ([]model.Report, error)
+	GetMergedIntoID(ctx context.Context, reportID uint) (*uint, error)
+	GetAnonymousOwnerID(ctx context.Context, reportID uint) (*uint, error)
+	Exists(ctx context.Context, reportID uint) (bool, error)
 }
 
 type reportRepository struct {
@@ -195,6 +214,244 @@ func (r *reportRepository) FullTextSearchReportPaginated(ctx context.Context, se
 	return &reports, err
 }
 
+func (r *reportRepository) GetClustersSince(ctx context.Context, since int64, gridSize float64, minCount int64) ([]dto.ReportCluster, error) {
+	var clusters []dto.ReportCluster
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			reports.report_type AS report_type,
+			AVG(report_locations.latitude) AS latitude,
+			AVG(report_locations.longitude) AS longitude,
+			COUNT(*) AS total
+		FROM reports
+		JOIN report_locations ON report_locations.report_id = reports.id
+		WHERE reports.created_at >= ? AND reports.is_deleted = false
+		GROUP BY reports.report_type, ST_SnapToGrid(report_locations.geometry, ?)
+		HAVING COUNT(*) >= ?
+	`, since, gridSize, minCount).Scan(&clusters).Error
+	if err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+func (r *reportRepository) GetCountInAreaBetween(ctx context.Context, reportType string, lat, lng float64, radiusMeters int, from, to int64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("reports").
+		Joins("JOIN report_locations ON report_locations.report_id = reports.id").
+		Where("reports.report_type = ?", reportType).
+		Where("reports.created_at >= ? AND reports.created_at < ?", from, to).
+		Where("reports.is_deleted = ?", false).
+		Where(`
+			ST_DWithin(
+				report_locations.geometry::geography,
+				ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography,
+				?
+			)
+		`, lng, lat, radiusMeters).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *reportRepository) GetNearbyDuplicateCount(ctx context.Context, reportID uint, reportType string, lat, lng float64, radiusMeters int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("reports").
+		Joins("JOIN report_locations ON report_locations.report_id = reports.id").
+		Where("reports.id <> ?", reportID).
+		Where("reports.report_type = ?", reportType).
+		Where("reports.is_deleted = ?", false).
+		Where("reports.report_status NOT IN ?", []model.ReportStatus{model.RESOLVED, model.EXPIRED}).
+		Where(`
+			ST_DWithin(
+				report_locations.geometry::geography,
+				ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography,
+				?
+			)
+		`, lng, lat, radiusMeters).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *reportRepository) GetIDsByReportStatus(ctx context.Context, status ...string) ([]uint, error) {
+	var reportIDs []uint
+	if err := r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("report_status IN ? AND is_deleted = ?", status, false).
+		Pluck("id", &reportIDs).Error; err != nil {
+		return nil, err
+	}
+	return reportIDs, nil
+}
+
+func (r *reportRepository) UpdatePriorityScore(ctx context.Context, reportID uint, score float64, updatedAt int64) error {
+	return r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("id = ?", reportID).
+		UpdateColumns(map[string]any{
+			"priority_score":      score,
+			"priority_updated_at": updatedAt,
+		}).Error
+}
+
+func (r *reportRepository) UpdateHotScore(ctx context.Context, reportID uint, score float64) error {
+	return r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("id = ?", reportID).
+		UpdateColumn("hot_score", score).Error
+}
+
+func (r *reportRepository) ResetHotScoreBefore(ctx context.Context, createdBefore int64) error {
+	return r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("created_at < ? AND hot_score <> ?", createdBefore, 0).
+		UpdateColumn("hot_score", 0).Error
+}
+
+func (r *reportRepository) GetIDsCreatedSince(ctx context.Context, since int64) ([]uint, error) {
+	var reportIDs []uint
+	if err := r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("created_at >= ? AND is_deleted = ?", since, false).
+		Pluck("id", &reportIDs).Error; err != nil {
+		return nil, err
+	}
+	return reportIDs, nil
+}
+
+func (r *reportRepository) IncrementViewCount(ctx context.Context, reportID uint) error {
+	return r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("id = ?", reportID).
+		UpdateColumn("view_count", gorm.Expr("view_count + ?", 1)).Error
+}
+
+func (r *reportRepository) GetFeedCandidatesByUserIDs(ctx context.Context, userIDs []uint, since int64, limit int) ([]dto.FeedCandidate, error) {
+	var candidates []dto.FeedCandidate
+	if len(userIDs) == 0 {
+		return candidates, nil
+	}
+	err := r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Select("id AS report_id, hot_score, created_at").
+		Where("user_id IN ? AND created_at >= ? AND is_deleted = ? AND is_anonymous = ?", userIDs, since, false, false).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+func (r *reportRepository) GetFeedCandidatesNearby(ctx context.Context, lat, lng float64, radiusMeters int, since int64, limit int) ([]dto.FeedCandidate, error) {
+	var candidates []dto.FeedCandidate
+	err := r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Select("reports.id AS report_id, reports.hot_score, reports.created_at").
+		Joins("JOIN report_locations ON report_locations.report_id = reports.id").
+		Where("reports.created_at >= ? AND reports.is_deleted = ?", since, false).
+		Where(`
+			ST_DWithin(
+				report_locations.geometry::geography,
+				ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography,
+				?
+			)
+		`, lng, lat, radiusMeters).
+		Order("reports.created_at DESC, reports.id DESC").
+		Limit(limit).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+func (r *reportRepository) GetFeedCandidatesTrending(ctx context.Context, limit int) ([]dto.FeedCandidate, error) {
+	var candidates []dto.FeedCandidate
+	err := r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Select("id AS report_id, hot_score, created_at").
+		Where("hot_score > ? AND is_deleted = ?", 0, false).
+		Order("hot_score DESC, id DESC").
+		Limit(limit).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+func (r *reportRepository) GetByIDs(ctx context.Context, reportIDs []uint) ([]model.Report, error) {
+	var reports []model.Report
+	if len(reportIDs) == 0 {
+		return reports, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Preload("User.Profile").
+		Preload("ReportLocation").
+		Preload("ReportImages").
+		Where("id IN ? AND is_deleted = ?", reportIDs, false).
+		Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *reportRepository) MarkMergedTX(ctx context.Context, tx *gorm.DB, reportIDs []uint, canonicalReportID uint, mergedAt int64) error {
+	return tx.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("id IN ?", reportIDs).
+		UpdateColumns(map[string]any{
+			"merged_into_id": canonicalReportID,
+			"merged_at":      mergedAt,
+			"is_deleted":     true,
+			"deleted_at":     mergedAt,
+		}).Error
+}
+
+// LockByIDsForUpdateTX locks the reports in ID order so concurrent merges
+// touching the same reports always acquire their row locks in the same order.
+func (r *reportRepository) LockByIDsForUpdateTX(ctx context.Context, tx *gorm.DB, reportIDs []uint) ([]model.Report, error) {
+	var reports []model.Report
+	if len(reportIDs) == 0 {
+		return reports, nil
+	}
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "is_deleted", "merged_into_id").
+		Where("id IN ?", reportIDs).
+		Order("id ASC").
+		Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *reportRepository) GetMergedIntoID(ctx context.Context, reportID uint) (*uint, error) {
+	var report model.Report
+	if err := r.db.WithContext(ctx).
+		Select("id", "merged_into_id").
+		Where("id = ? AND merged_into_id IS NOT NULL", reportID).
+		First(&report).Error; err != nil {
+		return nil, err
+	}
+	return report.MergedIntoID, nil
+}
+
+func (r *reportRepository) GetAnonymousOwnerID(ctx context.Context, reportID uint) (*uint, error) {
+	var report model.Report
+	if err := r.db.WithContext(ctx).
+		Select("id", "user_id").
+		Where("id = ? AND is_anonymous = ?", reportID, true).
+		First(&report).Error; err != nil {
+		return nil, err
+	}
+	return &report.UserID, nil
+}
+
+func (r *reportRepository) Exists(ctx context.Context, reportID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.Report{}).
+		Where("id = ? AND is_deleted = ?", reportID, false).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (r *reportRepository) GetByReportStatus(ctx context.Context, status ...string) (*[]model.Report, error) {
 	var reports []model.Report
 	if err := r.db.WithContext(ctx).
@@ -292,12 +549,20 @@ func (r *reportRepository) GetPaginated(ctx context.Context, limit, cursorID uin
 			Joins("LEFT JOIN report_reactions ON reports.id = report_reactions.report_id AND report_reactions.type = 'LIKE'").
 			Group("reports.id").
 			Order("COUNT(report_reactions.id) ASC")
+	case "priority":
+		subQuery = subQuery.Order("reports.priority_score DESC, reports.id DESC")
+	case "hot":
+		subQuery = subQuery.Order("reports.hot_score DESC, reports.id DESC")
 	default:
 		subQuery = subQuery.Order("reports.id DESC")
 	}
 
 	if cursorID != 0 {
-		if sortBy == "oldest" || sortBy == "least_liked" {
+		if sortBy == "priority" {
+			subQuery = subQuery.Where("(reports.priority_score, reports.id) < (SELECT priority_score, id FROM reports WHERE id = ?)", cursorID)
+		} else if sortBy == "hot" {
+			subQuery = subQuery.Where("(reports.hot_score, reports.id) < (SELECT hot_score, id FROM reports WHERE id = ?)", cursorID)
+		} else if sortBy == "oldest" || sortBy == "least_liked" {
 			subQuery = subQuery.Where("reports.id > ?", cursorID)
 		} else {
 			subQuery = subQuery.Where("reports.id < ?", cursorID)
@@ -326,6 +591,10 @@ func (r *reportRepository) GetPaginated(ctx context.Context, limit, cursorID uin
 		Where("id IN ?", reportIDs)
 
 	switch sortBy {
+	case "priority":
+		query = query.Order("priority_score DESC, id DESC")
+	case "hot":
+		query = query.Order("hot_score DESC, id DESC")
 	case "oldest", "least_liked":
 		query = query.Order("id ASC")
 	default:
@@ -399,12 +668,20 @@ func (r *reportRepository) GetByIsDeletedPaginated(ctx context.Context, limit, c
 			Joins("LEFT JOIN report_reactions ON reports.id = report_reactions.report_id AND report_reactions.type = 'LIKE'").
 			Group("reports.id").
 			Order("COUNT(report_reactions.id) ASC")
+	case "priority":
+		subQuery = subQuery.Order("reports.priority_score DESC, reports.id DESC")
+	case "hot":
+		subQuery = subQuery.Order("reports.hot_score DESC, reports.id DESC")
 	default:
 		subQuery = subQuery.Order("reports.id DESC")
 	}
 
 	if cursorID != 0 {
-		if sortBy == "oldest" || sortBy == "least_liked" {
+		if sortBy == "priority" {
+			subQuery = subQuery.Where("(reports.priority_score, reports.id) < (SELECT priority_score, id FROM reports WHERE id = ?)", cursorID)
+		} else if sortBy == "hot" {
+			subQuery = subQuery.Where("(reports.hot_score, reports.id) < (SELECT hot_score, id FROM reports WHERE id = ?)", cursorID)
+		} else if sortBy == "oldest" || sortBy == "least_liked" {
 			subQuery = subQuery.Where("reports.id > ?", cursorID)
 		} else {
 			subQuery = subQuery.Where("reports.id < ?", cursorID)
@@ -434,6 +711,10 @@ func (r *reportRepository) GetByIsDeletedPaginated(ctx context.Context, limit, c
 		Where("is_deleted = ?", isDeleted)
 
 	switch sortBy {
+	case "priority":
+		query = query.Order("priority_score DESC, id DESC")
+	case "hot":
+		query = query.Order("hot_score DESC, id DESC")
 	case "oldest", "least_liked":
 		query = query.Order("id ASC")
 	default:
@@ -19,6 +19,10 @@ type ReportVoteRepository interface {
 	GetResolvedVoteCount(ctx context.Context, reportID uint) (int64, error)
 	GetOnProgressVoteCount(ctx context.Context, reportID uint) (int64, error)
 	GetTotalVoteCountTX(ctx context.Context, tx *gorm.DB, reportID uint) (int64, error)
+	GetCountSince(ctx context.Context, reportID uint, since int64) (int64, error)
+	MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID, excludedUserID uint) (int64, error)
+	GetReportVoteWeightsTX(ctx context.Context, tx *gorm.DB, reportID uint) (map[model.ReportStatus]float64, error)
+	GetUserVoteAccuracy(ctx context.Context, userID uint) (int64, int64, error)
 }
 
 type reportVoteRepository struct {
@@ -147,4 +151,74 @@ func (r *reportVoteRepository) GetOnProgressVoteCount(ctx context.Context, repor
 		return 0, err
 	}
 	return count, nil
-}
\ No newline at end of file
+}
+
+func (r *reportVoteRepository) GetCountSince(ctx context.Context, reportID uint, since int64) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.ReportVote{}).
+		Where("report_id = ? AND created_at >= ?", reportID, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *reportVoteRepository) MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID, excludedUserID uint) (int64, error) {
+	existingVoters := tx.Model(&model.ReportVote{}).Select("user_id").Where("report_id = ?", toReportID)
+	if err := tx.WithContext(ctx).
+		Where("report_id = ? AND (user_id IN (?) OR user_id = ?)", fromReportID, existingVoters, excludedUserID).
+		Delete(&model.ReportVote{}).Error; err != nil {
+		return 0, err
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&model.ReportVote{}).
+		Where("report_id = ?", fromReportID).
+		UpdateColumn("report_id", toReportID)
+	return result.RowsAffected, result.Error
+}
+
+func (r *reportVoteRepository) GetReportVoteWeightsTX(ctx context.Context, tx *gorm.DB, reportID uint) (map[model.ReportStatus]float64, error) {
+	var grouped []struct {
+		VoteType model.ReportStatus
+		Weight   float64
+	}
+	if err := tx.WithContext(ctx).Model(&model.ReportVote{}).
+		Select("vote_type, COALESCE(SUM(weight), 0) AS weight").
+		Where("report_id = ?", reportID).
+		Group("vote_type").
+		Scan(&grouped).Error; err != nil {
+		return nil, err
+	}
+
+	weights := map[model.ReportStatus]float64{
+		model.RESOLVED:    0,
+		model.ON_PROGRESS: 0,
+	}
+	for _, g := range grouped {
+		weights[g.VoteType] = g.Weight
+	}
+	return weights, nil
+}
+
+func (r *reportVoteRepository) GetUserVoteAccuracy(ctx context.Context, userID uint) (int64, int64, error) {
+	var result struct {
+		Accurate int64
+		Total    int64
+	}
+	if err := r.db.WithContext(ctx).Model(&model.ReportVote{}).
+		Select(`
+			COALESCE(SUM(CASE
+				WHEN reports.report_status = ? AND report_votes.vote_type = ? THEN 1
+				WHEN reports.report_status = ? AND report_votes.vote_type <> ? THEN 1
+				ELSE 0
+			END), 0) AS accurate,
+			COUNT(*) AS total
+		`, model.RESOLVED, model.RESOLVED, model.EXPIRED, model.RESOLVED).
+		Joins("JOIN reports ON reports.id = report_votes.report_id").
+		Where("report_votes.user_id = ? AND reports.report_status IN ?", userID, []model.ReportStatus{model.RESOLVED, model.EXPIRED}).
+		Scan(&result).Error; err != nil {
+		return 0, 0, err
+	}
+	return result.Accurate, result.Total, nil
+}
@@ -7,8 +7,10 @@ import (
 	reportService "pingspot/internal/domain/report_service/service"
 	"pingspot/internal/domain/task_service/service"
 	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/cache"
 	"pingspot/internal/infrastructure/database"
 	"pingspot/internal/middleware"
+	cacheRepository "pingspot/internal/repository"
 	env "pingspot/pkg/utils/env_util"
 	"time"
 
@@ -29,6 +31,9 @@ func RegisterReportRoutes(app *fiber.App) {
 	userProfileRepo := userRepository.NewUserProfileRepository(postgreDB)
 	userRepo := userRepository.NewUserRepository(postgreDB)
 	reportCommentRepository := reportRepository.NewReportCommentRepository(mongoDB)
+	reportDraftRepo := reportRepository.NewReportDraftRepository(postgreDB)
+	rdb := cache.GetRedis()
+	cacheRepo := cacheRepository.NewCacheRepository(&rdb)
 
 	redisAddress := fmt.Sprintf("%s:%s", env.RedisHost(), env.RedisPort())
 	client := asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddress})
@@ -45,6 +50,8 @@ func RegisterReportRoutes(app *fiber.App) {
 		reportProgressRepo, 
 		reportVoteRepo, 
 		tasksService, reportCommentRepository,
+		reportDraftRepo,
+		cacheRepo,
 	)
 
 	reportHandler := handler.NewReportHandler(reportService)
@@ -61,6 +68,76 @@ func RegisterReportRoutes(app *fiber.App) {
 	reportHandler.CreateReportHandler,
 	)
 
+	reportRoute.Post("/draft",
+	middleware.TimeoutMiddleware(20*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      10 * time.Minute,
+		MaxRequests: 30,
+		KeyPrefix: "create_report_draft",
+	})),
+	reportHandler.CreateReportDraftHandler,
+	)
+
+	reportRoute.Put("/draft/:draftID",
+	middleware.TimeoutMiddleware(20*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      10 * time.Minute,
+		MaxRequests: 60,
+		KeyPrefix: "update_report_draft",
+	})),
+	reportHandler.UpdateReportDraftHandler,
+	)
+
+	reportRoute.Get("/draft",
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 60,
+		KeyPrefix: "get_report_drafts",
+	})),
+	reportHandler.GetReportDraftsHandler,
+	)
+
+	reportRoute.Get("/draft/:draftID",
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 60,
+		KeyPrefix: "get_report_draft",
+	})),
+	reportHandler.GetReportDraftHandler,
+	)
+
+	reportRoute.Get("/draft/:draftID/image/:image",
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 100,
+		KeyPrefix: "get_report_draft_image",
+	})),
+	reportHandler.GetReportDraftImageHandler,
+	)
+
+	reportRoute.Delete("/draft/:draftID",
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 30,
+		KeyPrefix: "delete_report_draft",
+	})),
+	reportHandler.DeleteReportDraftHandler,
+	)
+
+	reportRoute.Post("/draft/:draftID/publish",
+	middleware.TimeoutMiddleware(20*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      10 * time.Minute,
+		MaxRequests: 8,
+		KeyPrefix: "create_report",
+	})),
+	reportHandler.PublishReportDraftHandler,
+	)
+
 	reportRoute.Put("/:reportID", 
 	middleware.TimeoutMiddleware(20*time.Second),
 	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
@@ -100,6 +177,16 @@ func RegisterReportRoutes(app *fiber.App) {
 	reportHandler.VoteReportHandler,
 	)
 
+	reportRoute.Post("/:reportID/merge", 
+	middleware.TimeoutMiddleware(20*time.Second), 
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 10,
+		KeyPrefix: "merge_reports",
+	})), 
+	reportHandler.MergeReportsHandler,
+	)
+
 	reportRoute.Post("/:reportID/progress", middleware.TimeoutMiddleware(15*time.Second),
 	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
 		Window:      1 * time.Minute,
@@ -156,6 +243,15 @@ func RegisterReportRoutes(app *fiber.App) {
 	reportHandler.GetReportCommentRepliesHandler,
 	)
 
+	reportRoute.Post("/comment/:commentID/helpful", middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 30,
+		KeyPrefix: "mark_comment_helpful",
+	})),
+	reportHandler.MarkCommentHelpfulHandler,
+	)
+
 	reportRoute.Get("/statistics", 
 	middleware.TimeoutMiddleware(15 * time.Second),
 	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
@@ -7,11 +7,17 @@ import (
 	"pingspot/internal/domain/report_service/dto"
 	reportRepository "pingspot/internal/domain/report_service/repository"
 	"pingspot/internal/domain/report_service/util"
+	"pingspot/internal/domain/report_service/validation"
 	tasksService "pingspot/internal/domain/task_service/service"
 	userRepository "pingspot/internal/domain/user_service/repository"
+	webhookDTO "pingspot/internal/domain/webhook_service/dto"
+	realtimeDTO "pingspot/internal/domain/realtime_service/dto"
 	"pingspot/internal/model"
+	cacheRepository "pingspot/internal/repository"
 	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/i18n"
 	"pingspot/pkg/logger"
+	"pingspot/pkg/mailer"
 	contextutils "pingspot/pkg/utils/context_util"
 	env "pingspot/pkg/utils/env_util"
 	mainutils "pingspot/pkg/utils/main_util"
@@ -37,6 +43,8 @@ type ReportService struct {
 	userRepo           userRepository.UserRepository
 	userProfileRepo    userRepository.UserProfileRepository
 	reportCommentRepo  reportRepository.ReportCommentRepository
+	reportDraftRepo    reportRepository.ReportDraftRepository
+	cacheRepo          cacheRepository.CacheRepository
 }
 
 func NewreportService(
@@ -52,6 +60,8 @@ func NewreportService(
 	reportVoteRepo reportRepository.ReportVoteRepository,
 	tasksService tasksService.TaskService,
 	reportCommentRepo reportRepository.ReportCommentRepository,
+	reportDraftRepo reportRepository.ReportDraftRepository,
+	cacheRepo cacheRepository.CacheRepository,
 ) *ReportService {
 	return &ReportService{
 		postgreDB:          postgreDB,
@@ -66,6 +76,8 @@ func NewreportService(
 		reportVoteRepo:     reportVoteRepo,
 		tasksService:       tasksService,
 		reportCommentRepo:  reportCommentRepo,
+		reportDraftRepo:    reportDraftRepo,
+		cacheRepo:          cacheRepo,
 	}
 }
 
@@ -90,11 +102,39 @@ func (s *ReportService) CreateReport(ctx context.Context, userID uint, req dto.C
 			tx.Rollback()
 		}
 	}()
+	reportResult, err := s.createReportTX(ctx, tx, userID, req)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal menyimpan perubahan", err.Error(), nil)
+	}
+
+	logger.Info("Report created successfully",
+		zap.String("request_id", requestID),
+		zap.Uint("report_id", reportResult.Report.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return reportResult, nil
+}
+
+// createReportTX writes the report, its location, images and outbox events
+// inside tx. The caller owns the transaction.
+func (s *ReportService) createReportTX(ctx context.Context, tx *gorm.DB, userID uint, req dto.CreateReportRequest) (*dto.CreateReportResponse, error) {
+	if req.IsAnonymous && !util.IsAnonymousReportAllowed(model.ReportType(req.ReportType)) {
+		return nil, apperror.New(400, "ANONYMOUS_REPORT_NOT_ALLOWED", "Laporan anonim tidak tersedia untuk jenis laporan ini", "", nil)
+	}
+
 	var reportStruct model.Report
 	reportStruct = model.Report{
 		UserID:            userID,
 		ReportTitle:       req.ReportTitle,
 		HasProgress:       req.HasProgress,
+		IsAnonymous:       req.IsAnonymous,
 		ReportStatus:      model.WAITING,
 		ReportType:        model.ReportType(req.ReportType),
 		ReportDescription: req.ReportDescription,
@@ -102,7 +142,6 @@ func (s *ReportService) CreateReport(ctx context.Context, userID uint, req dto.C
 		UpdatedAt:         time.Now().Unix(),
 	}
 	if err := s.reportRepo.Create(ctx, &reportStruct, tx); err != nil {
-		tx.Rollback()
 		return nil, apperror.New(500, "REPORT_CREATE_FAILED", "Gagal membuat laporan", err.Error(), nil)
 	}
 
@@ -130,7 +169,6 @@ func (s *ReportService) CreateReport(ctx context.Context, userID uint, req dto.C
 	}
 
 	if err := s.reportLocationRepo.Create(ctx, &reportLocationStruct, tx); err != nil {
-		tx.Rollback()
 		return nil, apperror.New(500, "REPORT_LOCATION_CREATE_FAILED", "Gagal menyimpan lokasi laporan", err.Error(), nil)
 	}
 
@@ -144,27 +182,43 @@ func (s *ReportService) CreateReport(ctx context.Context, userID uint, req dto.C
 		ReportID:  reportID,
 	}
 	if err := s.reportImageRepo.Create(ctx, &reportImages, tx); err != nil {
-		tx.Rollback()
 		return nil, apperror.New(500, "REPORT_IMAGE_CREATE_FAILED", "Gagal menyimpan gambar laporan", err.Error(), nil)
 	}
-	if err := tx.Commit().Error; err != nil {
-		tx.Rollback()
-		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal menyimpan perubahan", err.Error(), nil)
-	}
 
-	reportResult := &dto.CreateReportResponse{
+	var reporterID *uint
+	if !reportStruct.IsAnonymous {
+		reporterID = &reportStruct.UserID
+	}
+	taskOutbox := s.tasksService.WithTx(tx)
+	// Points and badges for an anonymous report would show on the public
+	// profile and leaderboards, which ties the report back to its reporter.
+	var gamificationErr error
+	if !reportStruct.IsAnonymous {
+		gamificationErr = taskOutbox.GamificationEventTask(userID, model.GamificationReportCreated, reportID)
+	}
+	if err := errors.Join(
+		taskOutbox.RecalculateReportPriorityTask(reportID),
+		gamificationErr,
+		taskOutbox.DispatchWebhookEventTask(model.WebhookReportCreated, webhookDTO.ReportEventData{
+			ReportID:     reportID,
+			ReportTitle:  reportStruct.ReportTitle,
+			ReportType:   string(reportStruct.ReportType),
+			ReportStatus: string(reportStruct.ReportStatus),
+			IsAnonymous:  reportStruct.IsAnonymous,
+			UserID:       reporterID,
+			Latitude:     reportLocationStruct.Latitude,
+			Longitude:    reportLocationStruct.Longitude,
+			CreatedAt:    reportStruct.CreatedAt,
+		}),
+	); err != nil {
+		return nil, apperror.New(500, "OUTBOX_WRITE_FAILED", "Gagal menyimpan event laporan", err.Error(), nil)
+	}
+
+	return &dto.CreateReportResponse{
 		Report:         reportStruct,
 		ReportLocation: reportLocationStruct,
 		ReportImages:   reportImages,
-	}
-
-	logger.Info("Report created successfully",
-		zap.String("request_id", requestID),
-		zap.Uint("report_id", reportStruct.ID),
-		zap.Uint("user_id", userID),
-	)
-
-	return reportResult, nil
+	}, nil
 }
 
 func (s *ReportService) EditReport(ctx context.Context, userID, reportID uint, req dto.EditReportRequest) (*dto.EditReportResponse, error) {
@@ -365,6 +419,7 @@ func (s *ReportService) GetAllReport(ctx context.Context, userID, cursorID uint,
 			ReportType:        string(report.ReportType),
 			ReportDescription: report.ReportDescription,
 			ReportCreatedAt:   report.CreatedAt,
+			IsAnonymous:       report.IsAnonymous,
 			UserID:            report.UserID,
 			UserName:          report.User.Username,
 			FullName:          report.User.FullName,
@@ -449,6 +504,7 @@ func (s *ReportService) GetAllReport(ctx context.Context, userID, cursorID uint,
 						ReportID:  vote.ReportID,
 						UserID:    vote.UserID,
 						VoteType:  vote.VoteType,
+						Weight:    vote.Weight,
 						CreatedAt: vote.CreatedAt,
 						UpdatedAt: vote.UpdatedAt,
 					})
@@ -470,7 +526,15 @@ func (s *ReportService) GetAllReport(ctx context.Context, userID, cursorID uint,
 			LastUpdatedBy:              (*string)(&report.LastUpdatedBy),
 			LastUpdatedProgressAt:      report.LastUpdatedProgressAt,
 			ReportUpdatedAt:            report.UpdatedAt,
+			PriorityScore:              report.PriorityScore,
+			HotScore:                   report.HotScore,
+			ViewCount:                  report.ViewCount,
+			WeightedResolvedVotes:      report.ResolvedVoteWeight,
+			WeightedOnProgressVotes:    report.OnProgressVoteWeight,
 		})
+		if util.ShouldRedactReportOwner(&report, userID) {
+			util.RedactReportOwner(&fullReports[len(fullReports)-1])
+		}
 	}
 	reportsData := dto.GetReportsResponse{
 		Reports:     fullReports,
@@ -479,15 +543,52 @@ func (s *ReportService) GetAllReport(ctx context.Context, userID, cursorID uint,
 	return &reportsData, nil
 }
 
+// GetReportByID reads a report without counting a view.
 func (s *ReportService) GetReportByID(ctx context.Context, userID, reportID uint) (*dto.GetReportResponse, error) {
+	return s.getReportByID(ctx, userID, reportID, false)
+}
+
+// ViewReportByID reads a report for display and counts at most one view per
+// viewer within util.ReportViewDedupeWindow.
+func (s *ReportService) ViewReportByID(ctx context.Context, userID, reportID uint) (*dto.GetReportResponse, error) {
+	return s.getReportByID(ctx, userID, reportID, true)
+}
+
+func (s *ReportService) recordReportView(ctx context.Context, userID, reportID uint) {
+	claimed, err := s.cacheRepo.SetNX(ctx, util.GetReportViewKey(reportID, userID), time.Now().Unix(), util.ReportViewDedupeWindow)
+	if err != nil {
+		logger.Warn("Failed to claim report view", zap.Uint("report_id", reportID), zap.Error(err))
+		return
+	}
+	if !claimed {
+		return
+	}
+	if err := s.reportRepo.IncrementViewCount(ctx, reportID); err != nil {
+		logger.Warn("Failed to increment report view count", zap.Uint("report_id", reportID), zap.Error(err))
+	}
+}
+
+func (s *ReportService) getReportByID(ctx context.Context, userID, reportID uint, countView bool) (*dto.GetReportResponse, error) {
 	isDeleted := false
 	report, err := s.reportRepo.GetByIDIsDeleted(ctx, reportID, isDeleted)
+	// A merged report redirects to its canonical report. Merging into a merged
+	// report is rejected, so one hop is enough and a chain is never followed.
+	var redirectedFromID *uint
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if mergedIntoID, mergedErr := s.reportRepo.GetMergedIntoID(ctx, reportID); mergedErr == nil && mergedIntoID != nil {
+			redirectedFromID = &reportID
+			report, err = s.reportRepo.GetByIDIsDeleted(ctx, *mergedIntoID, isDeleted)
+		}
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, apperror.New(404, "REPORT_NOT_FOUND", "Laporan tidak ditemukan", "", nil)
 		}
 		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
 	}
+	if countView && report.UserID != userID {
+		s.recordReportView(ctx, userID, report.ID)
+	}
 	var isLikedByCurrentUser, isDislikedByCurrentUser, isResolvedByCurrentUser, isOnProgressByCurrentUser bool
 	likeReactionCount, err := s.reportReactionRepo.GetLikeReactionCount(ctx, report.ID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -511,6 +612,7 @@ func (s *ReportService) GetReportByID(ctx context.Context, userID, reportID uint
 		ReportType:        string(report.ReportType),
 		ReportDescription: report.ReportDescription,
 		ReportCreatedAt:   report.CreatedAt,
+		IsAnonymous:       report.IsAnonymous,
 		UserID:            report.UserID,
 		UserName:          report.User.Username,
 		FullName:          report.User.FullName,
@@ -593,6 +695,7 @@ func (s *ReportService) GetReportByID(ctx context.Context, userID, reportID uint
 					ReportID:  vote.ReportID,
 					UserID:    vote.UserID,
 					VoteType:  vote.VoteType,
+					Weight:    vote.Weight,
 					CreatedAt: vote.CreatedAt,
 					UpdatedAt: vote.UpdatedAt,
 				})
@@ -615,9 +718,18 @@ func (s *ReportService) GetReportByID(ctx context.Context, userID, reportID uint
 		LastUpdatedBy:              (*string)(&report.LastUpdatedBy),
 		LastUpdatedProgressAt:      report.LastUpdatedProgressAt,
 		ReportUpdatedAt:            report.UpdatedAt,
+		PriorityScore:              report.PriorityScore,
+		HotScore:                   report.HotScore,
+		ViewCount:                  report.ViewCount,
+		WeightedResolvedVotes:      report.ResolvedVoteWeight,
+		WeightedOnProgressVotes:    report.OnProgressVoteWeight,
+	}
+	if util.ShouldRedactReportOwner(report, userID) {
+		util.RedactReportOwner(&fullReport)
 	}
 	result := dto.GetReportResponse{
-		Report: fullReport,
+		Report:           fullReport,
+		RedirectedFromID: redirectedFromID,
 	}
 	return &result, nil
 }
@@ -677,6 +789,41 @@ func (s *ReportService) ReactToReport(ctx context.Context, userID uint, reportID
 		resultReaction = updatedReportReaction
 	}
 
+	taskOutbox := s.tasksService.WithTx(tx)
+	if !isDelete {
+		report, err := s.reportRepo.GetByID(ctx, reportID)
+		if err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mendapatkan laporan", err.Error(), nil)
+		}
+
+		reactorUser, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "USER_FETCH_FAILED", "Gagal mendapatkan data pengguna", err.Error(), nil)
+		}
+
+		if report.UserID != userID {
+			if err := taskOutbox.CreateGroupedNotificationTask(
+				report.UserID,
+				reactorUser.ID,
+				reactorUser.Username,
+				mainutils.StrPtrOrNil(strconv.FormatUint(uint64(reportID), 10)),
+				model.EntityTypeReport,
+				model.ReportNotificationCategory,
+				model.NotificationEventReaction,
+				&reportID,
+			); err != nil {
+				tx.Rollback()
+				return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "Gagal membuat tugas notifikasi", err.Error(), nil)
+			}
+		}
+	}
+	if err := taskOutbox.RecalculateReportPriorityTask(reportID); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "OUTBOX_WRITE_FAILED", "Gagal menyimpan event laporan", err.Error(), nil)
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
 		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal menyimpan perubahan", err.Error(), nil)
@@ -692,39 +839,10 @@ func (s *ReportService) ReactToReport(ctx context.Context, userID uint, reportID
 		response.UpdatedAt = resultReaction.UpdatedAt
 	}
 
-	if isDelete {
-		return response, nil
-	}
-
-	report, err := s.reportRepo.GetByID(ctx, reportID)
-	if err != nil {
-		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mendapatkan laporan", err.Error(), nil)
-	}
-
-	reactorUser, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return nil, apperror.New(500, "USER_FETCH_FAILED", "Gagal mendapatkan data pengguna", err.Error(), nil)
-	}
-
-	if report.UserID != userID {
-		stringUserID := strconv.FormatUint(uint64(userID), 10)
-		if err := s.tasksService.CreateNotificationTask(
-			report.UserID,
-			"Seseorang memberikan reaksi pada laporan Anda",
-			fmt.Sprintf("Pengguna %s memberikan reaksi pada laporan Anda", reactorUser.Username),
-			mainutils.StrPtrOrNil(stringUserID),
-			model.EntityTypeUser,
-			model.ReportNotificationCategory,
-			model.NotificationTypeInfo,
-		); err != nil {
-			return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "Gagal membuat tugas notifikasi", err.Error(), nil)
-		}
-	}
-
 	return response, nil
 }
 
-func (s *ReportService) VoteToReport(ctx context.Context, userID uint, reportID uint, voteType string) (*dto.GetVoteReportResponse, error) {
+func (s *ReportService) VoteToReport(ctx context.Context, userID uint, reportID uint, voteType string, voterLat, voterLng *float64) (*dto.GetVoteReportResponse, error) {
 	tx := s.postgreDB.Begin()
 	if tx.Error != nil {
 		return nil, apperror.New(500, "TRANSACTION_START_FAILED", "Gagal memulai transaksi", tx.Error.Error(), nil)
@@ -751,6 +869,7 @@ func (s *ReportService) VoteToReport(ctx context.Context, userID uint, reportID
 	}
 
 	if existingVote != nil {
+		tx.Rollback()
 		return nil, apperror.New(400, "ALREADY_VOTED", "Anda sudah memberikan suara pada laporan ini", "", nil)
 	}
 
@@ -774,17 +893,32 @@ func (s *ReportService) VoteToReport(ctx context.Context, userID uint, reportID
 		return nil, apperror.New(400, "REPORT_NO_PROGRESS", "Anda tidak dapat memberikan suara pada laporan tanpa progres (informasi saja)", "", nil)
 	}
 
+	var distanceMeters *float64
+	if voterLat != nil && voterLng != nil && report.ReportLocation != nil {
+		distance := util.HaversineDistanceMeters(*voterLat, *voterLng, report.ReportLocation.Latitude, report.ReportLocation.Longitude)
+		distanceMeters = &distance
+	}
+	accurateVotes, totalPastVotes, err := s.reportVoteRepo.GetUserVoteAccuracy(ctx, userID)
+	if err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "VOTE_ACCURACY_FETCH_FAILED", "Gagal mendapatkan riwayat akurasi suara", err.Error(), nil)
+	}
+	voteWeight := util.CalculateVoteWeight(distanceMeters, accurateVotes, totalPastVotes)
+
 	modelVoteType := model.ReportStatus(voteType)
 	var resultVote *model.ReportVote
+	previousStatus := report.ReportStatus
 
 	switch {
 	case existingVote == nil:
 		newVote := model.ReportVote{
-			UserID:    userID,
-			ReportID:  reportID,
-			VoteType:  modelVoteType,
-			CreatedAt: time.Now().Unix(),
-			UpdatedAt: time.Now().Unix(),
+			UserID:         userID,
+			ReportID:       reportID,
+			VoteType:       modelVoteType,
+			Weight:         voteWeight,
+			DistanceMeters: distanceMeters,
+			CreatedAt:      time.Now().Unix(),
+			UpdatedAt:      time.Now().Unix(),
 		}
 		newReportVote, err := s.reportVoteRepo.CreateTX(ctx, tx, &newVote)
 		if err != nil {
@@ -801,6 +935,8 @@ func (s *ReportService) VoteToReport(ctx context.Context, userID uint, reportID
 		resultVote = nil
 	default:
 		existingVote.VoteType = modelVoteType
+		existingVote.Weight = voteWeight
+		existingVote.DistanceMeters = distanceMeters
 		existingVote.UpdatedAt = time.Now().Unix()
 		updatedReportVote, err := s.reportVoteRepo.UpdateTX(ctx, tx, existingVote)
 		if err != nil {
@@ -810,110 +946,87 @@ func (s *ReportService) VoteToReport(ctx context.Context, userID uint, reportID
 		resultVote = updatedReportVote
 	}
 
+	// A retracted vote still changes the stored totals, but it never moves
+	// the report towards consensus.
 	if resultVote != nil {
-		reportVoteCounts, err := s.reportVoteRepo.GetReportVoteCountsTX(ctx, tx, reportID)
-		if err != nil {
-			tx.Rollback()
-			return nil, apperror.New(500, "VOTE_COUNT_FAILED", "Gagal mendapatkan jumlah suara laporan", err.Error(), nil)
-		}
-
-		voteTypeCountsOrder := util.GetVoteTypeOrder(reportVoteCounts)
-
-		totalVote, err := s.reportVoteRepo.GetTotalVoteCountTX(ctx, tx, reportID)
-		if err != nil {
-			tx.Rollback()
-			return nil, apperror.New(500, "VOTE_COUNT_FAILED", "Gagal mendapatkan total suara laporan", err.Error(), nil)
-		}
-		topVote := voteTypeCountsOrder[0]
-		secondVote := voteTypeCountsOrder[1]
-
-		marginVote := float64(topVote.Count-secondVote.Count) / float64(totalVote) * 100
-
-		limitTopVote := 2
-		if marginVote >= 20.0 && topVote.Count >= limitTopVote && report.ReportStatus != model.WAITING_CONFIRMATION {
-			report.ReportStatus = model.WAITING_CONFIRMATION
-			report.LastUpdatedBy = model.System
-			report.LastUpdatedProgressAt = mainutils.Int64PtrOrNil(time.Now().Unix())
-			report.PotentiallyResolvedAt = mainutils.Int64PtrOrNil(time.Now().Unix())
-			reportLink := fmt.Sprintf("%s/main/reports/%d", env.ClientURL(), report.ID)
-			go util.SendPotentiallyResolvedReportEmail(
-				report.User.Email,
-				report.User.Username,
-				report.ReportTitle,
-				reportLink,
-				7,
-			)
-			if err := s.tasksService.AutoResolveReportTask(reportID); err != nil {
-				tx.Rollback()
-				return nil, apperror.New(500, "AUTO_RESOLVE_TASK_FAILED", "Gagal membuat tugas penyelesaian otomatis", err.Error(), nil)
-			}
-
-			reportStatusMessage := map[any]string{
-				model.RESOLVED:        "TERSELESAIKAN",
-				model.ON_PROGRESS:     "SEDANG_DIPROSES",
-				model.WAITING_CONFIRMATION: "MENUNGGU_KONFIRMASI",
-			}
-			progressNotes := fmt.Sprintf("Laporan menunggu konfirmasi karena mendapatkan suara tertinggi dengan status laporan: '%s' dan Total suara: %d.", reportStatusMessage[topVote.Type], totalVote)
-
-			var newProgress *model.ReportProgress
-			if report.ReportStatus == model.WAITING_CONFIRMATION {
-				newProgress = &model.ReportProgress{
-					ReportID:    reportID,
-					UserID:      userID,
-					Status:      model.WAITING_CONFIRMATION,
-					Notes:       progressNotes,
-					CreatedAt:   time.Now().Unix(),
-				}
-			}
-
-			if _, err := s.reportProgressRepo.CreateTX(ctx, tx, newProgress); err != nil {
-				tx.Rollback()
-				return nil, apperror.New(500, "PROGRESS_CREATE_FAILED", "Gagal mengunggah progres laporan", err.Error(), nil)
-			}
-		}
-
-		if _, err := s.reportRepo.UpdateTX(ctx, tx, report); err != nil {
+		if err := s.evaluateVoteConsensusTX(ctx, tx, report, userID); err != nil {
 			tx.Rollback()
-			return nil, apperror.New(500, "REPORT_UPDATE_FAILED", "Gagal memperbarui status laporan", err.Error(), nil)
+			return nil, err
 		}
+	} else if _, _, err := s.refreshVoteTotalsTX(ctx, tx, report); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if _, err := s.reportRepo.UpdateTX(ctx, tx, report); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "REPORT_UPDATE_FAILED", "Gagal memperbarui status laporan", err.Error(), nil)
 	}
 
+	taskOutbox := s.tasksService.WithTx(tx)
 	if resultVote != nil && report.UserID != userID {
 		voterUser, err := s.userRepo.GetByID(ctx, userID)
 		if err != nil {
 			tx.Rollback()
 			return nil, apperror.New(500, "USER_FETCH_FAILED", "Gagal mendapatkan data pengguna", err.Error(), nil)
 		}
-		if err := s.tasksService.CreateNotificationTask(
+		if err := taskOutbox.CreateGroupedNotificationTask(
 			report.UserID,
-			"Seseorang memberikan suara pada laporan Anda",
-			fmt.Sprintf("Pengguna %s memberikan suara pada laporan Anda", voterUser.Username),
+			voterUser.ID,
+			voterUser.Username,
 			mainutils.StrPtrOrNil(strconv.FormatUint(uint64(reportID), 10)),
 			model.EntityTypeReport,
 			model.ReportNotificationCategory,
-			model.NotificationTypeInfo,
+			model.NotificationEventVote,
+			&reportID,
 		); err != nil {
 			tx.Rollback()
 			return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "Gagal membuat tugas notifikasi suara", err.Error(), nil)
 		}
 	}
 
+	outboxErrs := []error{
+		taskOutbox.RecalculateReportPriorityTask(reportID),
+		taskOutbox.GamificationEventTask(userID, model.GamificationVoteCast, reportID),
+		taskOutbox.PublishRealtimeEventTask(model.RealtimeScopeReport, reportID, model.RealtimeReportVoteChanged, realtimeDTO.ReportVoteEventData{
+			ReportID:             reportID,
+			ReportStatus:         string(report.ReportStatus),
+			ResolvedVoteCount:    report.ResolvedVoteCount,
+			OnProgressVoteCount:  report.OnProgressVoteCount,
+			ResolvedVoteWeight:   report.ResolvedVoteWeight,
+			OnProgressVoteWeight: report.OnProgressVoteWeight,
+		}),
+	}
+	if report.ReportStatus != previousStatus {
+		outboxErrs = append(outboxErrs,
+			taskOutbox.DispatchWebhookEventTask(model.WebhookReportStatusChanged, reportStatusChangedEventData(report, previousStatus)),
+			taskOutbox.PublishRealtimeEventTask(model.RealtimeScopeReport, reportID, model.RealtimeReportStatusChanged, reportStatusRealtimeEventData(report, previousStatus)),
+		)
+	}
+	if err := errors.Join(outboxErrs...); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "OUTBOX_WRITE_FAILED", "Gagal menyimpan event laporan", err.Error(), nil)
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
 		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal menyimpan perubahan", err.Error(), nil)
 	}
 
-	return &dto.GetVoteReportResponse{
-		ID:                    resultVote.ID,
+	response := &dto.GetVoteReportResponse{
 		ReportID:              reportID,
 		ReportStatus:          report.ReportStatus,
 		UserID:                userID,
-		VoteType:              resultVote.VoteType,
-		CreatedAt:             resultVote.CreatedAt,
-		UpdatedAt:             resultVote.UpdatedAt,
 		LastUpdatedBy:         (*string)(&report.LastUpdatedBy),
 		LastUpdatedProgressAt: report.LastUpdatedProgressAt,
-	}, nil
+	}
+	if resultVote != nil {
+		response.ID = resultVote.ID
+		response.VoteType = resultVote.VoteType
+		response.Weight = resultVote.Weight
+		response.CreatedAt = resultVote.CreatedAt
+		response.UpdatedAt = resultVote.UpdatedAt
+	}
+	return response, nil
 }
 
 func (s *ReportService) UploadProgressReport(ctx context.Context, userID, reportID uint, req dto.UploadProgressReportRequest) (*dto.UploadProgressReportResponse, error) {
@@ -956,6 +1069,7 @@ func (s *ReportService) UploadProgressReport(ctx context.Context, userID, report
 		tx.Rollback()
 		return nil, apperror.New(400, "REPORT_ALREADY_RESOLVED", "laporan sudah selesai, tidak dapat mengunggah progres lagi", "", nil)
 	}
+	previousStatus := report.ReportStatus
 
 	if req.Status == string(model.RESOLVED) {
 		report.ReportStatus = model.RESOLVED
@@ -999,6 +1113,30 @@ func (s *ReportService) UploadProgressReport(ctx context.Context, userID, report
 		LastUpdatedProgressAt: report.LastUpdatedProgressAt,
 	}
 
+	taskOutbox := s.tasksService.WithTx(tx)
+	outboxErrs := []error{
+		taskOutbox.RecalculateReportPriorityTask(reportID),
+		taskOutbox.DispatchWebhookEventTask(model.WebhookReportProgressAdded, webhookDTO.ReportProgressEventData{
+			ReportID:  reportID,
+			Status:    string(newProgress.Status),
+			Notes:     newProgress.Notes,
+			CreatedAt: newProgress.CreatedAt,
+		}),
+	}
+	if report.ReportStatus == model.RESOLVED {
+		outboxErrs = append(outboxErrs, taskOutbox.EvaluateReportReputationTask(reportID))
+	}
+	if report.ReportStatus != previousStatus {
+		outboxErrs = append(outboxErrs,
+			taskOutbox.DispatchWebhookEventTask(model.WebhookReportStatusChanged, reportStatusChangedEventData(report, previousStatus)),
+			taskOutbox.PublishRealtimeEventTask(model.RealtimeScopeReport, reportID, model.RealtimeReportStatusChanged, reportStatusRealtimeEventData(report, previousStatus)),
+		)
+	}
+	if err := errors.Join(outboxErrs...); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "OUTBOX_WRITE_FAILED", "gagal menyimpan event laporan", err.Error(), nil)
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
 		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyimpan transaksi", err.Error(), nil)
@@ -1104,17 +1242,38 @@ func (s *ReportService) CreateReportComment(ctx context.Context, userID, reportI
 	if err != nil {
 		return nil, apperror.New(500, "USER_FETCH_FAILED", "Gagal mendapatkan data pengguna", err.Error(), nil)
 	}
+	commenterName := commenter.Username
+	commenterID := &userID
+	if report.IsAnonymous && report.UserID == userID {
+		commenterName = util.AnonymousUsername
+		commenterID = nil
+	}
+
+	tx := s.postgreDB.Begin()
+	if tx.Error != nil {
+		return nil, apperror.New(500, "TRANSACTION_START_FAILED", "Gagal memulai transaksi", tx.Error.Error(), nil)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+	taskOutbox := s.tasksService.WithTx(tx)
 
 	if report.UserID != userID {
-		if err := s.tasksService.CreateNotificationTask(
+		if err := taskOutbox.CreateNotificationTask(
 			report.UserID,
-			"Seseorang mengomentari laporan Anda",
-			fmt.Sprintf("Pengguna %s mengomentari laporan Anda", commenter.Username),
+			"notification.comment",
+			map[string]string{"actor": commenterName},
 			mainutils.StrPtrOrNil(newCommentID),
 			model.EntityTypeComment,
 			model.ReportNotificationCategory,
 			model.NotificationTypeInfo,
+			model.NotificationEventComment,
+			&reportID,
 		); err != nil {
+			tx.Rollback()
 			return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "Gagal membuat tugas notifikasi laporan", err.Error(), nil)
 		}
 	}
@@ -1122,15 +1281,18 @@ func (s *ReportService) CreateReportComment(ctx context.Context, userID, reportI
 	if parentCommentIDObj != nil {
 		parentComment, err := s.reportCommentRepo.GetByID(ctx, *parentCommentIDObj)
 		if err == nil && parentComment.UserID != userID && parentComment.UserID != report.UserID {
-			if err := s.tasksService.CreateNotificationTask(
+			if err := taskOutbox.CreateNotificationTask(
 				parentComment.UserID,
-				fmt.Sprintf("Pengguna %s membalas komentar Anda", commenter.Username),
-				fmt.Sprintf("/reports/%d", reportID),
+				"notification.reply",
+				map[string]string{"actor": commenterName, "reportID": strconv.FormatUint(uint64(reportID), 10)},
 				mainutils.StrPtrOrNil(newCommentID),
 				model.EntityTypeComment,
 				model.ReportNotificationCategory,
 				model.NotificationTypeInfo,
+				model.NotificationEventReply,
+				&reportID,
 			); err != nil {
+				tx.Rollback()
 				return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "Gagal membuat tugas notifikasi balasan", err.Error(), nil)
 			}
 		}
@@ -1140,15 +1302,18 @@ func (s *ReportService) CreateReportComment(ctx context.Context, userID, reportI
 		if mentionedUserID == userID || mentionedUserID == report.UserID {
 			continue
 		}
-		if err := s.tasksService.CreateNotificationTask(
+		if err := taskOutbox.CreateNotificationTask(
 			mentionedUserID,
-			fmt.Sprintf("Pengguna %s menyebut Anda dalam komentar", commenter.Username),
-			fmt.Sprintf("/reports/%d", reportID),
+			"notification.mention",
+			map[string]string{"actor": commenterName, "reportID": strconv.FormatUint(uint64(reportID), 10)},
 			mainutils.StrPtrOrNil(newCommentID),
 			model.EntityTypeComment,
 			model.ReportNotificationCategory,
 			model.NotificationTypeInfo,
+			model.NotificationEventMention,
+			&reportID,
 		); err != nil {
+			tx.Rollback()
 			return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "Gagal membuat tugas notifikasi mention", err.Error(), nil)
 		}
 	}
@@ -1162,6 +1327,36 @@ func (s *ReportService) CreateReportComment(ctx context.Context, userID, reportI
 		hexValue := reportCommentCreated.ThreadRootID.Hex()
 		threadRootIDStr = &hexValue
 	}
+	if err := errors.Join(
+		taskOutbox.RecalculateReportPriorityTask(reportID),
+		taskOutbox.DispatchWebhookEventTask(model.WebhookCommentCreated, webhookDTO.CommentEventData{
+			CommentID:       newCommentID,
+			ReportID:        reportID,
+			UserID:          commenterID,
+			ParentCommentID: parentCommentIDStr,
+			Content:         reportComment.Content,
+			CreatedAt:       reportComment.CreatedAt,
+		}),
+		taskOutbox.PublishRealtimeEventTask(model.RealtimeScopeReport, reportID, model.RealtimeCommentCreated, realtimeDTO.CommentEventData{
+			CommentID:       newCommentID,
+			ReportID:        reportID,
+			UserID:          commenterID,
+			Username:        commenterName,
+			ParentCommentID: parentCommentIDStr,
+			ThreadRootID:    threadRootIDStr,
+			Content:         reportComment.Content,
+			CreatedAt:       reportComment.CreatedAt,
+		}),
+	); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "OUTBOX_WRITE_FAILED", "Gagal menyimpan event komentar", err.Error(), nil)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal menyimpan perubahan", err.Error(), nil)
+	}
+
 	return &dto.CreateReportCommentResponse{
 		CommentID:       newCommentID,
 		ReportID:        reportCommentCreated.ReportID,
@@ -1267,6 +1462,13 @@ func (s *ReportService) GetReportComments(ctx context.Context, reportID uint, cu
 		}
 	}
 	comments := util.ConvertRootCommentsToDTO(commentsFromDB, userMap, replyCounts, mentionsMap)
+	anonymousOwnerID, err := s.reportRepo.GetAnonymousOwnerID(ctx, reportID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
+	}
+	if anonymousOwnerID != nil {
+		util.RedactCommentsOwner(comments, *anonymousOwnerID)
+	}
 
 	resp := dto.GetReportCommentsResponse{
 		Comments: comments,
@@ -1392,6 +1594,13 @@ func (s *ReportService) GetReportCommentReplies(ctx context.Context, rootID stri
 	}
 
 	replies := util.ConvertRepliesToDTO(repliesFromDB, userMap, parentsData, mentionsMap)
+	anonymousOwnerID, err := s.reportRepo.GetAnonymousOwnerID(ctx, rootComment.ReportID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
+	}
+	if anonymousOwnerID != nil {
+		util.RedactRepliesOwner(replies, *anonymousOwnerID)
+	}
 
 	total, err := s.reportCommentRepo.GetCountsByRootID(ctx, *primitiveRootID)
 	if err != nil {
@@ -1404,3 +1613,596 @@ func (s *ReportService) GetReportCommentReplies(ctx context.Context, rootID stri
 		TotalCounts: total,
 	}, nil
 }
+
+func (s *ReportService) MergeReports(ctx context.Context, userID, reportID uint, req dto.MergeReportsRequest) (*dto.MergeReportsResponse, error) {
+	requestID := contextutils.GetRequestID(ctx)
+	logger.Info("Merging duplicate reports",
+		zap.String("request_id", requestID),
+		zap.Uint("user_id", userID),
+		zap.Uint("canonical_report_id", reportID),
+		zap.Any("duplicate_report_ids", req.DuplicateReportIDs),
+	)
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "USER_NOT_FOUND", "Pengguna tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "USER_FETCH_FAILED", "Gagal mendapatkan data pengguna", err.Error(), nil)
+	}
+	if user.Role != model.UserRoleModerator && user.Role != model.UserRoleAgency {
+		return nil, apperror.New(403, "FORBIDDEN", "Hanya moderator atau instansi yang dapat menggabungkan laporan", "", nil)
+	}
+
+	duplicateIDs := make([]uint, 0, len(req.DuplicateReportIDs))
+	seenIDs := make(map[uint]bool, len(req.DuplicateReportIDs))
+	for _, duplicateID := range req.DuplicateReportIDs {
+		if duplicateID == reportID {
+			return nil, apperror.New(400, "CANNOT_MERGE_SELF", "Laporan tidak dapat digabungkan ke dirinya sendiri", "", nil)
+		}
+		if !seenIDs[duplicateID] {
+			seenIDs[duplicateID] = true
+			duplicateIDs = append(duplicateIDs, duplicateID)
+		}
+	}
+
+	canonicalReport, err := s.reportRepo.GetByIDIsDeleted(ctx, reportID, false)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if mergedIntoID, mergedErr := s.reportRepo.GetMergedIntoID(ctx, reportID); mergedErr == nil && mergedIntoID != nil {
+				return nil, apperror.New(409, "CANONICAL_REPORT_MERGED", "Laporan utama sudah digabungkan ke laporan lain", "", nil)
+			}
+			return nil, apperror.New(404, "REPORT_NOT_FOUND", "Laporan tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
+	}
+
+	duplicateReports, err := s.reportRepo.GetByIDs(ctx, duplicateIDs)
+	if err != nil {
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan duplikat", err.Error(), nil)
+	}
+	if len(duplicateReports) != len(duplicateIDs) {
+		return nil, apperror.New(404, "DUPLICATE_REPORT_NOT_FOUND", "Sebagian laporan duplikat tidak ditemukan atau sudah digabungkan", "", nil)
+	}
+
+	tx := s.postgreDB.Begin()
+	if tx.Error != nil {
+		return nil, apperror.New(500, "TRANSACTION_START_FAILED", "Gagal memulai transaksi", tx.Error.Error(), nil)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Re-check every report under a row lock: another merge may have moved
+	// the canonical or a duplicate report since they were read above.
+	lockedReports, err := s.reportRepo.LockByIDsForUpdateTX(ctx, tx, append([]uint{reportID}, duplicateIDs...))
+	if err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "REPORT_LOCK_FAILED", "Gagal mengunci laporan", err.Error(), nil)
+	}
+	lockedByID := make(map[uint]model.Report, len(lockedReports))
+	for _, lockedReport := range lockedReports {
+		lockedByID[lockedReport.ID] = lockedReport
+	}
+	if locked, ok := lockedByID[reportID]; !ok || locked.MergedIntoID != nil || (locked.IsDeleted != nil && *locked.IsDeleted) {
+		tx.Rollback()
+		return nil, apperror.New(409, "CANONICAL_REPORT_MERGED", "Laporan utama sudah digabungkan ke laporan lain", "", nil)
+	}
+	for _, duplicateID := range duplicateIDs {
+		if locked, ok := lockedByID[duplicateID]; !ok || locked.MergedIntoID != nil || (locked.IsDeleted != nil && *locked.IsDeleted) {
+			tx.Rollback()
+			return nil, apperror.New(404, "DUPLICATE_REPORT_NOT_FOUND", "Sebagian laporan duplikat tidak ditemukan atau sudah digabungkan", "", nil)
+		}
+	}
+
+	var movedVotes, movedReactions int64
+	for _, duplicateReport := range duplicateReports {
+		votes, err := s.reportVoteRepo.MoveToReportTX(ctx, tx, duplicateReport.ID, reportID, canonicalReport.UserID)
+		if err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "VOTE_MOVE_FAILED", "Gagal memindahkan suara laporan", err.Error(), nil)
+		}
+		movedVotes += votes
+
+		reactions, err := s.reportReactionRepo.MoveToReportTX(ctx, tx, duplicateReport.ID, reportID)
+		if err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "REACTION_MOVE_FAILED", "Gagal memindahkan reaksi laporan", err.Error(), nil)
+		}
+		movedReactions += reactions
+
+		if err := s.reportProgressRepo.MoveToReportTX(ctx, tx, duplicateReport.ID, reportID); err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "PROGRESS_MOVE_FAILED", "Gagal memindahkan progres laporan", err.Error(), nil)
+		}
+	}
+
+	mergedAt := time.Now().Unix()
+	if err := s.reportRepo.MarkMergedTX(ctx, tx, duplicateIDs, reportID, mergedAt); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "REPORT_MERGE_FAILED", "Gagal menandai laporan sebagai duplikat", err.Error(), nil)
+	}
+
+	// The stored vote totals always follow the moved votes; only the status
+	// transition depends on the canonical report still being in progress.
+	if movedVotes > 0 && canonicalReport.HasProgress != nil && *canonicalReport.HasProgress &&
+		canonicalReport.ReportStatus != model.RESOLVED && canonicalReport.ReportStatus != model.EXPIRED {
+		if err := s.evaluateVoteConsensusTX(ctx, tx, canonicalReport, userID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	} else if _, _, err := s.refreshVoteTotalsTX(ctx, tx, canonicalReport); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if _, err := s.reportRepo.UpdateTX(ctx, tx, canonicalReport); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "REPORT_UPDATE_FAILED", "Gagal memperbarui status laporan", err.Error(), nil)
+	}
+
+	taskOutbox := s.tasksService.WithTx(tx)
+	canonicalEntityID := mainutils.StrPtrOrNil(strconv.FormatUint(uint64(reportID), 10))
+	notifiedOwners := map[uint]bool{userID: true}
+	for _, duplicateReport := range duplicateReports {
+		if notifiedOwners[duplicateReport.UserID] {
+			continue
+		}
+		notifiedOwners[duplicateReport.UserID] = true
+		if err := taskOutbox.CreateNotificationTask(
+			duplicateReport.UserID,
+			"notification.report_merged",
+			map[string]string{"report": duplicateReport.ReportTitle, "canonicalReport": canonicalReport.ReportTitle},
+			canonicalEntityID,
+			model.EntityTypeReport,
+			model.ReportNotificationCategory,
+			model.NotificationTypeInfo,
+			model.NotificationEventReportMerged,
+			&duplicateReport.ID,
+		); err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "Gagal membuat tugas notifikasi penggabungan", err.Error(), nil)
+		}
+	}
+	if canonicalReport.UserID != userID {
+		if err := taskOutbox.CreateNotificationTask(
+			canonicalReport.UserID,
+			"notification.duplicates_merged",
+			map[string]string{"count": strconv.Itoa(len(duplicateReports)), "report": canonicalReport.ReportTitle},
+			canonicalEntityID,
+			model.EntityTypeReport,
+			model.ReportNotificationCategory,
+			model.NotificationTypeInfo,
+			model.NotificationEventReportMerged,
+			&reportID,
+		); err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "Gagal membuat tugas notifikasi penggabungan", err.Error(), nil)
+		}
+	}
+
+	// Comments live in MongoDB, so they are moved by an outbox task after the
+	// merge commits instead of inside this transaction.
+	outboxErrs := []error{
+		taskOutbox.RecalculateReportPriorityTask(reportID),
+		taskOutbox.EvaluateReportReputationTask(reportID),
+	}
+	for _, duplicateID := range duplicateIDs {
+		outboxErrs = append(outboxErrs,
+			taskOutbox.MoveMergedReportCommentsTask(duplicateID, reportID),
+			taskOutbox.EvaluateReportReputationTask(duplicateID),
+		)
+	}
+	if err := errors.Join(outboxErrs...); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "OUTBOX_WRITE_FAILED", "Gagal menyimpan event laporan", err.Error(), nil)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal menyimpan perubahan", err.Error(), nil)
+	}
+
+	logger.Info("Duplicate reports merged successfully",
+		zap.String("request_id", requestID),
+		zap.Uint("canonical_report_id", reportID),
+		zap.Int64("moved_votes", movedVotes),
+		zap.Int64("moved_reactions", movedReactions),
+	)
+
+	return &dto.MergeReportsResponse{
+		CanonicalReportID: reportID,
+		MergedReportIDs:   duplicateIDs,
+		MovedVotes:        movedVotes,
+		MovedReactions:    movedReactions,
+		ReportStatus:      canonicalReport.ReportStatus,
+	}, nil
+}
+
+func (s *ReportService) MarkCommentHelpful(ctx context.Context, userID uint, commentID string) (*dto.MarkCommentHelpfulResponse, error) {
+	requestID := contextutils.GetRequestID(ctx)
+	logger.Info("Marking report comment as helpful",
+		zap.String("request_id", requestID),
+		zap.Uint("user_id", userID),
+		zap.String("comment_id", commentID),
+	)
+
+	primitiveCommentID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, apperror.New(400, "INVALID_COMMENT_ID", "ID komentar tidak valid", err.Error(), nil)
+	}
+
+	comment, err := s.reportCommentRepo.GetByID(ctx, primitiveCommentID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperror.New(404, "COMMENT_NOT_FOUND", "Komentar tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "COMMENT_FETCH_FAILED", "Gagal mengambil komentar", err.Error(), nil)
+	}
+
+	report, err := s.reportRepo.GetByIDIsDeleted(ctx, comment.ReportID, false)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "REPORT_NOT_FOUND", "Laporan tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "REPORT_FETCH_FAILED", "Gagal mengambil laporan", err.Error(), nil)
+	}
+	if report.UserID != userID {
+		return nil, apperror.New(403, "FORBIDDEN", "Hanya pemilik laporan yang dapat menandai komentar sebagai membantu", "", nil)
+	}
+	if comment.UserID == userID {
+		return nil, apperror.New(400, "CANNOT_MARK_OWN_COMMENT", "Anda tidak dapat menandai komentar sendiri sebagai membantu", "", nil)
+	}
+	if comment.IsHelpful {
+		return nil, apperror.New(409, "COMMENT_ALREADY_HELPFUL", "Komentar sudah ditandai sebagai membantu", "", nil)
+	}
+
+	if err := s.reportCommentRepo.MarkHelpful(ctx, primitiveCommentID); err != nil {
+		return nil, apperror.New(500, "COMMENT_UPDATE_FAILED", "Gagal menandai komentar sebagai membantu", err.Error(), nil)
+	}
+
+	if err := s.tasksService.AwardReputationTask(comment.UserID, model.ReputationHelpfulComment, model.ReputationSourceComment, commentID); err != nil {
+		logger.Error("Failed to enqueue helpful comment reputation",
+			zap.String("request_id", requestID),
+			zap.String("comment_id", commentID),
+			zap.Error(err),
+		)
+	}
+
+	return &dto.MarkCommentHelpfulResponse{
+		CommentID: commentID,
+		ReportID:  comment.ReportID,
+		UserID:    comment.UserID,
+		IsHelpful: true,
+	}, nil
+}
+
+func (s *ReportService) CreateReportDraft(ctx context.Context, userID uint, req dto.SaveReportDraftRequest) (*dto.SaveReportDraftResponse, error) {
+	now := time.Now()
+
+	totalDrafts, err := s.reportDraftRepo.CountActiveByUserID(ctx, userID, now.Unix())
+	if err != nil {
+		return nil, apperror.New(500, "REPORT_DRAFT_FETCH_FAILED", "Gagal mengambil draf laporan", err.Error(), nil)
+	}
+	if totalDrafts >= util.MaxReportDraftsPerUser {
+		return nil, apperror.New(400, "REPORT_DRAFT_LIMIT_REACHED", fmt.Sprintf("Maksimal %d draf laporan aktif", util.MaxReportDraftsPerUser), "", nil)
+	}
+
+	if len(req.ExistingImages) > 0 {
+		return nil, apperror.New(400, "INVALID_DRAFT_IMAGES", "Gambar draf laporan tidak valid", "", nil)
+	}
+	if len(req.NewImages) > util.MaxReportDraftImages {
+		return nil, apperror.New(400, "TOO_MANY_IMAGES", "Terlalu banyak gambar", "", nil)
+	}
+
+	draft := model.ReportDraft{
+		UserID:    userID,
+		ExpiresAt: now.Add(util.GetReportDraftTTL()).Unix(),
+	}
+	util.ApplyReportDraftRequest(&draft, req)
+	util.SetReportDraftImages(&draft, req.NewImages)
+
+	if err := s.reportDraftRepo.Create(ctx, &draft); err != nil {
+		return nil, apperror.New(500, "REPORT_DRAFT_CREATE_FAILED", "Gagal menyimpan draf laporan", err.Error(), nil)
+	}
+
+	return &dto.SaveReportDraftResponse{
+		Draft: util.ConvertReportDraftToDTO(&draft),
+	}, nil
+}
+
+func (s *ReportService) UpdateReportDraft(ctx context.Context, userID, draftID uint, req dto.SaveReportDraftRequest) (*dto.SaveReportDraftResponse, error) {
+	now := time.Now()
+
+	draft, err := s.getActiveReportDraft(ctx, userID, draftID, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	currentImages := make(map[string]bool)
+	for _, image := range util.GetReportDraftImages(draft) {
+		currentImages[image] = true
+	}
+
+	keptImages := make(map[string]bool)
+	images := make([]string, 0, util.MaxReportDraftImages)
+	for _, image := range req.ExistingImages {
+		if !currentImages[image] {
+			return nil, apperror.New(400, "INVALID_DRAFT_IMAGES", "Gambar draf laporan tidak valid", "", nil)
+		}
+		if keptImages[image] {
+			continue
+		}
+		keptImages[image] = true
+		images = append(images, image)
+	}
+	images = append(images, req.NewImages...)
+	if len(images) > util.MaxReportDraftImages {
+		return nil, apperror.New(400, "TOO_MANY_IMAGES", "Terlalu banyak gambar", "", nil)
+	}
+
+	var removedImages []string
+	for image := range currentImages {
+		if !keptImages[image] {
+			removedImages = append(removedImages, image)
+		}
+	}
+
+	util.ApplyReportDraftRequest(draft, req)
+	util.SetReportDraftImages(draft, images)
+	draft.ExpiresAt = now.Add(util.GetReportDraftTTL()).Unix()
+
+	updatedDraft, err := s.reportDraftRepo.Update(ctx, draft)
+	if err != nil {
+		return nil, apperror.New(500, "REPORT_DRAFT_UPDATE_FAILED", "Gagal memperbarui draf laporan", err.Error(), nil)
+	}
+
+	return &dto.SaveReportDraftResponse{
+		Draft:         util.ConvertReportDraftToDTO(updatedDraft),
+		RemovedImages: removedImages,
+	}, nil
+}
+
+func (s *ReportService) GetReportDrafts(ctx context.Context, userID uint) (*dto.GetReportDraftsResponse, error) {
+	drafts, err := s.reportDraftRepo.GetActiveByUserID(ctx, userID, time.Now().Unix())
+	if err != nil {
+		return nil, apperror.New(500, "REPORT_DRAFT_FETCH_FAILED", "Gagal mengambil draf laporan", err.Error(), nil)
+	}
+
+	result := make([]dto.ReportDraft, 0, len(drafts))
+	for i := range drafts {
+		result = append(result, util.ConvertReportDraftToDTO(&drafts[i]))
+	}
+	return &dto.GetReportDraftsResponse{Drafts: result}, nil
+}
+
+func (s *ReportService) GetReportDraft(ctx context.Context, userID, draftID uint) (*dto.ReportDraft, error) {
+	draft, err := s.getActiveReportDraft(ctx, userID, draftID, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	result := util.ConvertReportDraftToDTO(draft)
+	return &result, nil
+}
+
+// GetReportDraftImagePath returns the file of a draft image for its owner.
+// Draft images are not served statically, so this is the only way to view
+// them before the draft is published.
+func (s *ReportService) GetReportDraftImagePath(ctx context.Context, userID, draftID uint, image string) (string, error) {
+	draft, err := s.getActiveReportDraft(ctx, userID, draftID, time.Now().Unix())
+	if err != nil {
+		return "", err
+	}
+	for _, draftImage := range util.GetReportDraftImages(draft) {
+		if draftImage == image {
+			return util.GetReportDraftImagePath(image), nil
+		}
+	}
+	return "", apperror.New(404, "REPORT_DRAFT_IMAGE_NOT_FOUND", "Gambar draf laporan tidak ditemukan", "", nil)
+}
+
+func (s *ReportService) DeleteReportDraft(ctx context.Context, userID, draftID uint) ([]string, error) {
+	draft, err := s.getActiveReportDraft(ctx, userID, draftID, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reportDraftRepo.Delete(ctx, draft.ID); err != nil {
+		return nil, apperror.New(500, "REPORT_DRAFT_DELETE_FAILED", "Gagal menghapus draf laporan", err.Error(), nil)
+	}
+	return util.GetReportDraftImages(draft), nil
+}
+
+func (s *ReportService) PublishReportDraft(ctx context.Context, userID, draftID uint) (*dto.CreateReportResponse, error) {
+	draft, err := s.getActiveReportDraft(ctx, userID, draftID, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	req := util.ConvertReportDraftToCreateRequest(draft)
+	if err := validation.Validate.Struct(req); err != nil {
+		return nil, apperror.New(400, "VALIDATION_FAILED", "Validasi gagal", err.Error(), validation.FormatCreateReportValidationErrors(err))
+	}
+
+	// The draft is deleted first in the transaction that creates the report.
+	// Its row lock makes a concurrent publish of the same draft wait and then
+	// find nothing to delete, so only one report is created and only the
+	// winner copies the images. The images are copied to the public directory
+	// before the report is created so it never points at a missing file;
+	// after commit only the report references them, and the expired-draft
+	// cron cannot remove them.
+	tx := s.postgreDB.Begin()
+	if tx.Error != nil {
+		return nil, apperror.New(500, "TRANSACTION_START_FAILED", "Gagal memulai transaksi", tx.Error.Error(), nil)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	deleted, err := s.reportDraftRepo.DeleteTX(ctx, tx, draft.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "REPORT_DRAFT_DELETE_FAILED", "Gagal menghapus draf laporan", err.Error(), nil)
+	}
+	if !deleted {
+		tx.Rollback()
+		return nil, apperror.New(404, "REPORT_DRAFT_NOT_FOUND", "Draf laporan tidak ditemukan", "", nil)
+	}
+
+	images := util.GetReportDraftImages(draft)
+	if err := util.CopyReportDraftImagesToReport(images); err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "REPORT_DRAFT_IMAGE_PUBLISH_FAILED", "Gagal memindahkan gambar draf laporan", err.Error(), nil)
+	}
+
+	result, err := s.createReportTX(ctx, tx, userID, req)
+	if err != nil {
+		tx.Rollback()
+		util.RemovePublishedReportDraftCopies(images)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		util.RemovePublishedReportDraftCopies(images)
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal menyimpan perubahan", err.Error(), nil)
+	}
+
+	util.RemovePublishedReportDraftImages(images)
+
+	logger.Info("Report draft published",
+		zap.String("request_id", contextutils.GetRequestID(ctx)),
+		zap.Uint("draft_id", draft.ID),
+		zap.Uint("report_id", result.Report.ID),
+		zap.Uint("user_id", userID),
+	)
+	return result, nil
+}
+
+func (s *ReportService) getActiveReportDraft(ctx context.Context, userID, draftID uint, now int64) (*model.ReportDraft, error) {
+	draft, err := s.reportDraftRepo.GetActiveByIDAndUserID(ctx, draftID, userID, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "REPORT_DRAFT_NOT_FOUND", "Draf laporan tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "REPORT_DRAFT_FETCH_FAILED", "Gagal mengambil draf laporan", err.Error(), nil)
+	}
+	return draft, nil
+}
+
+func reportStatusChangedEventData(report *model.Report, previousStatus model.ReportStatus) webhookDTO.ReportStatusChangedEventData {
+	return webhookDTO.ReportStatusChangedEventData{
+		ReportID:       report.ID,
+		PreviousStatus: string(previousStatus),
+		ReportStatus:   string(report.ReportStatus),
+		UpdatedBy:      string(report.LastUpdatedBy),
+		UpdatedAt:      time.Now().Unix(),
+	}
+}
+
+func reportStatusRealtimeEventData(report *model.Report, previousStatus model.ReportStatus) realtimeDTO.ReportStatusEventData {
+	return realtimeDTO.ReportStatusEventData{
+		ReportID:       report.ID,
+		PreviousStatus: string(previousStatus),
+		ReportStatus:   string(report.ReportStatus),
+		UpdatedBy:      string(report.LastUpdatedBy),
+		UpdatedAt:      time.Now().Unix(),
+	}
+}
+
+// refreshVoteTotalsTX copies the current vote counts and weights onto the
+// report's stored totals without touching its status.
+func (s *ReportService) refreshVoteTotalsTX(ctx context.Context, tx *gorm.DB, report *model.Report) (map[model.ReportStatus]int64, map[model.ReportStatus]float64, error) {
+	reportVoteCounts, err := s.reportVoteRepo.GetReportVoteCountsTX(ctx, tx, report.ID)
+	if err != nil {
+		return nil, nil, apperror.New(500, "VOTE_COUNT_FAILED", "Gagal mendapatkan jumlah suara laporan", err.Error(), nil)
+	}
+	reportVoteWeights, err := s.reportVoteRepo.GetReportVoteWeightsTX(ctx, tx, report.ID)
+	if err != nil {
+		return nil, nil, apperror.New(500, "VOTE_WEIGHT_FAILED", "Gagal mendapatkan bobot suara laporan", err.Error(), nil)
+	}
+
+	report.ResolvedVoteCount = reportVoteCounts[model.RESOLVED]
+	report.OnProgressVoteCount = reportVoteCounts[model.ON_PROGRESS]
+	report.ResolvedVoteWeight = reportVoteWeights[model.RESOLVED]
+	report.OnProgressVoteWeight = reportVoteWeights[model.ON_PROGRESS]
+	return reportVoteCounts, reportVoteWeights, nil
+}
+
+func (s *ReportService) evaluateVoteConsensusTX(ctx context.Context, tx *gorm.DB, report *model.Report, actorUserID uint) error {
+	reportVoteCounts, reportVoteWeights, err := s.refreshVoteTotalsTX(ctx, tx, report)
+	if err != nil {
+		return err
+	}
+	totalVote, err := s.reportVoteRepo.GetTotalVoteCountTX(ctx, tx, report.ID)
+	if err != nil {
+		return apperror.New(500, "VOTE_COUNT_FAILED", "Gagal mendapatkan total suara laporan", err.Error(), nil)
+	}
+
+	voteTypeWeightsOrder := util.GetWeightedVoteTypeOrder(reportVoteWeights)
+	topVote := voteTypeWeightsOrder[0]
+	secondVote := voteTypeWeightsOrder[1]
+
+	var totalWeight float64
+	for _, weight := range reportVoteWeights {
+		totalWeight += weight
+	}
+	var marginVote float64
+	if totalWeight > 0 {
+		marginVote = (topVote.Weight - secondVote.Weight) / totalWeight * 100
+	}
+
+	limitTopVote := int64(2)
+	limitTopVoteWeight := 2.0
+	if marginVote >= 20.0 && reportVoteCounts[topVote.Type] >= limitTopVote && topVote.Weight >= limitTopVoteWeight && report.ReportStatus != model.WAITING_CONFIRMATION {
+		report.ReportStatus = model.WAITING_CONFIRMATION
+		report.LastUpdatedBy = model.System
+		report.LastUpdatedProgressAt = mainutils.Int64PtrOrNil(time.Now().Unix())
+		report.PotentiallyResolvedAt = mainutils.Int64PtrOrNil(time.Now().Unix())
+		reportLink := fmt.Sprintf("%s/main/reports/%d", env.ClientURL(), report.ID)
+		reminderEmail, err := util.BuildPotentiallyResolvedReportEmail(
+			report.User.Email,
+			report.User.Username,
+			report.ReportTitle,
+			reportLink,
+			7,
+			mailer.NewIdempotencyKey("progress_reminder", report.ID, *report.PotentiallyResolvedAt),
+			i18n.Preferred(report.User.Language, i18n.DefaultLanguage),
+		)
+		if err != nil {
+			logger.Error("Failed to build progress reminder email", zap.Uint("report_id", report.ID), zap.Error(err))
+		} else if err := s.tasksService.WithTx(tx).SendEmailTask(reminderEmail); err != nil {
+			return apperror.New(500, "EMAIL_QUEUE_FAILED", "Gagal mengirim email", err.Error(), nil)
+		}
+		if err := s.tasksService.WithTx(tx).AutoResolveReportTask(report.ID); err != nil {
+			return apperror.New(500, "AUTO_RESOLVE_TASK_FAILED", "Gagal membuat tugas penyelesaian otomatis", err.Error(), nil)
+		}
+
+		reportStatusMessage := map[any]string{
+			model.RESOLVED:        "TERSELESAIKAN",
+			model.ON_PROGRESS:     "SEDANG_DIPROSES",
+			model.WAITING_CONFIRMATION: "MENUNGGU_KONFIRMASI",
+		}
+		progressNotes := fmt.Sprintf("Laporan menunggu konfirmasi karena mendapatkan suara tertinggi dengan status laporan: '%s' dan Total suara: %d.", reportStatusMessage[topVote.Type], totalVote)
+
+		var newProgress *model.ReportProgress
+		if report.ReportStatus == model.WAITING_CONFIRMATION {
+			newProgress = &model.ReportProgress{
+				ReportID:    report.ID,
+				UserID:      actorUserID,
+				Status:      model.WAITING_CONFIRMATION,
+				Notes:       progressNotes,
+				CreatedAt:   time.Now().Unix(),
+			}
+		}
+
+		if _, err := s.reportProgressRepo.CreateTX(ctx, tx, newProgress); err != nil {
+			return apperror.New(500, "PROGRESS_CREATE_FAILED", "Gagal mengunggah progres laporan", err.Error(), nil)
+		}
+	}
+
+	return nil
+}
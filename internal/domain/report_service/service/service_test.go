@@ -3,7 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"pingspot/internal/domain/report_service/dto"
+	"pingspot/internal/domain/report_service/util"
+	"pingspot/internal/mocks"
 	"pingspot/internal/mocks/report"
 	taskServiceMocks "pingspot/internal/mocks/task"
 	userMocks "pingspot/internal/mocks/user"
@@ -65,6 +69,8 @@ func TestNewReportService(t *testing.T) {
 			mockReportVoteRepo,
 			mockTaskService,
 			mockReportCommentRepo,
+			new(report.MockReportDraftRepository),
+			new(mocks.MockCacheRepository),
 		)
 
 		require.NotNil(t, service)
@@ -86,6 +92,8 @@ func setupMocks(t *testing.T) (
 ) {
 	postgreDB := setupTestDB(t)
 	mockReportRepo := new(report.MockReportRepository)
+	mockReportRepo.On("IncrementViewCount", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockReportRepo.On("GetMergedIntoID", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
 	mockReportLocationRepo := new(report.MockReportLocationRepository)
 	mockReportReactionRepo := new(report.MockReportReactionRepository)
 	mockReportImageRepo := new(report.MockReportImageRepository)
@@ -93,7 +101,13 @@ func setupMocks(t *testing.T) (
 	mockUserProfileRepo := new(userMocks.MockUserProfileRepository)
 	mockReportProgressRepo := new(report.MockReportProgressRepository)
 	mockReportVoteRepo := new(report.MockReportVoteRepository)
+	mockReportVoteRepo.On("GetUserVoteAccuracy", mock.Anything, mock.Anything).Return(int64(0), int64(0), nil).Maybe()
 	mockTaskService := new(taskServiceMocks.MockTaskService)
+	mockTaskService.On("RecalculateReportPriorityTask", mock.Anything).Return(nil).Maybe()
+	mockTaskService.On("EvaluateReportReputationTask", mock.Anything).Return(nil).Maybe()
+	mockTaskService.On("GamificationEventTask", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTaskService.On("DispatchWebhookEventTask", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockTaskService.On("PublishRealtimeEventTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	mockReportCommentRepo := new(report.MockReportCommentRepository)
 
 	service := NewreportService(
@@ -109,6 +123,8 @@ func setupMocks(t *testing.T) (
 		mockReportVoteRepo,
 		mockTaskService,
 		mockReportCommentRepo,
+		new(report.MockReportDraftRepository),
+		new(mocks.MockCacheRepository),
 	)
 
 	return mockReportRepo, mockReportLocationRepo, mockReportReactionRepo, mockReportImageRepo,
@@ -154,6 +170,51 @@ func TestReportService_CreateReport(t *testing.T) {
 		mockReportImageRepo.AssertExpectations(t)
 	})
 
+	t.Run("should create anonymous report for allowed type", func(t *testing.T) {
+		mockReportRepo, mockReportLocationRepo, _, mockReportImageRepo, _, _, _, _, mockTaskService, _, service := setupMocks(t)
+		t.Setenv("ANONYMOUS_REPORT_TYPES", "")
+
+		req := dto.CreateReportRequest{
+			ReportTitle:       "Pungutan liar",
+			ReportDescription: "Ada pungutan liar di pasar",
+			ReportType:        "SAFETY",
+			IsAnonymous:       true,
+			Latitude:          -6.2,
+			Longitude:         106.816666,
+			DetailLocation:    "Pasar",
+		}
+
+		mockReportRepo.On("Create", ctx, mock.AnythingOfType("*model.Report"), mock.AnythingOfType("*gorm.DB")).Return(nil)
+		mockReportLocationRepo.On("Create", ctx, mock.AnythingOfType("*model.ReportLocation"), mock.AnythingOfType("*gorm.DB")).Return(nil)
+		mockReportImageRepo.On("Create", ctx, mock.AnythingOfType("*model.ReportImage"), mock.AnythingOfType("*gorm.DB")).Return(nil)
+
+		result, err := service.CreateReport(ctx, 1, req)
+
+		require.NoError(t, err)
+		assert.True(t, result.Report.IsAnonymous)
+		assert.Equal(t, uint(1), result.Report.UserID)
+		mockTaskService.AssertNotCalled(t, "GamificationEventTask", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject anonymous report for disallowed type", func(t *testing.T) {
+		mockReportRepo, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+		t.Setenv("ANONYMOUS_REPORT_TYPES", "")
+
+		req := dto.CreateReportRequest{
+			ReportTitle:       "Jalan rusak",
+			ReportDescription: "Jalan rusak parah",
+			ReportType:        "INFRASTRUCTURE",
+			IsAnonymous:       true,
+		}
+
+		result, err := service.CreateReport(ctx, 1, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Laporan anonim tidak tersedia")
+		mockReportRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("should return error when report creation fails", func(t *testing.T) {
 		mockReportRepo, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
 
@@ -506,6 +567,50 @@ func TestReportService_GetReportByID(t *testing.T) {
 		assert.Nil(t, result)
 		mockReportRepo.AssertExpectations(t)
 	})
+
+	t.Run("should count one view per viewer within the dedupe window", func(t *testing.T) {
+		mockReportRepo, _, mockReportReactionRepo, _, _, _, _, mockReportVoteRepo, _, _, service := setupMocks(t)
+		mockCacheRepo := new(mocks.MockCacheRepository)
+		service.cacheRepo = mockCacheRepo
+
+		existingReport := &model.Report{
+			ID:              1,
+			UserID:          1,
+			ReportTitle:     "Test Report",
+			ReportStatus:    model.WAITING,
+			HasProgress:     mainutils.BoolPtrOrNil(false),
+			ReportLocation:  &model.ReportLocation{},
+			ReportProgress:  &[]model.ReportProgress{},
+			ReportImages:    &model.ReportImage{},
+			IsDeleted:       mainutils.BoolPtrOrNil(false),
+			ReportType:      model.Infrastructure,
+			User:            model.User{ID: 1, Username: "owner"},
+			ReportVotes:     &[]model.ReportVote{},
+			ReportReactions: &[]model.ReportReaction{},
+		}
+
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(existingReport, nil)
+		mockReportReactionRepo.On("GetByUserReportID", ctx, uint(2), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockReportVoteRepo.On("GetByUserReportID", ctx, uint(2), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockReportReactionRepo.On("GetLikeReactionCount", ctx, uint(1)).Return(int64(0), nil)
+		mockReportReactionRepo.On("GetDislikeReactionCount", ctx, uint(1)).Return(int64(0), nil)
+		mockReportVoteRepo.On("GetResolvedVoteCount", ctx, uint(1)).Return(int64(0), nil)
+		mockReportVoteRepo.On("GetOnProgressVoteCount", ctx, uint(1)).Return(int64(0), nil)
+		mockReportVoteRepo.On("GetNotResolvedVoteCount", ctx, uint(1)).Return(int64(0), nil)
+		viewKey := util.GetReportViewKey(1, 2)
+		mockCacheRepo.On("SetNX", ctx, viewKey, mock.Anything, util.ReportViewDedupeWindow).Return(true, nil).Once()
+		mockCacheRepo.On("SetNX", ctx, viewKey, mock.Anything, util.ReportViewDedupeWindow).Return(false, nil).Once()
+
+		_, err := service.ViewReportByID(ctx, 2, 1)
+		require.NoError(t, err)
+		_, err = service.ViewReportByID(ctx, 2, 1)
+		require.NoError(t, err)
+		_, err = service.GetReportByID(ctx, 2, 1)
+		require.NoError(t, err)
+
+		mockReportRepo.AssertNumberOfCalls(t, "IncrementViewCount", 1)
+		mockCacheRepo.AssertNumberOfCalls(t, "SetNX", 2)
+	})
 }
 
 func TestReportService_ReactToReport(t *testing.T) {
@@ -616,10 +721,16 @@ func TestReportService_VoteToReport(t *testing.T) {
 				model.WAITING_CONFIRMATION: 0,
 			}, nil)
 		mockReportVoteRepo.On("GetTotalVoteCountTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).Return(int64(1), nil)
+		mockReportVoteRepo.On("GetReportVoteWeightsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).
+			Return(map[model.ReportStatus]float64{
+				model.RESOLVED:             1,
+				model.ON_PROGRESS:          0,
+				model.WAITING_CONFIRMATION: 0,
+			}, nil)
 		mockReportRepo.On("UpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Report")).
 			Return(&model.Report{}, nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -637,7 +748,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 
 		mockReportRepo.On("GetByID", ctx, uint(1)).Return(existingReport, nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -650,7 +761,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 
 		mockReportRepo.On("GetByID", ctx, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
-		result, err := service.VoteToReport(ctx, 1, 999, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 999, "RESOLVED", nil, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -669,7 +780,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 
 		mockReportRepo.On("GetByID", ctx, uint(1)).Return(existingReport, nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -688,7 +799,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 
 		mockReportRepo.On("GetByID", ctx, uint(1)).Return(existingReport, nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -707,7 +818,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 
 		mockReportRepo.On("GetByID", ctx, uint(1)).Return(existingReport, nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -737,7 +848,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 			Return(existingVote, nil)
 		mockReportVoteRepo.On("DeleteTX", ctx, mock.AnythingOfType("*gorm.DB"), existingVote).Return(nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.NoError(t, err)
 		assert.Nil(t, result)
@@ -779,10 +890,16 @@ func TestReportService_VoteToReport(t *testing.T) {
 				model.WAITING_CONFIRMATION: 0,
 			}, nil)
 		mockReportVoteRepo.On("GetTotalVoteCountTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).Return(int64(1), nil)
+		mockReportVoteRepo.On("GetReportVoteWeightsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).
+			Return(map[model.ReportStatus]float64{
+				model.RESOLVED:             1,
+				model.ON_PROGRESS:          0,
+				model.WAITING_CONFIRMATION: 0,
+			}, nil)
 		mockReportRepo.On("UpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Report")).
 			Return(&model.Report{}, nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -820,6 +937,12 @@ func TestReportService_VoteToReport(t *testing.T) {
 				model.RESOLVED:                     0,
 			}, nil)
 		mockReportVoteRepo.On("GetTotalVoteCountTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).Return(int64(5), nil)
+		mockReportVoteRepo.On("GetReportVoteWeightsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).
+			Return(map[model.ReportStatus]float64{
+				model.ReportStatus("NOT_RESOLVED"): 4,
+				model.ON_PROGRESS:                  1,
+				model.RESOLVED:                     0,
+			}, nil)
 		mockReportRepo.On("UpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(r *model.Report) bool {
 			return r.ID == 1 && r.ReportStatus == model.WAITING
 		})).Return(&model.Report{
@@ -827,7 +950,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 			ReportStatus: model.WAITING,
 		}, nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "NOT_RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "NOT_RESOLVED", nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -864,11 +987,17 @@ func TestReportService_VoteToReport(t *testing.T) {
 				model.WAITING_CONFIRMATION: 0,
 			}, nil)
 		mockReportVoteRepo.On("GetTotalVoteCountTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).Return(int64(5), nil)
+		mockReportVoteRepo.On("GetReportVoteWeightsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).
+			Return(map[model.ReportStatus]float64{
+				model.ON_PROGRESS:          4,
+				model.RESOLVED:             1,
+				model.WAITING_CONFIRMATION: 0,
+			}, nil)
 		mockReportRepo.On("UpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(r *model.Report) bool {
 			return r.ReportStatus == model.ON_PROGRESS
 		})).Return(&model.Report{}, nil)
 
-		result, err := service.VoteToReport(ctx, 1, 1, "ON_PROGRESS")
+		result, err := service.VoteToReport(ctx, 1, 1, "ON_PROGRESS", nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -899,7 +1028,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 		mockReportVoteRepo.On("GetReportVoteCountsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).
 			Return(nil, errors.New("database error"))
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -936,7 +1065,7 @@ func TestReportService_VoteToReport(t *testing.T) {
 		mockReportVoteRepo.On("GetTotalVoteCountTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).
 			Return(int64(0), errors.New("database error"))
 
-		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED")
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", nil, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -1287,7 +1416,7 @@ func TestReportService_GetReportComments(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("should get root comments successfully", func(t *testing.T) {
-		_, _, _, _, mockUserRepo, _, _, _, _, mockReportCommentRepo, service := setupMocks(t)
+		mockReportRepo, _, _, _, mockUserRepo, _, _, _, _, mockReportCommentRepo, service := setupMocks(t)
 
 		comments := []*model.ReportComment{
 			{
@@ -1314,6 +1443,7 @@ func TestReportService_GetReportComments(t *testing.T) {
 		mockReportCommentRepo.On("GetCountsByRootID", ctx, mock.AnythingOfType("primitive.ObjectID")).Return(int64(0), nil)
 
 		mockReportCommentRepo.On("GetCountsByReportID", ctx, uint(1)).Return(int64(1), nil)
+		mockReportRepo.On("GetAnonymousOwnerID", ctx, uint(1)).Return(nil, gorm.ErrRecordNotFound)
 
 		result, err := service.GetReportComments(ctx, 1, nil)
 
@@ -1323,6 +1453,36 @@ func TestReportService_GetReportComments(t *testing.T) {
 		mockReportCommentRepo.AssertExpectations(t)
 	})
 
+	t.Run("should redact owner of anonymous report", func(t *testing.T) {
+		mockReportRepo, _, _, _, mockUserRepo, _, _, _, _, mockReportCommentRepo, service := setupMocks(t)
+
+		comments := []*model.ReportComment{
+			{ID: primitive.NewObjectID(), UserID: 1, ReportID: 1, Content: mainutils.StrPtrOrNil("Sudah saya laporkan"), CreatedAt: time.Now().Unix()},
+			{ID: primitive.NewObjectID(), UserID: 2, ReportID: 1, Content: mainutils.StrPtrOrNil("Terima kasih [mention:1]"), CreatedAt: time.Now().Unix() + 1},
+		}
+
+		mockReportCommentRepo.On("GetPaginatedRootByReportID", ctx, uint(1), (*primitive.ObjectID)(nil), 51).Return(comments, nil)
+		mockUserRepo.On("GetByIDs", ctx, []uint{1, 2}).Return([]model.User{
+			{ID: 1, Username: "pelapor", FullName: "Pelapor Asli"},
+			{ID: 2, Username: "warga", FullName: "Warga"},
+		}, nil)
+		mockReportCommentRepo.On("GetCountsByRootID", ctx, mock.AnythingOfType("primitive.ObjectID")).Return(int64(0), nil)
+		mockReportCommentRepo.On("GetCountsByReportID", ctx, uint(1)).Return(int64(2), nil)
+		ownerID := uint(1)
+		mockReportRepo.On("GetAnonymousOwnerID", ctx, uint(1)).Return(&ownerID, nil)
+
+		result, err := service.GetReportComments(ctx, 1, nil)
+
+		require.NoError(t, err)
+		require.Len(t, result.Comments, 2)
+		assert.Equal(t, uint(0), result.Comments[0].UserInformation.UserID)
+		assert.Equal(t, "anonim", result.Comments[0].UserInformation.Username)
+		assert.Equal(t, "warga", result.Comments[1].UserInformation.Username)
+		assert.Equal(t, "Terima kasih [mention:0]", *result.Comments[1].Content)
+		require.Len(t, result.Comments[1].Mentions, 1)
+		assert.Equal(t, "anonim", result.Comments[1].Mentions[0].Username)
+	})
+
 	t.Run("should return error when fetching comments fails", func(t *testing.T) {
 		_, _, _, _, _, _, _, _, _, mockReportCommentRepo, service := setupMocks(t)
 
@@ -1341,7 +1501,7 @@ func TestReportService_GetReportCommentReplies(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("should get comment replies successfully", func(t *testing.T) {
-		_, _, _, _, mockUserRepo, _, _, _, _, mockReportCommentRepo, service := setupMocks(t)
+		mockReportRepo, _, _, _, mockUserRepo, _, _, _, _, mockReportCommentRepo, service := setupMocks(t)
 
 		rootID := primitive.NewObjectID()
 		replies := []*model.ReportComment{
@@ -1371,6 +1531,7 @@ func TestReportService_GetReportCommentReplies(t *testing.T) {
 		}, nil)
 
 		mockReportCommentRepo.On("GetCountsByRootID", ctx, rootID).Return(int64(1), nil)
+		mockReportRepo.On("GetAnonymousOwnerID", ctx, uint(0)).Return(nil, gorm.ErrRecordNotFound)
 
 		result, err := service.GetReportCommentReplies(ctx, rootID.Hex(), nil)
 
@@ -1402,3 +1563,435 @@ func TestReportService_GetReportCommentReplies(t *testing.T) {
 		assert.Nil(t, result)
 	})
 }
+
+func TestReportService_MergeReports(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should merge duplicate reports into canonical report", func(t *testing.T) {
+		mockReportRepo, _, mockReportReactionRepo, _, mockUserRepo, _, mockReportProgressRepo, mockReportVoteRepo, mockTaskService, mockReportCommentRepo, service := setupMocks(t)
+
+		canonicalReport := &model.Report{
+			ID:           1,
+			UserID:       2,
+			ReportTitle:  "Jalan berlubang",
+			ReportStatus: model.RESOLVED,
+			HasProgress:  mainutils.BoolPtrOrNil(true),
+		}
+
+		mockUserRepo.On("GetByID", ctx, uint(9)).Return(&model.User{ID: 9, Role: model.UserRoleModerator}, nil)
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(canonicalReport, nil)
+		mockReportRepo.On("GetByIDs", ctx, []uint{3}).Return([]model.Report{{ID: 3, UserID: 4, ReportTitle: "Lubang di jalan"}}, nil)
+		mockReportVoteRepo.On("MoveToReportTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(3), uint(1), uint(2)).Return(int64(2), nil)
+		mockReportReactionRepo.On("MoveToReportTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(3), uint(1)).Return(int64(1), nil)
+		mockReportProgressRepo.On("MoveToReportTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(3), uint(1)).Return(nil)
+		mockReportRepo.On("LockByIDsForUpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), []uint{1, 3}).Return([]model.Report{{ID: 1}, {ID: 3}}, nil)
+		mockReportRepo.On("MarkMergedTX", ctx, mock.AnythingOfType("*gorm.DB"), []uint{3}, uint(1), mock.AnythingOfType("int64")).Return(nil)
+		mockReportVoteRepo.On("GetReportVoteCountsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).Return(map[model.ReportStatus]int64{model.RESOLVED: 2}, nil)
+		mockReportVoteRepo.On("GetReportVoteWeightsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).Return(map[model.ReportStatus]float64{model.RESOLVED: 1.5}, nil)
+		mockReportRepo.On("UpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), canonicalReport).Return(canonicalReport, nil)
+		mockTaskService.On("CreateNotificationTask", uint(4), mock.Anything, mock.Anything, mock.Anything, model.EntityTypeReport, model.ReportNotificationCategory, model.NotificationTypeInfo, model.NotificationEventReportMerged, mock.Anything).Return(nil)
+		mockTaskService.On("CreateNotificationTask", uint(2), mock.Anything, mock.Anything, mock.Anything, model.EntityTypeReport, model.ReportNotificationCategory, model.NotificationTypeInfo, model.NotificationEventReportMerged, mock.Anything).Return(nil)
+		mockTaskService.On("MoveMergedReportCommentsTask", uint(3), uint(1)).Return(nil)
+
+		result, err := service.MergeReports(ctx, 9, 1, dto.MergeReportsRequest{DuplicateReportIDs: []uint{3, 3}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []uint{3}, result.MergedReportIDs)
+		assert.Equal(t, int64(2), result.MovedVotes)
+		assert.Equal(t, int64(1), result.MovedReactions)
+		mockReportRepo.AssertExpectations(t)
+		mockTaskService.AssertExpectations(t)
+		mockTaskService.AssertCalled(t, "EvaluateReportReputationTask", uint(3))
+		mockReportCommentRepo.AssertNotCalled(t, "MoveToReport", mock.Anything, mock.Anything, mock.Anything)
+		mockReportVoteRepo.AssertNotCalled(t, "GetTotalVoteCountTX", mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, int64(2), canonicalReport.ResolvedVoteCount)
+		assert.Equal(t, 1.5, canonicalReport.ResolvedVoteWeight)
+		assert.Equal(t, model.RESOLVED, result.ReportStatus)
+	})
+
+	t.Run("should reject canonical report merged by a concurrent request", func(t *testing.T) {
+		mockReportRepo, _, _, _, mockUserRepo, _, _, mockReportVoteRepo, _, _, service := setupMocks(t)
+
+		mockUserRepo.On("GetByID", ctx, uint(9)).Return(&model.User{ID: 9, Role: model.UserRoleModerator}, nil)
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(&model.Report{ID: 1, UserID: 2}, nil)
+		mockReportRepo.On("GetByIDs", ctx, []uint{3}).Return([]model.Report{{ID: 3, UserID: 4}}, nil)
+		mergedIntoID := uint(3)
+		mockReportRepo.On("LockByIDsForUpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), []uint{1, 3}).Return([]model.Report{{ID: 1, MergedIntoID: &mergedIntoID}, {ID: 3}}, nil)
+
+		result, err := service.MergeReports(ctx, 9, 1, dto.MergeReportsRequest{DuplicateReportIDs: []uint{3}})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Laporan utama sudah digabungkan ke laporan lain")
+		mockReportRepo.AssertNotCalled(t, "MarkMergedTX", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockReportVoteRepo.AssertNotCalled(t, "MoveToReportTX", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject regular users", func(t *testing.T) {
+		mockReportRepo, _, _, _, mockUserRepo, _, _, _, _, _, service := setupMocks(t)
+
+		mockUserRepo.On("GetByID", ctx, uint(9)).Return(&model.User{ID: 9, Role: model.UserRoleUser}, nil)
+
+		result, err := service.MergeReports(ctx, 9, 1, dto.MergeReportsRequest{DuplicateReportIDs: []uint{3}})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Hanya moderator atau instansi yang dapat menggabungkan laporan")
+		mockReportRepo.AssertNotCalled(t, "GetByIDIsDeleted", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject merging report into itself", func(t *testing.T) {
+		_, _, _, _, mockUserRepo, _, _, _, _, _, service := setupMocks(t)
+
+		mockUserRepo.On("GetByID", ctx, uint(9)).Return(&model.User{ID: 9, Role: model.UserRoleAgency}, nil)
+
+		result, err := service.MergeReports(ctx, 9, 1, dto.MergeReportsRequest{DuplicateReportIDs: []uint{1}})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Laporan tidak dapat digabungkan ke dirinya sendiri")
+	})
+}
+
+func TestReportService_VoteToReportWeighted(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep status when votes carry low weight", func(t *testing.T) {
+		mockReportRepo, _, _, _, mockUserRepo, _, _, mockReportVoteRepo, mockTaskService, _, service := setupMocks(t)
+
+		existingReport := &model.Report{
+			ID:             1,
+			UserID:         2,
+			ReportStatus:   model.WAITING,
+			HasProgress:    mainutils.BoolPtrOrNil(true),
+			ReportLocation: &model.ReportLocation{Latitude: -6.2, Longitude: 106.8},
+		}
+		voterLat, voterLng := -6.201, 106.801
+
+		mockReportRepo.On("GetByID", ctx, uint(1)).Return(existingReport, nil)
+		mockReportVoteRepo.On("GetByUserReportIDTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1), uint(1)).
+			Return(nil, gorm.ErrRecordNotFound)
+		mockReportVoteRepo.On("CreateTX", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(v *model.ReportVote) bool {
+			return v.Weight == 0.75 && v.DistanceMeters != nil && *v.DistanceMeters < 500
+		})).Return(&model.ReportVote{ID: 1, UserID: 1, ReportID: 1, VoteType: model.RESOLVED, Weight: 0.75}, nil)
+		mockReportVoteRepo.On("GetReportVoteCountsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).
+			Return(map[model.ReportStatus]int64{model.RESOLVED: 3, model.ON_PROGRESS: 0}, nil)
+		mockReportVoteRepo.On("GetTotalVoteCountTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).Return(int64(3), nil)
+		mockReportVoteRepo.On("GetReportVoteWeightsTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(1)).
+			Return(map[model.ReportStatus]float64{model.RESOLVED: 1.8, model.ON_PROGRESS: 0}, nil)
+		mockReportRepo.On("UpdateTX", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(r *model.Report) bool {
+			return r.ReportStatus == model.WAITING && r.ResolvedVoteCount == 3 && r.ResolvedVoteWeight == 1.8
+		})).Return(&model.Report{}, nil)
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Username: "voter"}, nil)
+		mockTaskService.On("CreateGroupedNotificationTask", uint(2), mock.Anything, mock.Anything, mock.Anything, model.EntityTypeReport, model.ReportNotificationCategory, model.NotificationEventVote, mock.Anything).Return(nil)
+
+		result, err := service.VoteToReport(ctx, 1, 1, "RESOLVED", &voterLat, &voterLng)
+
+		require.NoError(t, err)
+		assert.Equal(t, model.WAITING, result.ReportStatus)
+		assert.Equal(t, 0.75, result.Weight)
+		mockReportRepo.AssertExpectations(t)
+		mockReportVoteRepo.AssertExpectations(t)
+	})
+}
+
+func TestReportService_MarkCommentHelpful(t *testing.T) {
+	ctx := context.Background()
+	commentID := primitive.NewObjectID()
+
+	t.Run("should mark comment helpful and award reputation", func(t *testing.T) {
+		mockReportRepo, _, _, _, _, _, _, _, mockTaskService, mockReportCommentRepo, service := setupMocks(t)
+
+		mockReportCommentRepo.On("GetByID", ctx, commentID).Return(&model.ReportComment{ID: commentID, ReportID: 1, UserID: 5}, nil)
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(&model.Report{ID: 1, UserID: 2}, nil)
+		mockReportCommentRepo.On("MarkHelpful", ctx, commentID).Return(nil)
+		mockTaskService.On("AwardReputationTask", uint(5), model.ReputationHelpfulComment, model.ReputationSourceComment, commentID.Hex()).Return(nil)
+
+		result, err := service.MarkCommentHelpful(ctx, 2, commentID.Hex())
+
+		require.NoError(t, err)
+		assert.True(t, result.IsHelpful)
+		assert.Equal(t, uint(5), result.UserID)
+		mockReportCommentRepo.AssertExpectations(t)
+		mockTaskService.AssertExpectations(t)
+	})
+
+	t.Run("should reject when user is not the report owner", func(t *testing.T) {
+		mockReportRepo, _, _, _, _, _, _, _, _, mockReportCommentRepo, service := setupMocks(t)
+
+		mockReportCommentRepo.On("GetByID", ctx, commentID).Return(&model.ReportComment{ID: commentID, ReportID: 1, UserID: 5}, nil)
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(&model.Report{ID: 1, UserID: 2}, nil)
+
+		result, err := service.MarkCommentHelpful(ctx, 3, commentID.Hex())
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Hanya pemilik laporan yang dapat menandai komentar sebagai membantu")
+		mockReportCommentRepo.AssertNotCalled(t, "MarkHelpful", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject marking own comment", func(t *testing.T) {
+		mockReportRepo, _, _, _, _, _, _, _, _, mockReportCommentRepo, service := setupMocks(t)
+
+		mockReportCommentRepo.On("GetByID", ctx, commentID).Return(&model.ReportComment{ID: commentID, ReportID: 1, UserID: 2}, nil)
+		mockReportRepo.On("GetByIDIsDeleted", ctx, uint(1), false).Return(&model.Report{ID: 1, UserID: 2}, nil)
+
+		result, err := service.MarkCommentHelpful(ctx, 2, commentID.Hex())
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Anda tidak dapat menandai komentar sendiri sebagai membantu")
+	})
+
+	t.Run("should reject invalid comment id", func(t *testing.T) {
+		_, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+
+		result, err := service.MarkCommentHelpful(ctx, 2, "invalid")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "ID komentar tidak valid")
+	})
+}
+
+func TestReportService_CreateReportDraft(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should save partially filled draft", func(t *testing.T) {
+		_, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+
+		req := dto.SaveReportDraftRequest{
+			ReportTitle: mainutils.StrPtrOrNil("Jalan berlubang"),
+			NewImages:   []string{"image1.jpg", "image2.jpg"},
+		}
+
+		mockReportDraftRepo.On("CountActiveByUserID", ctx, uint(1), mock.AnythingOfType("int64")).Return(int64(0), nil)
+		mockReportDraftRepo.On("Create", ctx, mock.AnythingOfType("*model.ReportDraft")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*model.ReportDraft).ID = 7
+		})
+
+		result, err := service.CreateReportDraft(ctx, 1, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), result.Draft.ID)
+		assert.Equal(t, "Jalan berlubang", *result.Draft.ReportTitle)
+		assert.Nil(t, result.Draft.ReportDescription)
+		assert.Equal(t, []string{"image1.jpg", "image2.jpg"}, result.Draft.Images)
+		assert.Greater(t, result.Draft.ExpiresAt, time.Now().Unix())
+		mockReportDraftRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject when draft limit is reached", func(t *testing.T) {
+		_, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+
+		mockReportDraftRepo.On("CountActiveByUserID", ctx, uint(1), mock.AnythingOfType("int64")).Return(int64(10), nil)
+
+		result, err := service.CreateReportDraft(ctx, 1, dto.SaveReportDraftRequest{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "draf laporan aktif")
+		mockReportDraftRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReportService_UpdateReportDraft(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep selected images and report removed ones", func(t *testing.T) {
+		_, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+
+		draft := &model.ReportDraft{
+			ID:        3,
+			UserID:    1,
+			Image1URL: mainutils.StrPtrOrNil("old1.jpg"),
+			Image2URL: mainutils.StrPtrOrNil("old2.jpg"),
+		}
+		mockReportDraftRepo.On("GetActiveByIDAndUserID", ctx, uint(3), uint(1), mock.AnythingOfType("int64")).Return(draft, nil)
+		mockReportDraftRepo.On("Update", ctx, draft).Return(draft, nil)
+
+		result, err := service.UpdateReportDraft(ctx, 1, 3, dto.SaveReportDraftRequest{
+			ReportDescription: mainutils.StrPtrOrNil("Lubang besar di tengah jalan"),
+			ExistingImages:    []string{"old2.jpg"},
+			NewImages:         []string{"new1.jpg"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old2.jpg", "new1.jpg"}, result.Draft.Images)
+		assert.Equal(t, []string{"old1.jpg"}, result.RemovedImages)
+		assert.Equal(t, "Lubang besar di tengah jalan", *result.Draft.ReportDescription)
+		mockReportDraftRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject images that do not belong to the draft", func(t *testing.T) {
+		_, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+
+		draft := &model.ReportDraft{ID: 3, UserID: 1, Image1URL: mainutils.StrPtrOrNil("old1.jpg")}
+		mockReportDraftRepo.On("GetActiveByIDAndUserID", ctx, uint(3), uint(1), mock.AnythingOfType("int64")).Return(draft, nil)
+
+		result, err := service.UpdateReportDraft(ctx, 1, 3, dto.SaveReportDraftRequest{
+			ExistingImages: []string{"someone-else.jpg"},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Gambar draf laporan tidak valid")
+		mockReportDraftRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found for drafts of other users", func(t *testing.T) {
+		_, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+
+		mockReportDraftRepo.On("GetActiveByIDAndUserID", ctx, uint(3), uint(2), mock.AnythingOfType("int64")).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.UpdateReportDraft(ctx, 2, 3, dto.SaveReportDraftRequest{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Draf laporan tidak ditemukan")
+	})
+}
+
+func TestReportService_PublishReportDraft(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should publish complete draft and delete it", func(t *testing.T) {
+		mockReportRepo, mockReportLocationRepo, _, mockReportImageRepo, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+		util.ReportImageDir = t.TempDir()
+		util.ReportDraftImageDir = t.TempDir()
+		t.Cleanup(func() {
+			util.ReportImageDir = "uploads/main/report"
+			util.ReportDraftImageDir = "uploads/drafts/report"
+		})
+		require.NoError(t, os.WriteFile(filepath.Join(util.ReportDraftImageDir, "image1.jpg"), []byte("image"), 0o644))
+
+		latitude := -6.2
+		longitude := 106.816666
+		draft := &model.ReportDraft{
+			ID:                4,
+			UserID:            1,
+			ReportTitle:       mainutils.StrPtrOrNil("Lampu jalan mati"),
+			ReportType:        mainutils.StrPtrOrNil("ELECTRICITY"),
+			ReportDescription: mainutils.StrPtrOrNil("Lampu jalan mati sejak seminggu"),
+			DetailLocation:    mainutils.StrPtrOrNil("Depan masjid"),
+			Latitude:          &latitude,
+			Longitude:         &longitude,
+			Image1URL:         mainutils.StrPtrOrNil("image1.jpg"),
+		}
+		mockReportDraftRepo.On("GetActiveByIDAndUserID", ctx, uint(4), uint(1), mock.AnythingOfType("int64")).Return(draft, nil)
+		mockReportRepo.On("Create", ctx, mock.AnythingOfType("*model.Report"), mock.AnythingOfType("*gorm.DB")).
+			Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*model.Report).ID = 11
+		})
+		mockReportLocationRepo.On("Create", ctx, mock.AnythingOfType("*model.ReportLocation"), mock.AnythingOfType("*gorm.DB")).Return(nil)
+		mockReportImageRepo.On("Create", ctx, mock.AnythingOfType("*model.ReportImage"), mock.AnythingOfType("*gorm.DB")).Return(nil)
+		mockReportDraftRepo.On("DeleteTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(4)).Return(true, nil)
+
+		result, err := service.PublishReportDraft(ctx, 1, 4)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(11), result.Report.ID)
+		assert.Equal(t, "Lampu jalan mati", result.Report.ReportTitle)
+		assert.Equal(t, "image1.jpg", *result.ReportImages.Image1URL)
+		assert.FileExists(t, filepath.Join(util.ReportImageDir, "image1.jpg"))
+		assert.NoFileExists(t, filepath.Join(util.ReportDraftImageDir, "image1.jpg"))
+		mockReportDraftRepo.AssertExpectations(t)
+	})
+
+	t.Run("should roll back without publishing images when draft delete fails", func(t *testing.T) {
+		mockReportRepo, mockReportLocationRepo, _, mockReportImageRepo, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+		util.ReportImageDir = t.TempDir()
+		util.ReportDraftImageDir = t.TempDir()
+		t.Cleanup(func() {
+			util.ReportImageDir = "uploads/main/report"
+			util.ReportDraftImageDir = "uploads/drafts/report"
+		})
+		require.NoError(t, os.WriteFile(filepath.Join(util.ReportDraftImageDir, "image1.jpg"), []byte("image"), 0o644))
+
+		latitude := -6.2
+		longitude := 106.816666
+		draft := &model.ReportDraft{
+			ID:                4,
+			UserID:            1,
+			ReportTitle:       mainutils.StrPtrOrNil("Lampu jalan mati"),
+			ReportType:        mainutils.StrPtrOrNil("ELECTRICITY"),
+			ReportDescription: mainutils.StrPtrOrNil("Lampu jalan mati sejak seminggu"),
+			DetailLocation:    mainutils.StrPtrOrNil("Depan masjid"),
+			Latitude:          &latitude,
+			Longitude:         &longitude,
+			Image1URL:         mainutils.StrPtrOrNil("image1.jpg"),
+		}
+		mockReportDraftRepo.On("GetActiveByIDAndUserID", ctx, uint(4), uint(1), mock.AnythingOfType("int64")).Return(draft, nil)
+		mockReportRepo.On("Create", ctx, mock.AnythingOfType("*model.Report"), mock.AnythingOfType("*gorm.DB")).Return(nil)
+		mockReportLocationRepo.On("Create", ctx, mock.AnythingOfType("*model.ReportLocation"), mock.AnythingOfType("*gorm.DB")).Return(nil)
+		mockReportImageRepo.On("Create", ctx, mock.AnythingOfType("*model.ReportImage"), mock.AnythingOfType("*gorm.DB")).Return(nil)
+		mockReportDraftRepo.On("DeleteTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(4)).Return(false, errors.New("db error"))
+
+		result, err := service.PublishReportDraft(ctx, 1, 4)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.NoFileExists(t, filepath.Join(util.ReportImageDir, "image1.jpg"))
+		assert.FileExists(t, filepath.Join(util.ReportDraftImageDir, "image1.jpg"))
+		mockReportRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject draft already published by a concurrent request", func(t *testing.T) {
+		mockReportRepo, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+		util.ReportImageDir = t.TempDir()
+		util.ReportDraftImageDir = t.TempDir()
+		t.Cleanup(func() {
+			util.ReportImageDir = "uploads/main/report"
+			util.ReportDraftImageDir = "uploads/drafts/report"
+		})
+		require.NoError(t, os.WriteFile(filepath.Join(util.ReportDraftImageDir, "image1.jpg"), []byte("image"), 0o644))
+
+		latitude := -6.2
+		longitude := 106.816666
+		draft := &model.ReportDraft{
+			ID:                4,
+			UserID:            1,
+			ReportTitle:       mainutils.StrPtrOrNil("Lampu jalan mati"),
+			ReportType:        mainutils.StrPtrOrNil("ELECTRICITY"),
+			ReportDescription: mainutils.StrPtrOrNil("Lampu jalan mati sejak seminggu"),
+			DetailLocation:    mainutils.StrPtrOrNil("Depan masjid"),
+			Latitude:          &latitude,
+			Longitude:         &longitude,
+			Image1URL:         mainutils.StrPtrOrNil("image1.jpg"),
+		}
+		mockReportDraftRepo.On("GetActiveByIDAndUserID", ctx, uint(4), uint(1), mock.AnythingOfType("int64")).Return(draft, nil)
+		mockReportDraftRepo.On("DeleteTX", ctx, mock.AnythingOfType("*gorm.DB"), uint(4)).Return(false, nil)
+
+		result, err := service.PublishReportDraft(ctx, 1, 4)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Draf laporan tidak ditemukan")
+		assert.NoFileExists(t, filepath.Join(util.ReportImageDir, "image1.jpg"))
+		mockReportRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return validation errors for incomplete draft", func(t *testing.T) {
+		mockReportRepo, _, _, _, _, _, _, _, _, _, service := setupMocks(t)
+		mockReportDraftRepo := service.reportDraftRepo.(*report.MockReportDraftRepository)
+
+		draft := &model.ReportDraft{ID: 4, UserID: 1, ReportTitle: mainutils.StrPtrOrNil("Lampu jalan mati")}
+		mockReportDraftRepo.On("GetActiveByIDAndUserID", ctx, uint(4), uint(1), mock.AnythingOfType("int64")).Return(draft, nil)
+
+		result, err := service.PublishReportDraft(ctx, 1, 4)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "Validasi gagal")
+		mockReportRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		mockReportDraftRepo.AssertNotCalled(t, "DeleteTX", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,114 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Report images are served publicly from ReportImageDir, so draft images are
+// kept in ReportDraftImageDir, outside the static tree, until the draft is
+// published. They are variables so tests can point them at temp directories.
+var (
+	ReportImageDir      = "uploads/main/report"
+	ReportDraftImageDir = "uploads/drafts/report"
+)
+
+func GetReportImagePath(image string) string {
+	return filepath.Join(ReportImageDir, filepath.Base(image))
+}
+
+// GetReportDraftImagePath falls back to ReportImageDir for drafts saved before
+// draft images had their own directory.
+func GetReportDraftImagePath(image string) string {
+	path := filepath.Join(ReportDraftImageDir, filepath.Base(image))
+	if _, err := os.Stat(path); err != nil {
+		if legacyPath := GetReportImagePath(image); fileExists(legacyPath) {
+			return legacyPath
+		}
+	}
+	return path
+}
+
+func RemoveReportImages(images []string) {
+	for _, image := range images {
+		os.Remove(GetReportImagePath(image))
+	}
+}
+
+func RemoveReportDraftImages(images []string) {
+	for _, image := range images {
+		os.Remove(GetReportDraftImagePath(image))
+	}
+}
+
+// RemovePublishedReportDraftImages drops the draft copies once the images have
+// been copied to ReportImageDir. Legacy draft images that already live there
+// are now owned by the report and are left alone.
+func RemovePublishedReportDraftImages(images []string) {
+	for _, image := range images {
+		if path := GetReportDraftImagePath(image); path != GetReportImagePath(image) {
+			os.Remove(path)
+		}
+	}
+}
+
+// RemovePublishedReportDraftCopies undoes CopyReportDraftImagesToReport when
+// publishing fails, leaving legacy images that were never copied in place.
+func RemovePublishedReportDraftCopies(images []string) {
+	for _, image := range images {
+		if path := GetReportImagePath(image); path != GetReportDraftImagePath(image) {
+			os.Remove(path)
+		}
+	}
+}
+
+// CopyReportDraftImagesToReport makes the draft's images public under the same
+// names. The draft copies are left in place so a failed publish leaves the
+// draft intact; on error the copies made so far are removed. Draft image names
+// are unique, so an existing target can only be a leftover from an earlier
+// failed attempt and is overwritten.
+func CopyReportDraftImagesToReport(images []string) error {
+	copied := make([]string, 0, len(images))
+	for _, image := range images {
+		source := GetReportDraftImagePath(image)
+		target := GetReportImagePath(image)
+		if source == target {
+			continue
+		}
+		if err := copyFile(source, target); err != nil {
+			RemoveReportImages(copied)
+			return fmt.Errorf("failed to copy draft image %s: %w", image, err)
+		}
+		copied = append(copied, image)
+	}
+	return nil
+}
+
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(target)
+		return err
+	}
+	return out.Close()
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
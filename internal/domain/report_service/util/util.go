@@ -4,8 +4,16 @@ import (
 	reportDTO "pingspot/internal/domain/report_service/dto"
 	"pingspot/internal/domain/user_service/dto"
 	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	env "pingspot/pkg/utils/env_util"
+	"pingspot/pkg/mailer"
 	mainutils "pingspot/pkg/utils/main_util"
+	"fmt"
+	"math"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func GetMajorityVote(resolvedVote, onProgressVote int64) *string {
@@ -39,10 +47,134 @@ func GetVoteTypeOrder(voteCount map[model.ReportStatus]int64) []struct {
 	return votes
 }
 
-func SendPotentiallyResolvedReportEmail(to, username, reportTitle, reportLink string, daysRemaining int) error {
-	return mainutils.SendEmail(mainutils.EmailData{
+func GetWeightedVoteTypeOrder(voteWeights map[model.ReportStatus]float64) []struct {
+	Type   model.ReportStatus
+	Weight float64
+} {
+	votes := []struct {
+		Type   model.ReportStatus
+		Weight float64
+	}{
+		{model.RESOLVED, voteWeights[model.RESOLVED]},
+		{model.ON_PROGRESS, voteWeights[model.ON_PROGRESS]},
+	}
+
+	sort.SliceStable(votes, func(i, j int) bool {
+		return votes[i].Weight > votes[j].Weight
+	})
+
+	return votes
+}
+
+func HaversineDistanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusMeters = 6371000
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLng := (lng2 - lng1) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// CalculateVoteWeight scales a vote by how close the voter was to the report
+// and how often their past votes matched the final status. Sending no
+// location counts as far away, and accounts without a voting history start
+// below full weight so fresh accounts cannot reach consensus on their own.
+func CalculateVoteWeight(distanceMeters *float64, accurateVotes, totalVotes int64) float64 {
+	locationFactor := 0.3
+	if distanceMeters != nil {
+		switch {
+		case *distanceMeters <= 500:
+			locationFactor = 1.5
+		case *distanceMeters <= 2000:
+			locationFactor = 1.0
+		case *distanceMeters <= 10000:
+			locationFactor = 0.6
+		default:
+			locationFactor = 0.3
+		}
+	}
+
+	accuracyFactor := float64(accurateVotes+1) / float64(totalVotes+2)
+	if totalVotes >= 3 {
+		accuracyFactor += 0.5
+	}
+
+	return math.Round(locationFactor*accuracyFactor*100) / 100
+}
+
+type PriorityInput struct {
+	ReportType            model.ReportType
+	ReportStatus          model.ReportStatus
+	TotalVotes            int64
+	TotalReactions        int64
+	RecentVotes           int64
+	RecentReactions       int64
+	CreatedAt             int64
+	LastUpdatedProgressAt *int64
+	NearbyDuplicates      int64
+	Now                   int64
+}
+
+var reportTypePriorityWeight = map[model.ReportType]float64{
+	model.Disaster:       3.0,
+	model.Safety:         2.5,
+	model.Health:         2.0,
+	model.Water:          1.8,
+	model.Electricity:    1.8,
+	model.Infrastructure: 1.5,
+	model.Traffic:        1.5,
+	model.Environment:    1.2,
+	model.Waste:          1.2,
+	model.PublicFacility: 1.2,
+}
+
+func GetReportTypePriorityWeight(reportType model.ReportType) float64 {
+	if weight, ok := reportTypePriorityWeight[reportType]; ok {
+		return weight
+	}
+	return 1.0
+}
+
+func CalculatePriorityScore(input PriorityInput) float64 {
+	if input.ReportStatus == model.RESOLVED || input.ReportStatus == model.EXPIRED {
+		return 0
+	}
+
+	engagement := math.Log1p(float64(input.TotalVotes+input.TotalReactions)) * 10
+	growth := math.Log1p(float64(input.RecentVotes+input.RecentReactions)) * 15
+	duplicates := math.Log1p(float64(input.NearbyDuplicates)) * 12
+
+	lastActivity := input.CreatedAt
+	if input.LastUpdatedProgressAt != nil && *input.LastUpdatedProgressAt > lastActivity {
+		lastActivity = *input.LastUpdatedProgressAt
+	}
+	idleDays := math.Max(float64(input.Now-lastActivity)/86400, 0)
+	staleness := math.Min(idleDays, 30) * 2
+
+	score := GetReportTypePriorityWeight(input.ReportType) * (10 + engagement + growth + duplicates + staleness)
+	return math.Round(score*100) / 100
+}
+
+type HotInput struct {
+	Reactions int64
+	Votes     int64
+	Comments  int64
+	Views     int64
+	CreatedAt int64
+	Now       int64
+}
+
+func CalculateHotScore(input HotInput) float64 {
+	points := float64(input.Reactions) + float64(input.Votes)*2 + float64(input.Comments)*3 + float64(input.Views)*0.1
+	ageHours := math.Max(float64(input.Now-input.CreatedAt)/3600, 0)
+	score := (points + 1) / math.Pow(ageHours+2, 1.8)
+	return math.Round(score*10000) / 10000
+}
+
+func BuildPotentiallyResolvedReportEmail(to, username, reportTitle, reportLink string, daysRemaining int, idempotencyKey string, language i18n.Language) (mailer.Message, error) {
+	return mainutils.BuildEmail(mainutils.EmailData{
 		To:            to,
-		Subject:       "Pengingat: Perbarui Progress Laporan Anda",
+		Subject:       i18n.T(language, "email.progress_reminder.subject", nil),
 		RecipientName: username,
 		EmailType:     mainutils.EmailTypeProgressReminder,
 		TemplateData: map[string]any{
@@ -51,16 +183,18 @@ func SendPotentiallyResolvedReportEmail(to, username, reportTitle, reportLink st
 			"DaysRemaining": daysRemaining,
 		},
 		BodyTempate: getProgressReminderEmailTemplate(),
+		Language:    language,
+		IdempotencyKey: idempotencyKey,
 	})
 }
 
 func getProgressReminderEmailTemplate() string {
 	return `<!DOCTYPE html>
-<html lang="id">
+<html lang="{{lang}}">
 <head>
 	<meta charset="UTF-8">
 	<meta name="viewport" content="width=device-width, initial-scale=1.0">
-	<title>Pengingat Progress Laporan</title>
+	<title>{{t "email.progress_reminder.title"}}</title>
 </head>
 <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif; background-color: #f8fafc; line-height: 1.6;">
 	<table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%" style="background-color: #f8fafc;">
@@ -73,36 +207,36 @@ func getProgressReminderEmailTemplate() string {
 								PingSpot
 							</h1>
 							<p style="margin: 8px 0 0; color: rgba(255, 255, 255, 0.9); font-size: 16px; font-weight: 400;">
-								Pengingat Progress Laporan
+								{{t "email.progress_reminder.title"}}
 							</p>
 						</td>
 					</tr>
 					<tr>
 						<td style="padding: 50px 40px;">
 							<h2 style="margin: 0 0 20px; color: #1e293b; font-size: 24px; font-weight: 600; text-align: center;">
-								Halo {{.UserName}}! 👋
+								{{t "email.common.greeting" "name" .UserName}} 👋
 							</h2>
 							<p style="margin: 0 0 25px; color: #475569; font-size: 16px; text-align: center; line-height: 1.7;">
-								Laporan Anda berstatus <strong style="color: #f59e0b;">Dalam Peninjauan</strong> dan perlu diperbarui!
+								{{t "email.progress_reminder.status_prefix"}} <strong style="color: #f59e0b;">{{t "email.progress_reminder.status_label"}}</strong> {{t "email.progress_reminder.status_suffix"}}
 							</p>
 							<div style="margin: 30px 0; padding: 25px; background-color: #fef3c7; border-radius: 12px; border-left: 4px solid #f59e0b;">
 								<p style="margin: 0 0 15px; color: #92400e; font-size: 16px; font-weight: 600;">
 									📋 {{.ReportTitle}}
 								</p>
 								<p style="margin: 0; color: #78350f; font-size: 14px; line-height: 1.6;">
-									⏰ Anda memiliki <strong>7 minggu tersisa</strong> untuk mengunggah bukti progress.<br>
-									Jika tidak ada pembaruan, laporan akan <strong>otomatis ditandai sebagai Terselesaikan</strong> setelah periode ini berakhir.
+									⏰ {{t "email.progress_reminder.deadline_prefix"}} <strong>{{t "email.progress_reminder.deadline_label"}}</strong> {{t "email.progress_reminder.deadline_suffix"}}<br>
+									{{t "email.progress_reminder.auto_resolve_prefix"}} <strong>{{t "email.progress_reminder.auto_resolve_label"}}</strong> {{t "email.progress_reminder.auto_resolve_suffix"}}
 								</p>
 							</div>
 							<div style="text-align: center; margin: 35px 0;">
 								<a href="{{.ReportLink}}" 
 								   style="display: inline-block; background: linear-gradient(135deg, #f59e0b 0%, #d97706 100%); color: #ffffff; text-decoration: none; padding: 16px 32px; border-radius: 50px; font-weight: 600; font-size: 16px; box-shadow: 0 4px 15px rgba(245, 158, 11, 0.4); transition: all 0.3s ease; text-align: center; min-width: 200px;">
-									Perbarui Progress Sekarang
+									{{t "email.progress_reminder.button"}}
 								</a>
 							</div>
 							<div style="margin: 30px 0; text-align: center;">
 								<p style="margin: 0; color: #64748b; font-size: 14px; line-height: 1.6;">
-									Jika Anda memiliki pertanyaan, jangan ragu untuk menghubungi kami.
+									{{t "email.progress_reminder.contact"}}
 								</p>
 							</div>
 						</td>
@@ -110,10 +244,10 @@ func getProgressReminderEmailTemplate() string {
 					<tr>
 						<td style="background-color: #f8fafc; padding: 30px 40px; text-align: center; border-top: 1px solid #e2e8f0;">
 							<p style="margin: 0 0 10px; color: #64748b; font-size: 14px;">
-								© 2025 PingSpot. Hak cipta dilindungi undang-undang.
+								{{t "email.common.copyright"}}
 							</p>
 							<p style="margin: 0; color: #94a3b8; font-size: 12px;">
-								Pertanyaan? Hubungi kami di
+								{{t "email.common.questions"}}
 								<a href="mailto:support@pingspot.com" style="color: #667eea; text-decoration: none;">
 									support@pingspot.com
 								</a>
@@ -157,6 +291,7 @@ func convertToDTO(c *model.ReportComment, u *model.User) *reportDTO.Comment {
 			}
 			return nil
 		}(),
+		IsHelpful: c.IsHelpful,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: func() *int64 {
 			if c.UpdatedAt != nil {
@@ -208,6 +343,7 @@ func convertToReplyDTO(c *model.ReportComment, u *model.User, mentions []model.M
 			}
 			return nil
 		}(),
+		IsHelpful: c.IsHelpful,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: func() *int64 {
 			if c.UpdatedAt != nil {
@@ -307,3 +443,251 @@ func ConvertRepliesToDTO(comments []*model.ReportComment, users map[uint]*model.
 
 	return replies
 }
+
+const (
+	DefaultReportDraftTTLDays = 14
+	MaxReportDraftsPerUser    = 10
+	MaxReportDraftImages      = 5
+)
+
+func GetReportDraftTTL() time.Duration {
+	days := DefaultReportDraftTTLDays
+	if v, err := strconv.Atoi(env.ReportDraftTTLDays()); err == nil && v > 0 {
+		days = v
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func GetReportDraftImages(draft *model.ReportDraft) []string {
+	images := make([]string, 0, MaxReportDraftImages)
+	for _, image := range []*string{draft.Image1URL, draft.Image2URL, draft.Image3URL, draft.Image4URL, draft.Image5URL} {
+		if image != nil && *image != "" {
+			images = append(images, *image)
+		}
+	}
+	return images
+}
+
+func SetReportDraftImages(draft *model.ReportDraft, images []string) {
+	slots := make([]*string, MaxReportDraftImages)
+	for i, image := range images {
+		if i >= MaxReportDraftImages {
+			break
+		}
+		slots[i] = mainutils.StrPtrOrNil(image)
+	}
+	draft.Image1URL = slots[0]
+	draft.Image2URL = slots[1]
+	draft.Image3URL = slots[2]
+	draft.Image4URL = slots[3]
+	draft.Image5URL = slots[4]
+}
+
+func ConvertReportDraftToCreateRequest(draft *model.ReportDraft) reportDTO.CreateReportRequest {
+	req := reportDTO.CreateReportRequest{
+		HasProgress: draft.HasProgress,
+		IsAnonymous: draft.IsAnonymous != nil && *draft.IsAnonymous,
+		MapZoom:     draft.MapZoom,
+		DisplayName: draft.DisplayName,
+		AddressType: draft.AddressType,
+		Country:     draft.Country,
+		CountryCode: draft.CountryCode,
+		Region:      draft.Region,
+		PostCode:    draft.PostCode,
+		County:      draft.County,
+		State:       draft.State,
+		Road:        draft.Road,
+		Village:     draft.Village,
+		Suburb:      draft.Suburb,
+		Image1URL:   draft.Image1URL,
+		Image2URL:   draft.Image2URL,
+		Image3URL:   draft.Image3URL,
+		Image4URL:   draft.Image4URL,
+		Image5URL:   draft.Image5URL,
+	}
+	if draft.ReportTitle != nil {
+		req.ReportTitle = *draft.ReportTitle
+	}
+	if draft.ReportType != nil {
+		req.ReportType = *draft.ReportType
+	}
+	if draft.ReportDescription != nil {
+		req.ReportDescription = *draft.ReportDescription
+	}
+	if draft.DetailLocation != nil {
+		req.DetailLocation = *draft.DetailLocation
+	}
+	if draft.Latitude != nil {
+		req.Latitude = *draft.Latitude
+	}
+	if draft.Longitude != nil {
+		req.Longitude = *draft.Longitude
+	}
+	return req
+}
+
+func ConvertReportDraftToDTO(draft *model.ReportDraft) reportDTO.ReportDraft {
+	return reportDTO.ReportDraft{
+		ID:                draft.ID,
+		ReportTitle:       draft.ReportTitle,
+		ReportType:        draft.ReportType,
+		ReportDescription: draft.ReportDescription,
+		DetailLocation:    draft.DetailLocation,
+		HasProgress:       draft.HasProgress,
+		IsAnonymous:       draft.IsAnonymous,
+		MapZoom:           draft.MapZoom,
+		Latitude:          draft.Latitude,
+		Longitude:         draft.Longitude,
+		DisplayName:       draft.DisplayName,
+		AddressType:       draft.AddressType,
+		Country:           draft.Country,
+		CountryCode:       draft.CountryCode,
+		Region:            draft.Region,
+		PostCode:          draft.PostCode,
+		County:            draft.County,
+		State:             draft.State,
+		Road:              draft.Road,
+		Village:           draft.Village,
+		Suburb:            draft.Suburb,
+		Images:            GetReportDraftImages(draft),
+		ExpiresAt:         draft.ExpiresAt,
+		CreatedAt:         draft.CreatedAt,
+		UpdatedAt:         draft.UpdatedAt,
+	}
+}
+
+// ConvertReportDraftRequestToCreateRequest maps a parsed report form onto a
+// create request, so report creation and drafts share one form parser.
+func ConvertReportDraftRequestToCreateRequest(req reportDTO.SaveReportDraftRequest, images []string) reportDTO.CreateReportRequest {
+	var draft model.ReportDraft
+	ApplyReportDraftRequest(&draft, req)
+	SetReportDraftImages(&draft, images)
+	return ConvertReportDraftToCreateRequest(&draft)
+}
+
+func ApplyReportDraftRequest(draft *model.ReportDraft, req reportDTO.SaveReportDraftRequest) {
+	draft.ReportTitle = req.ReportTitle
+	draft.ReportType = req.ReportType
+	draft.ReportDescription = req.ReportDescription
+	draft.DetailLocation = req.DetailLocation
+	draft.HasProgress = req.HasProgress
+	draft.IsAnonymous = req.IsAnonymous
+	draft.MapZoom = req.MapZoom
+	draft.Latitude = req.Latitude
+	draft.Longitude = req.Longitude
+	draft.DisplayName = req.DisplayName
+	draft.AddressType = req.AddressType
+	draft.Country = req.Country
+	draft.CountryCode = req.CountryCode
+	draft.Region = req.Region
+	draft.PostCode = req.PostCode
+	draft.County = req.County
+	draft.State = req.State
+	draft.Road = req.Road
+	draft.Village = req.Village
+	draft.Suburb = req.Suburb
+}
+
+const ReportViewDedupeWindow = 24 * time.Hour
+
+func GetReportViewKey(reportID, userID uint) string {
+	return fmt.Sprintf("report_view:%d:%d", reportID, userID)
+}
+
+const (
+	AnonymousUsername = "anonim"
+	AnonymousFullName = "Pelapor Anonim"
+)
+
+var defaultAnonymousReportTypes = []model.ReportType{model.Safety, model.Health}
+
+func GetAnonymousReportTypes() map[model.ReportType]bool {
+	reportTypes := make(map[model.ReportType]bool)
+	configured := strings.TrimSpace(env.AnonymousReportTypes())
+	if configured == "" {
+		for _, reportType := range defaultAnonymousReportTypes {
+			reportTypes[reportType] = true
+		}
+		return reportTypes
+	}
+	for _, reportType := range strings.Split(configured, ",") {
+		reportType = strings.ToUpper(strings.TrimSpace(reportType))
+		if reportType != "" {
+			reportTypes[model.ReportType(reportType)] = true
+		}
+	}
+	return reportTypes
+}
+
+func IsAnonymousReportAllowed(reportType model.ReportType) bool {
+	return GetAnonymousReportTypes()[reportType]
+}
+
+func ShouldRedactReportOwner(report *model.Report, viewerID uint) bool {
+	return report.IsAnonymous && report.UserID != viewerID
+}
+
+func AnonymousUserProfile() dto.UserProfile {
+	return dto.UserProfile{
+		Username: AnonymousUsername,
+		FullName: AnonymousFullName,
+	}
+}
+
+func RedactReportOwner(report *reportDTO.Report) {
+	ownerID := report.UserID
+	report.UserID = 0
+	report.UserName = AnonymousUsername
+	report.FullName = AnonymousFullName
+	report.ProfilePicture = nil
+	for i := range report.ReportReactions {
+		if report.ReportReactions[i].UserID == ownerID {
+			report.ReportReactions[i].UserID = 0
+		}
+	}
+	for i := range report.ReportVotes {
+		if report.ReportVotes[i].UserID == ownerID {
+			report.ReportVotes[i].UserID = 0
+		}
+	}
+}
+
+func redactMentionContent(content *string, ownerID uint) {
+	if content == nil {
+		return
+	}
+	*content = strings.ReplaceAll(*content, "[mention:"+strconv.FormatUint(uint64(ownerID), 10)+"]", "[mention:0]")
+}
+
+func redactMentions(mentions []model.Mention, ownerID uint) {
+	for i := range mentions {
+		if mentions[i].UserID == ownerID {
+			mentions[i].UserID = 0
+			mentions[i].Username = AnonymousUsername
+		}
+	}
+}
+
+func RedactCommentsOwner(comments []*reportDTO.Comment, ownerID uint) {
+	for _, comment := range comments {
+		if comment.UserInformation.UserID == ownerID {
+			comment.UserInformation = AnonymousUserProfile()
+		}
+		redactMentions(comment.Mentions, ownerID)
+		redactMentionContent(comment.Content, ownerID)
+	}
+}
+
+func RedactRepliesOwner(replies []*reportDTO.CommentReply, ownerID uint) {
+	for _, reply := range replies {
+		if reply.UserInformation.UserID == ownerID {
+			reply.UserInformation = AnonymousUserProfile()
+		}
+		if reply.ReplyTo != nil && reply.ReplyTo.UserID == ownerID {
+			anonymous := AnonymousUserProfile()
+			reply.ReplyTo = &anonymous
+		}
+		redactMentions(reply.Mentions, ownerID)
+		redactMentionContent(reply.Content, ownerID)
+	}
+}
@@ -0,0 +1,206 @@
+package util
+
+import (
+	reportDTO "pingspot/internal/domain/report_service/dto"
+	"pingspot/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculatePriorityScore(t *testing.T) {
+	now := int64(1_760_000_000)
+
+	t.Run("should return zero for resolved reports", func(t *testing.T) {
+		score := CalculatePriorityScore(PriorityInput{
+			ReportType:   model.Disaster,
+			ReportStatus: model.RESOLVED,
+			TotalVotes:   50,
+			CreatedAt:    now - 86400,
+			Now:          now,
+		})
+
+		assert.Equal(t, float64(0), score)
+	})
+
+	t.Run("should weight disaster above other reports", func(t *testing.T) {
+		input := PriorityInput{
+			ReportStatus: model.WAITING,
+			TotalVotes:   5,
+			CreatedAt:    now - 3600,
+			Now:          now,
+		}
+		input.ReportType = model.Disaster
+		disasterScore := CalculatePriorityScore(input)
+		input.ReportType = model.Other
+		otherScore := CalculatePriorityScore(input)
+
+		assert.Greater(t, disasterScore, otherScore)
+	})
+
+	t.Run("should increase with growth and nearby duplicates", func(t *testing.T) {
+		base := PriorityInput{
+			ReportType:   model.Water,
+			ReportStatus: model.WAITING,
+			TotalVotes:   10,
+			CreatedAt:    now - 3600,
+			Now:          now,
+		}
+		growing := base
+		growing.RecentVotes = 8
+		growing.NearbyDuplicates = 4
+
+		assert.Greater(t, CalculatePriorityScore(growing), CalculatePriorityScore(base))
+	})
+
+	t.Run("should favour reports without recent progress", func(t *testing.T) {
+		recentProgress := now - 3600
+		base := PriorityInput{
+			ReportType:            model.Safety,
+			ReportStatus:          model.ON_PROGRESS,
+			CreatedAt:             now - 20*86400,
+			LastUpdatedProgressAt: &recentProgress,
+			Now:                   now,
+		}
+		stale := base
+		stale.LastUpdatedProgressAt = nil
+
+		assert.Greater(t, CalculatePriorityScore(stale), CalculatePriorityScore(base))
+	})
+}
+
+func TestCalculateHotScore(t *testing.T) {
+	now := int64(1_760_000_000)
+
+	t.Run("should rank engaged reports higher", func(t *testing.T) {
+		quiet := HotInput{CreatedAt: now - 3600, Now: now}
+		engaged := quiet
+		engaged.Reactions = 5
+		engaged.Votes = 3
+		engaged.Comments = 2
+		engaged.Views = 40
+
+		assert.Greater(t, CalculateHotScore(engaged), CalculateHotScore(quiet))
+	})
+
+	t.Run("should decay with age", func(t *testing.T) {
+		fresh := HotInput{Reactions: 10, Votes: 10, CreatedAt: now - 3600, Now: now}
+		old := fresh
+		old.CreatedAt = now - 3*86400
+
+		assert.Greater(t, CalculateHotScore(fresh), CalculateHotScore(old))
+	})
+}
+
+func TestCalculateVoteWeight(t *testing.T) {
+	near := 200.0
+	far := 50000.0
+
+	t.Run("should favour votes cast near the report", func(t *testing.T) {
+		assert.Greater(t, CalculateVoteWeight(&near, 0, 0), CalculateVoteWeight(&far, 0, 0))
+		assert.Greater(t, CalculateVoteWeight(&far, 0, 0), 0.0)
+		assert.Less(t, CalculateVoteWeight(nil, 0, 0), CalculateVoteWeight(&near, 0, 0))
+		assert.LessOrEqual(t, CalculateVoteWeight(nil, 0, 0), CalculateVoteWeight(&far, 0, 0))
+	})
+
+	t.Run("should favour voters with an accurate history", func(t *testing.T) {
+		assert.Greater(t, CalculateVoteWeight(&near, 9, 10), CalculateVoteWeight(&near, 1, 10))
+		assert.Greater(t, CalculateVoteWeight(&near, 2, 2), CalculateVoteWeight(&near, 0, 2))
+		assert.Greater(t, CalculateVoteWeight(&near, 9, 10), CalculateVoteWeight(&near, 0, 0))
+	})
+
+	t.Run("should keep two fresh accounts below the consensus weight", func(t *testing.T) {
+		assert.Less(t, CalculateVoteWeight(&near, 0, 0), 1.0)
+		assert.Less(t, 2*CalculateVoteWeight(&near, 0, 0), 2.0)
+	})
+}
+
+func TestHaversineDistanceMeters(t *testing.T) {
+	distance := HaversineDistanceMeters(-6.2, 106.8, -6.21, 106.8)
+	assert.InDelta(t, 1112, distance, 5)
+}
+
+func TestGetReportDraftTTL(t *testing.T) {
+	t.Run("should use default when env is not set", func(t *testing.T) {
+		t.Setenv("REPORT_DRAFT_TTL_DAYS", "")
+		assert.Equal(t, DefaultReportDraftTTLDays*24*time.Hour, GetReportDraftTTL())
+	})
+
+	t.Run("should use configured days", func(t *testing.T) {
+		t.Setenv("REPORT_DRAFT_TTL_DAYS", "3")
+		assert.Equal(t, 72*time.Hour, GetReportDraftTTL())
+	})
+
+	t.Run("should ignore invalid values", func(t *testing.T) {
+		t.Setenv("REPORT_DRAFT_TTL_DAYS", "-1")
+		assert.Equal(t, DefaultReportDraftTTLDays*24*time.Hour, GetReportDraftTTL())
+	})
+}
+
+func TestSetReportDraftImages(t *testing.T) {
+	t.Run("should compact images into leading slots", func(t *testing.T) {
+		old := "old.jpg"
+		draft := &model.ReportDraft{Image5URL: &old}
+
+		SetReportDraftImages(draft, []string{"a.jpg", "b.jpg"})
+
+		assert.Equal(t, []string{"a.jpg", "b.jpg"}, GetReportDraftImages(draft))
+		assert.Nil(t, draft.Image5URL)
+	})
+}
+
+func TestConvertReportDraftToCreateRequest(t *testing.T) {
+	t.Run("should leave missing required fields empty", func(t *testing.T) {
+		title := "Banjir"
+		latitude := -6.2
+		draft := &model.ReportDraft{ReportTitle: &title, Latitude: &latitude}
+
+		req := ConvertReportDraftToCreateRequest(draft)
+
+		assert.Equal(t, "Banjir", req.ReportTitle)
+		assert.Equal(t, -6.2, req.Latitude)
+		assert.Empty(t, req.ReportType)
+		assert.Zero(t, req.Longitude)
+	})
+}
+
+func TestIsAnonymousReportAllowed(t *testing.T) {
+	t.Run("should allow safety and health by default", func(t *testing.T) {
+		t.Setenv("ANONYMOUS_REPORT_TYPES", "")
+
+		assert.True(t, IsAnonymousReportAllowed(model.Safety))
+		assert.True(t, IsAnonymousReportAllowed(model.Health))
+		assert.False(t, IsAnonymousReportAllowed(model.Infrastructure))
+	})
+
+	t.Run("should use configured report types", func(t *testing.T) {
+		t.Setenv("ANONYMOUS_REPORT_TYPES", "social, environment")
+
+		assert.True(t, IsAnonymousReportAllowed(model.Social))
+		assert.True(t, IsAnonymousReportAllowed(model.Environment))
+		assert.False(t, IsAnonymousReportAllowed(model.Safety))
+	})
+}
+
+func TestRedactReportOwner(t *testing.T) {
+	t.Run("should hide owner identity and own interactions", func(t *testing.T) {
+		picture := "avatar.png"
+		report := &reportDTO.Report{
+			UserID:          5,
+			UserName:        "pelapor",
+			FullName:        "Pelapor Asli",
+			ProfilePicture:  &picture,
+			ReportReactions: []reportDTO.ReactReportResponse{{UserID: 5}, {UserID: 6}},
+		}
+
+		RedactReportOwner(report)
+
+		assert.Equal(t, uint(0), report.UserID)
+		assert.Equal(t, AnonymousUsername, report.UserName)
+		assert.Equal(t, AnonymousFullName, report.FullName)
+		assert.Nil(t, report.ProfilePicture)
+		assert.Equal(t, uint(0), report.ReportReactions[0].UserID)
+		assert.Equal(t, uint(6), report.ReportReactions[1].UserID)
+	})
+}
@@ -263,6 +263,41 @@ func FormatVoteReportValidationErrors(err error) map[string]string {
 				if e.Tag() == "oneof" {
 					errors["voteType"] = "Tipe vote harus salah satu antara RESOLVED, ON_PROGRESS, NOT_RESOLVED"
 				}
+			case "Latitude":
+				if e.Tag() == "required_with" {
+					errors["latitude"] = "Latitude wajib diisi jika longitude diisi"
+				}
+				if e.Tag() == "latitude" {
+					errors["latitude"] = "Latitude tidak valid"
+				}
+			case "Longitude":
+				if e.Tag() == "required_with" {
+					errors["longitude"] = "Longitude wajib diisi jika latitude diisi"
+				}
+				if e.Tag() == "longitude" {
+					errors["longitude"] = "Longitude tidak valid"
+				}
+		}
+	}
+	return errors
+}
+
+func FormatMergeReportsValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+			case "DuplicateReportIDs":
+				if e.Tag() == "required" || e.Tag() == "min" {
+					errors["duplicateReportIDs"] = "Minimal satu laporan duplikat wajib dipilih"
+				}
+				if e.Tag() == "max" {
+					errors["duplicateReportIDs"] = "Maksimal 10 laporan duplikat dalam satu penggabungan"
+				}
+			default:
+				errors["duplicateReportIDs"] = "ID laporan duplikat tidak valid"
 		}
 	}
 	return errors
@@ -0,0 +1,11 @@
+package dto
+
+type ReputationEvent struct {
+	ID         uint    `json:"id"`
+	EventType  string  `json:"eventType"`
+	Points     int64   `json:"points"`
+	SourceType string  `json:"sourceType"`
+	SourceID   string  `json:"sourceID"`
+	Reason     *string `json:"reason,omitempty"`
+	CreatedAt  int64   `json:"createdAt"`
+}
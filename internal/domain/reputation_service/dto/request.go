@@ -0,0 +1,7 @@
+package dto
+
+type ApplyPenaltyRequest struct {
+	Points   int64  `json:"points" validate:"required,min=1,max=100"`
+	Reason   string `json:"reason" validate:"required,min=5,max=500"`
+	ReportID *uint  `json:"reportID" validate:"omitempty,min=1"`
+}
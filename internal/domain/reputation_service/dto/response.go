@@ -0,0 +1,13 @@
+package dto
+
+type GetReputationHistoryResponse struct {
+	UserID          uint              `json:"userID"`
+	ReputationScore int64             `json:"reputationScore"`
+	Events          []ReputationEvent `json:"events"`
+}
+
+type RecomputeReputationResponse struct {
+	UserID          uint  `json:"userID"`
+	PreviousScore   int64 `json:"previousScore"`
+	ReputationScore int64 `json:"reputationScore"`
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"pingspot/internal/domain/reputation_service/dto"
+	"pingspot/internal/domain/reputation_service/service"
+	"pingspot/internal/domain/reputation_service/validation"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	response "pingspot/pkg/utils/response_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type ReputationHandler struct {
+	reputationService *service.ReputationService
+}
+
+func NewReputationHandler(reputationService *service.ReputationService) *ReputationHandler {
+	return &ReputationHandler{reputationService: reputationService}
+}
+
+func (h *ReputationHandler) GetReputationHistoryHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	targetUserID, err := c.ParamsInt("userID")
+	if err != nil || targetUserID <= 0 {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		return response.ResponseError(c, 400, "ID pengguna tidak valid", "", "ID pengguna harus berupa angka")
+	}
+
+	cursorID := c.Query("cursorID")
+	cursorIDUint, err := mainutils.StringToUint(cursorID)
+	if err != nil {
+		logger.Error("Invalid cursorID format", zap.String("cursorID", cursorID), zap.Error(err))
+		return response.ResponseError(c, 400, "Format cursorID tidak valid", "", "cursorID harus berupa angka")
+	}
+
+	history, err := h.reputationService.GetReputationHistory(ctx, userID, uint(targetUserID), cursorIDUint)
+	if err != nil {
+		logger.Error("Failed to get reputation history", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan riwayat reputasi", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan riwayat reputasi", "data", history)
+}
+
+func (h *ReputationHandler) ApplyPenaltyHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	targetUserID, err := c.ParamsInt("userID")
+	if err != nil || targetUserID <= 0 {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		return response.ResponseError(c, 400, "ID pengguna tidak valid", "", "ID pengguna harus berupa angka")
+	}
+
+	var req dto.ApplyPenaltyRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatApplyPenaltyValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	event, err := h.reputationService.ApplyPenalty(ctx, userID, uint(targetUserID), req)
+	if err != nil {
+		logger.Error("Failed to apply reputation penalty", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal memberikan penalti reputasi", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 201, "Berhasil memberikan penalti reputasi", "data", event)
+}
+
+func (h *ReputationHandler) RecomputeScoreHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	targetUserID, err := c.ParamsInt("userID")
+	if err != nil || targetUserID <= 0 {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		return response.ResponseError(c, 400, "ID pengguna tidak valid", "", "ID pengguna harus berupa angka")
+	}
+
+	result, err := h.reputationService.RecomputeScore(ctx, userID, uint(targetUserID))
+	if err != nil {
+		logger.Error("Failed to recompute reputation score", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menghitung ulang reputasi", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menghitung ulang reputasi", "data", result)
+}
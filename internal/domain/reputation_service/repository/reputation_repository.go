@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReputationRepository interface {
+	AwardTX(ctx context.Context, tx *gorm.DB, event *model.ReputationEvent) (bool, error)
+	GetBySource(ctx context.Context, userID uint, eventType model.ReputationEventType, sourceType model.ReputationSourceType, sourceID string) (*model.ReputationEvent, error)
+	GetPaginatedByUserID(ctx context.Context, userID uint, limit, cursorID uint) ([]model.ReputationEvent, error)
+	GetScore(ctx context.Context, userID uint) (int64, error)
+	GetScores(ctx context.Context, userIDs []uint) (map[uint]int64, error)
+	RecomputeTX(ctx context.Context, tx *gorm.DB, userID uint) (int64, error)
+}
+
+type reputationRepository struct {
+	db *gorm.DB
+}
+
+func NewReputationRepository(db *gorm.DB) ReputationRepository {
+	return &reputationRepository{db: db}
+}
+
+func (r *reputationRepository) AwardTX(ctx context.Context, tx *gorm.DB, event *model.ReputationEvent) (bool, error) {
+	result := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(event)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	if err := tx.WithContext(ctx).
+		Model(&model.User{}).
+		Where("id = ?", event.UserID).
+		UpdateColumn("reputation_score", gorm.Expr("reputation_score + ?", event.Points)).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *reputationRepository) GetBySource(ctx context.Context, userID uint, eventType model.ReputationEventType, sourceType model.ReputationSourceType, sourceID string) (*model.ReputationEvent, error) {
+	var event model.ReputationEvent
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND event_type = ? AND source_type = ? AND source_id = ?", userID, eventType, sourceType, sourceID).
+		First(&event).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *reputationRepository) GetPaginatedByUserID(ctx context.Context, userID uint, limit, cursorID uint) ([]model.ReputationEvent, error) {
+	var events []model.ReputationEvent
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if cursorID != 0 {
+		query = query.Where("id < ?", cursorID)
+	}
+	if err := query.Order("id DESC").Limit(int(limit)).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *reputationRepository) GetScore(ctx context.Context, userID uint) (int64, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).
+		Select("id", "reputation_score").
+		First(&user, userID).Error; err != nil {
+		return 0, err
+	}
+	return user.ReputationScore, nil
+}
+
+func (r *reputationRepository) GetScores(ctx context.Context, userIDs []uint) (map[uint]int64, error) {
+	scores := make(map[uint]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return scores, nil
+	}
+
+	var users []model.User
+	if err := r.db.WithContext(ctx).
+		Select("id", "reputation_score").
+		Where("id IN ?", userIDs).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		scores[user.ID] = user.ReputationScore
+	}
+	return scores, nil
+}
+
+func (r *reputationRepository) RecomputeTX(ctx context.Context, tx *gorm.DB, userID uint) (int64, error) {
+	var score int64
+	if err := tx.WithContext(ctx).
+		Model(&model.ReputationEvent{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(points), 0)").
+		Scan(&score).Error; err != nil {
+		return 0, err
+	}
+
+	if err := tx.WithContext(ctx).
+		Model(&model.User{}).
+		Where("id = ?", userID).
+		UpdateColumn("reputation_score", score).Error; err != nil {
+		return 0, err
+	}
+	return score, nil
+}
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"pingspot/internal/domain/reputation_service/handler"
+	reputationRepository "pingspot/internal/domain/reputation_service/repository"
+	"pingspot/internal/domain/reputation_service/service"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterReputationRoutes(app *fiber.App) {
+	db := database.GetPostgresDB()
+	reputationRepo := reputationRepository.NewReputationRepository(db)
+	userRepo := userRepository.NewUserRepository(db)
+	reputationService := service.NewReputationService(db, reputationRepo, userRepo)
+	reputationHandler := handler.NewReputationHandler(reputationService)
+
+	reputationRoute := app.Group("/pingspot/api/reputation", middleware.ValidateAccessToken())
+
+	reputationRoute.Get(
+		"/:userID/history",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 60,
+			KeyPrefix:   "get_reputation_history",
+		})),
+		reputationHandler.GetReputationHistoryHandler,
+	)
+
+	reputationRoute.Post(
+		"/:userID/penalty",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 30,
+			KeyPrefix:   "apply_reputation_penalty",
+		})),
+		reputationHandler.ApplyPenaltyHandler,
+	)
+
+	reputationRoute.Post(
+		"/:userID/recompute",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 10,
+			KeyPrefix:   "recompute_reputation",
+		})),
+		reputationHandler.RecomputeScoreHandler,
+	)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/domain/reputation_service/dto"
+	reputationRepository "pingspot/internal/domain/reputation_service/repository"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	contextutils "pingspot/pkg/utils/context_util"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const reputationHistoryPageSize = 20
+
+type ReputationService struct {
+	db             *gorm.DB
+	reputationRepo reputationRepository.ReputationRepository
+	userRepo       userRepository.UserRepository
+}
+
+func NewReputationService(db *gorm.DB, reputationRepo reputationRepository.ReputationRepository, userRepo userRepository.UserRepository) *ReputationService {
+	return &ReputationService{
+		db:             db,
+		reputationRepo: reputationRepo,
+		userRepo:       userRepo,
+	}
+}
+
+func (s *ReputationService) GetScore(ctx context.Context, userID uint) (int64, error) {
+	score, err := s.reputationRepo.GetScore(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, apperror.New(404, "USER_NOT_FOUND", "pengguna tidak ditemukan", "", nil)
+		}
+		return 0, apperror.New(500, "REPUTATION_FETCH_FAILED", "gagal mengambil reputasi pengguna", err.Error(), nil)
+	}
+	return score, nil
+}
+
+func (s *ReputationService) GetScores(ctx context.Context, userIDs []uint) (map[uint]int64, error) {
+	scores, err := s.reputationRepo.GetScores(ctx, userIDs)
+	if err != nil {
+		return nil, apperror.New(500, "REPUTATION_FETCH_FAILED", "gagal mengambil reputasi pengguna", err.Error(), nil)
+	}
+	return scores, nil
+}
+
+func (s *ReputationService) GetReputationHistory(ctx context.Context, requesterID, targetUserID, cursorID uint) (*dto.GetReputationHistoryResponse, error) {
+	if requesterID != targetUserID {
+		if _, err := s.getStaffUser(ctx, requesterID); err != nil {
+			return nil, err
+		}
+	}
+
+	score, err := s.GetScore(ctx, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.reputationRepo.GetPaginatedByUserID(ctx, targetUserID, reputationHistoryPageSize, cursorID)
+	if err != nil {
+		return nil, apperror.New(500, "REPUTATION_FETCH_FAILED", "gagal mengambil riwayat reputasi", err.Error(), nil)
+	}
+
+	eventsDTO := make([]dto.ReputationEvent, 0, len(events))
+	for _, event := range events {
+		eventsDTO = append(eventsDTO, mapReputationEvent(event))
+	}
+
+	return &dto.GetReputationHistoryResponse{
+		UserID:          targetUserID,
+		ReputationScore: score,
+		Events:          eventsDTO,
+	}, nil
+}
+
+func (s *ReputationService) ApplyPenalty(ctx context.Context, moderatorID, targetUserID uint, req dto.ApplyPenaltyRequest) (*dto.ReputationEvent, error) {
+	requestID := contextutils.GetRequestID(ctx)
+	logger.Info("Applying moderation penalty",
+		zap.String("request_id", requestID),
+		zap.Uint("moderator_id", moderatorID),
+		zap.Uint("target_user_id", targetUserID),
+		zap.Int64("points", req.Points),
+	)
+
+	if _, err := s.getStaffUser(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+	if moderatorID == targetUserID {
+		return nil, apperror.New(400, "CANNOT_PENALIZE_SELF", "anda tidak dapat memberikan penalti kepada diri sendiri", "", nil)
+	}
+	if _, err := s.userRepo.GetByID(ctx, targetUserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "USER_NOT_FOUND", "pengguna tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "USER_FETCH_FAILED", "gagal mengambil data pengguna", err.Error(), nil)
+	}
+
+	sourceType := model.ReputationSourceModeration
+	sourceID := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if req.ReportID != nil {
+		sourceType = model.ReputationSourceReport
+		sourceID = strconv.FormatUint(uint64(*req.ReportID), 10)
+	}
+
+	event := &model.ReputationEvent{
+		UserID:      targetUserID,
+		EventType:   model.ReputationModerationPenalty,
+		Points:      -req.Points,
+		SourceType:  sourceType,
+		SourceID:    sourceID,
+		Reason:      &req.Reason,
+		CreatedByID: &moderatorID,
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, apperror.New(500, "TRANSACTION_START_FAILED", "gagal memulai transaksi", tx.Error.Error(), nil)
+	}
+
+	inserted, err := s.reputationRepo.AwardTX(ctx, tx, event)
+	if err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "REPUTATION_PENALTY_FAILED", "gagal memberikan penalti reputasi", err.Error(), nil)
+	}
+	if !inserted {
+		tx.Rollback()
+		return nil, apperror.New(409, "PENALTY_ALREADY_APPLIED", "penalti untuk laporan ini sudah diberikan", "", nil)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyimpan transaksi", err.Error(), nil)
+	}
+
+	result := mapReputationEvent(*event)
+	return &result, nil
+}
+
+func (s *ReputationService) RecomputeScore(ctx context.Context, moderatorID, targetUserID uint) (*dto.RecomputeReputationResponse, error) {
+	if _, err := s.getStaffUser(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	previousScore, err := s.GetScore(ctx, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, apperror.New(500, "TRANSACTION_START_FAILED", "gagal memulai transaksi", tx.Error.Error(), nil)
+	}
+
+	score, err := s.reputationRepo.RecomputeTX(ctx, tx, targetUserID)
+	if err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "REPUTATION_RECOMPUTE_FAILED", "gagal menghitung ulang reputasi", err.Error(), nil)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyimpan transaksi", err.Error(), nil)
+	}
+
+	if score != previousScore {
+		logger.Warn("Reputation score drifted from ledger",
+			zap.Uint("user_id", targetUserID),
+			zap.Int64("previous_score", previousScore),
+			zap.Int64("recomputed_score", score),
+		)
+	}
+
+	return &dto.RecomputeReputationResponse{
+		UserID:          targetUserID,
+		PreviousScore:   previousScore,
+		ReputationScore: score,
+	}, nil
+}
+
+func (s *ReputationService) getStaffUser(ctx context.Context, userID uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "USER_NOT_FOUND", "pengguna tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "USER_FETCH_FAILED", "gagal mengambil data pengguna", err.Error(), nil)
+	}
+	if user.Role != model.UserRoleModerator && user.Role != model.UserRoleAgency {
+		return nil, apperror.New(403, "FORBIDDEN", "anda tidak memiliki akses ke fitur ini", "", nil)
+	}
+	return user, nil
+}
+
+func mapReputationEvent(event model.ReputationEvent) dto.ReputationEvent {
+	return dto.ReputationEvent{
+		ID:         event.ID,
+		EventType:  string(event.EventType),
+		Points:     event.Points,
+		SourceType: string(event.SourceType),
+		SourceID:   event.SourceID,
+		Reason:     event.Reason,
+		CreatedAt:  event.CreatedAt,
+	}
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"pingspot/internal/domain/reputation_service/dto"
+	reputationMocks "pingspot/internal/mocks/reputation"
+	userMocks "pingspot/internal/mocks/user"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
+
+func setupMocks(t *testing.T) (*reputationMocks.MockReputationRepository, *userMocks.MockUserRepository, *ReputationService) {
+	mockReputationRepo := new(reputationMocks.MockReputationRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+	service := NewReputationService(setupTestDB(t), mockReputationRepo, mockUserRepo)
+	return mockReputationRepo, mockUserRepo, service
+}
+
+func TestReputationService_GetReputationHistory(t *testing.T) {
+	t.Run("should get own reputation history", func(t *testing.T) {
+		mockReputationRepo, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockReputationRepo.On("GetScore", ctx, uint(1)).Return(int64(12), nil)
+		mockReputationRepo.On("GetPaginatedByUserID", ctx, uint(1), uint(reputationHistoryPageSize), uint(0)).Return([]model.ReputationEvent{
+			{ID: 2, UserID: 1, EventType: model.ReputationReportResolved, Points: 10, SourceType: model.ReputationSourceReport, SourceID: "5"},
+			{ID: 1, UserID: 1, EventType: model.ReputationVoteAccurate, Points: 2, SourceType: model.ReputationSourceReportVote, SourceID: "9"},
+		}, nil)
+
+		result, err := service.GetReputationHistory(ctx, 1, 1, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), result.ReputationScore)
+		require.Len(t, result.Events, 2)
+		assert.Equal(t, "REPORT_RESOLVED", result.Events[0].EventType)
+		mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject regular users reading another history", func(t *testing.T) {
+		_, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, uint(2)).Return(&model.User{ID: 2, Role: model.UserRoleUser}, nil)
+
+		result, err := service.GetReputationHistory(ctx, 2, 1, 0)
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 403, appErr.StatusCode)
+	})
+}
+
+func TestReputationService_ApplyPenalty(t *testing.T) {
+	t.Run("should record penalty as negative ledger entry", func(t *testing.T) {
+		mockReputationRepo, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+		reportID := uint(7)
+
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleModerator}, nil)
+		mockUserRepo.On("GetByID", ctx, uint(3)).Return(&model.User{ID: 3, Role: model.UserRoleUser}, nil)
+		mockReputationRepo.On("AwardTX", ctx, mock.Anything, mock.MatchedBy(func(event *model.ReputationEvent) bool {
+			return event.UserID == 3 &&
+				event.EventType == model.ReputationModerationPenalty &&
+				event.Points == -15 &&
+				event.SourceType == model.ReputationSourceReport &&
+				event.SourceID == "7" &&
+				*event.CreatedByID == 1
+		})).Return(true, nil)
+
+		result, err := service.ApplyPenalty(ctx, 1, 3, dto.ApplyPenaltyRequest{Points: 15, Reason: "Laporan palsu", ReportID: &reportID})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(-15), result.Points)
+		mockReputationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return conflict when penalty already applied", func(t *testing.T) {
+		mockReputationRepo, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+		reportID := uint(7)
+
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleAgency}, nil)
+		mockUserRepo.On("GetByID", ctx, uint(3)).Return(&model.User{ID: 3}, nil)
+		mockReputationRepo.On("AwardTX", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+		result, err := service.ApplyPenalty(ctx, 1, 3, dto.ApplyPenaltyRequest{Points: 5, Reason: "Spam komentar", ReportID: &reportID})
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 409, appErr.StatusCode)
+	})
+
+	t.Run("should reject penalizing self", func(t *testing.T) {
+		mockReputationRepo, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleModerator}, nil)
+
+		result, err := service.ApplyPenalty(ctx, 1, 1, dto.ApplyPenaltyRequest{Points: 5, Reason: "Uji coba"})
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockReputationRepo.AssertNotCalled(t, "AwardTX", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReputationService_RecomputeScore(t *testing.T) {
+	t.Run("should recompute score from ledger", func(t *testing.T) {
+		mockReputationRepo, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleModerator}, nil)
+		mockReputationRepo.On("GetScore", ctx, uint(3)).Return(int64(20), nil)
+		mockReputationRepo.On("RecomputeTX", ctx, mock.Anything, uint(3)).Return(int64(18), nil)
+
+		result, err := service.RecomputeScore(ctx, 1, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), result.PreviousScore)
+		assert.Equal(t, int64(18), result.ReputationScore)
+	})
+
+	t.Run("should reject regular users", func(t *testing.T) {
+		_, mockUserRepo, service := setupMocks(t)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, uint(2)).Return(&model.User{ID: 2, Role: model.UserRoleUser}, nil)
+
+		result, err := service.RecomputeScore(ctx, 2, 3)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,44 @@
+package util
+
+import "pingspot/internal/model"
+
+const (
+	ReportResolvedPoints int64 = 10
+	VoteAccuratePoints   int64 = 2
+	VoteInaccuratePoints int64 = -1
+	HelpfulCommentPoints int64 = 3
+)
+
+func GetEventPoints(eventType model.ReputationEventType) int64 {
+	switch eventType {
+	case model.ReputationReportResolved:
+		return ReportResolvedPoints
+	case model.ReputationReportMerged:
+		return -ReportResolvedPoints
+	case model.ReputationVoteAccurate:
+		return VoteAccuratePoints
+	case model.ReputationVoteInaccurate:
+		return VoteInaccuratePoints
+	case model.ReputationHelpfulComment:
+		return HelpfulCommentPoints
+	default:
+		return 0
+	}
+}
+
+func GetVoteOutcomeEvent(reportStatus, voteType model.ReportStatus) (model.ReputationEventType, bool) {
+	switch reportStatus {
+	case model.RESOLVED:
+		if voteType == model.RESOLVED {
+			return model.ReputationVoteAccurate, true
+		}
+		return model.ReputationVoteInaccurate, true
+	case model.EXPIRED:
+		if voteType == model.RESOLVED {
+			return model.ReputationVoteInaccurate, true
+		}
+		return model.ReputationVoteAccurate, true
+	default:
+		return "", false
+	}
+}
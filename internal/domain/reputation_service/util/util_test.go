@@ -0,0 +1,40 @@
+package util
+
+import (
+	"testing"
+
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEventPoints(t *testing.T) {
+	assert.Equal(t, ReportResolvedPoints, GetEventPoints(model.ReputationReportResolved))
+	assert.Equal(t, -ReportResolvedPoints, GetEventPoints(model.ReputationReportMerged))
+	assert.Equal(t, VoteInaccuratePoints, GetEventPoints(model.ReputationVoteInaccurate))
+	assert.Equal(t, int64(0), GetEventPoints(model.ReputationModerationPenalty))
+}
+
+func TestGetVoteOutcomeEvent(t *testing.T) {
+	tests := []struct {
+		name         string
+		reportStatus model.ReportStatus
+		voteType     model.ReportStatus
+		expected     model.ReputationEventType
+		evaluated    bool
+	}{
+		{"resolved vote on resolved report", model.RESOLVED, model.RESOLVED, model.ReputationVoteAccurate, true},
+		{"on progress vote on resolved report", model.RESOLVED, model.ON_PROGRESS, model.ReputationVoteInaccurate, true},
+		{"resolved vote on expired report", model.EXPIRED, model.RESOLVED, model.ReputationVoteInaccurate, true},
+		{"on progress vote on expired report", model.EXPIRED, model.ON_PROGRESS, model.ReputationVoteAccurate, true},
+		{"report still in progress", model.ON_PROGRESS, model.RESOLVED, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventType, evaluated := GetVoteOutcomeEvent(tt.reportStatus, tt.voteType)
+			assert.Equal(t, tt.expected, eventType)
+			assert.Equal(t, tt.evaluated, evaluated)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+var Validate *validator.Validate
+
+func init() {
+	Validate = validator.New()
+}
+
+func FormatApplyPenaltyValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Points":
+			if e.Tag() == "required" {
+				errors["points"] = "Poin penalti wajib diisi"
+			}
+			if e.Tag() == "min" {
+				errors["points"] = "Poin penalti minimal 1"
+			}
+			if e.Tag() == "max" {
+				errors["points"] = "Poin penalti maksimal 100"
+			}
+		case "Reason":
+			if e.Tag() == "required" {
+				errors["reason"] = "Alasan penalti wajib diisi"
+			}
+			if e.Tag() == "min" {
+				errors["reason"] = "Alasan penalti minimal 5 karakter"
+			}
+			if e.Tag() == "max" {
+				errors["reason"] = "Alasan penalti maksimal 500 karakter"
+			}
+		case "ReportID":
+			if e.Tag() == "min" {
+				errors["reportID"] = "ID laporan tidak valid"
+			}
+		}
+	}
+	return errors
+}
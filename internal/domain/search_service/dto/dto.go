@@ -18,6 +18,7 @@ type ReportsSearch struct {
 	ReportDescription   string  `json:"reportDescription"`
 	ReportHasProgress	bool	`json:"hasProgress"`
 	ReportStatus  string  `json:"reportStatus"`
+	IsAnonymous   bool    `json:"isAnonymous"`
 	CreatedAt     int64  `json:"reportCreatedAt"`
 	UpdatedAt     int64  `json:"reportUpdatedAt"`
 }
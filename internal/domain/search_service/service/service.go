@@ -82,6 +82,7 @@ func (s *SearchService) SearchData(ctx context.Context, searchQuery string, user
 			ReportDescription: report.ReportDescription,
 			ReportHasProgress: *report.HasProgress,
 			ReportStatus:      string(report.ReportStatus),
+			IsAnonymous:       report.IsAnonymous,
 			CreatedAt:         report.CreatedAt,
 			UpdatedAt:         report.UpdatedAt,
 		}
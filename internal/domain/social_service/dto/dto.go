@@ -16,5 +16,6 @@ type UserConnection struct {
 	FullName string `json:"fullName"`
 	ProfilePicture *string `json:"profilePicture"`
 	Status   string `json:"status"`
+	LastSeenAt *int64 `json:"lastSeenAt"`
 	Relation string `json:"relation"`
 }
\ No newline at end of file
@@ -101,6 +101,12 @@ func (h *SocialHandler) GetFollowDataHandler(c *fiber.Ctx) error {
 
 func (h *SocialHandler) GetUserConnectionsHandler(c *fiber.Ctx) error {
 	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	viewerID := uint(claims["user_id"].(float64))
 
 	userIDParam := c.Params("userID")
 
@@ -110,7 +116,7 @@ func (h *SocialHandler) GetUserConnectionsHandler(c *fiber.Ctx) error {
 		return response.ResponseError(c, 400, "Format userID tidak valid", "", "userID harus berupa angka")
 	}
 
-	userConnections, err := h.socialService.GetUserConnections(ctx, userID)
+	userConnections, err := h.socialService.GetUserConnections(ctx, viewerID, userID)
 	if err != nil {
 		logger.Error("Failed to get user connections", zap.Error(err))
 		if appErr, ok := err.(*apperror.AppError); ok {
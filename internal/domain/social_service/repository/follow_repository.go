@@ -15,6 +15,7 @@ type FollowRepository interface {
 	GetFollowingCount(ctx context.Context, followerUserID uint, followingType model.FollowingType) (int64, error)
 	GetFollowersByUserID(ctx context.Context, userID uint) ([]*model.User, error)
 	GetFollowingByUserID(ctx context.Context, userID uint) ([]*model.User, error)
+	GetConnectedUserIDs(ctx context.Context, userID uint, candidateIDs []uint) ([]uint, error)
 }
 
 type followRepository struct {
@@ -91,4 +92,21 @@ func (r *followRepository) GetFollowingByUserID(ctx context.Context, userID uint
     }
 
     return following, nil
-}
\ No newline at end of file
+}
+
+func (r *followRepository) GetConnectedUserIDs(ctx context.Context, userID uint, candidateIDs []uint) ([]uint, error) {
+	var connectedIDs []uint
+	if len(candidateIDs) == 0 {
+		return connectedIDs, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&model.Follow{}).
+		Select("CASE WHEN follower_user_id = ? THEN following_id ELSE follower_user_id END", userID).
+		Where("following_type = ?", model.FollowingTypeUser).
+		Where("(follower_user_id = ? AND following_id IN ?) OR (following_id = ? AND follower_user_id IN ?)", userID, candidateIDs, userID, candidateIDs).
+		Scan(&connectedIDs).Error; err != nil {
+		return nil, err
+	}
+	return connectedIDs, nil
+}
@@ -2,10 +2,13 @@ package router
 
 import (
 	"fmt"
+	presenceRepository "pingspot/internal/domain/presence_service/repository"
+	presenceService "pingspot/internal/domain/presence_service/service"
 	"pingspot/internal/domain/social_service/handler"
 	socialRepository "pingspot/internal/domain/social_service/repository"
 	"pingspot/internal/domain/social_service/service"
 	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/infrastructure/cache"
 	"pingspot/internal/infrastructure/database"
 	"pingspot/internal/middleware"
 	taskService "pingspot/internal/domain/task_service/service"
@@ -23,7 +26,11 @@ func RegisterSocialRoutes(app *fiber.App) {
 	client := asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddress})
 	tasksService := taskService.NewTaskService(client)
 	
-	socialService := service.NewSocialService(db, followRepo, userRepo, tasksService)
+	userProfileRepo := userRepository.NewUserProfileRepository(db)
+	presenceRepo := presenceRepository.NewPresenceRepository(cache.GetRedis())
+	presenceSvc := presenceService.NewPresenceService(presenceRepo, userRepo, userProfileRepo, followRepo)
+
+	socialService := service.NewSocialService(db, followRepo, userRepo, tasksService, presenceSvc)
 	socialHandler := handler.NewSocialHandler(socialService)
 
 	followRoute := app.Group("/pingspot/api/social/follow", middleware.ValidateAccessToken())
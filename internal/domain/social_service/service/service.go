@@ -3,15 +3,17 @@ package service
 import (
 	"context"
 	"errors"
-	"fmt"
+	presenceService "pingspot/internal/domain/presence_service/service"
 	"pingspot/internal/domain/social_service/dto"
 	socialRepository "pingspot/internal/domain/social_service/repository"
 	tasksService "pingspot/internal/domain/task_service/service"
 	userRepository "pingspot/internal/domain/user_service/repository"
+	webhookDTO "pingspot/internal/domain/webhook_service/dto"
 	"pingspot/internal/model"
 	apperror "pingspot/pkg/app_error"
 	mainutils "pingspot/pkg/utils/main_util"
 	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -21,14 +23,16 @@ type SocialService struct {
 	userRepo        userRepository.UserRepository
 	db              *gorm.DB
 	tasksService    tasksService.TaskService
+	presenceService *presenceService.PresenceService
 }
 
-func NewSocialService(db *gorm.DB, followRepo socialRepository.FollowRepository, userRepo userRepository.UserRepository, tasksService tasksService.TaskService) *SocialService {
+func NewSocialService(db *gorm.DB, followRepo socialRepository.FollowRepository, userRepo userRepository.UserRepository, tasksService tasksService.TaskService, presenceService *presenceService.PresenceService) *SocialService {
 	return &SocialService{
 		db:           db,
 		followRepo: followRepo,
 		userRepo:    userRepo,
 		tasksService: tasksService,
+		presenceService: presenceService,
 	}
 }
 
@@ -65,22 +69,45 @@ func (s *SocialService) Follow(ctx context.Context, userID uint, req dto.FollowR
 		}
 		followProcess = "unfollow"
 	} else {
-		if err := s.followRepo.CreateTX(ctx, s.db, &follow); err != nil {
+		tx := s.db.Begin()
+		if tx.Error != nil {
+			return nil, apperror.New(500, "TRANSACTION_START_FAILED", "gagal memulai transaksi", tx.Error.Error(), nil)
+		}
+		if err := s.followRepo.CreateTX(ctx, tx, &follow); err != nil {
+			tx.Rollback()
 			return nil, apperror.New(500, "FOLLOW_FAILED", "gagal mengikuti pengguna", err.Error(), nil)
 		}
 		followProcess = "follow"
 
-		if err := s.tasksService.CreateNotificationTask(
+		taskOutbox := s.tasksService.WithTx(tx)
+		if err := taskOutbox.CreateGroupedNotificationTask(
 			req.FollowingID,
-			"Seseorang mulai mengikuti Anda",
-			fmt.Sprintf("Pengguna %s mulai mengikuti Anda", currentUser.Username),
+			currentUser.ID,
+			currentUser.Username,
 			mainutils.StrPtrOrNil(strconv.FormatUint(uint64(currentUser.ID), 10)),
 			model.EntityTypeUser,
 			model.UserNotificationCategory,
-			model.NotificationTypeInfo,
+			model.NotificationEventFollow,
+			nil,
 		); err != nil {
+			tx.Rollback()
 			return nil, apperror.New(500, "NOTIFICATION_TASK_FAILED", "gagal membuat tugas notifikasi", err.Error(), nil)
 		}
+
+		if err := taskOutbox.DispatchWebhookEventTask(model.WebhookFollowCreated, webhookDTO.FollowEventData{
+			FollowerUserID: follow.FollowerUserID,
+			FollowingID:    follow.FollowingID,
+			FollowingType:  string(follow.FollowingType),
+			CreatedAt:      time.Now().Unix(),
+		}); err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "OUTBOX_WRITE_FAILED", "gagal menyimpan event mengikuti", err.Error(), nil)
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			tx.Rollback()
+			return nil, apperror.New(500, "TRANSACTION_COMMIT_FAILED", "gagal menyimpan perubahan", err.Error(), nil)
+		}
 	}
 
 	return &dto.FollowResponse{
@@ -123,7 +150,7 @@ func (s *SocialService) GetFollowing(ctx context.Context, followingID uint, foll
 	}, nil
 }
 
-func (s *SocialService) GetUserConnections(ctx context.Context, userID uint) (*dto.GetUserConnectionsResponse, error) {
+func (s *SocialService) GetUserConnections(ctx context.Context, viewerID, userID uint) (*dto.GetUserConnectionsResponse, error) {
 	currentUser, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -142,6 +169,11 @@ func (s *SocialService) GetUserConnections(ctx context.Context, userID uint) (*d
 		return nil, apperror.New(500, "USER_CONNECTIONS_FETCH_FAILED", "gagal mendapatkan koneksi pengguna", err.Error(), nil)
 	}
 
+	presences, err := s.presenceService.ResolvePresences(ctx, viewerID, append(append([]*model.User{}, userFollowers...), userFollowing...))
+	if err != nil {
+		return nil, err
+	}
+
 	var followersDTO []*dto.UserConnection
 	for _, user := range userFollowers {
 		followersDTO = append(followersDTO, &dto.UserConnection{
@@ -149,7 +181,8 @@ func (s *SocialService) GetUserConnections(ctx context.Context, userID uint) (*d
 			Username: user.Username,
 			FullName: user.FullName,
 			ProfilePicture: user.Profile.ProfilePicture,
-			Status:   "offline",
+			Status:   presences[user.ID].Status,
+			LastSeenAt: presences[user.ID].LastSeenAt,
 			Relation: "follower",
 		})
 	}
@@ -160,7 +193,8 @@ func (s *SocialService) GetUserConnections(ctx context.Context, userID uint) (*d
 			Username: user.Username,
 			FullName: user.FullName,
 			ProfilePicture: user.Profile.ProfilePicture,
-			Status:   "offline",
+			Status:   presences[user.ID].Status,
+			LastSeenAt: presences[user.ID].LastSeenAt,
 			Relation: "following",
 		})
 	}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pingspot/internal/domain/task_service/payload"
+	TaskRepo "pingspot/internal/domain/task_service/repository"
+	"pingspot/pkg/logger"
+	"pingspot/pkg/mailer"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+// SendEmailHandler delivers a queued email. Transient failures are retried by
+// asynq with backoff; permanent rejections and exhausted retries end up in the
+// archived (dead-letter) queue, where they can be inspected and re-run.
+func (h *TaskHandler) SendEmailHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.SendEmailPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("invalid send email payload: %v: %w", err, asynq.SkipRetry)
+	}
+	message := payload.Message
+	if h.Mailer == nil {
+		return fmt.Errorf("mailer is not configured")
+	}
+
+	key := message.IdempotencyKey
+	if key != "" {
+		state, err := h.EmailIdempotencyRepo.Acquire(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to claim email idempotency key: %w", err)
+		}
+		switch state {
+		case TaskRepo.EmailSendAlreadySent:
+			logger.Info("Email already sent, skipping", zap.String("idempotency_key", key))
+			return nil
+		case TaskRepo.EmailSendInFlight:
+			return fmt.Errorf("email %s is being sent by another worker", key)
+		}
+	}
+
+	if err := h.Mailer.Send(ctx, message); err != nil {
+		if key != "" {
+			if releaseErr := h.EmailIdempotencyRepo.Release(ctx, key); releaseErr != nil {
+				logger.Error("Failed to release email idempotency key", zap.String("idempotency_key", key), zap.Error(releaseErr))
+			}
+		}
+		retryCount, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if mailer.IsPermanent(err) {
+			logger.Error("Email permanently rejected", zap.String("idempotency_key", key), zap.String("subject", message.Subject), zap.Error(err))
+			return fmt.Errorf("failed to send email: %v: %w", err, asynq.SkipRetry)
+		}
+		if retryCount >= maxRetry {
+			logger.Error("Email delivery exhausted retries", zap.String("idempotency_key", key), zap.String("subject", message.Subject), zap.Int("retries", retryCount), zap.Error(err))
+		}
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	if key != "" {
+		if err := h.EmailIdempotencyRepo.MarkSent(ctx, key); err != nil {
+			logger.Error("Failed to mark email as sent", zap.String("idempotency_key", key), zap.Error(err))
+		}
+	}
+	logger.Info("Email sent", zap.String("idempotency_key", key), zap.String("subject", message.Subject))
+	return nil
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	gamificationUtil "pingspot/internal/domain/gamification_service/util"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/domain/task_service/payload"
+	"pingspot/internal/model"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func (h *TaskHandler) ProcessGamificationEventHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.GamificationEventPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+	return h.processGamificationEvent(ctx, payload)
+}
+
+func (h *TaskHandler) processGamificationEvent(ctx context.Context, event payload.GamificationEventPayload) error {
+	points := gamificationUtil.GetActivityPoints(event.EventType)
+	if points == 0 {
+		return fmt.Errorf("unsupported gamification event type: %s", event.EventType)
+	}
+
+	regions := []string{gamificationUtil.GlobalRegion}
+	if event.ReportID != 0 {
+		report, err := h.ReportRepo.GetByID(ctx, event.ReportID)
+		if err != nil {
+			logger.Warn("Failed to get report region for gamification event", zap.Uint("report_id", event.ReportID), zap.Error(err))
+		} else if region := gamificationUtil.GetReportRegion(report.ReportLocation); region != "" {
+			regions = append(regions, region)
+		}
+	}
+
+	occurredAt := time.Unix(event.OccurredAt, 0)
+	dedupeKey := gamificationUtil.GetEventDedupeKey(event.EventID, event.UserID, event.EventType, event.ReportID)
+	claimed, err := h.CacheRepo.SetNX(ctx, dedupeKey, occurredAt.Unix(), gamificationUtil.EventDedupeTTL)
+	if err != nil {
+		return fmt.Errorf("failed to claim gamification event %s: %w", dedupeKey, err)
+	}
+	if claimed {
+		if err := h.updateLeaderboards(ctx, event, regions, occurredAt, points); err != nil {
+			if delErr := h.CacheRepo.Del(ctx, dedupeKey); delErr != nil {
+				logger.Warn("Failed to release gamification event claim", zap.String("key", dedupeKey), zap.Error(delErr))
+			}
+			return err
+		}
+	} else {
+		logger.Info("Skipping leaderboard update for duplicate gamification event", zap.String("key", dedupeKey))
+	}
+
+	return h.evaluateBadges(ctx, event, regions, occurredAt)
+}
+
+func (h *TaskHandler) updateLeaderboards(ctx context.Context, event payload.GamificationEventPayload, regions []string, occurredAt time.Time, points float64) error {
+	member := strconv.FormatUint(uint64(event.UserID), 10)
+	for _, region := range regions {
+		for _, period := range gamificationUtil.LeaderboardPeriods {
+			if err := h.incrementLeaderboard(ctx, gamificationUtil.LeaderboardPoints, period, occurredAt, region, member, points); err != nil {
+				return err
+			}
+			if event.EventType == model.GamificationVoteCast {
+				if err := h.incrementLeaderboard(ctx, gamificationUtil.LeaderboardVotes, period, occurredAt, region, member, 1); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (h *TaskHandler) incrementLeaderboard(ctx context.Context, metric gamificationUtil.LeaderboardMetric, period gamificationUtil.LeaderboardPeriod, occurredAt time.Time, region, member string, increment float64) error {
+	key := gamificationUtil.GetLeaderboardKey(metric, period, gamificationUtil.GetPeriodKey(period, occurredAt), region)
+	if _, err := h.CacheRepo.ZIncrBy(ctx, key, increment, member); err != nil {
+		return fmt.Errorf("failed to update leaderboard %s: %w", key, err)
+	}
+	if ttl := gamificationUtil.GetPeriodTTL(period); ttl > 0 {
+		if _, err := h.CacheRepo.Expire(ctx, key, ttl); err != nil {
+			return fmt.Errorf("failed to set leaderboard expiration %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (h *TaskHandler) evaluateBadges(ctx context.Context, event payload.GamificationEventPayload, regions []string, occurredAt time.Time) error {
+	counts, err := h.GamificationRepo.GetUserActivityCounts(ctx, event.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user activity counts: %w", err)
+	}
+
+	var badges []model.UserBadge
+	for _, badgeCode := range gamificationUtil.GetEarnedBadges(*counts) {
+		badges = append(badges, model.UserBadge{UserID: event.UserID, BadgeCode: badgeCode})
+	}
+
+	if event.EventType == model.GamificationVoteCast {
+		member := strconv.FormatUint(uint64(event.UserID), 10)
+		for _, region := range regions {
+			if region == gamificationUtil.GlobalRegion {
+				continue
+			}
+			key := gamificationUtil.GetLeaderboardKey(gamificationUtil.LeaderboardVotes, gamificationUtil.LeaderboardMonthly, gamificationUtil.GetPeriodKey(gamificationUtil.LeaderboardMonthly, occurredAt), region)
+			rank, err := h.CacheRepo.ZRevRank(ctx, key, member)
+			if err != nil {
+				if errors.Is(err, redis.Nil) {
+					continue
+				}
+				return fmt.Errorf("failed to get leaderboard rank %s: %w", key, err)
+			}
+			score, err := h.CacheRepo.ZScore(ctx, key, member)
+			if err != nil && !errors.Is(err, redis.Nil) {
+				return fmt.Errorf("failed to get leaderboard score %s: %w", key, err)
+			}
+			if rank == 0 && score >= gamificationUtil.TopRegionalVoterMinVotes {
+				badges = append(badges, model.UserBadge{UserID: event.UserID, BadgeCode: model.BadgeTopRegionalVoter, Region: region})
+			}
+		}
+	}
+
+	if len(badges) == 0 {
+		return nil
+	}
+
+	tx := h.DB.Begin()
+	var badgeNotifications int64
+	for i := range badges {
+		inserted, err := h.GamificationRepo.AwardBadgeTX(ctx, tx, &badges[i])
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to award badge %s: %w", badges[i].BadgeCode, err)
+		}
+		if !inserted {
+			continue
+		}
+
+		if h.resolveNotificationChannels(ctx, event.UserID, model.UserNotificationCategory, "", nil).InApp {
+			entityType := model.EntityTypeUser
+			notification := &model.Notification{
+				UserID:      event.UserID,
+				EntityID:    mainutils.StrPtrOrNil(strconv.FormatUint(uint64(event.UserID), 10)),
+				EntityType:  &entityType,
+				Category:    model.UserNotificationCategory,
+				Type:        model.NotificationTypeInfo,
+				IsRead:      mainutils.BoolPtrOrNil(false),
+			}
+			NotificationUtil.SetNotificationMessage(notification, "notification.badge_awarded", map[string]string{
+				"badgeName":        gamificationUtil.GetBadgeNameKey(badges[i].BadgeCode),
+				"badgeDescription": gamificationUtil.GetBadgeDescriptionKey(badges[i].BadgeCode),
+			})
+			if err := h.NotificationRepo.CreateTX(ctx, tx, notification); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to create badge notification: %w", err)
+			}
+			badgeNotifications++
+		}
+
+		logger.Info("Badge awarded",
+			zap.Uint("user_id", event.UserID),
+			zap.String("badge_code", string(badges[i].BadgeCode)),
+			zap.String("region", badges[i].Region),
+		)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit badges: %w", err)
+	}
+	if badgeNotifications > 0 {
+		h.incrementUnreadCount(ctx, event.UserID, badgeNotifications)
+	}
+	return nil
+}
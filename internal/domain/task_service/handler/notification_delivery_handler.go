@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	NotificationChannel "pingspot/internal/domain/notification_service/channel"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/domain/task_service/payload"
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	"pingspot/pkg/backoff"
+	"pingspot/pkg/logger"
+	env "pingspot/pkg/utils/env_util"
+	mainutils "pingspot/pkg/utils/main_util"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// enqueueNotificationDeliveries fans a stored notification out to the external
+// channels the user allows. Channels without a configured provider are left
+// out rather than recorded as failures.
+func (h *TaskHandler) enqueueNotificationDeliveries(ctx context.Context, notification *model.Notification, channels NotificationUtil.DeliveryChannels) {
+	enabled := map[model.NotificationChannel]bool{
+		model.NotificationChannelEmail: channels.Email,
+		model.NotificationChannelPush:  channels.Push,
+		model.NotificationChannelSMS:   channels.SMS,
+	}
+	for _, channel := range []model.NotificationChannel{model.NotificationChannelEmail, model.NotificationChannelPush, model.NotificationChannelSMS} {
+		if !enabled[channel] {
+			continue
+		}
+		deliveryChannel, ok := h.NotificationChannels.Get(channel)
+		if !ok {
+			continue
+		}
+
+		delivery := model.NotificationDelivery{
+			NotificationID: notification.ID,
+			UserID:         notification.UserID,
+			Channel:        channel,
+			Status:         model.NotificationDeliveryPending,
+		}
+		created, err := h.NotificationDeliveryRepo.Create(ctx, &delivery)
+		if err != nil {
+			logger.Error("Failed to create notification delivery", zap.Uint("notification_id", notification.ID), zap.String("channel", string(channel)), zap.Error(err))
+			continue
+		}
+		if !created {
+			continue
+		}
+		if err := h.TaskService.DeliverNotificationTask(delivery.ID, deliveryChannel.MaxAttempts()); err != nil {
+			logger.Error("Failed to enqueue notification delivery", zap.Uint("delivery_id", delivery.ID), zap.Error(err))
+		}
+	}
+}
+
+func (h *TaskHandler) DeliverNotificationHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.DeliverNotificationPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+
+	delivery, err := h.NotificationDeliveryRepo.GetByID(ctx, payload.DeliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get notification delivery: %w", err)
+	}
+	if delivery.Status != model.NotificationDeliveryPending && delivery.Status != model.NotificationDeliveryRetrying {
+		return nil
+	}
+
+	channel, ok := h.NotificationChannels.Get(delivery.Channel)
+	if !ok {
+		delivery.Status = model.NotificationDeliverySkipped
+		delivery.NextRetryAt = nil
+		delivery.LastError = mainutils.StrPtrOrNil("channel is not configured")
+		_, err := h.NotificationDeliveryRepo.Update(ctx, delivery)
+		return err
+	}
+
+	setting, err := h.NotificationPreferenceRepo.GetSetting(ctx, delivery.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification setting: %w", err)
+	}
+
+	delivery.Attempts++
+	providerMessageID, sendErr := channel.Send(ctx, NotificationChannel.Message{
+		Notification: NotificationUtil.LocalizeNotification(i18n.Preferred(delivery.User.Language, i18n.DefaultLanguage), delivery.Notification),
+		Recipient:    delivery.User,
+		Setting:      setting,
+		Link:         NotificationUtil.GetNotificationLink(env.ClientURL(), delivery.Notification),
+	})
+
+	now := time.Now()
+	var retryErr error
+	switch {
+	case sendErr == nil:
+		delivery.Status = model.NotificationDeliverySent
+		delivery.ProviderMessageID = mainutils.StrPtrOrNil(providerMessageID)
+		delivery.DeliveredAt = mainutils.Int64PtrOrNil(now.Unix())
+		delivery.NextRetryAt = nil
+		delivery.LastError = nil
+	case errors.Is(sendErr, NotificationChannel.ErrNoRecipient):
+		delivery.Status = model.NotificationDeliverySkipped
+		delivery.NextRetryAt = nil
+		delivery.LastError = mainutils.StrPtrOrNil(sendErr.Error())
+	default:
+		delivery.LastError = mainutils.StrPtrOrNil(sendErr.Error())
+		if delivery.Attempts >= channel.MaxAttempts() {
+			delivery.Status = model.NotificationDeliveryFailed
+			delivery.NextRetryAt = nil
+		} else {
+			retryDelay := backoff.Exponential(delivery.Attempts, NotificationUtil.BaseDeliveryRetryDelay, NotificationUtil.MaxDeliveryRetryDelay)
+			delivery.Status = model.NotificationDeliveryRetrying
+			delivery.NextRetryAt = mainutils.Int64PtrOrNil(now.Add(retryDelay).Unix())
+			retryErr = fmt.Errorf("notification delivery %d attempt %d failed: %w", delivery.ID, delivery.Attempts, sendErr)
+		}
+	}
+
+	if _, err := h.NotificationDeliveryRepo.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+
+	logger.Info("Notification delivery attempted",
+		zap.Uint("delivery_id", delivery.ID),
+		zap.String("channel", string(delivery.Channel)),
+		zap.String("status", string(delivery.Status)),
+		zap.Int("attempts", delivery.Attempts),
+	)
+	return retryErr
+}
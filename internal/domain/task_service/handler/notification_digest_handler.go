@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	NotificationDTO "pingspot/internal/domain/notification_service/dto"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/domain/task_service/payload"
+	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	"pingspot/pkg/logger"
+	"pingspot/pkg/mailer"
+	env "pingspot/pkg/utils/env_util"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+func (h *TaskHandler) SendNotificationDigestHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.SendNotificationDigestPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+
+	setting, err := h.NotificationPreferenceRepo.GetSetting(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification setting: %w", err)
+	}
+	if setting == nil || setting.DigestFrequency == model.NotificationDigestNone {
+		return nil
+	}
+	user, err := h.UserRepo.GetByID(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get digest recipient: %w", err)
+	}
+	if user == nil || user.Email == "" {
+		return nil
+	}
+
+	now := time.Now()
+	language := i18n.Preferred(user.Language, i18n.DefaultLanguage)
+	digest, err := h.buildNotificationDigest(ctx, payload.UserID, *setting, language, now)
+	if err != nil {
+		return err
+	}
+	if !NotificationUtil.IsDigestEmpty(digest) {
+		unsubscribeLink, err := NotificationUtil.BuildDigestUnsubscribeLink(env.ServerURL(), env.DigestUnsubscribeSecret(), user.ID, now)
+		if err != nil {
+			return fmt.Errorf("failed to build digest unsubscribe link: %w", err)
+		}
+		idempotencyKey := mailer.NewIdempotencyKey("notification_digest", user.ID, NotificationUtil.GetDigestSince(*setting, now))
+		message, err := NotificationUtil.BuildDigestEmail(user.Email, user.Username, digest, unsubscribeLink, idempotencyKey, language)
+		if err != nil {
+			return fmt.Errorf("failed to build notification digest: %w", err)
+		}
+		if err := h.TaskService.SendEmailTask(message); err != nil {
+			return fmt.Errorf("failed to queue notification digest: %w", err)
+		}
+		logger.Info("Notification digest queued", zap.Uint("user_id", user.ID), zap.String("frequency", string(setting.DigestFrequency)))
+	}
+
+	if err := h.NotificationPreferenceRepo.UpdateDigestSentAt(ctx, payload.UserID, now.Unix()); err != nil {
+		logger.Error("Failed to record digest sent time", zap.Uint("user_id", payload.UserID), zap.Error(err))
+	}
+	return nil
+}
+
+func (h *TaskHandler) buildNotificationDigest(ctx context.Context, userID uint, setting model.NotificationSetting, language i18n.Language, now time.Time) (NotificationDTO.DigestEmail, error) {
+	since := NotificationUtil.GetDigestSince(setting, now)
+	digest := NotificationDTO.DigestEmail{
+		PeriodLabel: NotificationUtil.GetDigestPeriodLabel(language, setting.DigestFrequency),
+		AppLink:     env.ClientURL(),
+	}
+
+	unreadCount, err := h.NotificationRepo.CountUnreadByUserID(ctx, userID)
+	if err != nil {
+		return digest, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	digest.UnreadCount = unreadCount
+	if unreadCount > 0 {
+		isRead := false
+		notifications, err := h.NotificationRepo.GetPaginatedByUserID(ctx, userID, NotificationDTO.NotificationFilter{IsRead: &isRead}, 0, NotificationUtil.DigestItemLimit)
+		if err != nil {
+			return digest, fmt.Errorf("failed to get unread notifications: %w", err)
+		}
+		for _, notification := range notifications {
+			notification = NotificationUtil.LocalizeNotification(language, notification)
+			digest.Notifications = append(digest.Notifications, NotificationDTO.DigestItem{
+				Title:       notification.Title,
+				Description: notification.Description,
+			})
+		}
+	}
+
+	progresses, err := h.ReportProgressRepo.GetWatchedSince(ctx, userID, since, NotificationUtil.DigestItemLimit)
+	if err != nil {
+		return digest, fmt.Errorf("failed to get watched report progress: %w", err)
+	}
+	for _, progress := range progresses {
+		digest.WatchedProgress = append(digest.WatchedProgress, NotificationDTO.DigestItem{
+			Title:       progress.Report.ReportTitle,
+			Description: fmt.Sprintf("Status: %s. %s", progress.Status, progress.Notes),
+			Link:        fmt.Sprintf("%s/main/report/%d", env.ClientURL(), progress.ReportID),
+		})
+	}
+
+	nearbyReports, err := h.getDigestNearbyReports(ctx, userID, since)
+	if err != nil {
+		return digest, err
+	}
+	for _, report := range nearbyReports {
+		digest.NearbyReports = append(digest.NearbyReports, NotificationDTO.DigestItem{
+			Title:       report.ReportTitle,
+			Description: string(report.ReportType),
+			Link:        fmt.Sprintf("%s/main/report/%d", env.ClientURL(), report.ID),
+		})
+	}
+	return digest, nil
+}
+
+// getDigestNearbyReports uses the user's area subscriptions as their notion of
+// "near", skipping the user's own reports.
+func (h *TaskHandler) getDigestNearbyReports(ctx context.Context, userID uint, since int64) ([]model.Report, error) {
+	subscriptions, err := h.AreaSubscriptionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get area subscriptions: %w", err)
+	}
+
+	seen := make(map[uint]struct{})
+	reportIDs := make([]uint, 0, NotificationUtil.DigestItemLimit)
+	for _, subscription := range subscriptions {
+		candidates, err := h.ReportRepo.GetFeedCandidatesNearby(ctx, subscription.Latitude, subscription.Longitude, subscription.RadiusMeters, since, NotificationUtil.DigestNearbyPerArea)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nearby reports: %w", err)
+		}
+		for _, candidate := range candidates {
+			if _, ok := seen[candidate.ReportID]; ok {
+				continue
+			}
+			seen[candidate.ReportID] = struct{}{}
+			reportIDs = append(reportIDs, candidate.ReportID)
+		}
+	}
+	if len(reportIDs) > NotificationUtil.DigestItemLimit {
+		reportIDs = reportIDs[:NotificationUtil.DigestItemLimit]
+	}
+
+	reports, err := h.ReportRepo.GetByIDs(ctx, reportIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby report details: %w", err)
+	}
+	nearby := make([]model.Report, 0, len(reports))
+	for _, report := range reports {
+		if report.UserID == userID {
+			continue
+		}
+		nearby = append(nearby, report)
+	}
+	return nearby, nil
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/domain/task_service/payload"
+	"pingspot/internal/model"
+	mainutils "pingspot/pkg/utils/main_util"
+	"time"
+)
+
+// createGroupedNotification collapses notifications of the same event about the
+// same entity within one grouping window into a single row whose actor count and
+// description are updated in place. A grouped item that was already read is
+// surfaced as unread again when a new actor joins it. Only the first actor of a
+// group triggers external deliveries, so a burst of votes sends one email.
+func (h *TaskHandler) createGroupedNotification(ctx context.Context, payload payload.CreateNotificationPayload, channels NotificationUtil.DeliveryChannels) error {
+	entityID := ""
+	if payload.EntityID != nil {
+		entityID = *payload.EntityID
+	}
+	groupKey := NotificationUtil.GetGroupKey(payload.Event, payload.EntityType, entityID, time.Now())
+	messageKey, messageParams := NotificationUtil.FormatGroupedNotification(payload.Event, payload.ActorName, 1)
+
+	tx := h.DB.Begin()
+	notification := &model.Notification{
+		UserID:      payload.UserID,
+		EntityID:    payload.EntityID,
+		EntityType:  &payload.EntityType,
+		Category:    payload.Category,
+		Type:        payload.Type,
+		IsRead:      mainutils.BoolPtrOrNil(false),
+		GroupKey:    &groupKey,
+		ActorCount:  1,
+	}
+	NotificationUtil.SetNotificationMessage(notification, messageKey, messageParams)
+	created, err := h.NotificationRepo.CreateGroupedTX(ctx, tx, notification)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create grouped notification: %w", err)
+	}
+	if !created {
+		notification, err = h.NotificationRepo.GetByGroupKeyForUpdateTX(ctx, tx, payload.UserID, groupKey)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to get grouped notification: %w", err)
+		}
+	}
+
+	added, err := h.NotificationRepo.AddActorTX(ctx, tx, &model.NotificationActor{
+		NotificationID: notification.ID,
+		ActorID:        payload.ActorID,
+		ActorName:      payload.ActorName,
+	})
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to add notification actor: %w", err)
+	}
+	if created {
+		if err := tx.Commit().Error; err != nil {
+			return fmt.Errorf("failed to commit grouped notification: %w", err)
+		}
+		h.publishNotificationWithUnreadCount(ctx, model.RealtimeNotificationCreated, notification, 1)
+		h.enqueueNotificationDeliveries(ctx, notification, channels)
+		return nil
+	}
+	if !added {
+		return tx.Commit().Error
+	}
+
+	wasRead := notification.IsRead != nil && *notification.IsRead
+	notification.ActorCount++
+	messageKey, messageParams = NotificationUtil.FormatGroupedNotification(payload.Event, payload.ActorName, notification.ActorCount)
+	NotificationUtil.SetNotificationMessage(notification, messageKey, messageParams)
+	notification.EntityID = payload.EntityID
+	isRead := false
+	notification.IsRead = &isRead
+	notification.ReadAt = nil
+	if err := h.NotificationRepo.UpdateTX(ctx, tx, notification); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update grouped notification: %w", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit grouped notification: %w", err)
+	}
+
+	var delta int64
+	if wasRead {
+		delta = 1
+	}
+	h.publishNotificationWithUnreadCount(ctx, model.RealtimeNotificationUpdated, notification, delta)
+	return nil
+}
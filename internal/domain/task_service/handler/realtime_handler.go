@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pingspot/internal/domain/task_service/payload"
+
+	"github.com/hibiken/asynq"
+)
+
+func (h *TaskHandler) PublishRealtimeEventHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.PublishRealtimeEventPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+	if err := h.RealtimePublisher.Publish(ctx, payload.Scope, payload.ScopeID, payload.EventType, payload.Data); err != nil {
+		return fmt.Errorf("failed to publish realtime event %s: %w", payload.EventType, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"pingspot/internal/domain/report_service/util"
+	"pingspot/internal/model"
+	"pingspot/pkg/logger"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+const hotScoreWindow = 14 * 24 * time.Hour
+
+func (h *TaskHandler) RecalculateHotScoresHandler(ctx context.Context, t *asynq.Task) error {
+	now := time.Now()
+	windowStart := now.Add(-hotScoreWindow).Unix()
+
+	if err := h.ReportRepo.ResetHotScoreBefore(ctx, windowStart); err != nil {
+		return fmt.Errorf("failed to reset stale hot scores: %w", err)
+	}
+
+	reportIDs, err := h.ReportRepo.GetIDsCreatedSince(ctx, windowStart)
+	if err != nil {
+		return fmt.Errorf("failed to get recent reports: %w", err)
+	}
+
+	for _, reportID := range reportIDs {
+		report, err := h.ReportRepo.GetByID(ctx, reportID)
+		if err != nil {
+			logger.Error("Failed to get report for hot score", zap.Uint("report_id", reportID), zap.Error(err))
+			continue
+		}
+		if err := h.updateReportHotScore(ctx, report, now.Unix()); err != nil {
+			logger.Error("Failed to recalculate report hot score", zap.Uint("report_id", reportID), zap.Error(err))
+		}
+	}
+
+	logger.Info("Report hot scores recalculated", zap.Int("total_reports", len(reportIDs)))
+	return nil
+}
+
+func (h *TaskHandler) updateReportHotScore(ctx context.Context, report *model.Report, now int64) error {
+	if now-report.CreatedAt > int64(hotScoreWindow.Seconds()) {
+		return nil
+	}
+
+	var totalReactions, totalVotes int64
+	if report.ReportReactions != nil {
+		totalReactions = int64(len(*report.ReportReactions))
+	}
+	if report.ReportVotes != nil {
+		totalVotes = int64(len(*report.ReportVotes))
+	}
+
+	totalComments, err := h.ReportCommentRepo.GetCountsByReportID(ctx, report.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	score := util.CalculateHotScore(util.HotInput{
+		Reactions: totalReactions,
+		Votes:     totalVotes,
+		Comments:  totalComments,
+		Views:     report.ViewCount,
+		CreatedAt: report.CreatedAt,
+		Now:       now,
+	})
+
+	if err := h.ReportRepo.UpdateHotScore(ctx, report.ID, score); err != nil {
+		return fmt.Errorf("failed to update hot score: %w", err)
+	}
+	return nil
+}
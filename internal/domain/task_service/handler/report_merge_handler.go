@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pingspot/internal/domain/task_service/payload"
+	"pingspot/pkg/logger"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+// MoveMergedReportCommentsHandler re-points a merged duplicate's comments at
+// the canonical report. Moving is a plain update by report ID, so a retry after
+// a partial failure only moves what is left.
+func (h *TaskHandler) MoveMergedReportCommentsHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.MoveMergedReportCommentsPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+
+	moved, err := h.ReportCommentRepo.MoveToReport(ctx, payload.DuplicateReportID, payload.CanonicalReportID)
+	if err != nil {
+		return fmt.Errorf("failed to move comments of report %d to report %d: %w", payload.DuplicateReportID, payload.CanonicalReportID, err)
+	}
+
+	logger.Info("Merged report comments moved",
+		zap.Uint("duplicate_report_id", payload.DuplicateReportID),
+		zap.Uint("canonical_report_id", payload.CanonicalReportID),
+		zap.Int64("moved_comments", moved),
+	)
+	return nil
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pingspot/internal/domain/report_service/util"
+	"pingspot/internal/domain/task_service/payload"
+	"pingspot/internal/model"
+	"pingspot/pkg/logger"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+const duplicateRadiusMeters = 500
+
+func (h *TaskHandler) RecalculateReportPriorityHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.RecalculatePriorityPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+	return h.recalculateReportPriority(ctx, payload.ReportID)
+}
+
+func (h *TaskHandler) RecalculateAllReportPrioritiesHandler(ctx context.Context, t *asynq.Task) error {
+	reportIDs, err := h.ReportRepo.GetIDsByReportStatus(ctx,
+		string(model.WAITING),
+		string(model.ON_PROGRESS),
+		string(model.WAITING_CONFIRMATION),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get active reports: %w", err)
+	}
+
+	for _, reportID := range reportIDs {
+		if err := h.recalculateReportPriority(ctx, reportID); err != nil {
+			logger.Error("Failed to recalculate report priority", zap.Uint("report_id", reportID), zap.Error(err))
+		}
+	}
+
+	logger.Info("Report priorities recalculated", zap.Int("total_reports", len(reportIDs)))
+	return nil
+}
+
+func (h *TaskHandler) recalculateReportPriority(ctx context.Context, reportID uint) error {
+	report, err := h.ReportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return fmt.Errorf("report not found: %w", err)
+	}
+
+	now := time.Now()
+	since := now.Add(-24 * time.Hour).Unix()
+
+	var totalVotes int64
+	if report.ReportVotes != nil {
+		totalVotes = int64(len(*report.ReportVotes))
+	}
+	recentVotes, err := h.ReportVoteRepo.GetCountSince(ctx, reportID, since)
+	if err != nil {
+		return fmt.Errorf("failed to count recent votes: %w", err)
+	}
+
+	var totalReactions int64
+	if report.ReportReactions != nil {
+		totalReactions = int64(len(*report.ReportReactions))
+	}
+	recentReactions, err := h.ReportReactionRepo.GetCountSince(ctx, reportID, since)
+	if err != nil {
+		return fmt.Errorf("failed to count recent reactions: %w", err)
+	}
+
+	var nearbyDuplicates int64
+	if report.ReportLocation != nil {
+		nearbyDuplicates, err = h.ReportRepo.GetNearbyDuplicateCount(ctx, reportID, string(report.ReportType), report.ReportLocation.Latitude, report.ReportLocation.Longitude, duplicateRadiusMeters)
+		if err != nil {
+			return fmt.Errorf("failed to count nearby duplicates: %w", err)
+		}
+	}
+
+	score := util.CalculatePriorityScore(util.PriorityInput{
+		ReportType:            report.ReportType,
+		ReportStatus:          report.ReportStatus,
+		TotalVotes:            totalVotes,
+		TotalReactions:        totalReactions,
+		RecentVotes:           recentVotes,
+		RecentReactions:       recentReactions,
+		CreatedAt:             report.CreatedAt,
+		LastUpdatedProgressAt: report.LastUpdatedProgressAt,
+		NearbyDuplicates:      nearbyDuplicates,
+		Now:                   now.Unix(),
+	})
+
+	if err := h.ReportRepo.UpdatePriorityScore(ctx, reportID, score, now.Unix()); err != nil {
+		return fmt.Errorf("failed to update priority score: %w", err)
+	}
+	return h.updateReportHotScore(ctx, report, now.Unix())
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/model"
+	"pingspot/pkg/logger"
+	env "pingspot/pkg/utils/env_util"
+	mainutils "pingspot/pkg/utils/main_util"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const metersPerDegree = 111320.0
+
+var errIncidentAlertExists = errors.New("incident alert already exists")
+
+type surgeConfig struct {
+	Window            time.Duration
+	RadiusMeters      int
+	MinReports        int64
+	BaselineDays      int
+	Multiplier        float64
+	NotifySubscribers bool
+}
+
+func getSurgeConfig() surgeConfig {
+	cfg := surgeConfig{
+		Window:            60 * time.Minute,
+		RadiusMeters:      2000,
+		MinReports:        15,
+		BaselineDays:      28,
+		Multiplier:        3,
+		NotifySubscribers: env.SurgeNotifySubscribers(),
+	}
+	if v, err := strconv.Atoi(env.SurgeWindowMinutes()); err == nil && v > 0 {
+		cfg.Window = time.Duration(v) * time.Minute
+	}
+	if v, err := strconv.Atoi(env.SurgeRadiusMeters()); err == nil && v > 0 {
+		cfg.RadiusMeters = v
+	}
+	if v, err := strconv.ParseInt(env.SurgeMinReports(), 10, 64); err == nil && v > 0 {
+		cfg.MinReports = v
+	}
+	if v, err := strconv.Atoi(env.SurgeBaselineDays()); err == nil && v > 0 {
+		cfg.BaselineDays = v
+	}
+	if v, err := strconv.ParseFloat(env.SurgeMultiplier(), 64); err == nil && v > 0 {
+		cfg.Multiplier = v
+	}
+	return cfg
+}
+
+func isReportSurge(current int64, baseline float64, minReports int64, multiplier float64) bool {
+	if current < minReports {
+		return false
+	}
+	return float64(current) >= baseline*multiplier
+}
+
+func (h *TaskHandler) DetectReportSurgeHandler(ctx context.Context, t *asynq.Task) error {
+	cfg := getSurgeConfig()
+	now := time.Now()
+	windowStart := now.Add(-cfg.Window).Unix()
+	windowEnd := now.Unix()
+	baselineStart := now.AddDate(0, 0, -cfg.BaselineDays).Unix()
+	baselineWindows := float64(windowStart-baselineStart) / cfg.Window.Seconds()
+
+	clusters, err := h.ReportRepo.GetClustersSince(ctx, windowStart, float64(cfg.RadiusMeters)/metersPerDegree, cfg.MinReports)
+	if err != nil {
+		return fmt.Errorf("failed to get report clusters: %w", err)
+	}
+
+	for _, cluster := range clusters {
+		current, err := h.ReportRepo.GetCountInAreaBetween(ctx, cluster.ReportType, cluster.Latitude, cluster.Longitude, cfg.RadiusMeters, windowStart, windowEnd)
+		if err != nil {
+			logger.Error("Failed to count reports in surge window", zap.String("report_type", cluster.ReportType), zap.Error(err))
+			continue
+		}
+
+		historical, err := h.ReportRepo.GetCountInAreaBetween(ctx, cluster.ReportType, cluster.Latitude, cluster.Longitude, cfg.RadiusMeters, baselineStart, windowStart)
+		if err != nil {
+			logger.Error("Failed to count reports in baseline window", zap.String("report_type", cluster.ReportType), zap.Error(err))
+			continue
+		}
+
+		baseline := float64(historical) / baselineWindows
+		if !isReportSurge(current, baseline, cfg.MinReports, cfg.Multiplier) {
+			continue
+		}
+
+		reportType := model.ReportType(cluster.ReportType)
+		_, err = h.IncidentAlertRepo.GetActiveNearby(ctx, reportType, cluster.Latitude, cluster.Longitude, cfg.RadiusMeters, windowStart)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Failed to check existing incident alert", zap.String("report_type", cluster.ReportType), zap.Error(err))
+			continue
+		}
+
+		alert := &model.IncidentAlert{
+			ReportType:    reportType,
+			Latitude:      cluster.Latitude,
+			Longitude:     cluster.Longitude,
+			RadiusMeters:  cfg.RadiusMeters,
+			ReportCount:   current,
+			BaselineCount: baseline,
+			WindowStart:   windowStart,
+			WindowEnd:     windowEnd,
+			Status:        model.IncidentAlertActive,
+		}
+		if err := h.createIncidentAlert(ctx, alert, cfg.NotifySubscribers); err != nil {
+			if errors.Is(err, errIncidentAlertExists) {
+				continue
+			}
+			logger.Error("Failed to create incident alert", zap.String("report_type", cluster.ReportType), zap.Error(err))
+			continue
+		}
+
+		logger.Info("Report surge detected",
+			zap.Uint("alert_id", alert.ID),
+			zap.String("report_type", cluster.ReportType),
+			zap.Int64("report_count", current),
+			zap.Float64("baseline", baseline),
+		)
+	}
+
+	return nil
+}
+
+func (h *TaskHandler) createIncidentAlert(ctx context.Context, alert *model.IncidentAlert, notifySubscribers bool) error {
+	staffUsers, err := h.UserRepo.GetByRoles(ctx, model.UserRoleModerator, model.UserRoleAgency)
+	if err != nil {
+		return fmt.Errorf("failed to get moderators and agencies: %w", err)
+	}
+
+	recipients := make(map[uint]struct{}, len(staffUsers))
+	for _, user := range staffUsers {
+		recipients[user.ID] = struct{}{}
+	}
+
+	if notifySubscribers {
+		subscriberIDs, err := h.AreaSubscriptionRepo.GetSubscriberIDsNear(ctx, alert.Latitude, alert.Longitude)
+		if err != nil {
+			return fmt.Errorf("failed to get area subscribers: %w", err)
+		}
+		for _, userID := range subscriberIDs {
+			recipients[userID] = struct{}{}
+		}
+	}
+
+	tx := h.DB.Begin()
+	if err := h.IncidentAlertRepo.LockReportTypeTX(ctx, tx, alert.ReportType); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to lock incident alerts: %w", err)
+	}
+	_, err = h.IncidentAlertRepo.GetActiveNearbyTX(ctx, tx, alert.ReportType, alert.Latitude, alert.Longitude, alert.RadiusMeters, alert.WindowStart)
+	if err == nil {
+		tx.Rollback()
+		return errIncidentAlertExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return fmt.Errorf("failed to check existing incident alert: %w", err)
+	}
+
+	if err := h.IncidentAlertRepo.CreateTX(ctx, tx, alert); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	entityID := strconv.FormatUint(uint64(alert.ID), 10)
+	entityType := model.EntityTypeIncident
+	messageParams := map[string]string{
+		"count":      strconv.FormatInt(int64(alert.ReportCount), 10),
+		"reportType": string(alert.ReportType),
+		"radius":     strconv.FormatInt(int64(alert.RadiusMeters), 10),
+		"minutes":    strconv.FormatInt((alert.WindowEnd-alert.WindowStart)/60, 10),
+	}
+
+	notifiedUserIDs := make([]uint, 0, len(recipients))
+	for userID := range recipients {
+		if !h.resolveNotificationChannels(ctx, userID, model.IncidentNotificationCategory, "", nil).InApp {
+			continue
+		}
+		notification := &model.Notification{
+			UserID:      userID,
+			EntityID:    &entityID,
+			EntityType:  &entityType,
+			Category:    model.IncidentNotificationCategory,
+			Type:        model.NotificationTypeWarning,
+			IsRead:      mainutils.BoolPtrOrNil(false),
+		}
+		NotificationUtil.SetNotificationMessage(notification, "notification.incident_surge", messageParams)
+		if err := h.NotificationRepo.CreateTX(ctx, tx, notification); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to create incident notification: %w", err)
+		}
+		notifiedUserIDs = append(notifiedUserIDs, userID)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	for _, userID := range notifiedUserIDs {
+		h.incrementUnreadCount(ctx, userID, 1)
+	}
+	return nil
+}
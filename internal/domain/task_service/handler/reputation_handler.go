@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	reputationUtil "pingspot/internal/domain/reputation_service/util"
+	"pingspot/internal/domain/task_service/payload"
+	"pingspot/internal/model"
+	"pingspot/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func (h *TaskHandler) EvaluateReportReputationHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.EvaluateReportReputationPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+	return h.evaluateReportReputation(ctx, payload.ReportID)
+}
+
+func (h *TaskHandler) AwardReputationHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.AwardReputationPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+
+	points := reputationUtil.GetEventPoints(payload.EventType)
+	if points == 0 {
+		return fmt.Errorf("unsupported reputation event type: %s", payload.EventType)
+	}
+
+	awarded, err := h.awardReputationEvents(ctx, []model.ReputationEvent{{
+		UserID:     payload.UserID,
+		EventType:  payload.EventType,
+		Points:     points,
+		SourceType: payload.SourceType,
+		SourceID:   payload.SourceID,
+	}})
+	if err != nil {
+		return err
+	}
+	h.processAwardedReputationEvents(ctx, awarded, 0)
+	return nil
+}
+
+func (h *TaskHandler) evaluateReportReputation(ctx context.Context, reportID uint) error {
+	report, err := h.ReportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return fmt.Errorf("report not found: %w", err)
+	}
+
+	reportSourceID := strconv.FormatUint(uint64(report.ID), 10)
+	var events []model.ReputationEvent
+
+	if report.MergedIntoID != nil {
+		_, err := h.ReputationRepo.GetBySource(ctx, report.UserID, model.ReputationReportResolved, model.ReputationSourceReport, reportSourceID)
+		if err == nil {
+			events = append(events, model.ReputationEvent{
+				UserID:     report.UserID,
+				EventType:  model.ReputationReportMerged,
+				Points:     reputationUtil.GetEventPoints(model.ReputationReportMerged),
+				SourceType: model.ReputationSourceReport,
+				SourceID:   reportSourceID,
+			})
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get report reputation event: %w", err)
+		}
+	} else if report.ReportStatus == model.RESOLVED {
+		events = append(events, model.ReputationEvent{
+			UserID:     report.UserID,
+			EventType:  model.ReputationReportResolved,
+			Points:     reputationUtil.GetEventPoints(model.ReputationReportResolved),
+			SourceType: model.ReputationSourceReport,
+			SourceID:   reportSourceID,
+		})
+	}
+
+	if report.ReportVotes != nil {
+		for _, vote := range *report.ReportVotes {
+			eventType, evaluated := reputationUtil.GetVoteOutcomeEvent(report.ReportStatus, vote.VoteType)
+			if !evaluated {
+				break
+			}
+			events = append(events, model.ReputationEvent{
+				UserID:     vote.UserID,
+				EventType:  eventType,
+				Points:     reputationUtil.GetEventPoints(eventType),
+				SourceType: model.ReputationSourceReportVote,
+				SourceID:   strconv.FormatUint(uint64(vote.ID), 10),
+			})
+		}
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+	awarded, err := h.awardReputationEvents(ctx, events)
+	if err != nil {
+		return err
+	}
+	h.processAwardedReputationEvents(ctx, awarded, report.ID)
+
+	logger.Info("Report reputation evaluated",
+		zap.Uint("report_id", report.ID),
+		zap.String("report_status", string(report.ReportStatus)),
+		zap.Int("events", len(events)),
+	)
+	return nil
+}
+
+func (h *TaskHandler) awardReputationEvents(ctx context.Context, events []model.ReputationEvent) ([]model.ReputationEvent, error) {
+	var awarded []model.ReputationEvent
+	tx := h.DB.Begin()
+	for i := range events {
+		inserted, err := h.ReputationRepo.AwardTX(ctx, tx, &events[i])
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to award reputation to user %d: %w", events[i].UserID, err)
+		}
+		if inserted {
+			awarded = append(awarded, events[i])
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit reputation events: %w", err)
+	}
+	return awarded, nil
+}
+
+func (h *TaskHandler) processAwardedReputationEvents(ctx context.Context, events []model.ReputationEvent, reportID uint) {
+	for _, event := range events {
+		var eventType model.GamificationEventType
+		switch event.EventType {
+		case model.ReputationReportResolved:
+			eventType = model.GamificationReportResolved
+		case model.ReputationHelpfulComment:
+			eventType = model.GamificationCommentHelpful
+		default:
+			continue
+		}
+
+		if err := h.processGamificationEvent(ctx, payload.GamificationEventPayload{
+			EventID:    fmt.Sprintf("reputation:%d", event.ID),
+			UserID:     event.UserID,
+			EventType:  eventType,
+			ReportID:   reportID,
+			OccurredAt: time.Now().Unix(),
+		}); err != nil {
+			logger.Error("Failed to process gamification event",
+				zap.Uint("user_id", event.UserID),
+				zap.String("event_type", string(eventType)),
+				zap.Error(err),
+			)
+		}
+	}
+}
@@ -3,12 +3,28 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	ReportRepo "pingspot/internal/domain/report_service/repository"
 	NotificationRepo "pingspot/internal/domain/notification_service/repository"
+	NotificationChannel "pingspot/internal/domain/notification_service/channel"
+	NotificationUtil "pingspot/internal/domain/notification_service/util"
+	IncidentRepo "pingspot/internal/domain/incident_service/repository"
+	ReputationRepo "pingspot/internal/domain/reputation_service/repository"
+	GamificationRepo "pingspot/internal/domain/gamification_service/repository"
+	WebhookRepo "pingspot/internal/domain/webhook_service/repository"
+	WebhookDTO "pingspot/internal/domain/webhook_service/dto"
+	RealtimeDTO "pingspot/internal/domain/realtime_service/dto"
+	TaskService "pingspot/internal/domain/task_service/service"
+	TaskRepo "pingspot/internal/domain/task_service/repository"
+	RealtimeService "pingspot/internal/domain/realtime_service/service"
+	CacheRepo "pingspot/internal/repository"
+	UserRepo "pingspot/internal/domain/user_service/repository"
 	"pingspot/internal/domain/task_service/payload"
 	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
 	"pingspot/pkg/logger"
+	"pingspot/pkg/mailer"
 	mainutils "pingspot/pkg/utils/main_util"
 	"time"
 
@@ -20,14 +36,54 @@ import (
 type TaskHandler struct {
 	DB         *gorm.DB
 	ReportRepo ReportRepo.ReportRepository
+	ReportReactionRepo ReportRepo.ReportReactionRepository
+	ReportVoteRepo ReportRepo.ReportVoteRepository
+	ReportCommentRepo ReportRepo.ReportCommentRepository
+	ReportProgressRepo ReportRepo.ReportProgressRepository
 	NotificationRepo NotificationRepo.NotificationRepository
+	NotificationPreferenceRepo NotificationRepo.NotificationPreferenceRepository
+	NotificationDeliveryRepo NotificationRepo.NotificationDeliveryRepository
+	UserRepo UserRepo.UserRepository
+	IncidentAlertRepo IncidentRepo.IncidentAlertRepository
+	AreaSubscriptionRepo IncidentRepo.AreaSubscriptionRepository
+	ReputationRepo ReputationRepo.ReputationRepository
+	GamificationRepo GamificationRepo.GamificationRepository
+	CacheRepo CacheRepo.CacheRepository
+	WebhookRepo WebhookRepo.WebhookRepository
+	WebhookDeliveryRepo WebhookRepo.WebhookDeliveryRepository
+	TaskService TaskService.TaskService
+	RealtimePublisher RealtimeService.Publisher
+	InAppChannel *NotificationChannel.InAppChannel
+	NotificationChannels *NotificationChannel.Registry
+	Mailer mailer.Mailer
+	EmailIdempotencyRepo TaskRepo.EmailIdempotencyRepository
 }
 
-func NewTaskHandler(db *gorm.DB, reportRepo ReportRepo.ReportRepository, notificationRepo NotificationRepo.NotificationRepository) *TaskHandler {
+func NewTaskHandler(db *gorm.DB, reportRepo ReportRepo.ReportRepository, reportReactionRepo ReportRepo.ReportReactionRepository, reportVoteRepo ReportRepo.ReportVoteRepository, reportCommentRepo ReportRepo.ReportCommentRepository, reportProgressRepo ReportRepo.ReportProgressRepository, notificationRepo NotificationRepo.NotificationRepository, notificationPreferenceRepo NotificationRepo.NotificationPreferenceRepository, notificationDeliveryRepo NotificationRepo.NotificationDeliveryRepository, userRepo UserRepo.UserRepository, incidentAlertRepo IncidentRepo.IncidentAlertRepository, areaSubscriptionRepo IncidentRepo.AreaSubscriptionRepository, reputationRepo ReputationRepo.ReputationRepository, gamificationRepo GamificationRepo.GamificationRepository, cacheRepo CacheRepo.CacheRepository, webhookRepo WebhookRepo.WebhookRepository, webhookDeliveryRepo WebhookRepo.WebhookDeliveryRepository, taskService TaskService.TaskService, realtimePublisher RealtimeService.Publisher, inAppChannel *NotificationChannel.InAppChannel, notificationChannels *NotificationChannel.Registry, emailMailer mailer.Mailer, emailIdempotencyRepo TaskRepo.EmailIdempotencyRepository) *TaskHandler {
 	return &TaskHandler{
 		DB:         db,
 		ReportRepo: reportRepo,
+		ReportReactionRepo: reportReactionRepo,
+		ReportVoteRepo: reportVoteRepo,
+		ReportCommentRepo: reportCommentRepo,
+		ReportProgressRepo: reportProgressRepo,
 		NotificationRepo: notificationRepo,
+		NotificationPreferenceRepo: notificationPreferenceRepo,
+		NotificationDeliveryRepo: notificationDeliveryRepo,
+		UserRepo: userRepo,
+		IncidentAlertRepo: incidentAlertRepo,
+		AreaSubscriptionRepo: areaSubscriptionRepo,
+		ReputationRepo: reputationRepo,
+		GamificationRepo: gamificationRepo,
+		CacheRepo: cacheRepo,
+		WebhookRepo: webhookRepo,
+		WebhookDeliveryRepo: webhookDeliveryRepo,
+		TaskService: taskService,
+		RealtimePublisher: realtimePublisher,
+		InAppChannel: inAppChannel,
+		NotificationChannels: notificationChannels,
+		Mailer: emailMailer,
+		EmailIdempotencyRepo: emailIdempotencyRepo,
 	}
 }
 
@@ -59,8 +115,31 @@ func (h *TaskHandler) AutoResolveReportHandler(ctx context.Context, t *asynq.Tas
 				tx.Rollback()
 				return fmt.Errorf("failed to update report: %w", err)
 			}
+			taskOutbox := h.TaskService.WithTx(tx)
+			if err := errors.Join(
+				taskOutbox.DispatchWebhookEventTask(model.WebhookReportStatusChanged, WebhookDTO.ReportStatusChangedEventData{
+					ReportID:       report.ID,
+					PreviousStatus: "POTENTIALLY_RESOLVED",
+					ReportStatus:   string(report.ReportStatus),
+					UpdatedBy:      string(report.LastUpdatedBy),
+					UpdatedAt:      time.Now().Unix(),
+				}),
+				taskOutbox.PublishRealtimeEventTask(model.RealtimeScopeReport, report.ID, model.RealtimeReportStatusChanged, RealtimeDTO.ReportStatusEventData{
+					ReportID:       report.ID,
+					PreviousStatus: "POTENTIALLY_RESOLVED",
+					ReportStatus:   string(report.ReportStatus),
+					UpdatedBy:      string(report.LastUpdatedBy),
+					UpdatedAt:      time.Now().Unix(),
+				}),
+			); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to store outbox events: %w", err)
+			}
 			tx.Commit()
 			logger.Info("Auto resolve report handler success for", zap.Int("report_id", int(report.ID)))
+			if err := h.evaluateReportReputation(ctx, report.ID); err != nil {
+				logger.Error("Failed to evaluate report reputation", zap.Uint("report_id", report.ID), zap.Error(err))
+			}
 		} else {
 			tx.Rollback()
 		}
@@ -76,6 +155,17 @@ func (h *TaskHandler) CreateNotificationHandler(ctx context.Context, t *asynq.Ta
 	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
 		return err
 	}
+
+	channels := h.resolveNotificationChannels(ctx, payload.UserID, payload.Category, payload.Event, payload.ReportID)
+	if !channels.Any() {
+		logger.Info("Notification skipped by user preferences", zap.Uint("user_id", payload.UserID), zap.String("event", string(payload.Event)))
+		return nil
+	}
+	groupable := payload.ActorID != 0 && NotificationUtil.IsGroupableEvent(payload.Event)
+	if channels.InApp && groupable {
+		return h.createGroupedNotification(ctx, payload, channels)
+	}
+
 	Tx := h.DB.Begin()
 	notification := &model.Notification{
 		UserID:      payload.UserID,
@@ -86,6 +176,15 @@ func (h *TaskHandler) CreateNotificationHandler(ctx context.Context, t *asynq.Ta
 		Category:    payload.Category,
 		Type:        payload.Type,
 		IsRead:      mainutils.BoolPtrOrNil(false),
+		// With the inbox turned off the row only backs email, push and SMS
+		// deliveries, so it is kept out of the inbox and unread count.
+		IsHidden:    !channels.InApp,
+	}
+	if payload.MessageKey != "" {
+		NotificationUtil.SetNotificationMessage(notification, payload.MessageKey, payload.MessageParams)
+	} else if groupable {
+		messageKey, messageParams := NotificationUtil.FormatGroupedNotification(payload.Event, payload.ActorName, 1)
+		NotificationUtil.SetNotificationMessage(notification, messageKey, messageParams)
 	}
 
 	if err := h.NotificationRepo.CreateTX(ctx, Tx, notification); err != nil {
@@ -94,5 +193,53 @@ func (h *TaskHandler) CreateNotificationHandler(ctx context.Context, t *asynq.Ta
 	}
 	Tx.Commit()
 
+	if channels.InApp {
+		h.publishNotificationWithUnreadCount(ctx, model.RealtimeNotificationCreated, notification, 1)
+	}
+	h.enqueueNotificationDeliveries(ctx, notification, channels)
 	return nil
-}
\ No newline at end of file
+}
+
+func (h *TaskHandler) publishNotificationWithUnreadCount(ctx context.Context, eventType model.RealtimeEventType, notification *model.Notification, delta int64) {
+	localized := NotificationUtil.LocalizeNotification(h.getRecipientLanguage(ctx, notification.UserID), *notification)
+	h.InAppChannel.Publish(ctx, eventType, &localized, delta)
+}
+
+func (h *TaskHandler) getRecipientLanguage(ctx context.Context, userID uint) i18n.Language {
+	user, err := h.UserRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Preferred(user.Language, i18n.DefaultLanguage)
+}
+
+func (h *TaskHandler) incrementUnreadCount(ctx context.Context, userID uint, delta int64) (int64, bool) {
+	return h.InAppChannel.IncrementUnreadCount(ctx, userID, delta)
+}
+
+// resolveNotificationChannels fails open for the inbox only: when preferences
+// cannot be loaded the notification is still stored rather than silently lost,
+// but nothing interruptive is sent without knowing the user's quiet hours.
+func (h *TaskHandler) resolveNotificationChannels(ctx context.Context, userID uint, category model.NotificationCategory, event model.NotificationEvent, reportID *uint) NotificationUtil.DeliveryChannels {
+	allChannels := NotificationUtil.DeliveryChannels{InApp: true}
+
+	preferences, err := h.NotificationPreferenceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get notification preferences", zap.Uint("user_id", userID), zap.Error(err))
+		return allChannels
+	}
+	setting, err := h.NotificationPreferenceRepo.GetSetting(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get notification setting", zap.Uint("user_id", userID), zap.Error(err))
+		return allChannels
+	}
+	reportMuted := false
+	if reportID != nil {
+		reportMuted, err = h.NotificationPreferenceRepo.IsReportMuted(ctx, userID, *reportID)
+		if err != nil {
+			logger.Error("Failed to check report mute", zap.Uint("user_id", userID), zap.Uint("report_id", *reportID), zap.Error(err))
+			return allChannels
+		}
+	}
+	return NotificationUtil.ResolveDeliveryChannels(preferences, setting, reportMuted, category, event, time.Now())
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"pingspot/internal/domain/task_service/payload"
+	webhookUtil "pingspot/internal/domain/webhook_service/util"
+	"pingspot/internal/model"
+	"pingspot/pkg/backoff"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var webhookHTTPClient = webhookUtil.NewHTTPClient()
+
+func (h *TaskHandler) DispatchWebhookEventHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.DispatchWebhookEventPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+
+	webhooks, err := h.WebhookRepo.GetActiveByEvent(ctx, payload.EventType)
+	if err != nil {
+		return fmt.Errorf("failed to get webhooks for event %s: %w", payload.EventType, err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := webhookUtil.BuildEventBody(payload.EventID, payload.EventType, payload.OccurredAt, payload.Data)
+	if err != nil {
+		return fmt.Errorf("failed to build webhook event body: %w", err)
+	}
+
+	// A failed webhook does not stop the others, but the error is returned so
+	// asynq retries the dispatch. The retry finds the deliveries created
+	// earlier and enqueues again the ones that never left PENDING.
+	var dispatchErrs []error
+	for _, webhook := range webhooks {
+		delivery := &model.WebhookDelivery{
+			WebhookID: webhook.ID,
+			EventID:   payload.EventID,
+			EventType: payload.EventType,
+			Payload:   string(body),
+			Status:    model.WebhookDeliveryPending,
+		}
+		created, err := h.WebhookDeliveryRepo.CreateForEvent(ctx, delivery)
+		if err != nil {
+			dispatchErrs = append(dispatchErrs, fmt.Errorf("failed to create delivery for webhook %d: %w", webhook.ID, err))
+			continue
+		}
+		if !created {
+			delivery, err = h.WebhookDeliveryRepo.GetForEvent(ctx, webhook.ID, payload.EventID)
+			if err != nil {
+				dispatchErrs = append(dispatchErrs, fmt.Errorf("failed to get delivery for webhook %d: %w", webhook.ID, err))
+				continue
+			}
+			if delivery.Status != model.WebhookDeliveryPending {
+				continue
+			}
+		}
+		if err := h.TaskService.DeliverWebhookTask(delivery.ID, webhookUtil.MaxDeliveryAttempts); err != nil {
+			dispatchErrs = append(dispatchErrs, fmt.Errorf("failed to enqueue webhook delivery %d: %w", delivery.ID, err))
+		}
+	}
+	if err := errors.Join(dispatchErrs...); err != nil {
+		logger.Error("Failed to dispatch webhook event", zap.String("event_id", payload.EventID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (h *TaskHandler) DeliverWebhookHandler(ctx context.Context, t *asynq.Task) error {
+	var payload payload.DeliverWebhookPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+
+	delivery, err := h.WebhookDeliveryRepo.GetByID(ctx, payload.DeliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if delivery.Status == model.WebhookDeliverySuccess || delivery.Status == model.WebhookDeliveryFailed {
+		return nil
+	}
+
+	if !delivery.Webhook.IsActive {
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.NextRetryAt = nil
+		delivery.LastError = mainutils.StrPtrOrNil("webhook is inactive")
+		_, err := h.WebhookDeliveryRepo.Update(ctx, delivery)
+		return err
+	}
+
+	delivery.Attempts++
+	result, sendErr := webhookUtil.SendWebhook(ctx, webhookHTTPClient, delivery.Webhook, *delivery)
+	if result != nil {
+		delivery.ResponseStatus = &result.StatusCode
+		delivery.ResponseBody = mainutils.StrPtrOrNil(result.ResponseBody)
+	}
+
+	now := time.Now()
+	var retryErr error
+	switch {
+	case sendErr == nil && webhookUtil.IsSuccessStatus(result.StatusCode):
+		delivery.Status = model.WebhookDeliverySuccess
+		delivery.DeliveredAt = mainutils.Int64PtrOrNil(now.Unix())
+		delivery.NextRetryAt = nil
+		delivery.LastError = nil
+	default:
+		if sendErr != nil {
+			delivery.LastError = mainutils.StrPtrOrNil(sendErr.Error())
+		} else {
+			delivery.LastError = mainutils.StrPtrOrNil(fmt.Sprintf("unexpected response status %d", result.StatusCode))
+		}
+
+		if delivery.Attempts >= webhookUtil.MaxDeliveryAttempts {
+			delivery.Status = model.WebhookDeliveryFailed
+			delivery.NextRetryAt = nil
+		} else {
+			retryDelay := backoff.Exponential(delivery.Attempts, webhookUtil.BaseRetryDelay, webhookUtil.MaxRetryDelay)
+			delivery.Status = model.WebhookDeliveryRetrying
+			delivery.NextRetryAt = mainutils.Int64PtrOrNil(now.Add(retryDelay).Unix())
+			retryErr = fmt.Errorf("webhook delivery %d attempt %d failed: %s", delivery.ID, delivery.Attempts, *delivery.LastError)
+		}
+	}
+
+	if _, err := h.WebhookDeliveryRepo.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	logger.Info("Webhook delivery attempted",
+		zap.Uint("delivery_id", delivery.ID),
+		zap.Uint("webhook_id", delivery.WebhookID),
+		zap.String("status", string(delivery.Status)),
+		zap.Int("attempts", delivery.Attempts),
+	)
+	return retryErr
+}
@@ -1,17 +1,85 @@
 package payload
 
-import "pingspot/internal/model"
+import (
+	"encoding/json"
+	"pingspot/internal/model"
+	"pingspot/pkg/mailer"
+)
 
 type UpdateProgressPayload struct {
 	ReportID uint `json:"report_id"`
 }
 
+type RecalculatePriorityPayload struct {
+	ReportID uint `json:"report_id"`
+}
+
 type CreateNotificationPayload struct {
 	UserID      uint            `json:"user_id"`
 	Title       string          `json:"title"`
 	Description string          `json:"description"`
+	MessageKey  string          `json:"message_key,omitempty"`
+	MessageParams map[string]string `json:"message_params,omitempty"`
 	EntityID    *string         `json:"entity_id,omitempty"`
 	EntityType  model.EntityType         `json:"entity_type,omitempty"`
 	Category    model.NotificationCategory `json:"category,omitempty"`
 	Type        model.NotificationType     `json:"type,omitempty"`
-}
\ No newline at end of file
+	Event       model.NotificationEvent    `json:"event,omitempty"`
+	ReportID    *uint                      `json:"report_id,omitempty"`
+	ActorID     uint                       `json:"actor_id,omitempty"`
+	ActorName   string                     `json:"actor_name,omitempty"`
+}
+
+type SendNotificationDigestPayload struct {
+	UserID uint `json:"user_id"`
+}
+
+type DeliverNotificationPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+type EvaluateReportReputationPayload struct {
+	ReportID uint `json:"report_id"`
+}
+
+type AwardReputationPayload struct {
+	UserID     uint                       `json:"user_id"`
+	EventType  model.ReputationEventType  `json:"event_type"`
+	SourceType model.ReputationSourceType `json:"source_type"`
+	SourceID   string                     `json:"source_id"`
+}
+
+type GamificationEventPayload struct {
+	EventID    string                      `json:"event_id,omitempty"`
+	UserID     uint                        `json:"user_id"`
+	EventType  model.GamificationEventType `json:"event_type"`
+	ReportID   uint                        `json:"report_id,omitempty"`
+	OccurredAt int64                       `json:"occurred_at"`
+}
+
+type DispatchWebhookEventPayload struct {
+	EventID    string                 `json:"event_id"`
+	EventType  model.WebhookEventType `json:"event_type"`
+	OccurredAt int64                  `json:"occurred_at"`
+	Data       json.RawMessage        `json:"data"`
+}
+
+type DeliverWebhookPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+type PublishRealtimeEventPayload struct {
+	Scope     model.RealtimeScope     `json:"scope"`
+	ScopeID   uint                    `json:"scope_id"`
+	EventType model.RealtimeEventType `json:"event_type"`
+	Data      json.RawMessage         `json:"data"`
+}
+
+type SendEmailPayload struct {
+	Message mailer.Message `json:"message"`
+}
+
+type MoveMergedReportCommentsPayload struct {
+	DuplicateReportID uint `json:"duplicate_report_id"`
+	CanonicalReportID uint `json:"canonical_report_id"`
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"pingspot/internal/domain/task_service/util"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type EmailSendState int
+
+const (
+	EmailSendClaimed EmailSendState = iota
+	EmailSendInFlight
+	EmailSendAlreadySent
+)
+
+const (
+	emailStatusSending = "sending"
+	emailStatusSent    = "sent"
+)
+
+type EmailIdempotencyRepository interface {
+	Acquire(ctx context.Context, key string) (EmailSendState, error)
+	MarkSent(ctx context.Context, key string) error
+	Release(ctx context.Context, key string) error
+}
+
+type emailIdempotencyRepository struct {
+	rdb redis.UniversalClient
+}
+
+func NewEmailIdempotencyRepository(rdb redis.UniversalClient) EmailIdempotencyRepository {
+	return &emailIdempotencyRepository{rdb: rdb}
+}
+
+// Acquire claims key for sending. The claim expires on its own so a worker
+// that dies mid-send does not block the email forever.
+func (r *emailIdempotencyRepository) Acquire(ctx context.Context, key string) (EmailSendState, error) {
+	redisKey := util.GetEmailIdempotencyKey(key)
+	claimed, err := r.rdb.SetNX(ctx, redisKey, emailStatusSending, util.EmailSendLockTTL).Result()
+	if err != nil {
+		return EmailSendInFlight, err
+	}
+	if claimed {
+		return EmailSendClaimed, nil
+	}
+
+	status, err := r.rdb.Get(ctx, redisKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return r.Acquire(ctx, key)
+	}
+	if err != nil {
+		return EmailSendInFlight, err
+	}
+	if status == emailStatusSent {
+		return EmailSendAlreadySent, nil
+	}
+	return EmailSendInFlight, nil
+}
+
+func (r *emailIdempotencyRepository) MarkSent(ctx context.Context, key string) error {
+	return r.rdb.Set(ctx, util.GetEmailIdempotencyKey(key), emailStatusSent, util.EmailSentTTL).Err()
+}
+
+func (r *emailIdempotencyRepository) Release(ctx context.Context, key string) error {
+	return r.rdb.Del(ctx, util.GetEmailIdempotencyKey(key)).Err()
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	CreateTX(ctx context.Context, tx *gorm.DB, event *model.OutboxEvent) error
+	ClaimPending(ctx context.Context, now, leaseUntil int64, limit int) ([]model.OutboxEvent, error)
+	Update(ctx context.Context, event *model.OutboxEvent) error
+	DeletePublishedBefore(ctx context.Context, before int64) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) CreateTX(ctx context.Context, tx *gorm.DB, event *model.OutboxEvent) error {
+	return tx.WithContext(ctx).Create(event).Error
+}
+
+// ClaimPending moves due events to PUBLISHING until leaseUntil and returns
+// the ones this caller won. Each claim is a conditional UPDATE on the status
+// and available_at that were read, so relays in several processes never
+// publish the same event. Events whose lease ran out, because a relay stopped
+// mid-publish, are claimed again.
+func (r *outboxRepository) ClaimPending(ctx context.Context, now, leaseUntil int64, limit int) ([]model.OutboxEvent, error) {
+	var candidates []model.OutboxEvent
+	if err := r.db.WithContext(ctx).
+		Where("status IN ? AND available_at <= ?", []model.OutboxEventStatus{model.OutboxEventPending, model.OutboxEventPublishing}, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	claimed := make([]model.OutboxEvent, 0, len(candidates))
+	for _, event := range candidates {
+		result := r.db.WithContext(ctx).
+			Model(&model.OutboxEvent{}).
+			Where("id = ? AND status = ? AND available_at = ?", event.ID, event.Status, event.AvailableAt).
+			Updates(map[string]any{"status": model.OutboxEventPublishing, "available_at": leaseUntil})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		event.Status = model.OutboxEventPublishing
+		event.AvailableAt = leaseUntil
+		claimed = append(claimed, event)
+	}
+	return claimed, nil
+}
+
+func (r *outboxRepository) Update(ctx context.Context, event *model.OutboxEvent) error {
+	return r.db.WithContext(ctx).Save(event).Error
+}
+
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before int64) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND published_at < ?", model.OutboxEventPublished, before).
+		Delete(&model.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pingspot/internal/domain/task_service/repository"
+	"pingspot/internal/domain/task_service/util"
+	"pingspot/internal/model"
+	"pingspot/pkg/backoff"
+	"pingspot/pkg/logger"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+type TaskEnqueuer interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+type OutboxRelay struct {
+	outboxRepo repository.OutboxRepository
+	enqueuer   TaskEnqueuer
+}
+
+func NewOutboxRelay(outboxRepo repository.OutboxRepository, enqueuer TaskEnqueuer) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo: outboxRepo,
+		enqueuer:   enqueuer,
+	}
+}
+
+func (r *OutboxRelay) PublishPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	events, err := r.outboxRepo.ClaimPending(ctx, now.Unix(), now.Add(util.OutboxClaimLease).Unix(), util.OutboxBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim pending outbox events: %w", err)
+	}
+
+	published := 0
+	for i := range events {
+		event := &events[i]
+		task, opts := util.ToAsynqTask(*event, now)
+		_, err := r.enqueuer.Enqueue(task, opts...)
+		// A conflicting task ID means an earlier relay run already published this event.
+		if err == nil || errors.Is(err, asynq.ErrTaskIDConflict) || errors.Is(err, asynq.ErrDuplicateTask) {
+			publishedAt := now.Unix()
+			event.Status = model.OutboxEventPublished
+			event.PublishedAt = &publishedAt
+			event.LastError = nil
+			published++
+		} else {
+			errMessage := err.Error()
+			event.Status = model.OutboxEventPending
+			event.Attempts++
+			event.LastError = &errMessage
+			if event.Attempts >= util.MaxOutboxAttempts {
+				event.Status = model.OutboxEventFailed
+				logger.Error("Outbox event failed permanently",
+					zap.String("event_id", event.EventID),
+					zap.String("task_type", event.TaskType),
+					zap.Error(err),
+				)
+			} else {
+				event.AvailableAt = now.Add(backoff.Exponential(event.Attempts, util.OutboxBaseRetryDelay, util.OutboxMaxRetryDelay)).Unix()
+			}
+		}
+
+		if err := r.outboxRepo.Update(ctx, event); err != nil {
+			logger.Error("Failed to update outbox event",
+				zap.String("event_id", event.EventID),
+				zap.Error(err),
+			)
+		}
+	}
+	return published, nil
+}
+
+func (r *OutboxRelay) DeletePublished(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-util.OutboxRetentionPeriod).Unix()
+	deleted, err := r.outboxRepo.DeletePublishedBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"pingspot/internal/domain/task_service/repository"
+	"pingspot/internal/domain/task_service/util"
+	outboxMocks "pingspot/internal/mocks/outbox"
+	"pingspot/internal/model"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type countingEnqueuer struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (e *countingEnqueuer) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, opt := range opts {
+		if opt.Type() == asynq.TaskIDOpt {
+			e.counts[opt.Value().(string)]++
+		}
+	}
+	return &asynq.TaskInfo{}, nil
+}
+
+func setupRelayMocks() (*outboxMocks.MockOutboxRepository, *outboxMocks.MockTaskEnqueuer, *OutboxRelay) {
+	mockOutboxRepo := new(outboxMocks.MockOutboxRepository)
+	mockEnqueuer := new(outboxMocks.MockTaskEnqueuer)
+	relay := NewOutboxRelay(mockOutboxRepo, mockEnqueuer)
+	return mockOutboxRepo, mockEnqueuer, relay
+}
+
+func TestOutboxRelay_PublishPending(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should mark enqueued and already published events as published", func(t *testing.T) {
+		mockOutboxRepo, mockEnqueuer, relay := setupRelayMocks()
+		mockOutboxRepo.On("ClaimPending", ctx, mock.AnythingOfType("int64"), mock.AnythingOfType("int64"), util.OutboxBatchSize).Return([]model.OutboxEvent{
+			{ID: 1, EventID: "evt-1", TaskType: "notification:create", Payload: "{}", Status: model.OutboxEventPending},
+			{ID: 2, EventID: "evt-2", TaskType: "notification:create", Payload: "{}", Status: model.OutboxEventPending},
+		}, nil)
+		mockEnqueuer.On("Enqueue", mock.Anything, mock.Anything).Return(&asynq.TaskInfo{}, nil).Once()
+		mockEnqueuer.On("Enqueue", mock.Anything, mock.Anything).Return(nil, asynq.ErrTaskIDConflict).Once()
+		mockOutboxRepo.On("Update", ctx, mock.MatchedBy(func(event *model.OutboxEvent) bool {
+			return event.Status == model.OutboxEventPublished && event.PublishedAt != nil
+		})).Return(nil).Twice()
+
+		published, err := relay.PublishPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+		mockOutboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("should schedule retry when enqueue fails", func(t *testing.T) {
+		mockOutboxRepo, mockEnqueuer, relay := setupRelayMocks()
+		mockOutboxRepo.On("ClaimPending", ctx, mock.AnythingOfType("int64"), mock.AnythingOfType("int64"), util.OutboxBatchSize).Return([]model.OutboxEvent{
+			{ID: 1, EventID: "evt-1", TaskType: "notification:create", Payload: "{}", Status: model.OutboxEventPending, AvailableAt: 1},
+		}, nil)
+		mockEnqueuer.On("Enqueue", mock.Anything, mock.Anything).Return(nil, errors.New("redis unavailable"))
+		mockOutboxRepo.On("Update", ctx, mock.MatchedBy(func(event *model.OutboxEvent) bool {
+			return event.Status == model.OutboxEventPending && event.Attempts == 1 && event.AvailableAt > 1 && *event.LastError == "redis unavailable"
+		})).Return(nil)
+
+		published, err := relay.PublishPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, published)
+		mockOutboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("should give up after max attempts", func(t *testing.T) {
+		mockOutboxRepo, mockEnqueuer, relay := setupRelayMocks()
+		mockOutboxRepo.On("ClaimPending", ctx, mock.AnythingOfType("int64"), mock.AnythingOfType("int64"), util.OutboxBatchSize).Return([]model.OutboxEvent{
+			{ID: 1, EventID: "evt-1", TaskType: "notification:create", Payload: "{}", Status: model.OutboxEventPending, Attempts: util.MaxOutboxAttempts - 1},
+		}, nil)
+		mockEnqueuer.On("Enqueue", mock.Anything, mock.Anything).Return(nil, errors.New("redis unavailable"))
+		mockOutboxRepo.On("Update", ctx, mock.MatchedBy(func(event *model.OutboxEvent) bool {
+			return event.Status == model.OutboxEventFailed
+		})).Return(nil)
+
+		_, err := relay.PublishPending(ctx)
+
+		require.NoError(t, err)
+		mockOutboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when pending events cannot be fetched", func(t *testing.T) {
+		mockOutboxRepo, mockEnqueuer, relay := setupRelayMocks()
+		mockOutboxRepo.On("ClaimPending", ctx, mock.AnythingOfType("int64"), mock.AnythingOfType("int64"), util.OutboxBatchSize).Return(nil, errors.New("db down"))
+
+		_, err := relay.PublishPending(ctx)
+
+		assert.Error(t, err)
+		mockEnqueuer.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOutboxRelay_PublishPendingConcurrently(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should enqueue each event once when two relays run at the same time", func(t *testing.T) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.SetMaxOpenConns(1)
+		require.NoError(t, db.AutoMigrate(&model.OutboxEvent{}))
+
+		const total = 50
+		now := time.Now().Unix()
+		for i := 0; i < total; i++ {
+			require.NoError(t, db.Create(&model.OutboxEvent{
+				EventID:     fmt.Sprintf("evt-%d", i),
+				TaskType:    "notification:create",
+				Payload:     "{}",
+				Status:      model.OutboxEventPending,
+				AvailableAt: now,
+				CreatedAt:   now,
+			}).Error)
+		}
+
+		outboxRepo := repository.NewOutboxRepository(db)
+		enqueuer := &countingEnqueuer{counts: make(map[string]int)}
+		relays := []*OutboxRelay{NewOutboxRelay(outboxRepo, enqueuer), NewOutboxRelay(outboxRepo, enqueuer)}
+
+		var wg sync.WaitGroup
+		publishedCounts := make([]int, len(relays))
+		for i, relay := range relays {
+			wg.Add(1)
+			go func(i int, relay *OutboxRelay) {
+				defer wg.Done()
+				published, err := relay.PublishPending(ctx)
+				assert.NoError(t, err)
+				publishedCounts[i] = published
+			}(i, relay)
+		}
+		wg.Wait()
+
+		assert.Equal(t, total, publishedCounts[0]+publishedCounts[1])
+		assert.Len(t, enqueuer.counts, total)
+		for eventID, count := range enqueuer.counts {
+			assert.Equal(t, 1, count, eventID)
+		}
+
+		var pending int64
+		require.NoError(t, db.Model(&model.OutboxEvent{}).Where("status <> ?", model.OutboxEventPublished).Count(&pending).Error)
+		assert.Zero(t, pending)
+	})
+}
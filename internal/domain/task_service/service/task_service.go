@@ -1,25 +1,47 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"pingspot/internal/domain/task_service/payload"
+	"pingspot/internal/domain/task_service/repository"
 	"pingspot/internal/domain/task_service/tasks"
+	"pingspot/internal/domain/task_service/util"
 	"pingspot/internal/model"
+	"pingspot/pkg/mailer"
 	"pingspot/pkg/logger"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type TaskService interface {
 	AutoResolveReportTask(reportID uint) error
-	CreateNotificationTask(userID uint, title string, description string, entityID *string, entityType model.EntityType, category model.NotificationCategory, notificationType model.NotificationType) error
+	CreateNotificationTask(userID uint, messageKey string, messageParams map[string]string, entityID *string, entityType model.EntityType, category model.NotificationCategory, notificationType model.NotificationType, event model.NotificationEvent, reportID *uint) error
+	CreateGroupedNotificationTask(userID uint, actorID uint, actorName string, entityID *string, entityType model.EntityType, category model.NotificationCategory, event model.NotificationEvent, reportID *uint) error
+	SendNotificationDigestTask(userID uint) error
+	DeliverNotificationTask(deliveryID uint, maxAttempts int) error
+	RecalculateReportPriorityTask(reportID uint) error
+	EvaluateReportReputationTask(reportID uint) error
+	MoveMergedReportCommentsTask(duplicateReportID, canonicalReportID uint) error
+	AwardReputationTask(userID uint, eventType model.ReputationEventType, sourceType model.ReputationSourceType, sourceID string) error
+	GamificationEventTask(userID uint, eventType model.GamificationEventType, reportID uint) error
+	DispatchWebhookEventTask(eventType model.WebhookEventType, data any) error
+	DeliverWebhookTask(deliveryID uint, maxAttempts int) error
+	PublishRealtimeEventTask(scope model.RealtimeScope, scopeID uint, eventType model.RealtimeEventType, data any) error
+	SendEmailTask(message mailer.Message) error
+	WithTx(tx *gorm.DB) TaskService
 }
 
 type taskService struct {
 	client     *asynq.Client
+	outboxRepo repository.OutboxRepository
+	tx         *gorm.DB
 }
 
 func NewTaskService(client *asynq.Client) TaskService {
@@ -28,10 +50,29 @@ func NewTaskService(client *asynq.Client) TaskService {
 	}
 }
 
+// WithTx returns a TaskService that stores tasks in the outbox as part of tx
+// instead of enqueueing them, so they are only published once tx commits.
+func (s *taskService) WithTx(tx *gorm.DB) TaskService {
+	return &taskService{
+		client:     s.client,
+		outboxRepo: repository.NewOutboxRepository(tx),
+		tx:         tx,
+	}
+}
+
+func (s *taskService) enqueue(task *asynq.Task, opts ...asynq.Option) error {
+	if s.tx != nil {
+		event := util.NewOutboxEvent(task, time.Now(), opts...)
+		return s.outboxRepo.CreateTX(context.Background(), s.tx, &event)
+	}
+	_, err := s.client.Enqueue(task, opts...)
+	return err
+}
+
 func (s *taskService) AutoResolveReportTask(reportID uint) error {
 	payload, _ := json.Marshal(payload.UpdateProgressPayload{ReportID: reportID})
 	task := asynq.NewTask(tasks.TaskAutoResolveReport, payload)
-	_, err := s.client.Enqueue(task, asynq.ProcessIn(20*time.Minute))
+	err := s.enqueue(task, asynq.ProcessIn(20*time.Minute))
 	if err != nil {
 		return fmt.Errorf("failed to enqueue auto resolve report task: %w", err)
 	}
@@ -39,21 +80,208 @@ func (s *taskService) AutoResolveReportTask(reportID uint) error {
 	return nil
 }
 
-func (s *taskService) CreateNotificationTask(userID uint, title string, description string, entityID *string, entityType model.EntityType, category model.NotificationCategory, notificationType model.NotificationType) error {
+func (s *taskService) CreateNotificationTask(userID uint, messageKey string, messageParams map[string]string, entityID *string, entityType model.EntityType, category model.NotificationCategory, notificationType model.NotificationType, event model.NotificationEvent, reportID *uint) error {
 	payload, _ := json.Marshal(payload.CreateNotificationPayload{
 		UserID:      userID,
-		Title:       title,
-		Description: description,
+		MessageKey:  messageKey,
+		MessageParams: messageParams,
 		EntityID:    entityID,
 		EntityType:  entityType,
 		Category:    category,
 		Type:          notificationType,
+		Event:       event,
+		ReportID:    reportID,
 	})
 	task := asynq.NewTask(tasks.TaskCreateNotification, payload)
-	_, err := s.client.Enqueue(task, asynq.ProcessIn(5*time.Second))
+	err := s.enqueue(task, asynq.ProcessIn(5*time.Second))
 	if err != nil {
 		return fmt.Errorf("failed to enqueue create notification task: %w", err)
 	}
 	logger.Info("Create notification task enqueued for", zap.Int("user_id", int(userID)))
 	return nil
-}
\ No newline at end of file
+}
+
+// CreateGroupedNotificationTask lets the notification handler collapse this
+// notification with others of the same event and entity, so the title and
+// description are rendered by the handler from the actor list.
+func (s *taskService) CreateGroupedNotificationTask(userID uint, actorID uint, actorName string, entityID *string, entityType model.EntityType, category model.NotificationCategory, event model.NotificationEvent, reportID *uint) error {
+	payload, _ := json.Marshal(payload.CreateNotificationPayload{
+		UserID:     userID,
+		EntityID:   entityID,
+		EntityType: entityType,
+		Category:   category,
+		Type:       model.NotificationTypeInfo,
+		Event:      event,
+		ReportID:   reportID,
+		ActorID:    actorID,
+		ActorName:  actorName,
+	})
+	task := asynq.NewTask(tasks.TaskCreateNotification, payload)
+	err := s.enqueue(task, asynq.ProcessIn(5*time.Second))
+	if err != nil {
+		return fmt.Errorf("failed to enqueue create grouped notification task: %w", err)
+	}
+	logger.Info("Create grouped notification task enqueued for", zap.Int("user_id", int(userID)))
+	return nil
+}
+
+func (s *taskService) SendNotificationDigestTask(userID uint) error {
+	payload, _ := json.Marshal(payload.SendNotificationDigestPayload{UserID: userID})
+	task := asynq.NewTask(tasks.TaskSendNotificationDigest, payload)
+	err := s.enqueue(task, asynq.MaxRetry(3), asynq.Unique(time.Hour))
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		return fmt.Errorf("failed to enqueue send notification digest task: %w", err)
+	}
+	return nil
+}
+
+// DeliverNotificationTask leaves retries to asynq: the handler returns an error
+// for a failed attempt and the worker's retry delay function spaces them out.
+func (s *taskService) DeliverNotificationTask(deliveryID uint, maxAttempts int) error {
+	payload, _ := json.Marshal(payload.DeliverNotificationPayload{DeliveryID: deliveryID})
+	task := asynq.NewTask(tasks.TaskDeliverNotification, payload)
+	err := s.enqueue(task, asynq.MaxRetry(util.GetMaxRetry(maxAttempts)))
+	if err != nil {
+		return fmt.Errorf("failed to enqueue deliver notification task: %w", err)
+	}
+	return nil
+}
+
+func (s *taskService) RecalculateReportPriorityTask(reportID uint) error {
+	payload, _ := json.Marshal(payload.RecalculatePriorityPayload{ReportID: reportID})
+	task := asynq.NewTask(tasks.TaskRecalculateReportPriority, payload)
+	err := s.enqueue(task, asynq.ProcessIn(30*time.Second), asynq.Unique(30*time.Second))
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		return fmt.Errorf("failed to enqueue recalculate report priority task: %w", err)
+	}
+	return nil
+}
+
+func (s *taskService) EvaluateReportReputationTask(reportID uint) error {
+	payload, _ := json.Marshal(payload.EvaluateReportReputationPayload{ReportID: reportID})
+	task := asynq.NewTask(tasks.TaskEvaluateReportReputation, payload)
+	err := s.enqueue(task, asynq.ProcessIn(10*time.Second), asynq.Unique(time.Minute))
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		return fmt.Errorf("failed to enqueue evaluate report reputation task: %w", err)
+	}
+	return nil
+}
+
+// MoveMergedReportCommentsTask moves a merged duplicate's comments, which live
+// in MongoDB, once the merge has committed. The task ID is derived from the
+// report pair, and the handler is idempotent, so it is retried until it
+// completes.
+func (s *taskService) MoveMergedReportCommentsTask(duplicateReportID, canonicalReportID uint) error {
+	payload, _ := json.Marshal(payload.MoveMergedReportCommentsPayload{
+		DuplicateReportID: duplicateReportID,
+		CanonicalReportID: canonicalReportID,
+	})
+	task := asynq.NewTask(tasks.TaskMoveMergedReportComments, payload)
+	err := s.enqueue(task,
+		asynq.TaskID(util.GetMoveMergedCommentsTaskID(duplicateReportID, canonicalReportID)),
+		asynq.MaxRetry(util.MaxMoveMergedCommentsRetry),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("failed to enqueue move merged report comments task: %w", err)
+	}
+	return nil
+}
+
+func (s *taskService) AwardReputationTask(userID uint, eventType model.ReputationEventType, sourceType model.ReputationSourceType, sourceID string) error {
+	payload, _ := json.Marshal(payload.AwardReputationPayload{
+		UserID:     userID,
+		EventType:  eventType,
+		SourceType: sourceType,
+		SourceID:   sourceID,
+	})
+	task := asynq.NewTask(tasks.TaskAwardReputation, payload)
+	err := s.enqueue(task, asynq.ProcessIn(5*time.Second))
+	if err != nil {
+		return fmt.Errorf("failed to enqueue award reputation task: %w", err)
+	}
+	return nil
+}
+
+func (s *taskService) GamificationEventTask(userID uint, eventType model.GamificationEventType, reportID uint) error {
+	payload, _ := json.Marshal(payload.GamificationEventPayload{
+		UserID:     userID,
+		EventType:  eventType,
+		ReportID:   reportID,
+		OccurredAt: time.Now().Unix(),
+	})
+	task := asynq.NewTask(tasks.TaskProcessGamificationEvent, payload)
+	err := s.enqueue(task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue gamification event task: %w", err)
+	}
+	return nil
+}
+
+func (s *taskService) DispatchWebhookEventTask(eventType model.WebhookEventType, data any) error {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event data: %w", err)
+	}
+	payload, _ := json.Marshal(payload.DispatchWebhookEventPayload{
+		EventID:    uuid.New().String(),
+		EventType:  eventType,
+		OccurredAt: time.Now().Unix(),
+		Data:       rawData,
+	})
+	task := asynq.NewTask(tasks.TaskDispatchWebhookEvent, payload)
+	err = s.enqueue(task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue dispatch webhook event task: %w", err)
+	}
+	return nil
+}
+
+// DeliverWebhookTask leaves retries to asynq, like DeliverNotificationTask.
+func (s *taskService) DeliverWebhookTask(deliveryID uint, maxAttempts int) error {
+	payload, _ := json.Marshal(payload.DeliverWebhookPayload{DeliveryID: deliveryID})
+	task := asynq.NewTask(tasks.TaskDeliverWebhook, payload)
+	err := s.enqueue(task,
+		asynq.TaskID(util.GetDeliverWebhookTaskID(deliveryID)),
+		asynq.MaxRetry(util.GetMaxRetry(maxAttempts)),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("failed to enqueue deliver webhook task: %w", err)
+	}
+	return nil
+}
+
+func (s *taskService) PublishRealtimeEventTask(scope model.RealtimeScope, scopeID uint, eventType model.RealtimeEventType, data any) error {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal realtime event data: %w", err)
+	}
+	payload, _ := json.Marshal(payload.PublishRealtimeEventPayload{
+		Scope:     scope,
+		ScopeID:   scopeID,
+		EventType: eventType,
+		Data:      rawData,
+	})
+	task := asynq.NewTask(tasks.TaskPublishRealtimeEvent, payload)
+	err = s.enqueue(task, asynq.MaxRetry(2))
+	if err != nil {
+		return fmt.Errorf("failed to enqueue publish realtime event task: %w", err)
+	}
+	return nil
+}
+
+// SendEmailTask queues an already rendered email. Messages with an idempotency
+// key are enqueued at most once per key, and the handler skips keys that were
+// already sent, so callers may safely retry.
+func (s *taskService) SendEmailTask(message mailer.Message) error {
+	payload, _ := json.Marshal(payload.SendEmailPayload{Message: message})
+	task := asynq.NewTask(tasks.TaskSendEmail, payload)
+	opts := []asynq.Option{asynq.MaxRetry(util.MaxEmailRetry)}
+	if message.IdempotencyKey != "" {
+		opts = append(opts, asynq.TaskID(util.GetEmailIdempotencyKey(message.IdempotencyKey)))
+	}
+	err := s.enqueue(task, opts...)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("failed to enqueue send email task: %w", err)
+	}
+	return nil
+}
@@ -5,11 +5,29 @@ const (
 	TaskAutoResolveReports = "task:auto_resolve_reports"
 	TaskRecalculateVoteCount = "report:recalculate_votes"
 	TaskSendProgressReminder = "report:send_progress_reminder"
+	TaskDetectReportSurge = "report:detect_report_surge"
+	TaskRecalculateReportPriority = "report:recalculate_priority"
+	TaskRecalculateAllReportPriorities = "report:recalculate_all_priorities"
+	TaskRecalculateHotScores = "report:recalculate_hot_scores"
+	TaskMoveMergedReportComments = "report:move_merged_comments"
 
+	TaskSendEmail             = "email:send"
 	TaskSendWelcomeEmail      = "email:send_welcome"
 	TaskSendNotificationEmail = "email:send_notification"
 
 	TaskCleanupInactiveUsers = "user:cleanup_inactive"
 
 	TaskCreateNotification = "notification:create_notification"
+	TaskSendNotificationDigest = "notification:send_digest"
+	TaskDeliverNotification    = "notification:deliver"
+
+	TaskEvaluateReportReputation = "reputation:evaluate_report"
+	TaskAwardReputation          = "reputation:award"
+
+	TaskProcessGamificationEvent = "gamification:process_event"
+
+	TaskDispatchWebhookEvent = "webhook:dispatch_event"
+	TaskDeliverWebhook       = "webhook:deliver"
+
+	TaskPublishRealtimeEvent = "realtime:publish_event"
 )
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"fmt"
+	"pingspot/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	OutboxBatchSize       = 100
+	MaxOutboxAttempts     = 10
+	OutboxBaseRetryDelay  = 5 * time.Second
+	OutboxMaxRetryDelay   = 10 * time.Minute
+	OutboxRetentionPeriod = 7 * 24 * time.Hour
+	OutboxClaimLease      = time.Minute
+	// OutboxTaskRetention keeps completed tasks in asynq so their task ID
+	// still rejects a second enqueue of the same event.
+	OutboxTaskRetention = 24 * time.Hour
+
+	MaxMoveMergedCommentsRetry = 25
+
+	MaxEmailRetry    = 10
+	EmailSendLockTTL = 10 * time.Minute
+	EmailSentTTL     = 7 * 24 * time.Hour
+)
+
+func GetEmailIdempotencyKey(key string) string {
+	return "email:idempotency:" + key
+}
+
+// GetDeliverWebhookTaskID keys the delivery task by the delivery, so a
+// delivery that is still queued is not enqueued a second time.
+func GetDeliverWebhookTaskID(deliveryID uint) string {
+	return fmt.Sprintf("webhook:deliver:%d", deliveryID)
+}
+
+// GetMoveMergedCommentsTaskID keys the comment move by the merged pair, so the
+// outbox and asynq hold at most one move per duplicate and canonical report.
+func GetMoveMergedCommentsTaskID(duplicateReportID, canonicalReportID uint) string {
+	return fmt.Sprintf("report:move_merged_comments:%d:%d", duplicateReportID, canonicalReportID)
+}
+
+// GetMaxRetry converts a total number of attempts into asynq's retry count,
+// which excludes the first run.
+func GetMaxRetry(maxAttempts int) int {
+	if maxAttempts < 1 {
+		return 0
+	}
+	return maxAttempts - 1
+}
+
+func NewOutboxEvent(task *asynq.Task, now time.Time, opts ...asynq.Option) model.OutboxEvent {
+	event := model.OutboxEvent{
+		EventID:     uuid.New().String(),
+		TaskType:    task.Type(),
+		Payload:     string(task.Payload()),
+		ProcessAt:   now.Unix(),
+		Status:      model.OutboxEventPending,
+		AvailableAt: now.Unix(),
+	}
+	for _, opt := range opts {
+		switch opt.Type() {
+		case asynq.ProcessInOpt:
+			event.ProcessAt = now.Add(opt.Value().(time.Duration)).Unix()
+		case asynq.ProcessAtOpt:
+			event.ProcessAt = opt.Value().(time.Time).Unix()
+		case asynq.UniqueOpt:
+			event.UniqueTTLSeconds = int64(opt.Value().(time.Duration) / time.Second)
+		case asynq.TaskIDOpt:
+			event.EventID = opt.Value().(string)
+		case asynq.MaxRetryOpt:
+			maxRetry := opt.Value().(int)
+			event.MaxRetry = &maxRetry
+		}
+	}
+	return event
+}
+
+func ToAsynqTask(event model.OutboxEvent, now time.Time) (*asynq.Task, []asynq.Option) {
+	opts := []asynq.Option{asynq.TaskID(event.EventID), asynq.Retention(OutboxTaskRetention)}
+	if event.ProcessAt > now.Unix() {
+		opts = append(opts, asynq.ProcessAt(time.Unix(event.ProcessAt, 0)))
+	}
+	if event.UniqueTTLSeconds > 0 {
+		opts = append(opts, asynq.Unique(time.Duration(event.UniqueTTLSeconds)*time.Second))
+	}
+	if event.MaxRetry != nil {
+		opts = append(opts, asynq.MaxRetry(*event.MaxRetry))
+	}
+	return asynq.NewTask(event.TaskType, []byte(event.Payload)), opts
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"pingspot/internal/model"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOutboxEvent(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	task := asynq.NewTask("report:recalculate_priority", []byte(`{"report_id":1}`))
+
+	event := NewOutboxEvent(task, now, asynq.ProcessIn(30*time.Second), asynq.Unique(time.Minute), asynq.MaxRetry(0))
+
+	assert.NotEmpty(t, event.EventID)
+	assert.Equal(t, "report:recalculate_priority", event.TaskType)
+	assert.Equal(t, `{"report_id":1}`, event.Payload)
+	assert.Equal(t, now.Unix()+30, event.ProcessAt)
+	assert.Equal(t, int64(60), event.UniqueTTLSeconds)
+	require.NotNil(t, event.MaxRetry)
+	assert.Equal(t, 0, *event.MaxRetry)
+	assert.Equal(t, model.OutboxEventPending, event.Status)
+	assert.Equal(t, now.Unix(), event.AvailableAt)
+}
+
+func TestNewOutboxEventUsesTaskID(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	task := asynq.NewTask("report:move_merged_comments", []byte(`{}`))
+
+	event := NewOutboxEvent(task, now, asynq.TaskID(GetMoveMergedCommentsTaskID(3, 1)))
+	converted, opts := ToAsynqTask(event, now)
+
+	assert.Equal(t, "report:move_merged_comments:3:1", event.EventID)
+	assert.Equal(t, "report:move_merged_comments", converted.Type())
+	require.NotEmpty(t, opts)
+	assert.Equal(t, asynq.TaskIDOpt, opts[0].Type())
+	assert.Equal(t, "report:move_merged_comments:3:1", opts[0].Value())
+}
+
+func TestToAsynqTask(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	t.Run("should keep delay, uniqueness and event id", func(t *testing.T) {
+		event := NewOutboxEvent(asynq.NewTask("notification:create", []byte(`{}`)), now, asynq.ProcessIn(time.Minute), asynq.Unique(time.Minute))
+
+		task, opts := ToAsynqTask(event, now)
+
+		assert.Equal(t, "notification:create", task.Type())
+		assert.Equal(t, []byte(`{}`), task.Payload())
+		optTypes := map[asynq.OptionType]any{}
+		for _, opt := range opts {
+			optTypes[opt.Type()] = opt.Value()
+		}
+		assert.Equal(t, event.EventID, optTypes[asynq.TaskIDOpt])
+		assert.Equal(t, time.Unix(now.Unix()+60, 0), optTypes[asynq.ProcessAtOpt])
+		assert.Equal(t, time.Minute, optTypes[asynq.UniqueOpt])
+		assert.NotContains(t, optTypes, asynq.MaxRetryOpt)
+	})
+
+	t.Run("should process immediately when delay has passed", func(t *testing.T) {
+		event := NewOutboxEvent(asynq.NewTask("notification:create", nil), now, asynq.ProcessIn(time.Second))
+
+		_, opts := ToAsynqTask(event, now.Add(time.Minute))
+
+		require.Len(t, opts, 2)
+		assert.Equal(t, asynq.TaskIDOpt, opts[0].Type())
+		assert.Equal(t, asynq.RetentionOpt, opts[1].Type())
+		assert.Equal(t, OutboxTaskRetention, opts[1].Value())
+	})
+}
+
+func TestGetMaxRetry(t *testing.T) {
+	assert.Equal(t, 0, GetMaxRetry(0))
+	assert.Equal(t, 0, GetMaxRetry(1))
+	assert.Equal(t, 5, GetMaxRetry(6))
+}
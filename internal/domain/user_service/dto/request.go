@@ -7,6 +7,8 @@ type SaveUserProfileRequest struct {
 	ProfilePicture   *string `json:"profilePicture" validate:"omitempty,max=255"`
 	Gender   		 *string `json:"gender" validate:"omitempty,oneof=male female"`
 	Birthday 	   	 *string `json:"birthday" validate:"omitempty,datetime=2006-01-02"`
+	HomeLatitude	 *float64 `json:"homeLatitude" validate:"required_with=HomeLongitude,omitempty,latitude"`
+	HomeLongitude	 *float64 `json:"homeLongitude" validate:"required_with=HomeLatitude,omitempty,longitude"`
 }
 
 type SaveUserSecurityRequest struct {
@@ -14,4 +16,9 @@ type SaveUserSecurityRequest struct {
 	CurrentPasswordConfirmation string `json:"currentPasswordConfirmation" validate:"required,eqfield=CurrentPassword"`
 	NewPassword          string `json:"newPassword" validate:"required,min=6"`
 	NewPasswordConfirmation   string `json:"newPasswordConfirmation" validate:"required,eqfield=NewPassword"`
-}
\ No newline at end of file
+	RevokeAllSessions    bool   `json:"revokeAllSessions"`
+}
+
+type UpdateLanguageRequest struct {
+	Language string `json:"language" validate:"required,oneof=id en"`
+}
@@ -8,6 +8,8 @@ type SaveUserProfileResponse struct {
 	Username		string  `json:"username"`
 	Gender 	   		*string `json:"gender"`
 	Birthday   		*string `json:"birthday"`
+	HomeLatitude	*float64 `json:"homeLatitude"`
+	HomeLongitude	*float64 `json:"homeLongitude"`
 }
 
 type GetProfileResponse struct {
@@ -19,9 +21,14 @@ type GetProfileResponse struct {
 	Birthday   		*string `json:"birthday"`
 	Gender 	   		*string `json:"gender"`
 	Email			string  `json:"email"`	
+	HomeLatitude	*float64 `json:"homeLatitude,omitempty"`
+	HomeLongitude	*float64 `json:"homeLongitude,omitempty"`
+	ReputationScore int64    `json:"reputationScore"`
 	IsDefaultUsername bool    `json:"isDefaultUsername"`
 	IsCompleteProfile bool    `json:"isCompleteProfile"`
 	MissingFields 	[]string `json:"missingFields,omitempty"`
+	Language        *string  `json:"language"`
+	TwoFactorEnabled bool    `json:"twoFactorEnabled"`
 }
 
 type GetUserStatisticsResponse struct {
@@ -32,4 +39,25 @@ type GetUserStatisticsResponse struct {
 
 type SearchResponse struct {
 	UsersData []SearchUsers `json:"usersData"`
+}
+
+type UserSessionResponse struct {
+	SessionID      uint   `json:"sessionID"`
+	Browser        string `json:"browser"`
+	BrowserVersion string `json:"browserVersion,omitempty"`
+	OS             string `json:"os"`
+	OSVersion      string `json:"osVersion,omitempty"`
+	DeviceType     string `json:"deviceType"`
+	IPAddress      string `json:"ipAddress"`
+	CreatedAt      int64  `json:"createdAt"`
+	ExpiresAt      int64  `json:"expiresAt"`
+	IsCurrent      bool   `json:"isCurrent"`
+}
+
+type GetUserSessionsResponse struct {
+	Sessions []UserSessionResponse `json:"sessions"`
+}
+
+type RevokeUserSessionsResponse struct {
+	RevokedCount int `json:"revokedCount"`
 }
\ No newline at end of file
@@ -51,6 +51,9 @@ func (h *UserHandler) SaveUserSecurityHandler(c *fiber.Ctx) error {
 		}
 		return response.ResponseError(c, 500, "Gagal memperbarui kata sandi", "", err.Error())
 	}
+	if req.RevokeAllSessions {
+		tokenutils.ClearAuthCookies(c)
+	}
 	return response.ResponseSuccess(c, 200, "Kata sandi berhasil diperbarui. Silahkan masuk kembali dengan kata sandi baru anda.", "data", nil)
 }
 
@@ -213,4 +216,96 @@ func (h *UserHandler) GetProfileByUsernameHandler(c *fiber.Ctx) error {
 		return response.ResponseError(c, 500, "Gagal mendapatkan profil pengguna", "", err.Error())
 	}
 	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan profil pengguna", "data", userProfile)
-}
\ No newline at end of file
+}
+
+func (h *UserHandler) UpdateLanguageHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	var req dto.UpdateLanguageRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatUpdateLanguageValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+	if err := h.userService.UpdateLanguage(ctx, userId, req); err != nil {
+		logger.Error("Failed to update user language", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal memperbarui bahasa", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Bahasa berhasil diperbarui", "data", fiber.Map{"language": req.Language})
+}
+
+func (h *UserHandler) GetSessionsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+	sessionId := uint(claims["session_id"].(float64))
+	result, err := h.userService.GetSessions(ctx, userId, sessionId)
+	if err != nil {
+		logger.Error("Failed to get user sessions", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mengambil sesi user", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan sesi user", "data", result)
+}
+
+func (h *UserHandler) RevokeSessionHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	targetSessionID, err := c.ParamsInt("sessionID")
+	if err != nil || targetSessionID <= 0 {
+		logger.Error("Failed to parse session ID", zap.Error(err))
+		return response.ResponseError(c, 400, "ID sesi tidak valid", "", "ID sesi harus berupa angka")
+	}
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+	sessionId := uint(claims["session_id"].(float64))
+	if err := h.userService.RevokeSession(ctx, userId, sessionId, uint(targetSessionID)); err != nil {
+		logger.Error("Failed to revoke user session", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menghapus sesi user", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Sesi berhasil diakhiri", "data", nil)
+}
+
+func (h *UserHandler) RevokeOtherSessionsHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userId := uint(claims["user_id"].(float64))
+	sessionId := uint(claims["session_id"].(float64))
+	result, err := h.userService.RevokeOtherSessions(ctx, userId, sessionId)
+	if err != nil {
+		logger.Error("Failed to revoke other user sessions", zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menghapus sesi user", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Semua sesi lain berhasil diakhiri", "data", result)
+}
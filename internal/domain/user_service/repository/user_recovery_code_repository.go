@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type UserRecoveryCodeRepository interface {
+	ReplaceTX(ctx context.Context, tx *gorm.DB, userID uint, codes []model.UserRecoveryCode) error
+	DeleteByUserIDTX(ctx context.Context, tx *gorm.DB, userID uint) error
+	GetUnusedByUserID(ctx context.Context, userID uint) ([]model.UserRecoveryCode, error)
+	MarkUsed(ctx context.Context, id uint, usedAt int64) (bool, error)
+}
+
+type userRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRecoveryCodeRepository(db *gorm.DB) UserRecoveryCodeRepository {
+	return &userRecoveryCodeRepository{db: db}
+}
+
+func (r *userRecoveryCodeRepository) ReplaceTX(ctx context.Context, tx *gorm.DB, userID uint, codes []model.UserRecoveryCode) error {
+	if err := r.DeleteByUserIDTX(ctx, tx, userID); err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Create(&codes).Error
+}
+
+func (r *userRecoveryCodeRepository) DeleteByUserIDTX(ctx context.Context, tx *gorm.DB, userID uint) error {
+	return tx.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.UserRecoveryCode{}).Error
+}
+
+func (r *userRecoveryCodeRepository) GetUnusedByUserID(ctx context.Context, userID uint) ([]model.UserRecoveryCode, error) {
+	var codes []model.UserRecoveryCode
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND used_at IS NULL", userID).Order("id ASC").Find(&codes).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// MarkUsed only succeeds for a code that is still unused, so two concurrent
+// logins cannot both redeem it.
+func (r *userRecoveryCodeRepository) MarkUsed(ctx context.Context, id uint, usedAt int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.UserRecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -28,6 +28,7 @@ type UserRepository interface {
 	GetByUserGenderCount(ctx context.Context) (map[string]int64, error)
 	GetMonthlyUserCounts(ctx context.Context) (map[string]int64, error)
 	GetUsersCount(ctx context.Context) (int64, error)
+	GetByRoles(ctx context.Context, roles ...model.UserRole) ([]model.User, error)
 }
 
 type userRepository struct {
@@ -179,6 +180,16 @@ func (r *userRepository) GetByIDs(ctx context.Context, userIDs []uint) ([]model.
 	return users, nil
 }
 
+func (r *userRepository) GetByRoles(ctx context.Context, roles ...model.UserRole) ([]model.User, error) {
+	var users []model.User
+	if err := r.db.WithContext(ctx).
+		Where("role IN ?", roles).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
 	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
@@ -13,6 +13,7 @@ type UserSessionRepository interface {
 	GetByRefreshTokenID(ctx context.Context, refreshTokenID string) (*model.UserSession, error)
 	Update(ctx context.Context, userSession *model.UserSession) error
 	UpdateTX(ctx context.Context, tx *gorm.DB, userSession *model.UserSession) error
+	GetActiveByUserID(ctx context.Context, userID uint, now int64) ([]model.UserSession, error)
 }
 
 type userSessionRepository struct {
@@ -59,3 +60,14 @@ func (r *userSessionRepository) GetByRefreshTokenID(ctx context.Context, refresh
 	}
 	return &userSession, nil
 }
+
+func (r *userSessionRepository) GetActiveByUserID(ctx context.Context, userID uint, now int64) ([]model.UserSession, error) {
+	var userSessions []model.UserSession
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND is_active = ? AND expires_at > ?", userID, true, now).
+		Order("created_at DESC").
+		Find(&userSessions).Error; err != nil {
+		return nil, err
+	}
+	return userSessions, nil
+}
@@ -1,11 +1,14 @@
 package router
 
 import (
+	realtimeService "pingspot/internal/domain/realtime_service/service"
 	"pingspot/internal/domain/user_service/handler"
 	"pingspot/internal/domain/user_service/repository"
 	"pingspot/internal/domain/user_service/service"
 	"pingspot/internal/infrastructure/database"
 	"pingspot/internal/middleware"
+	cacheRepository "pingspot/internal/repository"
+	"pingspot/internal/infrastructure/cache"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,7 +18,11 @@ func RegisterUserRoutes(app *fiber.App) {
 	db := database.GetPostgresDB()
 	userRepo := repository.NewUserRepository(db)
 	userProfileRepo := repository.NewUserProfileRepository(db)
-	userService := service.NewUserService(db, userRepo, userProfileRepo)
+	userSessionRepo := repository.NewUserSessionRepository(db)
+	rdb := cache.GetRedis()
+	cacheRepo := cacheRepository.NewCacheRepository(&rdb)
+	realtimePublisher := realtimeService.NewPublisher(rdb)
+	userService := service.NewUserService(db, userRepo, userProfileRepo, userSessionRepo, cacheRepo, realtimePublisher)
 	userHandler := handler.NewUserHandler(userService)
 
 	userRoute := app.Group("/pingspot/api/user", middleware.ValidateAccessToken())
@@ -40,6 +47,16 @@ func RegisterUserRoutes(app *fiber.App) {
 	userHandler.GetUserSearch,
 	)
 
+	userRoute.Put("/language", 
+	middleware.TimeoutMiddleware(5*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 20,
+		KeyPrefix: "update_user_language",
+	})),  
+	userHandler.UpdateLanguageHandler,
+	)
+
 	profileRoute := app.Group("/pingspot/api/user/profile", middleware.ValidateAccessToken())
 
 	profileRoute.Get("/", 
@@ -81,4 +98,32 @@ func RegisterUserRoutes(app *fiber.App) {
 	})),  
 	userHandler.SaveUserSecurityHandler,
 	)
+
+	securityRoute.Get("/sessions", 
+	middleware.TimeoutMiddleware(5*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 50,
+		KeyPrefix: "get_user_sessions",
+	})),  
+	userHandler.GetSessionsHandler,
+	)
+	securityRoute.Delete("/sessions", 
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 10,
+		KeyPrefix: "revoke_other_user_sessions",
+	})),  
+	userHandler.RevokeOtherSessionsHandler,
+	)
+	securityRoute.Delete("/sessions/:sessionID", 
+	middleware.TimeoutMiddleware(10*time.Second),
+	middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+		Window:      1 * time.Minute,
+		MaxRequests: 20,
+		KeyPrefix: "revoke_user_session",
+	})),  
+	userHandler.RevokeSessionHandler,
+	)
 }
@@ -3,14 +3,21 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	realtimeDTO "pingspot/internal/domain/realtime_service/dto"
+	realtimeService "pingspot/internal/domain/realtime_service/service"
 	"pingspot/internal/domain/user_service/dto"
 	"pingspot/internal/domain/user_service/repository"
 	"pingspot/internal/model"
+	cacheRepo "pingspot/internal/repository"
 	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/i18n"
 	"pingspot/pkg/logger"
 	contextutils "pingspot/pkg/utils/context_util"
 	tokenutils "pingspot/pkg/utils/token_util"
+	useragentutils "pingspot/pkg/utils/useragent_util"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -19,14 +26,20 @@ import (
 type UserService struct {
 	userRepo        repository.UserRepository
 	userProfileRepo repository.UserProfileRepository
+	userSessionRepo repository.UserSessionRepository
+	cacheRepo       cacheRepo.CacheRepository
+	realtimePub     realtimeService.Publisher
 	db              *gorm.DB
 }
 
-func NewUserService(db *gorm.DB, userRepo repository.UserRepository, userProfileRepo repository.UserProfileRepository) *UserService {
+func NewUserService(db *gorm.DB, userRepo repository.UserRepository, userProfileRepo repository.UserProfileRepository, userSessionRepo repository.UserSessionRepository, cacheRepo cacheRepo.CacheRepository, realtimePub realtimeService.Publisher) *UserService {
 	return &UserService{
 		db:              db,
 		userRepo:        userRepo,
 		userProfileRepo: userProfileRepo,
+		userSessionRepo: userSessionRepo,
+		cacheRepo:       cacheRepo,
+		realtimePub:     realtimePub,
 	}
 }
 
@@ -87,6 +100,8 @@ func (s *UserService) SaveProfile(ctx context.Context, userID uint, req dto.Save
 				ProfilePicture: req.ProfilePicture,
 				Birthday:       req.Birthday,
 				Gender:         req.Gender,
+				HomeLatitude:   req.HomeLatitude,
+				HomeLongitude:  req.HomeLongitude,
 			}
 			if _, err := s.userProfileRepo.CreateTX(ctx, tx, &newProfile); err != nil {
 				tx.Rollback()
@@ -102,6 +117,8 @@ func (s *UserService) SaveProfile(ctx context.Context, userID uint, req dto.Save
 				ProfilePicture: req.ProfilePicture,
 				Birthday:       req.Birthday,
 				Gender:         req.Gender,
+				HomeLatitude:   req.HomeLatitude,
+				HomeLongitude:  req.HomeLongitude,
 				FullName:       updatedUser.FullName,
 			}
 			logger.Info("User profile created successfully",
@@ -119,6 +136,8 @@ func (s *UserService) SaveProfile(ctx context.Context, userID uint, req dto.Save
 	profile.ProfilePicture = req.ProfilePicture
 	profile.Birthday = req.Birthday
 	profile.Gender = req.Gender
+	profile.HomeLatitude = req.HomeLatitude
+	profile.HomeLongitude = req.HomeLongitude
 
 	if _, err := s.userProfileRepo.UpdateTX(ctx, tx, profile); err != nil {
 		tx.Rollback()
@@ -135,6 +154,8 @@ func (s *UserService) SaveProfile(ctx context.Context, userID uint, req dto.Save
 		ProfilePicture: profile.ProfilePicture,
 		Birthday:       profile.Birthday,
 		Gender:         profile.Gender,
+		HomeLatitude:   profile.HomeLatitude,
+		HomeLongitude:  profile.HomeLongitude,
 		FullName:       updatedUser.FullName,
 		Username:       updatedUser.Username,
 	}
@@ -233,6 +254,7 @@ func (s *UserService) GetProfileByUsername(ctx context.Context, username string)
 		Birthday:       user.Profile.Birthday,
 		Gender:         user.Profile.Gender,
 		Email:          user.Email,
+		ReputationScore: user.ReputationScore,
 	}, nil
 }
 
@@ -277,9 +299,14 @@ func (s *UserService) GetProfile(ctx context.Context, userID uint) (*dto.GetProf
 		Birthday:       user.Profile.Birthday,
 		Gender:         user.Profile.Gender,
 		Email:          user.Email,
+		HomeLatitude:   user.Profile.HomeLatitude,
+		HomeLongitude:  user.Profile.HomeLongitude,
+		ReputationScore: user.ReputationScore,
 		IsCompleteProfile: isCompleteProfile,
 		MissingFields:     missingFields,
 		IsDefaultUsername: user.IsDefaultUsername,
+		Language:          user.Language,
+		TwoFactorEnabled:  user.TwoFactorEnabled,
 	}, nil
 }
 
@@ -306,10 +333,192 @@ func (s *UserService) SaveSecurity(ctx context.Context, userID uint, req dto.Sav
 		return apperror.New(500, "PASSWORD_HASH_FAILED", "Gagal mengenkripsi kata sandi", "", nil)
 	}
 
+	var revokedSessions []model.UserSession
+	if req.RevokeAllSessions {
+		revokedSessions, err = s.userSessionRepo.GetActiveByUserID(ctx, userID, time.Now().Unix())
+		if err != nil {
+			return apperror.New(500, "USER_SESSION_FETCH_FAILED", "Gagal mengambil sesi user", err.Error(), nil)
+		}
+	}
+
+	// The password and the session revocation commit together, so a failed
+	// revocation never leaves the new password in place with old sessions alive.
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return apperror.New(500, "TRANSACTION_START_FAILED", "gagal memulai transaksi", tx.Error.Error(), nil)
+	}
+
 	user.Password = &hashedPassword
-	if err := s.userRepo.Save(ctx, user); err != nil {
+	if _, err := s.userRepo.UpdateTX(ctx, tx, user); err != nil {
+		tx.Rollback()
 		return apperror.New(500, "PASSWORD_UPDATE_FAILED", "Gagal memperbarui kata sandi", err.Error(), nil)
 	}
+	if err := s.deactivateSessionsTX(ctx, tx, revokedSessions); err != nil {
+		tx.Rollback()
+		return err
+	}
 
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal commit transaksi", err.Error(), nil)
+	}
+
+	s.clearRevokedSessions(ctx, userID, revokedSessions)
 	return nil
-}
\ No newline at end of file
+}
+
+// UpdateLanguage saves the user's preferred language. Access tokens carry the
+// language, so it applies to API responses from the next token refresh while
+// emails and notifications use it right away.
+func (s *UserService) UpdateLanguage(ctx context.Context, userID uint, req dto.UpdateLanguageRequest) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.New(404, "USER_NOT_FOUND", "pengguna tidak ditemukan", "", nil)
+		}
+		return apperror.New(500, "USER_FETCH_FAILED", "gagal mengambil data pengguna", err.Error(), nil)
+	}
+
+	language := string(i18n.Normalize(req.Language))
+	user.Language = &language
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return apperror.New(500, "LANGUAGE_UPDATE_FAILED", "gagal memperbarui bahasa pengguna", err.Error(), nil)
+	}
+	return nil
+}
+
+func (s *UserService) GetSessions(ctx context.Context, userID, currentSessionID uint) (*dto.GetUserSessionsResponse, error) {
+	userSessions, err := s.userSessionRepo.GetActiveByUserID(ctx, userID, time.Now().Unix())
+	if err != nil {
+		return nil, apperror.New(500, "USER_SESSION_FETCH_FAILED", "Gagal mengambil sesi user", err.Error(), nil)
+	}
+
+	sessions := make([]dto.UserSessionResponse, 0, len(userSessions))
+	for _, userSession := range userSessions {
+		device := useragentutils.Parse(userSession.UserAgent)
+		sessions = append(sessions, dto.UserSessionResponse{
+			SessionID:      userSession.ID,
+			Browser:        device.Browser,
+			BrowserVersion: device.BrowserVersion,
+			OS:             device.OS,
+			OSVersion:      device.OSVersion,
+			DeviceType:     device.DeviceType,
+			IPAddress:      userSession.IPAddress,
+			CreatedAt:      userSession.CreatedAt,
+			ExpiresAt:      userSession.ExpiresAt,
+			IsCurrent:      userSession.ID == currentSessionID,
+		})
+	}
+	return &dto.GetUserSessionsResponse{Sessions: sessions}, nil
+}
+
+// RevokeSession signs another device out. The current session is ended with
+// logout instead, which also clears the caller's cookies.
+func (s *UserService) RevokeSession(ctx context.Context, userID, currentSessionID, sessionID uint) error {
+	if sessionID == currentSessionID {
+		return apperror.New(400, "CANNOT_REVOKE_CURRENT_SESSION", "Gunakan logout untuk mengakhiri sesi saat ini", "", nil)
+	}
+
+	userSession, err := s.userSessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.New(404, "USER_SESSION_NOT_FOUND", "Sesi user tidak ditemukan", err.Error(), nil)
+		}
+		return apperror.New(500, "USER_SESSION_FETCH_FAILED", "Gagal mengambil sesi user", err.Error(), nil)
+	}
+	if userSession.UserID != userID || !userSession.IsActive {
+		return apperror.New(404, "USER_SESSION_NOT_FOUND", "Sesi user tidak ditemukan", "", nil)
+	}
+
+	return s.revokeSessions(ctx, userID, []model.UserSession{*userSession})
+}
+
+func (s *UserService) RevokeOtherSessions(ctx context.Context, userID, currentSessionID uint) (*dto.RevokeUserSessionsResponse, error) {
+	userSessions, err := s.userSessionRepo.GetActiveByUserID(ctx, userID, time.Now().Unix())
+	if err != nil {
+		return nil, apperror.New(500, "USER_SESSION_FETCH_FAILED", "Gagal mengambil sesi user", err.Error(), nil)
+	}
+
+	otherSessions := make([]model.UserSession, 0, len(userSessions))
+	for _, userSession := range userSessions {
+		if userSession.ID != currentSessionID {
+			otherSessions = append(otherSessions, userSession)
+		}
+	}
+
+	if err := s.revokeSessions(ctx, userID, otherSessions); err != nil {
+		return nil, err
+	}
+	return &dto.RevokeUserSessionsResponse{RevokedCount: len(otherSessions)}, nil
+}
+
+// revokeSessions deactivates the session rows, as logout does, and then
+// clears the Redis keys that ValidateAccessToken and token refresh check
+// first, so revoked tokens stop working on the next request.
+func (s *UserService) revokeSessions(ctx context.Context, userID uint, userSessions []model.UserSession) error {
+	if len(userSessions) == 0 {
+		return nil
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return apperror.New(500, "TRANSACTION_START_FAILED", "gagal memulai transaksi", tx.Error.Error(), nil)
+	}
+
+	if err := s.deactivateSessionsTX(ctx, tx, userSessions); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return apperror.New(500, "TRANSACTION_COMMIT_FAILED", "Gagal commit transaksi", err.Error(), nil)
+	}
+
+	s.clearRevokedSessions(ctx, userID, userSessions)
+	return nil
+}
+
+func (s *UserService) deactivateSessionsTX(ctx context.Context, tx *gorm.DB, userSessions []model.UserSession) error {
+	for i := range userSessions {
+		userSessions[i].IsActive = false
+		if err := s.userSessionRepo.UpdateTX(ctx, tx, &userSessions[i]); err != nil {
+			return apperror.New(500, "USER_SESSION_UPDATE_FAILED", "Gagal memperbarui sesi user", err.Error(), nil)
+		}
+	}
+	return nil
+}
+
+// clearRevokedSessions runs after the revocation commits. It drops the cached
+// session keys and tells the user's open realtime streams which sessions
+// ended so those streams close right away.
+func (s *UserService) clearRevokedSessions(ctx context.Context, userID uint, userSessions []model.UserSession) {
+	if len(userSessions) == 0 {
+		return
+	}
+
+	userSessionKey := fmt.Sprintf("user_session:%d", userID)
+	sessionIDs := make([]uint, 0, len(userSessions))
+	for _, userSession := range userSessions {
+		if err := s.cacheRepo.Del(ctx, fmt.Sprintf("refresh_token:%s", userSession.RefreshTokenID)); err != nil {
+			logger.Warn("Failed to delete refresh token from Redis", zap.Uint("session_id", userSession.ID), zap.Error(err))
+		}
+		if err := s.cacheRepo.Del(ctx, fmt.Sprintf("session:%d", userSession.ID)); err != nil {
+			logger.Warn("Failed to delete session data from Redis", zap.Uint("session_id", userSession.ID), zap.Error(err))
+		}
+		if err := s.cacheRepo.SRem(ctx, userSessionKey, userSession.ID); err != nil {
+			logger.Warn("Failed to remove user session ID from Redis set", zap.Uint("session_id", userSession.ID), zap.Error(err))
+		}
+		sessionIDs = append(sessionIDs, userSession.ID)
+	}
+
+	if err := s.realtimePub.Publish(ctx, model.RealtimeScopeUser, userID, model.RealtimeSessionRevoked, realtimeDTO.SessionRevokedEventData{SessionIDs: sessionIDs}); err != nil {
+		logger.Warn("Failed to publish session revocation", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	logger.Info("User sessions revoked",
+		zap.String("request_id", contextutils.GetRequestID(ctx)),
+		zap.Uint("user_id", userID),
+		zap.Int("count", len(userSessions)),
+	)
+}
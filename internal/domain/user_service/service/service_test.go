@@ -3,10 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	realtimeDTO "pingspot/internal/domain/realtime_service/dto"
 	"pingspot/internal/domain/user_service/dto"
+	"pingspot/internal/mocks"
+	realtimeMocks "pingspot/internal/mocks/realtime"
 	userMocks "pingspot/internal/mocks/user"
 	"pingspot/internal/model"
 	mainutils "pingspot/pkg/utils/main_util"
+	tokenutils "pingspot/pkg/utils/token_util"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,7 +37,7 @@ func TestNewUserService(t *testing.T) {
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
 
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		assert.NotNil(t, service)
 		assert.Equal(t, mockUserRepo, service.userRepo)
@@ -45,7 +50,7 @@ func TestUserService_GetProfile(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -54,6 +59,7 @@ func TestUserService_GetProfile(t *testing.T) {
 			FullName: "John Doe",
 			Email:    "john@example.com",
 			Username: "johndoe",
+			ReputationScore: 42,
 			Profile: model.UserProfile{
 				Bio:            mainutils.StrPtrOrNil("test_bio"),
 				ProfilePicture: mainutils.StrPtrOrNil("test_picture.jpg"),
@@ -73,6 +79,7 @@ func TestUserService_GetProfile(t *testing.T) {
 		assert.Equal(t, "john@example.com", result.Email)
 		assert.Equal(t, "johndoe", result.Username)
 		assert.Equal(t, mainutils.StrPtrOrNil("test_bio"), result.Bio)
+		assert.Equal(t, int64(42), result.ReputationScore)
 		mockUserRepo.AssertExpectations(t)
 	})
 
@@ -80,7 +87,7 @@ func TestUserService_GetProfile(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		userID := uint(999)
@@ -99,7 +106,7 @@ func TestUserService_GetProfile(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -120,7 +127,7 @@ func TestUserService_GetProfileByUsername(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		username := "johndoe"
@@ -129,6 +136,7 @@ func TestUserService_GetProfileByUsername(t *testing.T) {
 			FullName: "John Doe",
 			Email:    "john@example.com",
 			Username: username,
+			ReputationScore: 15,
 			Profile: model.UserProfile{
 				Bio:            mainutils.StrPtrOrNil("test_bio"),
 				ProfilePicture: mainutils.StrPtrOrNil("test_picture.jpg"),
@@ -144,6 +152,7 @@ func TestUserService_GetProfileByUsername(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, username, result.Username)
 		assert.Equal(t, "John Doe", result.FullName)
+		assert.Equal(t, int64(15), result.ReputationScore)
 		mockUserRepo.AssertExpectations(t)
 	})
 
@@ -151,7 +160,7 @@ func TestUserService_GetProfileByUsername(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		username := "nonexistent"
@@ -171,7 +180,7 @@ func TestUserService_SaveSecurity(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -203,7 +212,7 @@ func TestUserService_SaveSecurity(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		userID := uint(999)
@@ -225,7 +234,7 @@ func TestUserService_SaveSecurity(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -257,7 +266,7 @@ func TestUserService_SaveProfile(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		userID := uint(1)
@@ -306,7 +315,7 @@ func TestUserService_SaveProfile(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 		ctx := context.Background()
 		userID := uint(1)
 		req := dto.SaveUserProfileRequest{
@@ -337,7 +346,7 @@ func TestUserService_SaveProfile(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 		ctx := context.Background()
 		userID := uint(1)
 		req := dto.SaveUserProfileRequest{
@@ -375,7 +384,7 @@ func TestUserService_SaveProfile(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 		ctx := context.Background()
 		userID := uint(1)
 		req := dto.SaveUserProfileRequest{
@@ -404,7 +413,7 @@ func TestUserService_GetUserStatistics(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 
 		ctx := context.Background()
 		expectedStats := dto.GetUserStatisticsResponse{
@@ -449,7 +458,7 @@ func TestUserService_GetUserStatistics(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 		ctx := context.Background()
 
 		mockUserRepo.On("GetUsersCount", ctx).Return(int64(0), errors.New("database error"))
@@ -465,7 +474,7 @@ func TestUserService_GetUserStatistics(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 		ctx := context.Background()
 		mockUserRepo.On("GetUsersCount", ctx).Return(int64(100), nil)
 		mockUserRepo.On("GetByUserGenderCount", ctx).Return(nil, errors.New("database error"))
@@ -481,7 +490,7 @@ func TestUserService_GetUserStatistics(t *testing.T) {
 		mockUserRepo := new(userMocks.MockUserRepository)
 		mockProfileRepo := new(userMocks.MockUserProfileRepository)
 		db := setupTestDB(t)
-		service := NewUserService(db, mockUserRepo, mockProfileRepo)
+		service := NewUserService(db, mockUserRepo, mockProfileRepo, nil, nil, nil)
 		ctx := context.Background()
 		mockUserRepo.On("GetUsersCount", ctx).Return(int64(100), nil)
 		mockUserRepo.On("GetByUserGenderCount", ctx).Return(map[string]int64{
@@ -497,3 +506,148 @@ func TestUserService_GetUserStatistics(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserService_Sessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uint(1)
+	sessions := []model.UserSession{
+		{ID: 10, UserID: userID, RefreshTokenID: "rt-10", IPAddress: "10.0.0.1", UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", IsActive: true},
+		{ID: 11, UserID: userID, RefreshTokenID: "rt-11", IPAddress: "10.0.0.2", UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1", IsActive: true},
+	}
+
+	expectRevoked := func(sessionRepo *userMocks.MockUserSessionRepository, cacheRepo *mocks.MockCacheRepository, session model.UserSession) {
+		sessionRepo.On("UpdateTX", ctx, mock.Anything, mock.MatchedBy(func(userSession *model.UserSession) bool {
+			return userSession.ID == session.ID && !userSession.IsActive
+		})).Return(nil).Once()
+		cacheRepo.On("Del", ctx, "refresh_token:"+session.RefreshTokenID).Return(nil).Once()
+		cacheRepo.On("Del", ctx, fmt.Sprintf("session:%d", session.ID)).Return(nil).Once()
+		cacheRepo.On("SRem", ctx, "user_session:1", []any{session.ID}).Return(nil).Once()
+	}
+	expectPublished := func(publisher *realtimeMocks.MockPublisher, sessionIDs ...uint) {
+		publisher.On("Publish", ctx, model.RealtimeScopeUser, userID, model.RealtimeSessionRevoked, realtimeDTO.SessionRevokedEventData{SessionIDs: sessionIDs}).Return(nil).Once()
+	}
+	activeSessions := func() []model.UserSession {
+		return append([]model.UserSession(nil), sessions...)
+	}
+
+	t.Run("should list active sessions with device info", func(t *testing.T) {
+		sessionRepo := new(userMocks.MockUserSessionRepository)
+		service := NewUserService(setupTestDB(t), new(userMocks.MockUserRepository), new(userMocks.MockUserProfileRepository), sessionRepo, new(mocks.MockCacheRepository), new(realtimeMocks.MockPublisher))
+
+		sessionRepo.On("GetActiveByUserID", ctx, userID, mock.AnythingOfType("int64")).Return(sessions, nil)
+
+		result, err := service.GetSessions(ctx, userID, 11)
+
+		require.NoError(t, err)
+		require.Len(t, result.Sessions, 2)
+		assert.Equal(t, "Chrome", result.Sessions[0].Browser)
+		assert.Equal(t, "desktop", result.Sessions[0].DeviceType)
+		assert.False(t, result.Sessions[0].IsCurrent)
+		assert.Equal(t, "iOS", result.Sessions[1].OS)
+		assert.Equal(t, "mobile", result.Sessions[1].DeviceType)
+		assert.True(t, result.Sessions[1].IsCurrent)
+	})
+
+	t.Run("should revoke another session and clear its cache keys", func(t *testing.T) {
+		sessionRepo := new(userMocks.MockUserSessionRepository)
+		cacheRepo := new(mocks.MockCacheRepository)
+		publisher := new(realtimeMocks.MockPublisher)
+		service := NewUserService(setupTestDB(t), new(userMocks.MockUserRepository), new(userMocks.MockUserProfileRepository), sessionRepo, cacheRepo, publisher)
+
+		session := sessions[0]
+		sessionRepo.On("GetByID", ctx, uint(10)).Return(&session, nil)
+		expectRevoked(sessionRepo, cacheRepo, sessions[0])
+		expectPublished(publisher, 10)
+
+		err := service.RevokeSession(ctx, userID, 11, 10)
+
+		require.NoError(t, err)
+		sessionRepo.AssertExpectations(t)
+		cacheRepo.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("should not revoke the current session or another user's session", func(t *testing.T) {
+		sessionRepo := new(userMocks.MockUserSessionRepository)
+		service := NewUserService(setupTestDB(t), new(userMocks.MockUserRepository), new(userMocks.MockUserProfileRepository), sessionRepo, new(mocks.MockCacheRepository), new(realtimeMocks.MockPublisher))
+
+		err := service.RevokeSession(ctx, userID, 11, 11)
+		assert.Error(t, err)
+
+		sessionRepo.On("GetByID", ctx, uint(20)).Return(&model.UserSession{ID: 20, UserID: 2}, nil)
+		err = service.RevokeSession(ctx, userID, 11, 20)
+		assert.Error(t, err)
+		sessionRepo.AssertNotCalled(t, "UpdateTX", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should revoke all other sessions", func(t *testing.T) {
+		sessionRepo := new(userMocks.MockUserSessionRepository)
+		cacheRepo := new(mocks.MockCacheRepository)
+		publisher := new(realtimeMocks.MockPublisher)
+		service := NewUserService(setupTestDB(t), new(userMocks.MockUserRepository), new(userMocks.MockUserProfileRepository), sessionRepo, cacheRepo, publisher)
+
+		sessionRepo.On("GetActiveByUserID", ctx, userID, mock.AnythingOfType("int64")).Return(activeSessions(), nil)
+		expectRevoked(sessionRepo, cacheRepo, sessions[0])
+		expectPublished(publisher, 10)
+
+		result, err := service.RevokeOtherSessions(ctx, userID, 11)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.RevokedCount)
+		sessionRepo.AssertExpectations(t)
+		cacheRepo.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("should revoke every session after password change when requested", func(t *testing.T) {
+		userRepo := new(userMocks.MockUserRepository)
+		sessionRepo := new(userMocks.MockUserSessionRepository)
+		cacheRepo := new(mocks.MockCacheRepository)
+		publisher := new(realtimeMocks.MockPublisher)
+		service := NewUserService(setupTestDB(t), userRepo, new(userMocks.MockUserProfileRepository), sessionRepo, cacheRepo, publisher)
+
+		hashedPassword, err := tokenutils.HashString("oldPassword123")
+		require.NoError(t, err)
+		userRepo.On("GetByID", ctx, userID).Return(&model.User{ID: userID, Password: &hashedPassword}, nil)
+		userRepo.On("UpdateTX", ctx, mock.Anything, mock.AnythingOfType("*model.User")).Return(&model.User{}, nil)
+		sessionRepo.On("GetActiveByUserID", ctx, userID, mock.AnythingOfType("int64")).Return(activeSessions(), nil)
+		expectRevoked(sessionRepo, cacheRepo, sessions[0])
+		expectRevoked(sessionRepo, cacheRepo, sessions[1])
+		expectPublished(publisher, 10, 11)
+
+		err = service.SaveSecurity(ctx, userID, dto.SaveUserSecurityRequest{
+			CurrentPassword:   "oldPassword123",
+			NewPassword:       "newPassword456",
+			RevokeAllSessions: true,
+		})
+
+		require.NoError(t, err)
+		sessionRepo.AssertExpectations(t)
+		cacheRepo.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("should keep the old password when session revocation fails", func(t *testing.T) {
+		userRepo := new(userMocks.MockUserRepository)
+		sessionRepo := new(userMocks.MockUserSessionRepository)
+		cacheRepo := new(mocks.MockCacheRepository)
+		db := setupTestDB(t)
+		service := NewUserService(db, userRepo, new(userMocks.MockUserProfileRepository), sessionRepo, cacheRepo, new(realtimeMocks.MockPublisher))
+
+		hashedPassword, err := tokenutils.HashString("oldPassword123")
+		require.NoError(t, err)
+		userRepo.On("GetByID", ctx, userID).Return(&model.User{ID: userID, Password: &hashedPassword}, nil)
+		userRepo.On("UpdateTX", ctx, mock.Anything, mock.AnythingOfType("*model.User")).Return(&model.User{}, nil)
+		sessionRepo.On("GetActiveByUserID", ctx, userID, mock.AnythingOfType("int64")).Return(activeSessions(), nil)
+		sessionRepo.On("UpdateTX", ctx, mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+		err = service.SaveSecurity(ctx, userID, dto.SaveUserSecurityRequest{
+			CurrentPassword:   "oldPassword123",
+			NewPassword:       "newPassword456",
+			RevokeAllSessions: true,
+		})
+
+		assert.Error(t, err)
+		cacheRepo.AssertNotCalled(t, "Del", mock.Anything, mock.Anything)
+	})
+}
@@ -129,4 +129,23 @@ func FormatGetFollowDataValidationErrors(err error) map[string]string {
 		}
 	}
 	return errors
-}
\ No newline at end of file
+}
+
+func FormatUpdateLanguageValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch e.Field() {
+		case "Language":
+			if e.Tag() == "required" {
+				errors["language"] = "Bahasa wajib diisi"
+			}
+			if e.Tag() == "oneof" {
+				errors["language"] = "Bahasa harus salah satu dari id atau en"
+			}
+		}
+	}
+	return errors
+}
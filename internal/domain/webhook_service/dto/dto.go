@@ -0,0 +1,81 @@
+package dto
+
+import "encoding/json"
+
+type Webhook struct {
+	ID        uint     `json:"id"`
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	IsActive  bool     `json:"isActive"`
+	CreatedAt int64    `json:"createdAt"`
+	UpdatedAt int64    `json:"updatedAt"`
+}
+
+type WebhookDelivery struct {
+	ID             uint            `json:"id"`
+	WebhookID      uint            `json:"webhookID"`
+	EventID        string          `json:"eventID"`
+	EventType      string          `json:"eventType"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus *int            `json:"responseStatus"`
+	ResponseBody   *string         `json:"responseBody"`
+	LastError      *string         `json:"lastError"`
+	NextRetryAt    *int64          `json:"nextRetryAt"`
+	DeliveredAt    *int64          `json:"deliveredAt"`
+	ReplayOfID     *uint           `json:"replayOfID"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      int64           `json:"createdAt"`
+	UpdatedAt      int64           `json:"updatedAt"`
+}
+
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt int64           `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type ReportEventData struct {
+	ReportID     uint    `json:"reportID"`
+	ReportTitle  string  `json:"reportTitle"`
+	ReportType   string  `json:"reportType"`
+	ReportStatus string  `json:"reportStatus"`
+	IsAnonymous  bool    `json:"isAnonymous"`
+	UserID       *uint   `json:"userID"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	CreatedAt    int64   `json:"createdAt"`
+}
+
+type ReportStatusChangedEventData struct {
+	ReportID       uint   `json:"reportID"`
+	PreviousStatus string `json:"previousStatus"`
+	ReportStatus   string `json:"reportStatus"`
+	UpdatedBy      string `json:"updatedBy"`
+	UpdatedAt      int64  `json:"updatedAt"`
+}
+
+type ReportProgressEventData struct {
+	ReportID  uint   `json:"reportID"`
+	Status    string `json:"status"`
+	Notes     string `json:"notes"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+type CommentEventData struct {
+	CommentID       string  `json:"commentID"`
+	ReportID        uint    `json:"reportID"`
+	UserID          *uint   `json:"userID"`
+	ParentCommentID *string `json:"parentCommentID"`
+	Content         *string `json:"content"`
+	CreatedAt       int64   `json:"createdAt"`
+}
+
+type FollowEventData struct {
+	FollowerUserID uint   `json:"followerUserID"`
+	FollowingID    uint   `json:"followingID"`
+	FollowingType  string `json:"followingType"`
+	CreatedAt      int64  `json:"createdAt"`
+}
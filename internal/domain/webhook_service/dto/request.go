@@ -0,0 +1,14 @@
+package dto
+
+type CreateWebhookRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	URL    string   `json:"url" validate:"required,url,max=500"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=report.created report.status_changed report.progress_added comment.created follow.created"`
+}
+
+type UpdateWebhookRequest struct {
+	Name     string   `json:"name" validate:"required,max=100"`
+	URL      string   `json:"url" validate:"required,url,max=500"`
+	Events   []string `json:"events" validate:"required,min=1,dive,oneof=report.created report.status_changed report.progress_added comment.created follow.created"`
+	IsActive *bool    `json:"isActive"`
+}
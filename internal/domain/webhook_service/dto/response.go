@@ -0,0 +1,15 @@
+package dto
+
+type CreateWebhookResponse struct {
+	Webhook Webhook `json:"webhook"`
+	Secret  string  `json:"secret"`
+}
+
+type GetWebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	NextCursor *uint             `json:"nextCursor"`
+}
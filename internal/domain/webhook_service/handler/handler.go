@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"pingspot/internal/domain/webhook_service/dto"
+	"pingspot/internal/domain/webhook_service/service"
+	"pingspot/internal/domain/webhook_service/validation"
+	apperror "pingspot/pkg/app_error"
+	"pingspot/pkg/logger"
+	mainutils "pingspot/pkg/utils/main_util"
+	response "pingspot/pkg/utils/response_util"
+	tokenutils "pingspot/pkg/utils/token_util"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) CreateWebhookHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	var req dto.CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.URL = strings.TrimSpace(req.URL)
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatSaveWebhookValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(ctx, userID, req)
+	if err != nil {
+		logger.Error("Failed to create webhook", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal membuat webhook", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 201, "Berhasil membuat webhook", "data", webhook)
+}
+
+func (h *WebhookHandler) GetWebhooksHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	webhooks, err := h.webhookService.GetWebhooks(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get webhooks", zap.Uint("user_id", userID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan webhook", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan webhook", "data", webhooks)
+}
+
+func (h *WebhookHandler) UpdateWebhookHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	webhookID, err := c.ParamsInt("webhookID")
+	if err != nil || webhookID <= 0 {
+		return response.ResponseError(c, 400, "ID webhook tidak valid", "", "ID webhook harus berupa angka")
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error("Failed to parse request body", zap.Error(err))
+		return response.ResponseError(c, 400, "Format body request tidak valid", "", err.Error())
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.URL = strings.TrimSpace(req.URL)
+	if err := validation.Validate.Struct(req); err != nil {
+		errors := validation.FormatSaveWebhookValidationErrors(err)
+		logger.Error("Validation failed", zap.Error(err))
+		return response.ResponseError(c, 400, "Validasi gagal", "errors", errors)
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(ctx, userID, uint(webhookID), req)
+	if err != nil {
+		logger.Error("Failed to update webhook", zap.Int("webhook_id", webhookID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal memperbarui webhook", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil memperbarui webhook", "data", webhook)
+}
+
+func (h *WebhookHandler) DeleteWebhookHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	webhookID, err := c.ParamsInt("webhookID")
+	if err != nil || webhookID <= 0 {
+		return response.ResponseError(c, 400, "ID webhook tidak valid", "", "ID webhook harus berupa angka")
+	}
+
+	if err := h.webhookService.DeleteWebhook(ctx, userID, uint(webhookID)); err != nil {
+		logger.Error("Failed to delete webhook", zap.Int("webhook_id", webhookID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal menghapus webhook", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil menghapus webhook", "", nil)
+}
+
+func (h *WebhookHandler) GetWebhookDeliveriesHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	webhookID, err := c.ParamsInt("webhookID")
+	if err != nil || webhookID <= 0 {
+		return response.ResponseError(c, 400, "ID webhook tidak valid", "", "ID webhook harus berupa angka")
+	}
+
+	cursorID := c.Query("cursorID")
+	cursorIDUint, err := mainutils.StringToUint(cursorID)
+	if err != nil && cursorID != "" {
+		logger.Error("Invalid cursorID format", zap.String("cursorID", cursorID), zap.Error(err))
+		return response.ResponseError(c, 400, "Format cursorID tidak valid", "", "cursorID harus berupa angka")
+	}
+
+	deliveries, err := h.webhookService.GetWebhookDeliveries(ctx, userID, uint(webhookID), cursorIDUint, strings.ToUpper(c.Query("status")))
+	if err != nil {
+		logger.Error("Failed to get webhook deliveries", zap.Int("webhook_id", webhookID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mendapatkan riwayat pengiriman webhook", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 200, "Berhasil mendapatkan riwayat pengiriman webhook", "data", deliveries)
+}
+
+func (h *WebhookHandler) ReplayWebhookDeliveryHandler(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	claims, err := tokenutils.GetJWTClaims(c)
+	if err != nil {
+		logger.Error("Failed to get JWT claims", zap.Error(err))
+		return response.ResponseError(c, 401, "Token tidak valid", "", "Anda harus login terlebih dahulu")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	webhookID, err := c.ParamsInt("webhookID")
+	if err != nil || webhookID <= 0 {
+		return response.ResponseError(c, 400, "ID webhook tidak valid", "", "ID webhook harus berupa angka")
+	}
+	deliveryID, err := c.ParamsInt("deliveryID")
+	if err != nil || deliveryID <= 0 {
+		return response.ResponseError(c, 400, "ID pengiriman tidak valid", "", "ID pengiriman harus berupa angka")
+	}
+
+	delivery, err := h.webhookService.ReplayWebhookDelivery(ctx, userID, uint(webhookID), uint(deliveryID))
+	if err != nil {
+		logger.Error("Failed to replay webhook delivery", zap.Int("delivery_id", deliveryID), zap.Error(err))
+		if appErr, ok := err.(*apperror.AppError); ok {
+			return response.ResponseError(c, appErr.StatusCode, appErr.Message, "error_code", appErr.Code)
+		}
+		return response.ResponseError(c, 500, "Gagal mengirim ulang webhook", "", err.Error())
+	}
+	return response.ResponseSuccess(c, 202, "Pengiriman ulang webhook dijadwalkan", "data", delivery)
+}
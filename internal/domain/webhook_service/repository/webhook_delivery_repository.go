@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *model.WebhookDelivery) error
+	CreateForEvent(ctx context.Context, delivery *model.WebhookDelivery) (bool, error)
+	Update(ctx context.Context, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error)
+	GetByID(ctx context.Context, deliveryID uint) (*model.WebhookDelivery, error)
+	GetByIDAndWebhookID(ctx context.Context, deliveryID, webhookID uint) (*model.WebhookDelivery, error)
+	GetForEvent(ctx context.Context, webhookID uint, eventID string) (*model.WebhookDelivery, error)
+	GetByWebhookIDPaginated(ctx context.Context, webhookID, cursorID uint, status string, limit int) ([]model.WebhookDelivery, error)
+}
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
+
+// CreateForEvent reports false when the webhook already has a delivery for the
+// event, so a republished outbox event does not deliver it twice. Replays are
+// created with Create and are not affected.
+func (r *webhookDeliveryRepository) CreateForEvent(ctx context.Context, delivery *model.WebhookDelivery) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "webhook_id"}, {Name: "event_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "replay_of_id IS NULL"}}},
+			DoNothing:   true,
+		}).
+		Create(delivery)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error) {
+	if err := r.db.WithContext(ctx).Omit("Webhook").Save(delivery).Error; err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, deliveryID uint) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Preload("Webhook").
+		First(&delivery, deliveryID).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepository) GetByIDAndWebhookID(ctx context.Context, deliveryID, webhookID uint) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND webhook_id = ?", deliveryID, webhookID).
+		First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetForEvent returns the original delivery of an event to a webhook, the one
+// CreateForEvent created, ignoring replays.
+func (r *webhookDeliveryRepository) GetForEvent(ctx context.Context, webhookID uint, eventID string) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Where("webhook_id = ? AND event_id = ? AND replay_of_id IS NULL", webhookID, eventID).
+		First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepository) GetByWebhookIDPaginated(ctx context.Context, webhookID, cursorID uint, status string, limit int) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	query := r.db.WithContext(ctx).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if cursorID != 0 {
+		query = query.Where("id < ?", cursorID)
+	}
+	if err := query.Order("id DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *model.Webhook) error
+	Update(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	Delete(ctx context.Context, webhookID uint) error
+	GetByIDAndUserID(ctx context.Context, webhookID, userID uint) (*model.Webhook, error)
+	GetByUserID(ctx context.Context, userID uint) ([]model.Webhook, error)
+	CountByUserID(ctx context.Context, userID uint) (int64, error)
+	GetActiveByEvent(ctx context.Context, eventType model.WebhookEventType) ([]model.Webhook, error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *model.Webhook) error {
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	if err := r.db.WithContext(ctx).Save(webhook).Error; err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, webhookID uint) error {
+	return r.db.WithContext(ctx).Delete(&model.Webhook{}, webhookID).Error
+}
+
+func (r *webhookRepository) GetByIDAndUserID(ctx context.Context, webhookID, userID uint) (*model.Webhook, error) {
+	var webhook model.Webhook
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", webhookID, userID).
+		First(&webhook).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) CountByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.Webhook{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *webhookRepository) GetActiveByEvent(ctx context.Context, eventType model.WebhookEventType) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ? AND ',' || events || ',' LIKE ?", true, "%,"+string(eventType)+",%").
+		Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
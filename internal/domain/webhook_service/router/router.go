@@ -0,0 +1,67 @@
+package router
+
+import (
+	"fmt"
+	"pingspot/internal/domain/task_service/service"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/domain/webhook_service/handler"
+	webhookRepository "pingspot/internal/domain/webhook_service/repository"
+	webhookService "pingspot/internal/domain/webhook_service/service"
+	"pingspot/internal/infrastructure/database"
+	"pingspot/internal/middleware"
+	env "pingspot/pkg/utils/env_util"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hibiken/asynq"
+)
+
+func RegisterWebhookRoutes(app *fiber.App) {
+	db := database.GetPostgresDB()
+	webhookRepo := webhookRepository.NewWebhookRepository(db)
+	webhookDeliveryRepo := webhookRepository.NewWebhookDeliveryRepository(db)
+	userRepo := userRepository.NewUserRepository(db)
+
+	redisAddress := fmt.Sprintf("%s:%s", env.RedisHost(), env.RedisPort())
+	client := asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddress})
+	tasksService := service.NewTaskService(client)
+
+	webhookSvc := webhookService.NewWebhookService(webhookRepo, webhookDeliveryRepo, userRepo, tasksService)
+	webhookHandler := handler.NewWebhookHandler(webhookSvc)
+
+	webhookRoute := app.Group("/pingspot/api/webhook", middleware.ValidateAccessToken())
+	webhookRoute.Post("/",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Hour,
+			MaxRequests: 20,
+			KeyPrefix:   "create_webhook",
+		})),
+		webhookHandler.CreateWebhookHandler,
+	)
+	webhookRoute.Get("/",
+		middleware.TimeoutMiddleware(10*time.Second),
+		webhookHandler.GetWebhooksHandler,
+	)
+	webhookRoute.Put("/:webhookID",
+		middleware.TimeoutMiddleware(10*time.Second),
+		webhookHandler.UpdateWebhookHandler,
+	)
+	webhookRoute.Delete("/:webhookID",
+		middleware.TimeoutMiddleware(10*time.Second),
+		webhookHandler.DeleteWebhookHandler,
+	)
+	webhookRoute.Get("/:webhookID/deliveries",
+		middleware.TimeoutMiddleware(10*time.Second),
+		webhookHandler.GetWebhookDeliveriesHandler,
+	)
+	webhookRoute.Post("/:webhookID/deliveries/:deliveryID/replay",
+		middleware.TimeoutMiddleware(10*time.Second),
+		middleware.UserRateLimiterMiddleware(middleware.NewRateLimiter(middleware.RateLimiterConfig{
+			Window:      1 * time.Minute,
+			MaxRequests: 20,
+			KeyPrefix:   "replay_webhook_delivery",
+		})),
+		webhookHandler.ReplayWebhookDeliveryHandler,
+	)
+}
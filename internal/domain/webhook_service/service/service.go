@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tasksService "pingspot/internal/domain/task_service/service"
+	userRepository "pingspot/internal/domain/user_service/repository"
+	"pingspot/internal/domain/webhook_service/dto"
+	"pingspot/internal/domain/webhook_service/repository"
+	"pingspot/internal/domain/webhook_service/util"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"gorm.io/gorm"
+)
+
+type WebhookService struct {
+	webhookRepo         repository.WebhookRepository
+	webhookDeliveryRepo repository.WebhookDeliveryRepository
+	userRepo            userRepository.UserRepository
+	tasksService        tasksService.TaskService
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, webhookDeliveryRepo repository.WebhookDeliveryRepository, userRepo userRepository.UserRepository, tasksService tasksService.TaskService) *WebhookService {
+	return &WebhookService{
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+		userRepo:            userRepo,
+		tasksService:        tasksService,
+	}
+}
+
+func (s *WebhookService) getStaffUser(ctx context.Context, userID uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "USER_NOT_FOUND", "pengguna tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "USER_FETCH_FAILED", "gagal mengambil data pengguna", err.Error(), nil)
+	}
+	if user.Role != model.UserRoleModerator && user.Role != model.UserRoleAgency {
+		return nil, apperror.New(403, "FORBIDDEN", "anda tidak memiliki akses ke fitur ini", "", nil)
+	}
+	return user, nil
+}
+
+func (s *WebhookService) getOwnedWebhook(ctx context.Context, userID, webhookID uint) (*model.Webhook, error) {
+	if _, err := s.getStaffUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	webhook, err := s.webhookRepo.GetByIDAndUserID(ctx, webhookID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "WEBHOOK_NOT_FOUND", "webhook tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "WEBHOOK_FETCH_FAILED", "gagal mengambil webhook", err.Error(), nil)
+	}
+	return webhook, nil
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, userID uint, req dto.CreateWebhookRequest) (*dto.CreateWebhookResponse, error) {
+	if _, err := s.getStaffUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	if !util.IsValidWebhookURL(req.URL) {
+		return nil, apperror.New(400, "INVALID_WEBHOOK_URL", "URL webhook harus menggunakan http atau https", "", nil)
+	}
+
+	totalWebhooks, err := s.webhookRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.New(500, "WEBHOOK_FETCH_FAILED", "gagal mengambil webhook", err.Error(), nil)
+	}
+	if totalWebhooks >= util.MaxWebhooksPerUser {
+		return nil, apperror.New(400, "WEBHOOK_LIMIT_REACHED", fmt.Sprintf("maksimal %d webhook per pengguna", util.MaxWebhooksPerUser), "", nil)
+	}
+
+	secret, err := util.GenerateSecret()
+	if err != nil {
+		return nil, apperror.New(500, "WEBHOOK_SECRET_GENERATE_FAILED", "gagal membuat secret webhook", err.Error(), nil)
+	}
+
+	webhook := model.Webhook{
+		UserID:   userID,
+		Name:     req.Name,
+		URL:      req.URL,
+		Secret:   secret,
+		Events:   util.JoinEvents(req.Events),
+		IsActive: true,
+	}
+	if err := s.webhookRepo.Create(ctx, &webhook); err != nil {
+		return nil, apperror.New(500, "WEBHOOK_CREATE_FAILED", "gagal membuat webhook", err.Error(), nil)
+	}
+
+	return &dto.CreateWebhookResponse{
+		Webhook: util.ToWebhookDTO(webhook),
+		Secret:  secret,
+	}, nil
+}
+
+func (s *WebhookService) GetWebhooks(ctx context.Context, userID uint) (*dto.GetWebhooksResponse, error) {
+	if _, err := s.getStaffUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhookRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.New(500, "WEBHOOK_FETCH_FAILED", "gagal mengambil webhook", err.Error(), nil)
+	}
+
+	response := dto.GetWebhooksResponse{Webhooks: make([]dto.Webhook, 0, len(webhooks))}
+	for _, webhook := range webhooks {
+		response.Webhooks = append(response.Webhooks, util.ToWebhookDTO(webhook))
+	}
+	return &response, nil
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, userID, webhookID uint, req dto.UpdateWebhookRequest) (*dto.Webhook, error) {
+	webhook, err := s.getOwnedWebhook(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !util.IsValidWebhookURL(req.URL) {
+		return nil, apperror.New(400, "INVALID_WEBHOOK_URL", "URL webhook harus menggunakan http atau https", "", nil)
+	}
+
+	webhook.Name = req.Name
+	webhook.URL = req.URL
+	webhook.Events = util.JoinEvents(req.Events)
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+
+	updatedWebhook, err := s.webhookRepo.Update(ctx, webhook)
+	if err != nil {
+		return nil, apperror.New(500, "WEBHOOK_UPDATE_FAILED", "gagal memperbarui webhook", err.Error(), nil)
+	}
+	result := util.ToWebhookDTO(*updatedWebhook)
+	return &result, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, userID, webhookID uint) error {
+	webhook, err := s.getOwnedWebhook(ctx, userID, webhookID)
+	if err != nil {
+		return err
+	}
+	if err := s.webhookRepo.Delete(ctx, webhook.ID); err != nil {
+		return apperror.New(500, "WEBHOOK_DELETE_FAILED", "gagal menghapus webhook", err.Error(), nil)
+	}
+	return nil
+}
+
+func (s *WebhookService) GetWebhookDeliveries(ctx context.Context, userID, webhookID, cursorID uint, status string) (*dto.GetWebhookDeliveriesResponse, error) {
+	webhook, err := s.getOwnedWebhook(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookDeliveryRepo.GetByWebhookIDPaginated(ctx, webhook.ID, cursorID, status, util.DeliveryPageSize)
+	if err != nil {
+		return nil, apperror.New(500, "WEBHOOK_DELIVERY_FETCH_FAILED", "gagal mengambil riwayat pengiriman webhook", err.Error(), nil)
+	}
+
+	response := dto.GetWebhookDeliveriesResponse{Deliveries: make([]dto.WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, util.ToWebhookDeliveryDTO(delivery))
+	}
+	if len(deliveries) == util.DeliveryPageSize {
+		nextCursor := deliveries[len(deliveries)-1].ID
+		response.NextCursor = &nextCursor
+	}
+	return &response, nil
+}
+
+func (s *WebhookService) ReplayWebhookDelivery(ctx context.Context, userID, webhookID, deliveryID uint) (*dto.WebhookDelivery, error) {
+	webhook, err := s.getOwnedWebhook(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.webhookDeliveryRepo.GetByIDAndWebhookID(ctx, deliveryID, webhook.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(404, "WEBHOOK_DELIVERY_NOT_FOUND", "riwayat pengiriman webhook tidak ditemukan", "", nil)
+		}
+		return nil, apperror.New(500, "WEBHOOK_DELIVERY_FETCH_FAILED", "gagal mengambil riwayat pengiriman webhook", err.Error(), nil)
+	}
+
+	replay := model.WebhookDelivery{
+		WebhookID:  webhook.ID,
+		EventID:    original.EventID,
+		EventType:  original.EventType,
+		Payload:    original.Payload,
+		Status:     model.WebhookDeliveryPending,
+		ReplayOfID: &original.ID,
+	}
+	if err := s.webhookDeliveryRepo.Create(ctx, &replay); err != nil {
+		return nil, apperror.New(500, "WEBHOOK_DELIVERY_CREATE_FAILED", "gagal membuat pengiriman ulang webhook", err.Error(), nil)
+	}
+	if err := s.tasksService.DeliverWebhookTask(replay.ID, util.MaxDeliveryAttempts); err != nil {
+		return nil, apperror.New(500, "WEBHOOK_DELIVERY_TASK_FAILED", "gagal menjadwalkan pengiriman ulang webhook", err.Error(), nil)
+	}
+
+	result := util.ToWebhookDeliveryDTO(replay)
+	return &result, nil
+}
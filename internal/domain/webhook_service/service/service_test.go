@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"pingspot/internal/domain/webhook_service/dto"
+	"pingspot/internal/domain/webhook_service/util"
+	taskMocks "pingspot/internal/mocks/task"
+	userMocks "pingspot/internal/mocks/user"
+	webhookMocks "pingspot/internal/mocks/webhook"
+	"pingspot/internal/model"
+	apperror "pingspot/pkg/app_error"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupMocks() (*webhookMocks.MockWebhookRepository, *webhookMocks.MockWebhookDeliveryRepository, *userMocks.MockUserRepository, *taskMocks.MockTaskService, *WebhookService) {
+	mockWebhookRepo := new(webhookMocks.MockWebhookRepository)
+	mockWebhookDeliveryRepo := new(webhookMocks.MockWebhookDeliveryRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+	mockTaskService := new(taskMocks.MockTaskService)
+	service := NewWebhookService(mockWebhookRepo, mockWebhookDeliveryRepo, mockUserRepo, mockTaskService)
+	return mockWebhookRepo, mockWebhookDeliveryRepo, mockUserRepo, mockTaskService, service
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	req := dto.CreateWebhookRequest{
+		Name:   "CRM Kota",
+		URL:    "https://crm.example.com/hooks",
+		Events: []string{"report.created", "report.created", "comment.created"},
+	}
+
+	t.Run("should create webhook and return secret once", func(t *testing.T) {
+		mockWebhookRepo, _, mockUserRepo, _, service := setupMocks()
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleAgency}, nil)
+		mockWebhookRepo.On("CountByUserID", ctx, uint(1)).Return(int64(0), nil)
+		mockWebhookRepo.On("Create", ctx, mock.AnythingOfType("*model.Webhook")).Return(nil)
+
+		result, err := service.CreateWebhook(ctx, 1, req)
+
+		require.NoError(t, err)
+		stored := mockWebhookRepo.Calls[1].Arguments.Get(1).(*model.Webhook)
+		assert.Equal(t, stored.Secret, result.Secret)
+		assert.Contains(t, result.Secret, util.SecretPrefix)
+		assert.Equal(t, "report.created,comment.created", stored.Events)
+		assert.True(t, stored.IsActive)
+		assert.Equal(t, []string{"report.created", "comment.created"}, result.Webhook.Events)
+	})
+
+	t.Run("should reject non staff user", func(t *testing.T) {
+		mockWebhookRepo, _, mockUserRepo, _, service := setupMocks()
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleUser}, nil)
+
+		result, err := service.CreateWebhook(ctx, 1, req)
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, 403, appErr.StatusCode)
+		mockWebhookRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject when limit is reached", func(t *testing.T) {
+		mockWebhookRepo, _, mockUserRepo, _, service := setupMocks()
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleModerator}, nil)
+		mockWebhookRepo.On("CountByUserID", ctx, uint(1)).Return(int64(util.MaxWebhooksPerUser), nil)
+
+		result, err := service.CreateWebhook(ctx, 1, req)
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, "WEBHOOK_LIMIT_REACHED", appErr.Code)
+	})
+}
+
+func TestWebhookService_UpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	inactive := false
+
+	t.Run("should update events and deactivate webhook", func(t *testing.T) {
+		mockWebhookRepo, _, mockUserRepo, _, service := setupMocks()
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleAgency}, nil)
+		mockWebhookRepo.On("GetByIDAndUserID", ctx, uint(4), uint(1)).Return(&model.Webhook{ID: 4, UserID: 1, Events: "report.created", IsActive: true}, nil)
+		mockWebhookRepo.On("Update", ctx, mock.MatchedBy(func(webhook *model.Webhook) bool {
+			return webhook.Events == "follow.created" && !webhook.IsActive
+		})).Return(&model.Webhook{ID: 4, Events: "follow.created", IsActive: false}, nil)
+
+		result, err := service.UpdateWebhook(ctx, 1, 4, dto.UpdateWebhookRequest{
+			Name:     "CRM",
+			URL:      "https://crm.example.com/hooks",
+			Events:   []string{"follow.created"},
+			IsActive: &inactive,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsActive)
+	})
+
+	t.Run("should return not found for webhook owned by another user", func(t *testing.T) {
+		mockWebhookRepo, _, mockUserRepo, _, service := setupMocks()
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleAgency}, nil)
+		mockWebhookRepo.On("GetByIDAndUserID", ctx, uint(4), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.UpdateWebhook(ctx, 1, 4, dto.UpdateWebhookRequest{URL: "https://crm.example.com/hooks"})
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, "WEBHOOK_NOT_FOUND", appErr.Code)
+	})
+}
+
+func TestWebhookService_GetWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	mockWebhookRepo, mockWebhookDeliveryRepo, mockUserRepo, _, service := setupMocks()
+	mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleAgency}, nil)
+	mockWebhookRepo.On("GetByIDAndUserID", ctx, uint(4), uint(1)).Return(&model.Webhook{ID: 4, UserID: 1}, nil)
+	deliveries := make([]model.WebhookDelivery, util.DeliveryPageSize)
+	for i := range deliveries {
+		deliveries[i] = model.WebhookDelivery{ID: uint(100 - i), WebhookID: 4, Status: model.WebhookDeliveryFailed, Payload: "{}"}
+	}
+	mockWebhookDeliveryRepo.On("GetByWebhookIDPaginated", ctx, uint(4), uint(0), "FAILED", util.DeliveryPageSize).Return(deliveries, nil)
+
+	result, err := service.GetWebhookDeliveries(ctx, 1, 4, 0, "FAILED")
+
+	require.NoError(t, err)
+	require.Len(t, result.Deliveries, util.DeliveryPageSize)
+	require.NotNil(t, result.NextCursor)
+	assert.Equal(t, deliveries[len(deliveries)-1].ID, *result.NextCursor)
+}
+
+func TestWebhookService_ReplayWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create replay delivery and enqueue it", func(t *testing.T) {
+		mockWebhookRepo, mockWebhookDeliveryRepo, mockUserRepo, mockTaskService, service := setupMocks()
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleAgency}, nil)
+		mockWebhookRepo.On("GetByIDAndUserID", ctx, uint(4), uint(1)).Return(&model.Webhook{ID: 4, UserID: 1}, nil)
+		mockWebhookDeliveryRepo.On("GetByIDAndWebhookID", ctx, uint(8), uint(4)).Return(&model.WebhookDelivery{
+			ID:        8,
+			WebhookID: 4,
+			EventID:   "evt_1",
+			EventType: model.WebhookCommentCreated,
+			Payload:   `{"id":"evt_1"}`,
+			Status:    model.WebhookDeliveryFailed,
+			Attempts:  util.MaxDeliveryAttempts,
+		}, nil)
+		mockWebhookDeliveryRepo.On("Create", ctx, mock.AnythingOfType("*model.WebhookDelivery")).Run(func(args mock.Arguments) {
+			args.Get(1).(*model.WebhookDelivery).ID = 9
+		}).Return(nil)
+		mockTaskService.On("DeliverWebhookTask", uint(9), mock.Anything).Return(nil)
+
+		result, err := service.ReplayWebhookDelivery(ctx, 1, 4, 8)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(9), result.ID)
+		assert.Equal(t, "PENDING", result.Status)
+		assert.Equal(t, 0, result.Attempts)
+		assert.Equal(t, "evt_1", result.EventID)
+		require.NotNil(t, result.ReplayOfID)
+		assert.Equal(t, uint(8), *result.ReplayOfID)
+		mockTaskService.AssertExpectations(t)
+	})
+
+	t.Run("should return not found for unknown delivery", func(t *testing.T) {
+		mockWebhookRepo, mockWebhookDeliveryRepo, mockUserRepo, mockTaskService, service := setupMocks()
+		mockUserRepo.On("GetByID", ctx, uint(1)).Return(&model.User{ID: 1, Role: model.UserRoleAgency}, nil)
+		mockWebhookRepo.On("GetByIDAndUserID", ctx, uint(4), uint(1)).Return(&model.Webhook{ID: 4, UserID: 1}, nil)
+		mockWebhookDeliveryRepo.On("GetByIDAndWebhookID", ctx, uint(8), uint(4)).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.ReplayWebhookDelivery(ctx, 1, 4, 8)
+
+		assert.Nil(t, result)
+		appErr, ok := err.(*apperror.AppError)
+		require.True(t, ok)
+		assert.Equal(t, "WEBHOOK_DELIVERY_NOT_FOUND", appErr.Code)
+		mockTaskService.AssertNotCalled(t, "DeliverWebhookTask", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,173 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"pingspot/internal/domain/webhook_service/dto"
+	"pingspot/internal/model"
+	"pingspot/pkg/safehttp"
+	tokenutils "pingspot/pkg/utils/token_util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SecretPrefix            = "whsec_"
+	SecretLength            = 32
+	MaxWebhooksPerUser      = 10
+	MaxDeliveryAttempts     = 6
+	DeliveryPageSize        = 20
+	BaseRetryDelay          = 30 * time.Second
+	MaxRetryDelay           = 6 * time.Hour
+	DeliveryTimeout         = 10 * time.Second
+	MaxResponseSnippetLength = 256
+
+	HeaderEvent     = "X-Pingspot-Event"
+	HeaderDelivery  = "X-Pingspot-Delivery"
+	HeaderTimestamp = "X-Pingspot-Timestamp"
+	HeaderSignature = "X-Pingspot-Signature"
+)
+
+var SupportedEvents = []model.WebhookEventType{
+	model.WebhookReportCreated,
+	model.WebhookReportStatusChanged,
+	model.WebhookReportProgressAdded,
+	model.WebhookCommentCreated,
+	model.WebhookFollowCreated,
+}
+
+type DeliveryResult struct {
+	StatusCode   int
+	ResponseBody string
+}
+
+func GenerateSecret() (string, error) {
+	code, err := tokenutils.GenerateRandomCode(SecretLength)
+	if err != nil {
+		return "", err
+	}
+	return SecretPrefix + code, nil
+}
+
+func SignPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifySignature(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignPayload(secret, timestamp, body)), []byte(signature))
+}
+
+func JoinEvents(events []string) string {
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(events))
+	for _, event := range events {
+		if !seen[event] {
+			seen[event] = true
+			unique = append(unique, event)
+		}
+	}
+	return strings.Join(unique, ",")
+}
+
+func SplitEvents(events string) []string {
+	if events == "" {
+		return []string{}
+	}
+	return strings.Split(events, ",")
+}
+
+// IsValidWebhookURL rejects non-http(s) URLs and literal internal addresses.
+// Hostnames resolving to internal addresses are refused by NewHTTPClient when
+// the delivery connects.
+func IsValidWebhookURL(rawURL string) bool {
+	return safehttp.CheckURL(rawURL) == nil
+}
+
+func NewHTTPClient() *http.Client {
+	return safehttp.NewClient(DeliveryTimeout)
+}
+
+func BuildEventBody(eventID string, eventType model.WebhookEventType, occurredAt int64, data json.RawMessage) ([]byte, error) {
+	return json.Marshal(dto.WebhookEvent{
+		ID:        eventID,
+		Type:      string(eventType),
+		CreatedAt: occurredAt,
+		Data:      data,
+	})
+}
+
+func SendWebhook(ctx context.Context, client *http.Client, webhook model.Webhook, delivery model.WebhookDelivery) (*DeliveryResult, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Pingspot-Webhook/1.0")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, SignPayload(webhook.Secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, MaxResponseSnippetLength))
+	return &DeliveryResult{
+		StatusCode:   resp.StatusCode,
+		ResponseBody: strings.ToValidUTF8(string(snippet), ""),
+	}, nil
+}
+
+func IsSuccessStatus(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+func ToWebhookDTO(webhook model.Webhook) dto.Webhook {
+	return dto.Webhook{
+		ID:        webhook.ID,
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		Events:    SplitEvents(webhook.Events),
+		IsActive:  webhook.IsActive,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+func ToWebhookDeliveryDTO(delivery model.WebhookDelivery) dto.WebhookDelivery {
+	return dto.WebhookDelivery{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		NextRetryAt:    delivery.NextRetryAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		ReplayOfID:     delivery.ReplayOfID,
+		Payload:        json.RawMessage(delivery.Payload),
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignPayload(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	signature := SignPayload("whsec_secret", 1700000000, body)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.True(t, VerifySignature("whsec_secret", 1700000000, body, signature))
+	assert.False(t, VerifySignature("whsec_other", 1700000000, body, signature))
+	assert.False(t, VerifySignature("whsec_secret", 1700000001, body, signature))
+	assert.False(t, VerifySignature("whsec_secret", 1700000000, []byte(`{"id":"evt_2"}`), signature))
+}
+
+func TestJoinEvents(t *testing.T) {
+	joined := JoinEvents([]string{"report.created", "comment.created", "report.created"})
+
+	assert.Equal(t, "report.created,comment.created", joined)
+	assert.Equal(t, []string{"report.created", "comment.created"}, SplitEvents(joined))
+	assert.Empty(t, SplitEvents(""))
+}
+
+func TestIsValidWebhookURL(t *testing.T) {
+	t.Setenv("OUTBOUND_ALLOW_PRIVATE_NETWORKS", "")
+	assert.True(t, IsValidWebhookURL("https://example.com/hooks"))
+	assert.False(t, IsValidWebhookURL("http://localhost:8080/hooks"))
+	assert.False(t, IsValidWebhookURL("http://169.254.169.254/latest/meta-data"))
+	assert.False(t, IsValidWebhookURL("http://10.0.0.5/hooks"))
+	assert.False(t, IsValidWebhookURL("ftp://example.com/hooks"))
+	assert.False(t, IsValidWebhookURL("https://"))
+	assert.False(t, IsValidWebhookURL("not a url"))
+}
+
+func TestSendWebhook(t *testing.T) {
+	body, err := BuildEventBody("evt_1", model.WebhookReportCreated, 1700000000, []byte(`{"reportID":7}`))
+	require.NoError(t, err)
+
+	var receivedHeaders http.Header
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedHeaders = r.Header.Clone()
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	webhook := model.Webhook{ID: 1, URL: server.URL, Secret: "whsec_secret"}
+	delivery := model.WebhookDelivery{ID: 9, EventType: model.WebhookReportCreated, Payload: string(body)}
+
+	result, err := SendWebhook(context.Background(), server.Client(), webhook, delivery)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, result.StatusCode)
+	assert.Equal(t, "ok", result.ResponseBody)
+	assert.True(t, IsSuccessStatus(result.StatusCode))
+	assert.Equal(t, body, receivedBody)
+	assert.Equal(t, "report.created", receivedHeaders.Get(HeaderEvent))
+	assert.Equal(t, "9", receivedHeaders.Get(HeaderDelivery))
+
+	timestamp, err := strconv.ParseInt(receivedHeaders.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, VerifySignature("whsec_secret", timestamp, receivedBody, receivedHeaders.Get(HeaderSignature)))
+}
+
+func TestSendWebhook_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(strings.Repeat("x", MaxResponseSnippetLength*4)))
+	}))
+	defer server.Close()
+
+	result, err := SendWebhook(context.Background(), server.Client(), model.Webhook{URL: server.URL}, model.WebhookDelivery{Payload: "{}"})
+
+	require.NoError(t, err)
+	assert.False(t, IsSuccessStatus(result.StatusCode))
+	assert.Len(t, result.ResponseBody, MaxResponseSnippetLength)
+}
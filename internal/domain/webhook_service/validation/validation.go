@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var Validate *validator.Validate
+
+func init() {
+	Validate = validator.New()
+}
+
+func FormatSaveWebhookValidationErrors(err error) map[string]string {
+	errors := map[string]string{}
+	if err == nil {
+		return errors
+	}
+	for _, e := range err.(validator.ValidationErrors) {
+		switch {
+		case e.Field() == "Name":
+			if e.Tag() == "required" {
+				errors["name"] = "Nama webhook wajib diisi"
+			}
+			if e.Tag() == "max" {
+				errors["name"] = "Nama webhook maksimal 100 karakter"
+			}
+		case e.Field() == "URL":
+			if e.Tag() == "required" {
+				errors["url"] = "URL webhook wajib diisi"
+			}
+			if e.Tag() == "url" {
+				errors["url"] = "URL webhook tidak valid"
+			}
+			if e.Tag() == "max" {
+				errors["url"] = "URL webhook maksimal 500 karakter"
+			}
+		case e.Field() == "Events" || strings.HasPrefix(e.Field(), "Events["):
+			if e.Tag() == "oneof" {
+				errors["events"] = "Jenis event tidak didukung"
+			} else {
+				errors["events"] = "Minimal satu event wajib dipilih"
+			}
+		}
+	}
+	return errors
+}
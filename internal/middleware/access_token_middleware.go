@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
+	presenceRepository "pingspot/internal/domain/presence_service/repository"
 	"pingspot/internal/domain/user_service/repository"
 	"pingspot/internal/infrastructure/cache"
 	"pingspot/internal/infrastructure/database"
+	"pingspot/pkg/i18n"
 	"pingspot/pkg/logger"
 	mainutils "pingspot/pkg/utils/main_util"
 	response "pingspot/pkg/utils/response_util"
@@ -14,6 +17,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
@@ -70,6 +74,10 @@ func ValidateAccessToken() fiber.Handler {
 		}
 		userID := uint(userIDFloat)
 
+		if language, ok := claims["language"].(string); ok && i18n.IsSupported(language) {
+			setLanguage(c, i18n.Normalize(language))
+		}
+
 		ctx := c.UserContext()
 		redisClient := cache.GetRedis()
 		sessionKey := fmt.Sprintf("session:%d", sessionID)
@@ -83,6 +91,7 @@ func ValidateAccessToken() fiber.Handler {
 
 			c.Locals("token", parsedToken)
 			c.Locals("claims", claims)
+			recordPresence(ctx, redisClient, userID)
 			return c.Next()
 		}
 
@@ -119,7 +128,14 @@ func ValidateAccessToken() fiber.Handler {
 
 		c.Locals("token", parsedToken)
 		c.Locals("claims", claims)
+		recordPresence(ctx, redisClient, userID)
 
 		return c.Next()
 	}
+}
+
+func recordPresence(ctx context.Context, redisClient redis.UniversalClient, userID uint) {
+	if err := presenceRepository.NewPresenceRepository(redisClient).Touch(ctx, userID, time.Now().Unix()); err != nil {
+		logger.Warn("Failed to record user presence", zap.Uint("user_id", userID), zap.Error(err))
+	}
 }
\ No newline at end of file
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"pingspot/pkg/i18n"
+	contextutils "pingspot/pkg/utils/context_util"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func LocalizationMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		setLanguage(c, i18n.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage)))
+		c.Vary(fiber.HeaderAcceptLanguage)
+		return c.Next()
+	}
+}
+
+func setLanguage(c *fiber.Ctx, language i18n.Language) {
+	c.Locals("language", language)
+	c.SetUserContext(contextutils.SetLanguageInContext(c.UserContext(), language))
+	c.Set(fiber.HeaderContentLanguage, string(language))
+}
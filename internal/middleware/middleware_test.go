@@ -277,5 +277,5 @@ func TestTimeoutMiddleware_TimeoutBodyShape(t *testing.T) {
 	err = json.NewDecoder(resp.Body).Decode(&body)
 	require.NoError(t, err)
 	assert.Equal(t, false, body["success"])
-	assert.Equal(t, "Request timeout exceeded", body["message"])
+	assert.Equal(t, "Batas waktu permintaan terlampaui", body["message"])
 }
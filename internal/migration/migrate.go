@@ -3,6 +3,8 @@ package migration
 import (
 	"pingspot/internal/model"
 	"pingspot/pkg/logger"
+	env "pingspot/pkg/utils/env_util"
+	totputils "pingspot/pkg/utils/totp_util"
 
 	"github.com/go-gormigrate/gormigrate/v2"
 	"go.uber.org/zap"
@@ -134,6 +136,316 @@ func Migrate(db *gorm.DB) error {
 				return tx.Migrator().DropTable(&model.Notification{})
 			},
 		},
+		{
+			ID: "18102026_add_role_to_users",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.User{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropColumn(&model.User{}, "role")
+			},
+		},
+		{
+			ID: "18102026_add_incident_alert_model",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.IncidentAlert{}, &model.AreaSubscription{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&model.IncidentAlert{}, &model.AreaSubscription{})
+			},
+		},
+		{
+			ID: "18102026_add_priority_score_to_reports",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Report{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropColumn(&model.Report{}, "priority_updated_at"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&model.Report{}, "priority_score")
+			},
+		},
+		{
+			ID: "18102026_add_hot_score_and_view_count_to_reports",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Report{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropColumn(&model.Report{}, "view_count"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&model.Report{}, "hot_score")
+			},
+		},
+		{
+			ID: "18102026_add_home_location_to_user_profiles",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.UserProfile{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropColumn(&model.UserProfile{}, "home_longitude"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&model.UserProfile{}, "home_latitude")
+			},
+		},
+		{
+			ID: "18102026_add_merged_into_to_reports",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Report{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropColumn(&model.Report{}, "merged_at"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&model.Report{}, "merged_into_id")
+			},
+		},
+		{
+			ID: "18102026_add_weighted_votes",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.ReportVote{}, &model.Report{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				for _, column := range []string{"resolved_vote_count", "on_progress_vote_count", "resolved_vote_weight", "on_progress_vote_weight"} {
+					if err := tx.Migrator().DropColumn(&model.Report{}, column); err != nil {
+						return err
+					}
+				}
+				if err := tx.Migrator().DropColumn(&model.ReportVote{}, "distance_meters"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&model.ReportVote{}, "weight")
+			},
+		},
+		{
+			ID: "18102026_create_reputation_events",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.ReputationEvent{}, &model.User{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropColumn(&model.User{}, "reputation_score"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&model.ReputationEvent{})
+			},
+		},
+		{
+			ID: "18102026_create_user_badges",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.UserBadge{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&model.UserBadge{})
+			},
+		},
+		{
+			ID: "18102026_create_report_drafts",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.ReportDraft{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&model.ReportDraft{})
+			},
+		},
+		{
+			ID: "18102026_add_anonymous_reports",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Report{}, &model.ReportDraft{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropColumn(&model.ReportDraft{}, "is_anonymous"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&model.Report{}, "is_anonymous")
+			},
+		},
+		{
+			ID: "18102026_create_open311_api_keys",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Open311APIKey{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&model.Open311APIKey{})
+			},
+		},
+		{
+			ID: "18102026_create_webhooks",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Webhook{}, &model.WebhookDelivery{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&model.WebhookDelivery{}, &model.Webhook{})
+			},
+		},
+		{
+			ID: "18102026_create_outbox_events",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.OutboxEvent{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&model.OutboxEvent{})
+			},
+		},
+		{
+			ID: "18102026_add_presence_visibility_to_user_profiles",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.UserProfile{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropColumn(&model.UserProfile{}, "presence_visibility")
+			},
+		},
+		{
+			ID: "18102026_create_notification_preferences",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.NotificationPreference{}, &model.NotificationSetting{}, &model.NotificationReportMute{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&model.NotificationReportMute{}, &model.NotificationSetting{}, &model.NotificationPreference{})
+			},
+		},
+		{
+			ID: "18102026_add_notification_inbox_index",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.Migrator().CreateIndex(&model.Notification{}, "idx_notifications_user_read")
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropIndex(&model.Notification{}, "idx_notifications_user_read")
+			},
+		},
+		{
+			ID: "18102026_add_notification_grouping",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Notification{}, &model.NotificationActor{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&model.NotificationActor{}); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropIndex(&model.Notification{}, "idx_notifications_user_group"); err != nil {
+					return err
+				}
+				for _, column := range []string{"GroupKey", "ActorCount", "UpdatedAt"} {
+					if err := tx.Migrator().DropColumn(&model.Notification{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "18102026_add_notification_digest_settings",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.NotificationSetting{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				for _, column := range []string{"DigestFrequency", "DigestHour", "LastDigestSentAt"} {
+					if err := tx.Migrator().DropColumn(&model.NotificationSetting{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "18102026_create_notification_deliveries",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.NotificationSetting{}, &model.NotificationDelivery{}, &model.PushSubscription{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&model.NotificationDelivery{}, &model.PushSubscription{}); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&model.NotificationSetting{}, "PhoneNumber")
+			},
+		},
+		{
+			ID: "18102026_add_user_language",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.User{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropColumn(&model.User{}, "Language")
+			},
+		},
+		{
+			ID: "18102026_add_notification_message_key",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Notification{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				for _, column := range []string{"MessageKey", "MessageParams"} {
+					if err := tx.Migrator().DropColumn(&model.Notification{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "18102026_add_two_factor_auth",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.User{}, &model.UserRecoveryCode{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&model.UserRecoveryCode{}); err != nil {
+					return err
+				}
+				for _, column := range []string{"TwoFactorEnabled", "TwoFactorSecret"} {
+					if err := tx.Migrator().DropColumn(&model.User{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "18102026_add_notification_is_hidden",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&model.Notification{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropColumn(&model.Notification{}, "IsHidden")
+			},
+		},
+		{
+			ID: "18102026_add_webhook_delivery_event_unique_index",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.Exec(`DELETE FROM webhook_deliveries WHERE replay_of_id IS NULL AND id NOT IN (
+					SELECT MIN(id) FROM webhook_deliveries WHERE replay_of_id IS NULL GROUP BY webhook_id, event_id
+				)`).Error; err != nil {
+					return err
+				}
+				return tx.Migrator().CreateIndex(&model.WebhookDelivery{}, "idx_webhook_deliveries_event")
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropIndex(&model.WebhookDelivery{}, "idx_webhook_deliveries_event")
+			},
+		},
+		{
+			ID: "18102026_encrypt_two_factor_secrets",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.Migrator().AlterColumn(&model.User{}, "TwoFactorSecret"); err != nil {
+					return err
+				}
+				return convertTwoFactorSecrets(tx, func(secret string, key []byte) (string, error) {
+					if totputils.IsEncryptedSecret(secret) {
+						return secret, nil
+					}
+					return totputils.EncryptSecret(secret, key)
+				})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return convertTwoFactorSecrets(tx, func(secret string, key []byte) (string, error) {
+					if !totputils.IsEncryptedSecret(secret) {
+						return secret, nil
+					}
+					return totputils.DecryptSecret(secret, key)
+				})
+			},
+		},
 	})
 
 	err := m.Migrate()
@@ -144,3 +456,32 @@ func Migrate(db *gorm.DB) error {
 	logger.Info("Migrations ran successfully")
 	return nil
 }
+
+// convertTwoFactorSecrets rewrites every stored two-factor secret with convert.
+// The key is only required when there are secrets to convert.
+func convertTwoFactorSecrets(tx *gorm.DB, convert func(secret string, key []byte) (string, error)) error {
+	var users []model.User
+	if err := tx.Select("id", "two_factor_secret").Where("two_factor_secret IS NOT NULL").Find(&users).Error; err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return nil
+	}
+	key, err := totputils.ParseEncryptionKey(env.TwoFactorEncryptionKey())
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		converted, err := convert(*user.TwoFactorSecret, key)
+		if err != nil {
+			return err
+		}
+		if converted == *user.TwoFactorSecret {
+			continue
+		}
+		if err := tx.Model(&model.User{}).Where("id = ?", user.ID).UpdateColumn("two_factor_secret", converted).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
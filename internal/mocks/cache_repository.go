@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -16,6 +17,16 @@ func (m *MockCacheRepository) Set(ctx context.Context, key string, value any, ex
 	return args.Error(0)
 }
 
+func (m *MockCacheRepository) SetNX(ctx context.Context, key string, value any, expiration time.Duration) (bool, error) {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCacheRepository) Incr(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockCacheRepository) Get(ctx context.Context, key string) (string, error) {
 	args := m.Called(ctx, key)
 	return args.String(0), args.Error(1)
@@ -49,4 +60,27 @@ func (m *MockCacheRepository) SRem(ctx context.Context, key string, members ...a
 func (m *MockCacheRepository) SIsMember(ctx context.Context, key string, member any) (bool, error) {
 	args := m.Called(ctx, key, member)
 	return args.Bool(0), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockCacheRepository) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	args := m.Called(ctx, key, increment, member)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCacheRepository) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	args := m.Called(ctx, key, start, stop)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]redis.Z), args.Error(1)
+}
+
+func (m *MockCacheRepository) ZRevRank(ctx context.Context, key string, member string) (int64, error) {
+	args := m.Called(ctx, key, member)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCacheRepository) ZScore(ctx context.Context, key string, member string) (float64, error) {
+	args := m.Called(ctx, key, member)
+	return args.Get(0).(float64), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package gamification
+
+import (
+	"context"
+	"pingspot/internal/domain/gamification_service/dto"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockGamificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockGamificationRepository) AwardBadgeTX(ctx context.Context, tx *gorm.DB, badge *model.UserBadge) (bool, error) {
+	args := m.Called(ctx, tx, badge)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGamificationRepository) GetBadgesByUserID(ctx context.Context, userID uint) ([]model.UserBadge, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.UserBadge), args.Error(1)
+}
+
+func (m *MockGamificationRepository) GetUserActivityCounts(ctx context.Context, userID uint) (*dto.UserActivityCounts, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserActivityCounts), args.Error(1)
+}
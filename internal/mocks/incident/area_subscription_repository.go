@@ -0,0 +1,51 @@
+package incident
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAreaSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAreaSubscriptionRepository) Create(ctx context.Context, subscription *model.AreaSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockAreaSubscriptionRepository) GetByID(ctx context.Context, id uint) (*model.AreaSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AreaSubscription), args.Error(1)
+}
+
+func (m *MockAreaSubscriptionRepository) GetByUserID(ctx context.Context, userID uint) ([]model.AreaSubscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.AreaSubscription), args.Error(1)
+}
+
+func (m *MockAreaSubscriptionRepository) CountByUserID(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAreaSubscriptionRepository) Delete(ctx context.Context, subscription *model.AreaSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockAreaSubscriptionRepository) GetSubscriberIDsNear(ctx context.Context, lat, lng float64) ([]uint, error) {
+	args := m.Called(ctx, lat, lng)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
@@ -0,0 +1,60 @@
+package incident
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockIncidentAlertRepository struct {
+	mock.Mock
+}
+
+func (m *MockIncidentAlertRepository) CreateTX(ctx context.Context, tx *gorm.DB, alert *model.IncidentAlert) error {
+	args := m.Called(ctx, tx, alert)
+	return args.Error(0)
+}
+
+func (m *MockIncidentAlertRepository) UpdateTX(ctx context.Context, tx *gorm.DB, alert *model.IncidentAlert) error {
+	args := m.Called(ctx, tx, alert)
+	return args.Error(0)
+}
+
+func (m *MockIncidentAlertRepository) GetByID(ctx context.Context, id uint) (*model.IncidentAlert, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.IncidentAlert), args.Error(1)
+}
+
+func (m *MockIncidentAlertRepository) GetActiveNearby(ctx context.Context, reportType model.ReportType, lat, lng float64, radiusMeters int, since int64) (*model.IncidentAlert, error) {
+	args := m.Called(ctx, reportType, lat, lng, radiusMeters, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.IncidentAlert), args.Error(1)
+}
+
+func (m *MockIncidentAlertRepository) GetActiveNearbyTX(ctx context.Context, tx *gorm.DB, reportType model.ReportType, lat, lng float64, radiusMeters int, since int64) (*model.IncidentAlert, error) {
+	args := m.Called(ctx, tx, reportType, lat, lng, radiusMeters, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.IncidentAlert), args.Error(1)
+}
+
+func (m *MockIncidentAlertRepository) LockReportTypeTX(ctx context.Context, tx *gorm.DB, reportType model.ReportType) error {
+	args := m.Called(ctx, tx, reportType)
+	return args.Error(0)
+}
+
+func (m *MockIncidentAlertRepository) GetPaginated(ctx context.Context, limit, cursorID uint, status string) ([]model.IncidentAlert, error) {
+	args := m.Called(ctx, limit, cursorID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.IncidentAlert), args.Error(1)
+}
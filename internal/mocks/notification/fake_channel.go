@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"context"
+	"pingspot/internal/domain/notification_service/channel"
+	"pingspot/internal/model"
+	"sync"
+)
+
+// FakeChannel stands in for any provider: it records every message and
+// answers with the configured message id or error.
+type FakeChannel struct {
+	Name              model.NotificationChannel
+	Attempts          int
+	ProviderMessageID string
+	Err               error
+
+	mu   sync.Mutex
+	sent []channel.Message
+}
+
+func NewFakeChannel(name model.NotificationChannel) *FakeChannel {
+	return &FakeChannel{Name: name, Attempts: 3}
+}
+
+func (f *FakeChannel) Channel() model.NotificationChannel {
+	return f.Name
+}
+
+func (f *FakeChannel) MaxAttempts() int {
+	return f.Attempts
+}
+
+func (f *FakeChannel) Send(ctx context.Context, message channel.Message) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, message)
+	if f.Err != nil {
+		return "", f.Err
+	}
+	return f.ProviderMessageID, nil
+}
+
+func (f *FakeChannel) Sent() []channel.Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]channel.Message(nil), f.sent...)
+}
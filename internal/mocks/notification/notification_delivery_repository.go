@@ -0,0 +1,33 @@
+package notification
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationDeliveryRepository) Create(ctx context.Context, delivery *model.NotificationDelivery) (bool, error) {
+	args := m.Called(ctx, delivery)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationDeliveryRepository) GetByID(ctx context.Context, deliveryID uint) (*model.NotificationDelivery, error) {
+	args := m.Called(ctx, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NotificationDelivery), args.Error(1)
+}
+
+func (m *MockNotificationDeliveryRepository) Update(ctx context.Context, delivery *model.NotificationDelivery) (*model.NotificationDelivery, error) {
+	args := m.Called(ctx, delivery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NotificationDelivery), args.Error(1)
+}
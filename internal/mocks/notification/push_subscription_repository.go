@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPushSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPushSubscriptionRepository) Upsert(ctx context.Context, subscription *model.PushSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockPushSubscriptionRepository) GetByUserID(ctx context.Context, userID uint) ([]model.PushSubscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PushSubscription), args.Error(1)
+}
+
+func (m *MockPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID uint, endpoint string) (int64, error) {
+	args := m.Called(ctx, userID, endpoint)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPushSubscriptionRepository) DeleteByID(ctx context.Context, subscriptionID uint) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
@@ -0,0 +1,48 @@
+package open311
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, apiKey *model.Open311APIKey) error {
+	args := m.Called(ctx, apiKey)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetActiveByHashedKey(ctx context.Context, hashedKey string) (*model.Open311APIKey, error) {
+	args := m.Called(ctx, hashedKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Open311APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetActiveByUserID(ctx context.Context, userID uint) ([]model.Open311APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Open311APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) CountActiveByUserID(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) UpdateLastUsedAt(ctx context.Context, keyID uint, usedAt int64) error {
+	args := m.Called(ctx, keyID, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, keyID, userID uint, revokedAt int64) (int64, error) {
+	args := m.Called(ctx, keyID, userID, revokedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package open311
+
+import (
+	"context"
+	"pingspot/internal/domain/report_service/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) CreateReport(ctx context.Context, userID uint, req dto.CreateReportRequest) (*dto.CreateReportResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CreateReportResponse), args.Error(1)
+}
+
+func (m *MockReportService) GetAllReport(ctx context.Context, userID, cursorID uint, reportType, status, sortBy, hasProgress string, distance dto.Distance) (*dto.GetReportsResponse, error) {
+	args := m.Called(ctx, userID, cursorID, reportType, status, sortBy, hasProgress, distance)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.GetReportsResponse), args.Error(1)
+}
+
+func (m *MockReportService) GetReportByID(ctx context.Context, userID, reportID uint) (*dto.GetReportResponse, error) {
+	args := m.Called(ctx, userID, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.GetReportResponse), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) CreateTX(ctx context.Context, tx *gorm.DB, event *model.OutboxEvent) error {
+	args := m.Called(ctx, tx, event)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) ClaimPending(ctx context.Context, now, leaseUntil int64, limit int) ([]model.OutboxEvent, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OutboxEvent), args.Error(1)
+}
+
+func (m *MockOutboxRepository) Update(ctx context.Context, event *model.OutboxEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) DeletePublishedBefore(ctx context.Context, before int64) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
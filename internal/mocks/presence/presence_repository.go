@@ -0,0 +1,24 @@
+package presence
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPresenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPresenceRepository) Touch(ctx context.Context, userID uint, seenAt int64) error {
+	args := m.Called(ctx, userID, seenAt)
+	return args.Error(0)
+}
+
+func (m *MockPresenceRepository) GetLastSeen(ctx context.Context, userIDs []uint) (map[uint]int64, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uint]int64), args.Error(1)
+}
@@ -0,0 +1,17 @@
+package realtime
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, scope model.RealtimeScope, scopeID uint, eventType model.RealtimeEventType, data any) error {
+	args := m.Called(ctx, scope, scopeID, eventType, data)
+	return args.Error(0)
+}
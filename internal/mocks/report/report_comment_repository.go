@@ -69,3 +69,13 @@ func (m *MockReportCommentRepository) GetPaginatedRepliesByRootID(ctx context.Co
 	}
 	return args.Get(0).([]*model.ReportComment), args.Error(1)
 }
+
+func (m *MockReportCommentRepository) MoveToReport(ctx context.Context, fromReportID, toReportID uint) (int64, error) {
+	args := m.Called(ctx, fromReportID, toReportID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportCommentRepository) MarkHelpful(ctx context.Context, commentID primitive.ObjectID) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
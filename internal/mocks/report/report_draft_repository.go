@@ -0,0 +1,65 @@
+package report
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockReportDraftRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportDraftRepository) Create(ctx context.Context, draft *model.ReportDraft) error {
+	args := m.Called(ctx, draft)
+	return args.Error(0)
+}
+
+func (m *MockReportDraftRepository) Update(ctx context.Context, draft *model.ReportDraft) (*model.ReportDraft, error) {
+	args := m.Called(ctx, draft)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReportDraft), args.Error(1)
+}
+
+func (m *MockReportDraftRepository) GetActiveByIDAndUserID(ctx context.Context, draftID, userID uint, now int64) (*model.ReportDraft, error) {
+	args := m.Called(ctx, draftID, userID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReportDraft), args.Error(1)
+}
+
+func (m *MockReportDraftRepository) GetActiveByUserID(ctx context.Context, userID uint, now int64) ([]model.ReportDraft, error) {
+	args := m.Called(ctx, userID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ReportDraft), args.Error(1)
+}
+
+func (m *MockReportDraftRepository) CountActiveByUserID(ctx context.Context, userID uint, now int64) (int64, error) {
+	args := m.Called(ctx, userID, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportDraftRepository) GetExpired(ctx context.Context, now int64) ([]model.ReportDraft, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ReportDraft), args.Error(1)
+}
+
+func (m *MockReportDraftRepository) Delete(ctx context.Context, draftID uint) error {
+	args := m.Called(ctx, draftID)
+	return args.Error(0)
+}
+
+func (m *MockReportDraftRepository) DeleteTX(ctx context.Context, tx *gorm.DB, draftID uint) (bool, error) {
+	args := m.Called(ctx, tx, draftID)
+	return args.Bool(0), args.Error(1)
+}
@@ -32,3 +32,8 @@ func (m *MockReportImageRepository) GetByReportID(ctx context.Context, reportID
 	}
 	return args.Get(0).(*model.ReportImage), args.Error(1)
 }
+
+func (m *MockReportImageRepository) IsImageInUse(ctx context.Context, image string) (bool, error) {
+	args := m.Called(ctx, image)
+	return args.Bool(0), args.Error(1)
+}
@@ -35,3 +35,16 @@ func (m *MockReportProgressRepository) GetByReportID(ctx context.Context, report
 	}
 	return args.Get(0).([]model.ReportProgress), args.Error(1)
 }
+
+func (m *MockReportProgressRepository) MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID uint) error {
+	args := m.Called(ctx, tx, fromReportID, toReportID)
+	return args.Error(0)
+}
+
+func (m *MockReportProgressRepository) GetWatchedSince(ctx context.Context, userID uint, since int64, limit int) ([]model.ReportProgress, error) {
+	args := m.Called(ctx, userID, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ReportProgress), args.Error(1)
+}
@@ -58,3 +58,13 @@ func (m *MockReportReactionRepository) GetDislikeReactionCount(ctx context.Conte
 	args := m.Called(ctx, reportID)
 	return args.Get(0).(int64), args.Error(1)
 }
+
+func (m *MockReportReactionRepository) GetCountSince(ctx context.Context, reportID uint, since int64) (int64, error) {
+	args := m.Called(ctx, reportID, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportReactionRepository) MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID uint) (int64, error) {
+	args := m.Called(ctx, tx, fromReportID, toReportID)
+	return args.Get(0).(int64), args.Error(1)
+}
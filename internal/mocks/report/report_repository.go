@@ -137,3 +137,123 @@ func (m *MockReportRepository) FullTextSearchReportPaginated(ctx context.Context
 	}
 	return args.Get(0).(*[]model.Report), args.Error(1)
 }
+
+func (m *MockReportRepository) GetClustersSince(ctx context.Context, since int64, gridSize float64, minCount int64) ([]dto.ReportCluster, error) {
+	args := m.Called(ctx, since, gridSize, minCount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ReportCluster), args.Error(1)
+}
+
+func (m *MockReportRepository) GetCountInAreaBetween(ctx context.Context, reportType string, lat, lng float64, radiusMeters int, from, to int64) (int64, error) {
+	args := m.Called(ctx, reportType, lat, lng, radiusMeters, from, to)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportRepository) GetNearbyDuplicateCount(ctx context.Context, reportID uint, reportType string, lat, lng float64, radiusMeters int) (int64, error) {
+	args := m.Called(ctx, reportID, reportType, lat, lng, radiusMeters)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportRepository) GetIDsByReportStatus(ctx context.Context, status ...string) ([]uint, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockReportRepository) UpdatePriorityScore(ctx context.Context, reportID uint, score float64, updatedAt int64) error {
+	args := m.Called(ctx, reportID, score, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) UpdateHotScore(ctx context.Context, reportID uint, score float64) error {
+	args := m.Called(ctx, reportID, score)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) ResetHotScoreBefore(ctx context.Context, createdBefore int64) error {
+	args := m.Called(ctx, createdBefore)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) GetIDsCreatedSince(ctx context.Context, since int64) ([]uint, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockReportRepository) IncrementViewCount(ctx context.Context, reportID uint) error {
+	args := m.Called(ctx, reportID)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) GetFeedCandidatesByUserIDs(ctx context.Context, userIDs []uint, since int64, limit int) ([]dto.FeedCandidate, error) {
+	args := m.Called(ctx, userIDs, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.FeedCandidate), args.Error(1)
+}
+
+func (m *MockReportRepository) GetFeedCandidatesNearby(ctx context.Context, lat, lng float64, radiusMeters int, since int64, limit int) ([]dto.FeedCandidate, error) {
+	args := m.Called(ctx, lat, lng, radiusMeters, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.FeedCandidate), args.Error(1)
+}
+
+func (m *MockReportRepository) GetFeedCandidatesTrending(ctx context.Context, limit int) ([]dto.FeedCandidate, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.FeedCandidate), args.Error(1)
+}
+
+func (m *MockReportRepository) GetByIDs(ctx context.Context, reportIDs []uint) ([]model.Report, error) {
+	args := m.Called(ctx, reportIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) LockByIDsForUpdateTX(ctx context.Context, tx *gorm.DB, reportIDs []uint) ([]model.Report, error) {
+	args := m.Called(ctx, tx, reportIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) MarkMergedTX(ctx context.Context, tx *gorm.DB, reportIDs []uint, canonicalReportID uint, mergedAt int64) error {
+	args := m.Called(ctx, tx, reportIDs, canonicalReportID, mergedAt)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) GetAnonymousOwnerID(ctx context.Context, reportID uint) (*uint, error) {
+	args := m.Called(ctx, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*uint), args.Error(1)
+}
+
+func (m *MockReportRepository) Exists(ctx context.Context, reportID uint) (bool, error) {
+	args := m.Called(ctx, reportID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReportRepository) GetMergedIntoID(ctx context.Context, reportID uint) (*uint, error) {
+	args := m.Called(ctx, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*uint), args.Error(1)
+}
@@ -86,3 +86,26 @@ func (m *MockReportVoteRepository) GetTotalVoteCountTX(ctx context.Context, tx *
 	args := m.Called(ctx, tx, reportID)
 	return args.Get(0).(int64), args.Error(1)
 }
+
+func (m *MockReportVoteRepository) GetCountSince(ctx context.Context, reportID uint, since int64) (int64, error) {
+	args := m.Called(ctx, reportID, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportVoteRepository) MoveToReportTX(ctx context.Context, tx *gorm.DB, fromReportID, toReportID, excludedUserID uint) (int64, error) {
+	args := m.Called(ctx, tx, fromReportID, toReportID, excludedUserID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportVoteRepository) GetReportVoteWeightsTX(ctx context.Context, tx *gorm.DB, reportID uint) (map[model.ReportStatus]float64, error) {
+	args := m.Called(ctx, tx, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[model.ReportStatus]float64), args.Error(1)
+}
+
+func (m *MockReportVoteRepository) GetUserVoteAccuracy(ctx context.Context, userID uint) (int64, int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
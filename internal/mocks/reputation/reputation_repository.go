@@ -0,0 +1,52 @@
+package reputation
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockReputationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReputationRepository) AwardTX(ctx context.Context, tx *gorm.DB, event *model.ReputationEvent) (bool, error) {
+	args := m.Called(ctx, tx, event)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReputationRepository) GetBySource(ctx context.Context, userID uint, eventType model.ReputationEventType, sourceType model.ReputationSourceType, sourceID string) (*model.ReputationEvent, error) {
+	args := m.Called(ctx, userID, eventType, sourceType, sourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReputationEvent), args.Error(1)
+}
+
+func (m *MockReputationRepository) GetPaginatedByUserID(ctx context.Context, userID uint, limit, cursorID uint) ([]model.ReputationEvent, error) {
+	args := m.Called(ctx, userID, limit, cursorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ReputationEvent), args.Error(1)
+}
+
+func (m *MockReputationRepository) GetScore(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReputationRepository) GetScores(ctx context.Context, userIDs []uint) (map[uint]int64, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uint]int64), args.Error(1)
+}
+
+func (m *MockReputationRepository) RecomputeTX(ctx context.Context, tx *gorm.DB, userID uint) (int64, error) {
+	args := m.Called(ctx, tx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,65 @@
+package social
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockFollowRepository struct {
+	mock.Mock
+}
+
+func (m *MockFollowRepository) CreateTX(ctx context.Context, tx *gorm.DB, follow *model.Follow) error {
+	args := m.Called(ctx, tx, follow)
+	return args.Error(0)
+}
+
+func (m *MockFollowRepository) GetByFollowerAndFollowing(ctx context.Context, followerUserID uint, followingID uint, followingType model.FollowingType) (*model.Follow, error) {
+	args := m.Called(ctx, followerUserID, followingID, followingType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Follow), args.Error(1)
+}
+
+func (m *MockFollowRepository) DeleteTX(ctx context.Context, tx *gorm.DB, follow *model.Follow) error {
+	args := m.Called(ctx, tx, follow)
+	return args.Error(0)
+}
+
+func (m *MockFollowRepository) GetFollowersCount(ctx context.Context, followingID uint, followingType model.FollowingType) (int64, error) {
+	args := m.Called(ctx, followingID, followingType)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowingCount(ctx context.Context, followerUserID uint, followingType model.FollowingType) (int64, error) {
+	args := m.Called(ctx, followerUserID, followingType)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowersByUserID(ctx context.Context, userID uint) ([]*model.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowingByUserID(ctx context.Context, userID uint) ([]*model.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetConnectedUserIDs(ctx context.Context, userID uint, candidateIDs []uint) ([]uint, error) {
+	args := m.Called(ctx, userID, candidateIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
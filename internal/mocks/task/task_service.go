@@ -1,9 +1,12 @@
 package task
 
 import (
+	"pingspot/internal/domain/task_service/service"
 	"pingspot/internal/model"
+	"pingspot/pkg/mailer"
 
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 type MockTaskService struct {
@@ -15,7 +18,73 @@ func (m *MockTaskService) AutoResolveReportTask(reportID uint) error {
 	return args.Error(0)
 }
 
-func (m *MockTaskService) CreateNotificationTask(userID uint, title string, description string, entityID *string, entityType model.EntityType, category model.NotificationCategory, notificationType model.NotificationType) error {
-	args := m.Called(userID, title, description, entityID, entityType, category, notificationType)
+func (m *MockTaskService) CreateNotificationTask(userID uint, messageKey string, messageParams map[string]string, entityID *string, entityType model.EntityType, category model.NotificationCategory, notificationType model.NotificationType, event model.NotificationEvent, reportID *uint) error {
+	args := m.Called(userID, messageKey, messageParams, entityID, entityType, category, notificationType, event, reportID)
 	return args.Error(0)
 }
+
+func (m *MockTaskService) CreateGroupedNotificationTask(userID uint, actorID uint, actorName string, entityID *string, entityType model.EntityType, category model.NotificationCategory, event model.NotificationEvent, reportID *uint) error {
+	args := m.Called(userID, actorID, actorName, entityID, entityType, category, event, reportID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) SendNotificationDigestTask(userID uint) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) DeliverNotificationTask(deliveryID uint, maxAttempts int) error {
+	args := m.Called(deliveryID, maxAttempts)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) RecalculateReportPriorityTask(reportID uint) error {
+	args := m.Called(reportID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) EvaluateReportReputationTask(reportID uint) error {
+	args := m.Called(reportID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) MoveMergedReportCommentsTask(duplicateReportID, canonicalReportID uint) error {
+	args := m.Called(duplicateReportID, canonicalReportID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) AwardReputationTask(userID uint, eventType model.ReputationEventType, sourceType model.ReputationSourceType, sourceID string) error {
+	args := m.Called(userID, eventType, sourceType, sourceID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) GamificationEventTask(userID uint, eventType model.GamificationEventType, reportID uint) error {
+	args := m.Called(userID, eventType, reportID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) DispatchWebhookEventTask(eventType model.WebhookEventType, data any) error {
+	args := m.Called(eventType, data)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) DeliverWebhookTask(deliveryID uint, maxAttempts int) error {
+	args := m.Called(deliveryID, maxAttempts)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) PublishRealtimeEventTask(scope model.RealtimeScope, scopeID uint, eventType model.RealtimeEventType, data any) error {
+	args := m.Called(scope, scopeID, eventType, data)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) SendEmailTask(message mailer.Message) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+// WithTx returns the same mock so expectations can be set regardless of
+// whether the caller publishes through the outbox.
+func (m *MockTaskService) WithTx(tx *gorm.DB) service.TaskService {
+	return m
+}
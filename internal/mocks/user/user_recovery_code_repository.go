@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockUserRecoveryCodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRecoveryCodeRepository) ReplaceTX(ctx context.Context, tx *gorm.DB, userID uint, codes []model.UserRecoveryCode) error {
+	args := m.Called(ctx, tx, userID, codes)
+	return args.Error(0)
+}
+
+func (m *MockUserRecoveryCodeRepository) DeleteByUserIDTX(ctx context.Context, tx *gorm.DB, userID uint) error {
+	args := m.Called(ctx, tx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRecoveryCodeRepository) GetUnusedByUserID(ctx context.Context, userID uint) ([]model.UserRecoveryCode, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.UserRecoveryCode), args.Error(1)
+}
+
+func (m *MockUserRecoveryCodeRepository) MarkUsed(ctx context.Context, id uint, usedAt int64) (bool, error) {
+	args := m.Called(ctx, id, usedAt)
+	return args.Bool(0), args.Error(1)
+}
@@ -14,7 +14,11 @@ type MockUserRepository struct {
 
 
 func (m *MockUserRepository) GetByEmailOrUsername(ctx context.Context, emailOrUsername string) (*model.User, error) {
-	panic("unimplemented")
+	args := m.Called(ctx, emailOrUsername)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
 }
 
 func (m *MockUserRepository) UpdateByEmail(ctx context.Context, email string, updatedUser *model.User) (*model.User, error) {
@@ -140,3 +144,11 @@ func (m *MockUserRepository) Get(ctx context.Context) (*[]model.User, error) {
 	}
 	return args.Get(0).(*[]model.User), args.Error(1)
 }
+
+func (m *MockUserRepository) GetByRoles(ctx context.Context, roles ...model.UserRole) ([]model.User, error) {
+	args := m.Called(ctx, roles)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.User), args.Error(1)
+}
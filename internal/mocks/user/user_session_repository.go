@@ -32,11 +32,6 @@ func (m *MockUserSessionRepository) GetByRefreshTokenID(ctx context.Context, ref
 	return args.Get(0).(*model.UserSession), args.Error(1)
 }
 
-func (m *MockUserSessionRepository) DeleteTX(ctx context.Context, tx *gorm.DB, sessionID uint) error {
-	args := m.Called(ctx, tx, sessionID)
-	return args.Error(0)
-}
-
 func (m *MockUserSessionRepository) UpdateTX(ctx context.Context, tx *gorm.DB, session *model.UserSession) error {
 	args := m.Called(ctx, tx, session)
 	return args.Error(0)
@@ -61,4 +56,12 @@ func (m *MockUserSessionRepository) GetByUserID(ctx context.Context, userID uint
 func (m *MockUserSessionRepository) DeleteByUserIDTX(ctx context.Context, tx *gorm.DB, userID uint) error {
 	args := m.Called(ctx, tx, userID)
 	return args.Error(0)
+}
+
+func (m *MockUserSessionRepository) GetActiveByUserID(ctx context.Context, userID uint, now int64) ([]model.UserSession, error) {
+	args := m.Called(ctx, userID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.UserSession), args.Error(1)
 }
\ No newline at end of file
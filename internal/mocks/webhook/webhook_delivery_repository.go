@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDeliveryRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepository) CreateForEvent(ctx context.Context, delivery *model.WebhookDelivery) (bool, error) {
+	args := m.Called(ctx, delivery)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) Update(ctx context.Context, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error) {
+	args := m.Called(ctx, delivery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) GetByID(ctx context.Context, deliveryID uint) (*model.WebhookDelivery, error) {
+	args := m.Called(ctx, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) GetByIDAndWebhookID(ctx context.Context, deliveryID, webhookID uint) (*model.WebhookDelivery, error) {
+	args := m.Called(ctx, deliveryID, webhookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) GetForEvent(ctx context.Context, webhookID uint, eventID string) (*model.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) GetByWebhookIDPaginated(ctx context.Context, webhookID, cursorID uint, status string, limit int) ([]model.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, cursorID, status, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.WebhookDelivery), args.Error(1)
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"context"
+	"pingspot/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *model.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	args := m.Called(ctx, webhook)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, webhookID uint) error {
+	args := m.Called(ctx, webhookID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetByIDAndUserID(ctx context.Context, webhookID, userID uint) (*model.Webhook, error) {
+	args := m.Called(ctx, webhookID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Webhook, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) CountByUserID(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetActiveByEvent(ctx context.Context, eventType model.WebhookEventType) ([]model.Webhook, error) {
+	args := m.Called(ctx, eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Webhook), args.Error(1)
+}
@@ -0,0 +1,12 @@
+package model
+
+type AreaSubscription struct {
+	ID           uint    `gorm:"primaryKey"`
+	UserID       uint    `gorm:"not null;index"`
+	User         User    `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Label        *string `gorm:"size:100"`
+	Latitude     float64 `gorm:"not null"`
+	Longitude    float64 `gorm:"not null"`
+	RadiusMeters int     `gorm:"not null"`
+	CreatedAt    int64   `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,24 @@
+package model
+
+type IncidentAlertStatus string
+
+const (
+	IncidentAlertActive   IncidentAlertStatus = "ACTIVE"
+	IncidentAlertResolved IncidentAlertStatus = "RESOLVED"
+)
+
+type IncidentAlert struct {
+	ID            uint                `gorm:"primaryKey"`
+	ReportType    ReportType          `gorm:"type:varchar(30);not null;index"`
+	Latitude      float64             `gorm:"not null"`
+	Longitude     float64             `gorm:"not null"`
+	RadiusMeters  int                 `gorm:"not null"`
+	ReportCount   int64               `gorm:"not null"`
+	BaselineCount float64             `gorm:"not null"`
+	WindowStart   int64               `gorm:"not null"`
+	WindowEnd     int64               `gorm:"not null"`
+	Status        IncidentAlertStatus `gorm:"type:varchar(20);default:'ACTIVE';not null;index"`
+	ResolvedAt    *int64              `gorm:"default:null"`
+	ResolvedByID  *uint               `gorm:"default:null"`
+	CreatedAt     int64               `gorm:"autoCreateTime"`
+}
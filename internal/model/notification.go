@@ -14,6 +14,7 @@ const (
 	GeneralNotificationCategory NotificationCategory = "GENERAL"
 	ReportNotificationCategory  NotificationCategory = "REPORT"
 	UserNotificationCategory    NotificationCategory = "USER"
+	IncidentNotificationCategory NotificationCategory = "INCIDENT"
 )
 
 type EntityType string
@@ -22,21 +23,78 @@ const (
 	EntityTypeReport EntityType = "REPORT"
 	EntityTypeUser   EntityType = "USER"
 	EntityTypeComment EntityType = "COMMENT"
+	EntityTypeIncident EntityType = "INCIDENT"
 )
 
 type Notification struct {
 	ID             uint   `gorm:"primaryKey"`
-	UserID         uint   `gorm:"not null"`
+	UserID         uint   `gorm:"not null;index:idx_notifications_user_read,priority:1;uniqueIndex:idx_notifications_user_group,priority:1"`
 	User           User   `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Title          string `gorm:"size:255;not null"`
 	Description    string `gorm:"size:1000;not null"`
+	MessageKey     *string `gorm:"size:100;default:null"`
+	MessageParams  *string `gorm:"type:text;default:null"`
 	Type           NotificationType           `gorm:"size:50;not null"`
 	Category       NotificationCategory       `gorm:"size:50;not null"`
-	IsRead         *bool  `gorm:"default:false"`
+	IsRead         *bool  `gorm:"default:false;index:idx_notifications_user_read,priority:2"`
 	ReadAt         *int64 `gorm:"default:null"`
 	EntityID       *string `gorm:"default:null"`
 	EntityType     *EntityType `gorm:"size:50;default:null"`
+	GroupKey       *string `gorm:"size:255;default:null;uniqueIndex:idx_notifications_user_group,priority:2"`
+	ActorCount     int    `gorm:"not null;default:0"`
+	IsHidden       bool   `gorm:"not null;default:false"`
 	CreatedAt      int64  `gorm:"autoCreateTime"`
+	UpdatedAt      int64  `gorm:"autoUpdateTime"`
 	DeletedAt      *int64 `gorm:"default:null"`
 	IsDeleted      *bool  `gorm:"default:false"`
 }
+
+type NotificationActor struct {
+	ID             uint         `gorm:"primaryKey"`
+	NotificationID uint         `gorm:"not null;uniqueIndex:idx_notification_actor"`
+	Notification   Notification `gorm:"foreignKey:NotificationID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ActorID        uint         `gorm:"not null;uniqueIndex:idx_notification_actor"`
+	ActorName      string       `gorm:"size:255;not null"`
+	CreatedAt      int64        `gorm:"autoCreateTime"`
+}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryPending  NotificationDeliveryStatus = "PENDING"
+	NotificationDeliveryRetrying NotificationDeliveryStatus = "RETRYING"
+	NotificationDeliverySent     NotificationDeliveryStatus = "SENT"
+	NotificationDeliveryFailed   NotificationDeliveryStatus = "FAILED"
+	NotificationDeliverySkipped  NotificationDeliveryStatus = "SKIPPED"
+)
+
+// NotificationDelivery tracks one attempt chain of an in-app notification on an
+// external channel. The in-app row itself is its own delivery record.
+type NotificationDelivery struct {
+	ID                uint                       `gorm:"primaryKey"`
+	NotificationID    uint                       `gorm:"not null;uniqueIndex:idx_notification_delivery_channel"`
+	Notification      Notification               `gorm:"foreignKey:NotificationID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	UserID            uint                       `gorm:"not null;index"`
+	User              User                       `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Channel           NotificationChannel        `gorm:"type:varchar(20);not null;uniqueIndex:idx_notification_delivery_channel"`
+	Status            NotificationDeliveryStatus `gorm:"type:varchar(20);default:PENDING;not null;index"`
+	Attempts          int                        `gorm:"default:0;not null"`
+	ProviderMessageID *string                    `gorm:"size:255"`
+	LastError         *string                    `gorm:"type:text"`
+	NextRetryAt       *int64
+	DeliveredAt       *int64
+	CreatedAt         int64 `gorm:"autoCreateTime"`
+	UpdatedAt         int64 `gorm:"autoUpdateTime"`
+}
+
+type PushSubscription struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	User      User   `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Endpoint  string `gorm:"type:text;not null;uniqueIndex"`
+	P256dh    string `gorm:"size:255;not null"`
+	Auth      string `gorm:"size:255;not null"`
+	UserAgent *string `gorm:"type:text"`
+	CreatedAt int64  `gorm:"autoCreateTime"`
+	UpdatedAt int64  `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,66 @@
+package model
+
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "IN_APP"
+	NotificationChannelEmail NotificationChannel = "EMAIL"
+	NotificationChannelPush  NotificationChannel = "PUSH"
+	NotificationChannelSMS   NotificationChannel = "SMS"
+)
+
+type NotificationEvent string
+
+const (
+	NotificationEventVote         NotificationEvent = "VOTE"
+	NotificationEventComment      NotificationEvent = "COMMENT"
+	NotificationEventReply        NotificationEvent = "REPLY"
+	NotificationEventMention      NotificationEvent = "MENTION"
+	NotificationEventFollow       NotificationEvent = "FOLLOW"
+	NotificationEventStatusChange NotificationEvent = "STATUS_CHANGE"
+	NotificationEventReaction     NotificationEvent = "REACTION"
+	NotificationEventReportMerged NotificationEvent = "REPORT_MERGED"
+)
+
+// NotificationPreference is a single opt-out rule scoped to either a category
+// or an event kind; the other scope column is left empty.
+type NotificationPreference struct {
+	ID        uint                 `gorm:"primaryKey"`
+	UserID    uint                 `gorm:"not null;uniqueIndex:idx_notification_preferences_rule"`
+	User      User                 `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Category  NotificationCategory `gorm:"size:50;not null;default:'';uniqueIndex:idx_notification_preferences_rule"`
+	Event     NotificationEvent    `gorm:"size:50;not null;default:'';uniqueIndex:idx_notification_preferences_rule"`
+	Channel   NotificationChannel  `gorm:"size:20;not null;uniqueIndex:idx_notification_preferences_rule"`
+	Enabled   bool                 `gorm:"not null"`
+	UpdatedAt int64                `gorm:"autoUpdateTime"`
+}
+
+type NotificationDigestFrequency string
+
+const (
+	NotificationDigestNone   NotificationDigestFrequency = "NONE"
+	NotificationDigestDaily  NotificationDigestFrequency = "DAILY"
+	NotificationDigestWeekly NotificationDigestFrequency = "WEEKLY"
+)
+
+type NotificationSetting struct {
+	ID              uint    `gorm:"primaryKey"`
+	UserID          uint    `gorm:"not null;uniqueIndex"`
+	User            User    `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	QuietHoursStart *string `gorm:"size:5"`
+	QuietHoursEnd   *string `gorm:"size:5"`
+	Timezone        string  `gorm:"size:64;not null;default:'Asia/Jakarta'"`
+	DigestFrequency NotificationDigestFrequency `gorm:"size:20;not null;default:'NONE';index"`
+	DigestHour      int     `gorm:"not null;default:8"`
+	LastDigestSentAt *int64 `gorm:"default:null"`
+	PhoneNumber     *string `gorm:"size:20;default:null"`
+	UpdatedAt       int64   `gorm:"autoUpdateTime"`
+}
+
+type NotificationReportMute struct {
+	ID        uint  `gorm:"primaryKey"`
+	UserID    uint  `gorm:"not null;uniqueIndex:idx_notification_report_mutes"`
+	User      User  `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ReportID  uint  `gorm:"not null;uniqueIndex:idx_notification_report_mutes"`
+	CreatedAt int64 `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,13 @@
+package model
+
+type Open311APIKey struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"not null;index"`
+	User       User   `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name       string `gorm:"size:100;not null"`
+	KeyPrefix  string `gorm:"size:16;not null"`
+	HashedKey  string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	LastUsedAt *int64
+	RevokedAt  *int64 `gorm:"index"`
+	CreatedAt  int64  `gorm:"autoCreateTime"`
+}
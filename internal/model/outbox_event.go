@@ -0,0 +1,26 @@
+package model
+
+type OutboxEventStatus string
+
+const (
+	OutboxEventPending    OutboxEventStatus = "PENDING"
+	OutboxEventPublishing OutboxEventStatus = "PUBLISHING"
+	OutboxEventPublished  OutboxEventStatus = "PUBLISHED"
+	OutboxEventFailed     OutboxEventStatus = "FAILED"
+)
+
+type OutboxEvent struct {
+	ID               uint   `gorm:"primaryKey"`
+	EventID          string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	TaskType         string `gorm:"type:varchar(100);not null"`
+	Payload          string `gorm:"type:text;not null"`
+	ProcessAt        int64  `gorm:"not null"`
+	UniqueTTLSeconds int64  `gorm:"default:0;not null"`
+	MaxRetry         *int
+	Status           OutboxEventStatus `gorm:"type:varchar(20);default:PENDING;not null;index:idx_outbox_status_available"`
+	Attempts         int               `gorm:"default:0;not null"`
+	LastError        *string           `gorm:"type:text"`
+	AvailableAt      int64             `gorm:"not null;index:idx_outbox_status_available"`
+	PublishedAt      *int64
+	CreatedAt        int64 `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,17 @@
+package model
+
+type PresenceStatus string
+
+const (
+	PresenceOnline  PresenceStatus = "online"
+	PresenceIdle    PresenceStatus = "idle"
+	PresenceOffline PresenceStatus = "offline"
+)
+
+type PresenceVisibility string
+
+const (
+	PresenceVisibleEveryone    PresenceVisibility = "EVERYONE"
+	PresenceVisibleConnections PresenceVisibility = "CONNECTIONS"
+	PresenceVisibleNobody      PresenceVisibility = "NOBODY"
+)
@@ -0,0 +1,20 @@
+package model
+
+type RealtimeScope string
+
+const (
+	RealtimeScopeUser   RealtimeScope = "user"
+	RealtimeScopeReport RealtimeScope = "report"
+)
+
+type RealtimeEventType string
+
+const (
+	RealtimeNotificationCreated RealtimeEventType = "notification.created"
+	RealtimeNotificationUpdated RealtimeEventType = "notification.updated"
+	RealtimeUnreadCount         RealtimeEventType = "notification.unread_count"
+	RealtimeCommentCreated      RealtimeEventType = "comment.created"
+	RealtimeReportVoteChanged   RealtimeEventType = "report.vote_changed"
+	RealtimeReportStatusChanged RealtimeEventType = "report.status_changed"
+	RealtimeSessionRevoked      RealtimeEventType = "session.revoked"
+)
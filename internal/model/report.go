@@ -42,11 +42,22 @@ type Report struct {
 	PotentiallyResolvedAt *int64            `gorm:"default:null"`
 	ReportStatus      ReportStatus      `gorm:"type:varchar(50);default:'WAITING'"`
 	HasProgress       *bool             `gorm:"default:true"`
+	IsAnonymous       bool              `gorm:"default:false;not null"`
 	LastUpdatedBy 	LastUpdatedBy `gorm:"type:varchar(50);default:NULL"`
 	LastUpdatedProgressAt *int64            `gorm:"default:null"`
 	AdminOverride   *bool             `gorm:"default:false"`
+	PriorityScore     float64           `gorm:"default:0;not null;index"`
+	PriorityUpdatedAt *int64            `gorm:"default:null"`
+	HotScore          float64           `gorm:"default:0;not null;index"`
+	ViewCount         int64             `gorm:"default:0;not null"`
+	ResolvedVoteCount    int64          `gorm:"default:0;not null"`
+	OnProgressVoteCount  int64          `gorm:"default:0;not null"`
+	ResolvedVoteWeight   float64        `gorm:"default:0;not null"`
+	OnProgressVoteWeight float64        `gorm:"default:0;not null"`
 	IsDeleted         *bool             `gorm:"default:false"`
 	DeletedAt 		*int64            `gorm:"default:null"`
+	MergedIntoID      *uint             `gorm:"default:null;index"`
+	MergedAt          *int64            `gorm:"default:null"`
 	SearchVector string `gorm:"column:search_vector;->;-:migration"`
 	ReportLocation    *ReportLocation   `gorm:"foreignKey:ReportID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	ReportImages      *ReportImage      `gorm:"foreignKey:ReportID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
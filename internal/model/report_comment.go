@@ -34,6 +34,8 @@ type ReportComment struct {
 	ParentCommentID *primitive.ObjectID `bson:"parent_comment_id,omitempty"`
 	ThreadRootID    *primitive.ObjectID `bson:"thread_root_id,omitempty"`
 
+	IsHelpful bool `bson:"is_helpful,omitempty"`
+
 	CreatedAt int64 `bson:"created_at"`
 	UpdatedAt *int64 `bson:"updated_at,omitempty"`
 }
\ No newline at end of file
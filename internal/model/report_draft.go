@@ -0,0 +1,35 @@
+package model
+
+type ReportDraft struct {
+	ID                uint     `gorm:"primaryKey"`
+	UserID            uint     `gorm:"not null;index"`
+	User              User     `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ReportTitle       *string  `gorm:"type:text"`
+	ReportType        *string  `gorm:"type:text"`
+	ReportDescription *string  `gorm:"type:text"`
+	HasProgress       *bool    `gorm:"type:boolean"`
+	IsAnonymous       *bool    `gorm:"type:boolean"`
+	DetailLocation    *string  `gorm:"type:text"`
+	Latitude          *float64 `gorm:"type:double precision"`
+	Longitude         *float64 `gorm:"type:double precision"`
+	DisplayName       *string  `gorm:"type:text"`
+	MapZoom           *int     `gorm:"type:int"`
+	AddressType       *string  `gorm:"type:text"`
+	Country           *string  `gorm:"type:text"`
+	CountryCode       *string  `gorm:"type:text"`
+	Region            *string  `gorm:"type:text"`
+	Road              *string  `gorm:"type:text"`
+	PostCode          *string  `gorm:"type:text"`
+	County            *string  `gorm:"type:text"`
+	State             *string  `gorm:"type:text"`
+	Village           *string  `gorm:"type:text"`
+	Suburb            *string  `gorm:"type:text"`
+	Image1URL         *string  `gorm:"size:255"`
+	Image2URL         *string  `gorm:"size:255"`
+	Image3URL         *string  `gorm:"size:255"`
+	Image4URL         *string  `gorm:"size:255"`
+	Image5URL         *string  `gorm:"size:255"`
+	ExpiresAt         int64    `gorm:"not null;index"`
+	CreatedAt         int64    `gorm:"autoCreateTime"`
+	UpdatedAt         int64    `gorm:"autoUpdateTime"`
+}
@@ -7,6 +7,8 @@ type ReportVote struct {
 	User     User   `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Report   Report `gorm:"foreignKey:ReportID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	VoteType ReportStatus `gorm:"type:varchar(50);not null"`
+	Weight   float64 `gorm:"default:1;not null"`
+	DistanceMeters *float64 `gorm:"default:null"`
 	CreatedAt int64  `gorm:"autoCreateTime"`
 	UpdatedAt int64  `gorm:"autoUpdateTime"`
 }
\ No newline at end of file
@@ -0,0 +1,34 @@
+package model
+
+type ReputationEventType string
+
+const (
+	ReputationReportResolved    ReputationEventType = "REPORT_RESOLVED"
+	ReputationReportMerged      ReputationEventType = "REPORT_MERGED"
+	ReputationVoteAccurate      ReputationEventType = "VOTE_ACCURATE"
+	ReputationVoteInaccurate    ReputationEventType = "VOTE_INACCURATE"
+	ReputationHelpfulComment    ReputationEventType = "HELPFUL_COMMENT"
+	ReputationModerationPenalty ReputationEventType = "MODERATION_PENALTY"
+)
+
+type ReputationSourceType string
+
+const (
+	ReputationSourceReport     ReputationSourceType = "REPORT"
+	ReputationSourceReportVote ReputationSourceType = "REPORT_VOTE"
+	ReputationSourceComment    ReputationSourceType = "REPORT_COMMENT"
+	ReputationSourceModeration ReputationSourceType = "MODERATION"
+)
+
+type ReputationEvent struct {
+	ID          uint                 `gorm:"primaryKey"`
+	UserID      uint                 `gorm:"not null;index;uniqueIndex:idx_reputation_event_source"`
+	User        User                 `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	EventType   ReputationEventType  `gorm:"type:varchar(30);not null;uniqueIndex:idx_reputation_event_source"`
+	Points      int64                `gorm:"not null"`
+	SourceType  ReputationSourceType `gorm:"type:varchar(30);not null;uniqueIndex:idx_reputation_event_source"`
+	SourceID    string               `gorm:"size:64;not null;uniqueIndex:idx_reputation_event_source"`
+	Reason      *string              `gorm:"type:text"`
+	CreatedByID *uint                `gorm:"default:null"`
+	CreatedAt   int64                `gorm:"autoCreateTime"`
+}
@@ -12,6 +12,14 @@ const (
 	ProviderFacebook Provider = "FACEBOOK"
 )
 
+type UserRole string
+
+const (
+	UserRoleUser      UserRole = "USER"
+	UserRoleModerator UserRole = "MODERATOR"
+	UserRoleAgency    UserRole = "AGENCY"
+)
+
 type User struct {
 	ID         uint      `gorm:"primaryKey;autoIncrement"`
 	Username   string    `gorm:"size:30;unique;not null"`
@@ -20,9 +28,14 @@ type User struct {
 	FullName   string    `gorm:"size:100;not null"`
 	Provider   Provider  `gorm:"type:varchar(20);default:EMAIL;not null"`
 	IsVerified bool      `gorm:"default:false;not null"`
+	Role       UserRole  `gorm:"type:varchar(20);default:USER;not null"`
 	ProviderID *string   `gorm:"size:100"`
 	Profile	UserProfile `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	IsDefaultUsername bool      `gorm:"default:true;not null"`
+	ReputationScore int64     `gorm:"default:0;not null;index"`
+	Language   *string   `gorm:"size:5"`
+	TwoFactorEnabled bool    `gorm:"default:false;not null"`
+	TwoFactorSecret  *string `gorm:"size:255"`
 	CreatedAt  time.Time `gorm:"autoCreateTime"`
 	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
 	SearchVector string    `gorm:"column:search_vector;->;-:migration"`
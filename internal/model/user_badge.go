@@ -0,0 +1,29 @@
+package model
+
+type BadgeCode string
+
+const (
+	BadgeFirstReport       BadgeCode = "FIRST_REPORT"
+	BadgeResolvedReports10 BadgeCode = "RESOLVED_REPORTS_10"
+	BadgeActiveVoter50     BadgeCode = "ACTIVE_VOTER_50"
+	BadgeHelpfulCommenter5 BadgeCode = "HELPFUL_COMMENTER_5"
+	BadgeTopRegionalVoter  BadgeCode = "TOP_REGIONAL_VOTER"
+)
+
+type GamificationEventType string
+
+const (
+	GamificationReportCreated  GamificationEventType = "REPORT_CREATED"
+	GamificationVoteCast       GamificationEventType = "VOTE_CAST"
+	GamificationReportResolved GamificationEventType = "REPORT_RESOLVED"
+	GamificationCommentHelpful GamificationEventType = "COMMENT_HELPFUL"
+)
+
+type UserBadge struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index;uniqueIndex:idx_user_badge"`
+	User      User      `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	BadgeCode BadgeCode `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_badge"`
+	Region    string    `gorm:"size:100;not null;default:'';uniqueIndex:idx_user_badge"`
+	AwardedAt int64     `gorm:"autoCreateTime"`
+}
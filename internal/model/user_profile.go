@@ -7,4 +7,7 @@ type UserProfile struct {
 	ProfilePicture *string `gorm:"size:255"`
 	Gender 		   *string `gorm:"size:20"`
 	Birthday	   *string `gorm:"type:date"`
+	HomeLatitude   *float64 `gorm:"type:decimal(10,8)"`
+	HomeLongitude  *float64 `gorm:"type:decimal(11,8)"`
+	PresenceVisibility PresenceVisibility `gorm:"type:varchar(20);default:EVERYONE;not null"`
 }
\ No newline at end of file
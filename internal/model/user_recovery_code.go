@@ -0,0 +1,10 @@
+package model
+
+type UserRecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	User      User   `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CodeHash  string `gorm:"type:varchar(255);not null"`
+	UsedAt    *int64
+	CreatedAt int64 `gorm:"autoCreateTime"`
+}
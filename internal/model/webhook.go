@@ -0,0 +1,52 @@
+package model
+
+type WebhookEventType string
+
+const (
+	WebhookReportCreated       WebhookEventType = "report.created"
+	WebhookReportStatusChanged WebhookEventType = "report.status_changed"
+	WebhookReportProgressAdded WebhookEventType = "report.progress_added"
+	WebhookCommentCreated      WebhookEventType = "comment.created"
+	WebhookFollowCreated       WebhookEventType = "follow.created"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending  WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryRetrying WebhookDeliveryStatus = "RETRYING"
+	WebhookDeliverySuccess  WebhookDeliveryStatus = "SUCCESS"
+	WebhookDeliveryFailed   WebhookDeliveryStatus = "FAILED"
+)
+
+type Webhook struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	User      User   `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name      string `gorm:"size:100;not null"`
+	URL       string `gorm:"size:500;not null"`
+	Secret    string `gorm:"size:128;not null"`
+	Events    string `gorm:"type:text;not null"`
+	IsActive  bool   `gorm:"default:true;not null"`
+	CreatedAt int64  `gorm:"autoCreateTime"`
+	UpdatedAt int64  `gorm:"autoUpdateTime"`
+}
+
+type WebhookDelivery struct {
+	ID             uint                  `gorm:"primaryKey"`
+	WebhookID      uint                  `gorm:"not null;index;uniqueIndex:idx_webhook_deliveries_event,priority:1,where:replay_of_id IS NULL"`
+	Webhook        Webhook               `gorm:"foreignKey:WebhookID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	EventID        string                `gorm:"type:varchar(64);not null;index;uniqueIndex:idx_webhook_deliveries_event,priority:2,where:replay_of_id IS NULL"`
+	EventType      WebhookEventType      `gorm:"type:varchar(50);not null"`
+	Payload        string                `gorm:"type:text;not null"`
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);default:PENDING;not null;index"`
+	Attempts       int                   `gorm:"default:0;not null"`
+	ResponseStatus *int
+	ResponseBody   *string `gorm:"type:text"`
+	LastError      *string `gorm:"type:text"`
+	NextRetryAt    *int64
+	DeliveredAt    *int64
+	ReplayOfID     *uint
+	CreatedAt      int64 `gorm:"autoCreateTime"`
+	UpdatedAt      int64 `gorm:"autoUpdateTime"`
+}
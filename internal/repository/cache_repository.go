@@ -9,6 +9,8 @@ import (
 
 type CacheRepository interface {
 	Set(ctx context.Context, key string, value any, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value any, expiration time.Duration) (bool, error)
+	Incr(ctx context.Context, key string) (int64, error)
 	Get(ctx context.Context, key string) (string, error)
 	SAdd(ctx context.Context, key string, members ...any) error
 	Expire(ctx context.Context, key string, expiration time.Duration) (bool, error)
@@ -16,6 +18,10 @@ type CacheRepository interface {
 	SRem(ctx context.Context, key string, members ...any) error
 	SIsMember(ctx context.Context, key string, member any) (bool, error)
 	TTL(ctx context.Context, key string) (time.Duration, error)
+	ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error)
+	ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error)
+	ZRevRank(ctx context.Context, key string, member string) (int64, error)
+	ZScore(ctx context.Context, key string, member string) (float64, error)
 }
 
 type cacheRepository struct {
@@ -30,6 +36,14 @@ func (r *cacheRepository) Set(ctx context.Context, key string, value any, expira
     return (*r.rdb).Set(ctx, key, value, expiration).Err()
 }
 
+func (r *cacheRepository) SetNX(ctx context.Context, key string, value any, expiration time.Duration) (bool, error) {
+	return (*r.rdb).SetNX(ctx, key, value, expiration).Result()
+}
+
+func (r *cacheRepository) Incr(ctx context.Context, key string) (int64, error) {
+	return (*r.rdb).Incr(ctx, key).Result()
+}
+
 func (r *cacheRepository) SAdd(ctx context.Context, key string, members ...any) error {
     return (*r.rdb).SAdd(ctx, key, members...).Err()
 }
@@ -56,4 +70,20 @@ func (r *cacheRepository) SIsMember(ctx context.Context, key string, member any)
 
 func (r *cacheRepository) Get(ctx context.Context, key string) (string, error) {
     return (*r.rdb).Get(ctx, key).Result()
-}
\ No newline at end of file
+}
+
+func (r *cacheRepository) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	return (*r.rdb).ZIncrBy(ctx, key, increment, member).Result()
+}
+
+func (r *cacheRepository) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	return (*r.rdb).ZRevRangeWithScores(ctx, key, start, stop).Result()
+}
+
+func (r *cacheRepository) ZRevRank(ctx context.Context, key string, member string) (int64, error) {
+	return (*r.rdb).ZRevRank(ctx, key, member).Result()
+}
+
+func (r *cacheRepository) ZScore(ctx context.Context, key string, member string) (float64, error) {
+	return (*r.rdb).ZScore(ctx, key, member).Result()
+}
@@ -7,6 +7,15 @@ import (
 	userRouter "pingspot/internal/domain/user_service/router"
 	socialRouter "pingspot/internal/domain/social_service/router"
 	notificationRouter "pingspot/internal/domain/notification_service/router"
+	incidentRouter "pingspot/internal/domain/incident_service/router"
+	feedRouter "pingspot/internal/domain/feed_service/router"
+	reputationRouter "pingspot/internal/domain/reputation_service/router"
+	gamificationRouter "pingspot/internal/domain/gamification_service/router"
+	publicRouter "pingspot/internal/domain/public_service/router"
+	open311Router "pingspot/internal/domain/open311_service/router"
+	webhookRouter "pingspot/internal/domain/webhook_service/router"
+	realtimeRouter "pingspot/internal/domain/realtime_service/router"
+	presenceRouter "pingspot/internal/domain/presence_service/router"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,4 +27,13 @@ func RegisterRoutes(app *fiber.App) {
 	mainRouter.RegisterReportRoutes(app)
 	socialRouter.RegisterSocialRoutes(app)
 	notificationRouter.RegisterNotificationRoutes(app)
+	incidentRouter.RegisterIncidentRoutes(app)
+	feedRouter.RegisterFeedRoutes(app)
+	reputationRouter.RegisterReputationRoutes(app)
+	gamificationRouter.RegisterGamificationRoutes(app)
+	publicRouter.RegisterPublicRoutes(app)
+	open311Router.RegisterOpen311Routes(app)
+	webhookRouter.RegisterWebhookRoutes(app)
+	realtimeRouter.RegisterRealtimeRoutes(app)
+	presenceRouter.RegisterPresenceRoutes(app)
 }
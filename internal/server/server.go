@@ -29,13 +29,14 @@ func New() *FiberServer {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     env.AllowedOrigins(),
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS,PATCH",
-		AllowHeaders:     "Accept,Authorization,Content-Type,X-Requested-With",
+		AllowHeaders:     "Accept,Accept-Language,Authorization,Content-Type,X-Requested-With",
 		ExposeHeaders:    "Set-Cookie",
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
 	app.Use(middleware.RequestIDMiddleware())
+	app.Use(middleware.LocalizationMiddleware())
 	app.Use(middleware.LoggingMiddleware())
 	app.Use(middleware.GlobalRateLimiterMiddleware())
 
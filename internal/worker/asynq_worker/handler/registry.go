@@ -1,21 +1,78 @@
 package handler
 
 import (
+	"fmt"
 	reportRepo "pingspot/internal/domain/report_service/repository"
 	notificationRepo "pingspot/internal/domain/notification_service/repository"
+	notificationChannel "pingspot/internal/domain/notification_service/channel"
+	incidentRepo "pingspot/internal/domain/incident_service/repository"
+	reputationRepo "pingspot/internal/domain/reputation_service/repository"
+	gamificationRepo "pingspot/internal/domain/gamification_service/repository"
+	webhookRepo "pingspot/internal/domain/webhook_service/repository"
+	taskService "pingspot/internal/domain/task_service/service"
+	taskRepo "pingspot/internal/domain/task_service/repository"
+	realtimeService "pingspot/internal/domain/realtime_service/service"
+	cacheRepo "pingspot/internal/repository"
+	"pingspot/internal/infrastructure/cache"
+	userRepo "pingspot/internal/domain/user_service/repository"
 	taskHandler "pingspot/internal/domain/task_service/handler"
 	"pingspot/internal/domain/task_service/tasks"
 	"pingspot/internal/infrastructure/database"
+	"pingspot/pkg/logger"
+	"pingspot/pkg/mailer"
+	env "pingspot/pkg/utils/env_util"
 
 	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
 )
 
 func RegisterAllHandlers(mux *asynq.ServeMux) {
 	db := database.GetPostgresDB()
+	reportReactionRepo := reportRepo.NewReportReactionRepository(db)
+	reportVoteRepo := reportRepo.NewReportVoteRepository(db)
+	reportCommentRepo := reportRepo.NewReportCommentRepository(database.GetMongoDB())
+	reportProgressRepo := reportRepo.NewReportProgressRepository(db)
 	reportRepo := reportRepo.NewReportRepository(db)
+	notificationPreferenceRepo := notificationRepo.NewNotificationPreferenceRepository(db)
+	notificationUnreadCounterRepo := notificationRepo.NewNotificationUnreadCounterRepository(cache.GetRedis())
+	notificationDeliveryRepo := notificationRepo.NewNotificationDeliveryRepository(db)
+	pushSubscriptionRepo := notificationRepo.NewPushSubscriptionRepository(db)
 	notificationRepo := notificationRepo.NewNotificationRepository(db)
-	taskHandler := taskHandler.NewTaskHandler(db, reportRepo, notificationRepo)
+	userRepo := userRepo.NewUserRepository(db)
+	incidentAlertRepo := incidentRepo.NewIncidentAlertRepository(db)
+	areaSubscriptionRepo := incidentRepo.NewAreaSubscriptionRepository(db)
+	reputationRepo := reputationRepo.NewReputationRepository(db)
+	gamificationRepo := gamificationRepo.NewGamificationRepository(db)
+	rdb := cache.GetRedis()
+	cacheRepo := cacheRepo.NewCacheRepository(&rdb)
+	webhookRepository := webhookRepo.NewWebhookRepository(db)
+	webhookDeliveryRepository := webhookRepo.NewWebhookDeliveryRepository(db)
+	redisAddress := fmt.Sprintf("%s:%s", env.RedisHost(), env.RedisPort())
+	tasksService := taskService.NewTaskService(asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddress}))
+	realtimePublisher := realtimeService.NewPublisher(rdb)
+	inAppChannel := notificationChannel.NewInAppChannel(notificationRepo, notificationUnreadCounterRepo, realtimePublisher)
+	notificationChannels := notificationChannel.NewDefaultRegistry(inAppChannel, pushSubscriptionRepo)
+	emailMailer, err := mailer.Default()
+	if err != nil {
+		logger.Error("Failed to configure mailer, queued emails will be retried", zap.Error(err))
+	}
+	emailIdempotencyRepo := taskRepo.NewEmailIdempotencyRepository(rdb)
+	taskHandler := taskHandler.NewTaskHandler(db, reportRepo, reportReactionRepo, reportVoteRepo, reportCommentRepo, reportProgressRepo, notificationRepo, notificationPreferenceRepo, notificationDeliveryRepo, userRepo, incidentAlertRepo, areaSubscriptionRepo, reputationRepo, gamificationRepo, cacheRepo, webhookRepository, webhookDeliveryRepository, tasksService, realtimePublisher, inAppChannel, notificationChannels, emailMailer, emailIdempotencyRepo)
 
 	mux.HandleFunc(tasks.TaskAutoResolveReport, taskHandler.AutoResolveReportHandler)
 	mux.HandleFunc(tasks.TaskCreateNotification, taskHandler.CreateNotificationHandler)
+	mux.HandleFunc(tasks.TaskDetectReportSurge, taskHandler.DetectReportSurgeHandler)
+	mux.HandleFunc(tasks.TaskRecalculateReportPriority, taskHandler.RecalculateReportPriorityHandler)
+	mux.HandleFunc(tasks.TaskRecalculateAllReportPriorities, taskHandler.RecalculateAllReportPrioritiesHandler)
+	mux.HandleFunc(tasks.TaskRecalculateHotScores, taskHandler.RecalculateHotScoresHandler)
+	mux.HandleFunc(tasks.TaskMoveMergedReportComments, taskHandler.MoveMergedReportCommentsHandler)
+	mux.HandleFunc(tasks.TaskEvaluateReportReputation, taskHandler.EvaluateReportReputationHandler)
+	mux.HandleFunc(tasks.TaskAwardReputation, taskHandler.AwardReputationHandler)
+	mux.HandleFunc(tasks.TaskProcessGamificationEvent, taskHandler.ProcessGamificationEventHandler)
+	mux.HandleFunc(tasks.TaskDispatchWebhookEvent, taskHandler.DispatchWebhookEventHandler)
+	mux.HandleFunc(tasks.TaskDeliverWebhook, taskHandler.DeliverWebhookHandler)
+	mux.HandleFunc(tasks.TaskPublishRealtimeEvent, taskHandler.PublishRealtimeEventHandler)
+	mux.HandleFunc(tasks.TaskSendNotificationDigest, taskHandler.SendNotificationDigestHandler)
+	mux.HandleFunc(tasks.TaskDeliverNotification, taskHandler.DeliverNotificationHandler)
+	mux.HandleFunc(tasks.TaskSendEmail, taskHandler.SendEmailHandler)
 }
@@ -0,0 +1,80 @@
+package asynqWorker
+
+import (
+	"errors"
+	"fmt"
+	"pingspot/internal/config"
+	"pingspot/internal/domain/task_service/tasks"
+	"pingspot/pkg/logger"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// SchedulerServer enqueues the periodic tasks. Every API process runs one, so
+// each tick is enqueued with a task ID derived from the period it belongs to
+// and only the first process to reach Redis gets its task accepted.
+type SchedulerServer struct {
+	cron   *cron.Cron
+	client *asynq.Client
+}
+
+type periodicTask struct {
+	spec     string
+	taskType string
+	period   time.Duration
+}
+
+var periodicTasks = []periodicTask{
+	{spec: "*/15 * * * *", taskType: tasks.TaskDetectReportSurge, period: 15 * time.Minute},
+	{spec: "0 * * * *", taskType: tasks.TaskRecalculateAllReportPriorities, period: time.Hour},
+	{spec: "*/10 * * * *", taskType: tasks.TaskRecalculateHotScores, period: 10 * time.Minute},
+}
+
+func NewSchedulerServer(cfg config.RedisConfig) *SchedulerServer {
+	opt := asynq.RedisClientOpt{
+		Addr:     fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	}
+
+	return &SchedulerServer{
+		cron:   cron.New(),
+		client: asynq.NewClient(opt),
+	}
+}
+
+func (s *SchedulerServer) Run() error {
+	for _, task := range periodicTasks {
+		if _, err := s.cron.AddFunc(task.spec, func() { s.enqueuePeriodic(task, time.Now()) }); err != nil {
+			logger.Error("Failed to register periodic task", zap.String("task_type", task.taskType), zap.Error(err))
+			return err
+		}
+	}
+
+	s.cron.Start()
+	return nil
+}
+
+func (s *SchedulerServer) enqueuePeriodic(task periodicTask, now time.Time) {
+	taskID := getPeriodicTaskID(task.taskType, task.period, now)
+	_, err := s.client.Enqueue(
+		asynq.NewTask(task.taskType, nil),
+		asynq.TaskID(taskID),
+		asynq.Retention(task.period),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		logger.Error("Failed to enqueue periodic task", zap.String("task_id", taskID), zap.Error(err))
+	}
+}
+
+func getPeriodicTaskID(taskType string, period time.Duration, now time.Time) string {
+	return fmt.Sprintf("%s:%d", taskType, now.Truncate(period).Unix())
+}
+
+func (s *SchedulerServer) Stop() {
+	<-s.cron.Stop().Done()
+	s.client.Close()
+}
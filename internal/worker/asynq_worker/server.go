@@ -3,8 +3,13 @@ package asynqWorker
 import (
 	"fmt"
 	"pingspot/internal/config"
+	notificationUtil "pingspot/internal/domain/notification_service/util"
+	"pingspot/internal/domain/task_service/tasks"
+	webhookUtil "pingspot/internal/domain/webhook_service/util"
 	"pingspot/internal/worker/asynq_worker/handler"
+	"pingspot/pkg/backoff"
 	"pingspot/pkg/logger"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"go.uber.org/zap"
@@ -29,7 +34,8 @@ func NewWorkerServer(cfg config.RedisConfig) *WorkerServer {
 		server: asynq.NewServer(
 			opt,
 			asynq.Config{
-				Concurrency: 10,
+				Concurrency:    10,
+				RetryDelayFunc: retryDelay,
 			},
 		),
 		client:    asynq.NewClient(opt),
@@ -37,6 +43,19 @@ func NewWorkerServer(cfg config.RedisConfig) *WorkerServer {
 	}
 }
 
+// retryDelay spaces out delivery retries with the same backoff the handlers
+// record in NextRetryAt. n counts earlier retries, so n+1 is the attempt that
+// just failed. Other tasks keep asynq's default.
+func retryDelay(n int, err error, task *asynq.Task) time.Duration {
+	switch task.Type() {
+	case tasks.TaskDeliverWebhook:
+		return backoff.Exponential(n+1, webhookUtil.BaseRetryDelay, webhookUtil.MaxRetryDelay)
+	case tasks.TaskDeliverNotification:
+		return backoff.Exponential(n+1, notificationUtil.BaseDeliveryRetryDelay, notificationUtil.MaxDeliveryRetryDelay)
+	}
+	return asynq.DefaultRetryDelayFunc(n, err, task)
+}
+
 func (w *WorkerServer) Run() error {
 	mux := asynq.NewServeMux()
 
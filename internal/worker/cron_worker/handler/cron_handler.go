@@ -2,11 +2,20 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	reportUtil "pingspot/internal/domain/report_service/util"
+	notificationRepository "pingspot/internal/domain/notification_service/repository"
+	notificationUtil "pingspot/internal/domain/notification_service/util"
 	"pingspot/internal/domain/report_service/repository"
 	"pingspot/internal/domain/task_service/service"
+	webhookDTO "pingspot/internal/domain/webhook_service/dto"
+	realtimeDTO "pingspot/internal/domain/realtime_service/dto"
+	"pingspot/internal/model"
 	"pingspot/internal/worker/cron_worker/util"
+	"pingspot/pkg/i18n"
 	"pingspot/pkg/logger"
+	"pingspot/pkg/mailer"
 	env "pingspot/pkg/utils/env_util"
 	mainutils "pingspot/pkg/utils/main_util"
 	"time"
@@ -15,16 +24,24 @@ import (
 )
 
 type CronHandler struct {
-	db           *gorm.DB
-	reportRepo   repository.ReportRepository
-	tasksService service.TaskService
+	db              *gorm.DB
+	reportRepo      repository.ReportRepository
+	reportDraftRepo repository.ReportDraftRepository
+	reportImageRepo repository.ReportImageRepository
+	notificationPreferenceRepo notificationRepository.NotificationPreferenceRepository
+	tasksService    service.TaskService
+	outboxRelay     *service.OutboxRelay
 }
 
-func NewCronHandler(db *gorm.DB, reportRepo repository.ReportRepository, tasksService service.TaskService) *CronHandler {
+func NewCronHandler(db *gorm.DB, reportRepo repository.ReportRepository, reportDraftRepo repository.ReportDraftRepository, reportImageRepo repository.ReportImageRepository, notificationPreferenceRepo notificationRepository.NotificationPreferenceRepository, tasksService service.TaskService, outboxRelay *service.OutboxRelay) *CronHandler {
 	return &CronHandler{
-		db:           db,
-		reportRepo:   reportRepo,
-		tasksService: tasksService,
+		db:              db,
+		reportRepo:      reportRepo,
+		reportDraftRepo: reportDraftRepo,
+		reportImageRepo: reportImageRepo,
+		notificationPreferenceRepo: notificationPreferenceRepo,
+		tasksService:    tasksService,
+		outboxRelay:     outboxRelay,
 	}
 }
 
@@ -48,7 +65,14 @@ func (h *CronHandler) CheckPotentiallyResolvedReport() error {
 		}
 		remainingDay := util.GetAutoResolvedRemainingDay(report)
 		reportLink := fmt.Sprintf("%s/main/report/%d", env.ClientURL(), report.ID)
-		go util.SendAutoResolvedRemainingDayEmail(report.User.Email, report.User.Username, report.ReportTitle, reportLink, remainingDay)
+		message, err := util.BuildAutoResolvedRemainingDayEmail(report.User.Email, report.User.Username, report.ReportTitle, reportLink, remainingDay, mailer.NewIdempotencyKey("auto_resolve_reminder", report.ID, remainingDay), i18n.Preferred(report.User.Language, i18n.DefaultLanguage))
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to build auto resolve reminder email for report ID %d: %v", report.ID, err))
+			continue
+		}
+		if err := h.tasksService.SendEmailTask(message); err != nil {
+			logger.Error(fmt.Sprintf("failed to enqueue auto resolve reminder email for report ID %d: %v", report.ID, err))
+		}
 	}
 	return nil
 }
@@ -70,6 +94,7 @@ func (h *CronHandler) ExpireOldReports() error {
 			if *report.LastUpdatedProgressAt <= threshold && report.LastUpdatedBy == "SYSTEM" {
 				tx := h.db.Begin()
 
+				previousStatus := report.ReportStatus
 				report.ReportStatus = "EXPIRED"
 				report.LastUpdatedBy = "SYSTEM"
 				report.UpdatedAt = time.Now().Unix()
@@ -80,6 +105,29 @@ func (h *CronHandler) ExpireOldReports() error {
 					continue
 				}
 
+				taskOutbox := h.tasksService.WithTx(tx)
+				if err := errors.Join(
+					taskOutbox.EvaluateReportReputationTask(report.ID),
+					taskOutbox.DispatchWebhookEventTask(model.WebhookReportStatusChanged, webhookDTO.ReportStatusChangedEventData{
+						ReportID:       report.ID,
+						PreviousStatus: string(previousStatus),
+						ReportStatus:   string(report.ReportStatus),
+						UpdatedBy:      string(report.LastUpdatedBy),
+						UpdatedAt:      report.UpdatedAt,
+					}),
+					taskOutbox.PublishRealtimeEventTask(model.RealtimeScopeReport, report.ID, model.RealtimeReportStatusChanged, realtimeDTO.ReportStatusEventData{
+						ReportID:       report.ID,
+						PreviousStatus: string(previousStatus),
+						ReportStatus:   string(report.ReportStatus),
+						UpdatedBy:      string(report.LastUpdatedBy),
+						UpdatedAt:      report.UpdatedAt,
+					}),
+				); err != nil {
+					tx.Rollback()
+					logger.Error(fmt.Sprintf("Failed to store outbox events for report ID %d: %v", report.ID, err))
+					continue
+				}
+
 				if err := tx.Commit().Error; err != nil {
 					logger.Error(fmt.Sprintf("Failed to commit transaction for report ID %d: %v", report.ID, err))
 					continue
@@ -123,3 +171,83 @@ func (h *CronHandler) HardDeleteOldReports() error {
 	}
 	return nil
 }
+
+func (h *CronHandler) DeleteExpiredReportDrafts() error {
+	logger.Info("Executing DeleteExpiredReportDrafts cron job")
+	ctx := context.Background()
+
+	drafts, err := h.reportDraftRepo.GetExpired(ctx, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to get expired report drafts: %w", err)
+	}
+
+	for i := range drafts {
+		draft := &drafts[i]
+		if err := h.reportDraftRepo.Delete(ctx, draft.ID); err != nil {
+			logger.Error(fmt.Sprintf("Failed to delete expired report draft ID %d: %v", draft.ID, err))
+			continue
+		}
+		reportUtil.RemoveReportDraftImages(h.unusedReportDraftImages(ctx, draft))
+		logger.Info(fmt.Sprintf("Expired report draft ID %d has been deleted", draft.ID))
+	}
+	return nil
+}
+
+// unusedReportDraftImages leaves out images a report references, which can
+// happen for drafts saved before draft images had their own directory. Images
+// whose usage cannot be checked are kept.
+func (h *CronHandler) unusedReportDraftImages(ctx context.Context, draft *model.ReportDraft) []string {
+	var unused []string
+	for _, image := range reportUtil.GetReportDraftImages(draft) {
+		inUse, err := h.reportImageRepo.IsImageInUse(ctx, image)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to check usage of report draft image %s: %v", image, err))
+			continue
+		}
+		if !inUse {
+			unused = append(unused, image)
+		}
+	}
+	return unused
+}
+
+func (h *CronHandler) PublishOutboxEvents() error {
+	published, err := h.outboxRelay.PublishPending(context.Background())
+	if err != nil {
+		return err
+	}
+	if published > 0 {
+		logger.Info(fmt.Sprintf("Published %d outbox events", published))
+	}
+	return nil
+}
+
+func (h *CronHandler) DeletePublishedOutboxEvents() error {
+	logger.Info("Executing DeletePublishedOutboxEvents cron job")
+	deleted, err := h.outboxRelay.DeletePublished(context.Background())
+	if err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("Deleted %d published outbox events", deleted))
+	return nil
+}
+
+func (h *CronHandler) EnqueueNotificationDigests() error {
+	logger.Info("Executing EnqueueNotificationDigests cron job")
+	ctx := context.Background()
+	settings, err := h.notificationPreferenceRepo.GetDigestSubscribers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get digest subscribers: %w", err)
+	}
+
+	now := time.Now()
+	for _, setting := range settings {
+		if !notificationUtil.IsDigestDue(setting, now) {
+			continue
+		}
+		if err := h.tasksService.SendNotificationDigestTask(setting.UserID); err != nil {
+			logger.Error(fmt.Sprintf("failed to enqueue notification digest task for user ID %d: %v", setting.UserID, err))
+		}
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package cron_Worker
 
 import (
 	reportRepo "pingspot/internal/domain/report_service/repository"
+	notificationRepo "pingspot/internal/domain/notification_service/repository"
+	taskRepo "pingspot/internal/domain/task_service/repository"
 	tasksService "pingspot/internal/domain/task_service/service"
 	"pingspot/internal/infrastructure/database"
 	"pingspot/internal/worker/cron_worker/handler"
@@ -15,12 +17,36 @@ import (
 func StartCron(client *asynq.Client) {
 	c := cron.New(cron.WithSeconds())
 	db := database.GetPostgresDB()
+	reportDraftRepo := reportRepo.NewReportDraftRepository(db)
+	reportImageRepo := reportRepo.NewReportImageRepository(db)
 	reportRepo := reportRepo.NewReportRepository(db)
+	outboxRelay := tasksService.NewOutboxRelay(taskRepo.NewOutboxRepository(db), client)
 	tasksService := tasksService.NewTaskService(client)
 
-	cronHandler := handler.NewCronHandler(db, reportRepo, tasksService)
+	notificationPreferenceRepo := notificationRepo.NewNotificationPreferenceRepository(db)
+	cronHandler := handler.NewCronHandler(db, reportRepo, reportDraftRepo, reportImageRepo, notificationPreferenceRepo, tasksService, outboxRelay)
 
-	_, err := c.AddFunc("0 0 11 * * *", func() {
+	_, err := c.AddJob("*/2 * * * * *", cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(cron.FuncJob(func() {
+		err := cronHandler.PublishOutboxEvents()
+		if err != nil {
+			logger.Error("Error executing PublishOutboxEvents", zap.Error(err))
+		}
+	})))
+	if err != nil {
+		logger.Error("Failed to schedule publish outbox events task", zap.Error(err))
+	}
+
+	_, err = c.AddFunc("0 15 3 * * *", func() {
+		err := cronHandler.DeletePublishedOutboxEvents()
+		if err != nil {
+			logger.Error("Error executing DeletePublishedOutboxEvents", zap.Error(err))
+		}
+	})
+	if err != nil {
+		logger.Error("Failed to schedule delete published outbox events task", zap.Error(err))
+	}
+
+	_, err = c.AddFunc("0 0 11 * * *", func() {
 		err := cronHandler.CheckPotentiallyResolvedReport()
 		if err != nil {
 			logger.Error("Error executing CheckPotentiallyResolvedReport", zap.Error(err))
@@ -50,6 +76,26 @@ func StartCron(client *asynq.Client) {
 		logger.Error("Failed to schedule hard delete reports task", zap.Error(err))
 	}
 
+	_, err = c.AddFunc("0 30 * * * *", func() {
+		err := cronHandler.DeleteExpiredReportDrafts()
+		if err != nil {
+			logger.Error("Error executing DeleteExpiredReportDrafts", zap.Error(err))
+		}
+	})
+	if err != nil {
+		logger.Error("Failed to schedule delete expired report drafts task", zap.Error(err))
+	}
+
+	_, err = c.AddFunc("0 0 * * * *", func() {
+		err := cronHandler.EnqueueNotificationDigests()
+		if err != nil {
+			logger.Error("Error executing EnqueueNotificationDigests", zap.Error(err))
+		}
+	})
+	if err != nil {
+		logger.Error("Failed to schedule notification digest task", zap.Error(err))
+	}
+
 	// _, err = c.AddFunc("0 */5 * * * *", func() {
 	// })
 	// if err != nil {
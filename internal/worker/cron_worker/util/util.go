@@ -2,6 +2,8 @@ package util
 
 import (
 	"pingspot/internal/model"
+	"pingspot/pkg/i18n"
+	"pingspot/pkg/mailer"
 	mainutils "pingspot/pkg/utils/main_util"
 	"time"
 )
@@ -19,10 +21,10 @@ func GetAutoResolvedRemainingDay(report model.Report) int {
     return daysLeft
 }
 
-func SendAutoResolvedRemainingDayEmail(to, username, reportTitle, reportLink string, daysRemaining int) error {
-	return mainutils.SendEmail(mainutils.EmailData{
+func BuildAutoResolvedRemainingDayEmail(to, username, reportTitle, reportLink string, daysRemaining int, idempotencyKey string, language i18n.Language) (mailer.Message, error) {
+	return mainutils.BuildEmail(mainutils.EmailData{
 		To:            to,
-		Subject:       "Pemberitahuan: Laporan Anda Akan Otomatis Ditandai Sebagai Terselesaikan",
+		Subject:       i18n.T(language, "email.auto_resolve.subject", nil),
 		RecipientName: username,
 		EmailType:     mainutils.EmailTypeProgressReminder,
 		TemplateData: map[string]any{
@@ -32,16 +34,18 @@ func SendAutoResolvedRemainingDayEmail(to, username, reportTitle, reportLink str
 			"DaysRemaining": daysRemaining,
 		},
 		BodyTempate: getAutoResolveRemainingDayEmailTemplate(),
+		Language:    language,
+		IdempotencyKey: idempotencyKey,
 	})
 }
 
 func getAutoResolveRemainingDayEmailTemplate() string {
 	return `<!DOCTYPE html>
-<html lang="id">
+<html lang="{{lang}}">
 <head>
 	<meta charset="UTF-8">
 	<meta name="viewport" content="width=device-width, initial-scale=1.0">
-	<title>Pengingat Progress Laporan</title>
+	<title>{{t "email.progress_reminder.title"}}</title>
 </head>
 <body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif; background-color: #f8fafc; line-height: 1.6;">
 	<table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%" style="background-color: #f8fafc;">
@@ -54,17 +58,17 @@ func getAutoResolveRemainingDayEmailTemplate() string {
 								PingSpot
 							</h1>
 							<p style="margin: 8px 0 0; color: rgba(255, 255, 255, 0.9); font-size: 16px; font-weight: 400;">
-								Pengingat Progress Laporan
+								{{t "email.progress_reminder.title"}}
 							</p>
 						</td>
 					</tr>
 					<tr>
 						<td style="padding: 50px 40px;">
 							<h2 style="margin: 0 0 20px; color: #1e293b; font-size: 24px; font-weight: 600; text-align: center;">
-								Halo {{.UserName}}! 👋
+								{{t "email.common.greeting" "name" .UserName}} 👋
 							</h2>
 							<p style="margin: 0 0 25px; color: #475569; font-size: 16px; text-align: center; line-height: 1.7;">
-								Laporan Anda berstatus <strong style="color: #f59e0b;">Dalam Peninjauan</strong> dan memerlukan pembaruan segera!
+								{{t "email.progress_reminder.status_prefix"}} <strong style="color: #f59e0b;">{{t "email.progress_reminder.status_label"}}</strong> {{t "email.auto_resolve.status_suffix"}}
 							</p>
 							<div style="margin: 30px 0; padding: 25px; background-color: #fef3c7; border-radius: 12px; border-left: 4px solid #f59e0b;">
 								<p style="margin: 0 0 15px; color: #92400e; font-size: 16px; font-weight: 600;">
@@ -72,33 +76,33 @@ func getAutoResolveRemainingDayEmailTemplate() string {
 								</p>
 								<div style="margin: 20px 0; padding: 15px; background-color: #ffffff; border-radius: 8px; border: 2px solid #f59e0b;">
 									<p style="margin: 0; color: #78350f; font-size: 18px; font-weight: 700; text-align: center;">
-										⏰ {{.DaysRemaining}} Hari Tersisa
+										⏰ {{t "email.auto_resolve.days_remaining" "days" .DaysRemaining}}
 									</p>
 								</div>
 								<p style="margin: 15px 0 0; color: #78350f; font-size: 14px; line-height: 1.6;">
-									<strong>⚠️ Tindakan Diperlukan:</strong><br>
-									Harap unggah bukti progress laporan Anda sebelum batas waktu berakhir. Jika tidak ada pembaruan dalam {{.DaysRemaining}} hari ke depan, laporan akan <strong>otomatis ditandai sebagai Terselesaikan</strong> oleh sistem.
+									<strong>⚠️ {{t "email.auto_resolve.action_label"}}</strong><br>
+									{{t "email.auto_resolve.action_prefix" "days" .DaysRemaining}} <strong>{{t "email.progress_reminder.auto_resolve_label"}}</strong> {{t "email.auto_resolve.action_suffix"}}
 								</p>
 							</div>
 							<div style="margin: 25px 0; padding: 20px; background-color: #eff6ff; border-radius: 12px; border-left: 4px solid #3b82f6;">
 								<p style="margin: 0 0 10px; color: #1e40af; font-size: 14px; font-weight: 600;">
-									💡 Apa yang perlu Anda lakukan?
+									💡 {{t "email.auto_resolve.todo_title"}}
 								</p>
 								<ul style="margin: 0; padding-left: 20px; color: #1e40af; font-size: 14px; line-height: 1.8;">
-									<li>Unggah foto atau bukti progress terbaru</li>
-									<li>Berikan update status penanganan</li>
-									<li>Tambahkan keterangan jika diperlukan</li>
+									<li>{{t "email.auto_resolve.todo_upload"}}</li>
+									<li>{{t "email.auto_resolve.todo_status"}}</li>
+									<li>{{t "email.auto_resolve.todo_notes"}}</li>
 								</ul>
 							</div>
 							<div style="text-align: center; margin: 35px 0;">
 								<a href="{{.ReportLink}}" 
 								   style="display: inline-block; background: linear-gradient(135deg, #f59e0b 0%, #d97706 100%); color: #ffffff; text-decoration: none; padding: 16px 32px; border-radius: 50px; font-weight: 600; font-size: 16px; box-shadow: 0 4px 15px rgba(245, 158, 11, 0.4); transition: all 0.3s ease; text-align: center; min-width: 200px;">
-									Perbarui Progress Sekarang
+									{{t "email.progress_reminder.button"}}
 								</a>
 							</div>
 							<div style="margin: 30px 0; text-align: center;">
 								<p style="margin: 0; color: #64748b; font-size: 14px; line-height: 1.6;">
-									Jika Anda memiliki pertanyaan atau kendala, jangan ragu untuk menghubungi kami.
+									{{t "email.auto_resolve.contact"}}
 								</p>
 							</div>
 						</td>
@@ -106,10 +110,10 @@ func getAutoResolveRemainingDayEmailTemplate() string {
 					<tr>
 						<td style="background-color: #f8fafc; padding: 30px 40px; text-align: center; border-top: 1px solid #e2e8f0;">
 							<p style="margin: 0 0 10px; color: #64748b; font-size: 14px;">
-								© 2025 PingSpot. Hak cipta dilindungi undang-undang.
+								{{t "email.common.copyright"}}
 							</p>
 							<p style="margin: 0; color: #94a3b8; font-size: 12px;">
-								Pertanyaan? Hubungi kami di
+								{{t "email.common.questions"}}
 								<a href="mailto:support@pingspot.com" style="color: #f59e0b; text-decoration: none; font-weight: 500;">
 									support@pingspot.com
 								</a>
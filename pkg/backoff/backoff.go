@@ -0,0 +1,20 @@
+package backoff
+
+import "time"
+
+// Exponential returns the delay before retrying after the given attempt: base
+// after the first attempt, doubling after each further one, capped at max.
+// Attempts below 1 are treated as the first.
+func Exponential(attempt int, base, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
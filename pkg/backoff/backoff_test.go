@@ -0,0 +1,17 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponential(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Exponential(0, 30*time.Second, 6*time.Hour))
+	assert.Equal(t, 30*time.Second, Exponential(1, 30*time.Second, 6*time.Hour))
+	assert.Equal(t, time.Minute, Exponential(2, 30*time.Second, 6*time.Hour))
+	assert.Equal(t, 4*time.Minute, Exponential(4, 30*time.Second, 6*time.Hour))
+	assert.Equal(t, 6*time.Hour, Exponential(20, 30*time.Second, 6*time.Hour))
+	assert.Equal(t, 6*time.Hour, Exponential(1000, 30*time.Second, 6*time.Hour))
+}
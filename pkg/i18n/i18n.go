@@ -0,0 +1,171 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	LanguageIndonesian Language = "id"
+	LanguageEnglish    Language = "en"
+	DefaultLanguage             = LanguageIndonesian
+)
+
+var SupportedLanguages = []Language{LanguageIndonesian, LanguageEnglish}
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	catalogs = map[Language]map[string]string{}
+	// defaultKeys maps a message in the default language back to its key, so
+	// call sites that still pass Indonesian text can be translated.
+	defaultKeys        = map[string]string{}
+	placeholderPattern = regexp.MustCompile(`\{(@?)(\w+)\}`)
+)
+
+func init() {
+	for _, language := range SupportedLanguages {
+		raw, err := localeFiles.ReadFile(fmt.Sprintf("locales/%s.json", language))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing catalog for %s: %v", language, err))
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(raw, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog for %s: %v", language, err))
+		}
+		catalogs[language] = catalog
+	}
+
+	keys := make([]string, 0, len(catalogs[DefaultLanguage]))
+	for key := range catalogs[DefaultLanguage] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		message := catalogs[DefaultLanguage][key]
+		if _, ok := defaultKeys[message]; !ok {
+			defaultKeys[message] = key
+		}
+	}
+}
+
+func IsSupported(value string) bool {
+	_, ok := catalogs[Language(strings.ToLower(strings.TrimSpace(value)))]
+	return ok
+}
+
+// Normalize reduces a language tag such as "en-US" to a supported language and
+// falls back to the default language for anything else.
+func Normalize(value string) Language {
+	tag := strings.ToLower(strings.TrimSpace(value))
+	if base, _, found := strings.Cut(tag, "-"); found {
+		tag = base
+	}
+	if base, _, found := strings.Cut(tag, "_"); found {
+		tag = base
+	}
+	if _, ok := catalogs[Language(tag)]; ok {
+		return Language(tag)
+	}
+	return DefaultLanguage
+}
+
+// ParseAcceptLanguage picks the supported language with the highest q-value
+// from an Accept-Language header, keeping header order on ties.
+func ParseAcceptLanguage(header string) Language {
+	best := DefaultLanguage
+	bestWeight := -1.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		weight := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 || weight <= bestWeight {
+			continue
+		}
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if _, ok := catalogs[Language(base)]; !ok {
+			continue
+		}
+		best = Language(base)
+		bestWeight = weight
+	}
+	return best
+}
+
+func Has(language Language, key string) bool {
+	_, ok := catalogs[language][key]
+	return ok
+}
+
+// T renders key in the given language, falling back to the default language
+// and then to the key itself. "{name}" is replaced by params["name"], while
+// "{@name}" treats params["name"] as another key and renders it too.
+func T(language Language, key string, params map[string]string) string {
+	message, ok := catalogs[language][key]
+	if !ok {
+		message, ok = catalogs[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(params) == 0 {
+		return message
+	}
+	return placeholderPattern.ReplaceAllStringFunc(message, func(placeholder string) string {
+		match := placeholderPattern.FindStringSubmatch(placeholder)
+		value, ok := params[match[2]]
+		if !ok {
+			return placeholder
+		}
+		if match[1] == "@" {
+			return T(language, value, nil)
+		}
+		return value
+	})
+}
+
+// Localize translates a message that was written in the default language. The
+// error code is the stable key, so it is looked up first; messages without a
+// code are found by their default-language text.
+func Localize(language Language, message string, code string) string {
+	if language == DefaultLanguage || message == "" {
+		return message
+	}
+	if code != "" {
+		if translated, ok := catalogs[language]["error."+code]; ok {
+			return translated
+		}
+	}
+	if key, ok := defaultKeys[message]; ok {
+		if translated, ok := catalogs[language][key]; ok {
+			return translated
+		}
+	}
+	return message
+}
+
+// Preferred returns the language a user saved, or fallback when they never
+// chose one.
+func Preferred(saved *string, fallback Language) Language {
+	if saved == nil || !IsSupported(*saved) {
+		return fallback
+	}
+	return Normalize(*saved)
+}
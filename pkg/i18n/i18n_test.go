@@ -0,0 +1,148 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for key := range catalogs[DefaultLanguage] {
+		assert.True(t, Has(LanguageEnglish, key), "missing english translation for %s", key)
+	}
+	for key := range catalogs[LanguageEnglish] {
+		assert.True(t, Has(DefaultLanguage, key), "missing indonesian translation for %s", key)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, LanguageEnglish, Normalize("en-US"))
+	assert.Equal(t, LanguageEnglish, Normalize(" EN_gb "))
+	assert.Equal(t, LanguageIndonesian, Normalize("id"))
+	assert.Equal(t, DefaultLanguage, Normalize("fr"))
+	assert.Equal(t, DefaultLanguage, Normalize(""))
+
+	assert.True(t, IsSupported("en"))
+	assert.False(t, IsSupported("en-US"))
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, LanguageEnglish, ParseAcceptLanguage("en-US,en;q=0.9,id;q=0.8"))
+	assert.Equal(t, LanguageIndonesian, ParseAcceptLanguage("fr-FR,id;q=0.7,en;q=0.5"))
+	assert.Equal(t, LanguageEnglish, ParseAcceptLanguage("id;q=0.2, en;q=0.8"))
+	assert.Equal(t, LanguageIndonesian, ParseAcceptLanguage("id, en"))
+	assert.Equal(t, DefaultLanguage, ParseAcceptLanguage("fr, de;q=0.5"))
+	assert.Equal(t, DefaultLanguage, ParseAcceptLanguage("en;q=0"))
+	assert.Equal(t, DefaultLanguage, ParseAcceptLanguage(""))
+}
+
+func TestT(t *testing.T) {
+	params := map[string]string{"actor": "budi", "others": "2"}
+	assert.Equal(t, "budi and 2 others voted on your report", T(LanguageEnglish, "notification.vote.grouped.description", params))
+	assert.Equal(t, "budi dan 2 lainnya memberikan suara pada laporan Anda", T(LanguageIndonesian, "notification.vote.grouped.description", params))
+
+	assert.Equal(t, "Hello {name}!", T(LanguageEnglish, "email.common.greeting", nil))
+	assert.Equal(t, "unknown.key", T(LanguageEnglish, "unknown.key", nil))
+
+	badge := map[string]string{"badgeName": "badge.FIRST_REPORT.name", "badgeDescription": "badge.FIRST_REPORT.description"}
+	assert.Equal(t, "Congratulations! You earned the 'First Reporter' badge: Created a first report", T(LanguageEnglish, "notification.badge_awarded.description", badge))
+}
+
+func TestLocalize(t *testing.T) {
+	assert.Equal(t, "Report not found", Localize(LanguageEnglish, "Laporan tidak ditemukan", "REPORT_NOT_FOUND"))
+	assert.Equal(t, "Laporan tidak ditemukan", Localize(LanguageIndonesian, "Laporan tidak ditemukan", "REPORT_NOT_FOUND"))
+	assert.Equal(t, "Invalid report ID", Localize(LanguageEnglish, "ID laporan tidak valid", ""))
+
+	assert.Equal(t, "Maximum number of webhooks reached", Localize(LanguageEnglish, "maksimal 5 webhook per pengguna", "WEBHOOK_LIMIT_REACHED"))
+	assert.Equal(t, "pesan tanpa terjemahan", Localize(LanguageEnglish, "pesan tanpa terjemahan", "UNKNOWN_CODE"))
+	assert.Equal(t, "Some duplicate reports were not found or are already merged", Localize(LanguageEnglish, "Laporan tidak ditemukan", "DUPLICATE_REPORT_NOT_FOUND"))
+}
+
+// TestErrorMessagesHaveCatalogEntries fails when an apperror.New or
+// ResponseError call site uses a literal code or message the catalog cannot
+// translate.
+func TestErrorMessagesHaveCatalogEntries(t *testing.T) {
+	root := filepath.Join("..", "..")
+	fileSet := token.NewFileSet()
+	var missing []string
+
+	stringArg := func(args []ast.Expr, index int) (string, bool) {
+		if index >= len(args) {
+			return "", false
+		}
+		literal, ok := args[index].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(literal.Value)
+		return value, err == nil
+	}
+	hasMessage := func(message string) bool {
+		_, ok := defaultKeys[message]
+		return ok
+	}
+
+	for _, dir := range []string{"cmd", "internal", "pkg"} {
+		err := filepath.WalkDir(filepath.Join(root, dir), func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+			file, err := parser.ParseFile(fileSet, path, nil, 0)
+			if err != nil {
+				return err
+			}
+			ast.Inspect(file, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				selector, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				position := fileSet.Position(call.Pos())
+				switch selector.Sel.Name {
+				case "New":
+					if pkg, ok := selector.X.(*ast.Ident); !ok || pkg.Name != "apperror" {
+						return true
+					}
+					code, hasCode := stringArg(call.Args, 1)
+					message, hasText := stringArg(call.Args, 2)
+					if hasCode && Has(DefaultLanguage, "error."+code) {
+						return true
+					}
+					if hasText && !hasMessage(message) {
+						missing = append(missing, position.String()+": "+code+" "+strconv.Quote(message))
+					}
+				case "ResponseError":
+					message, hasText := stringArg(call.Args, 2)
+					if !hasText || hasMessage(message) {
+						return true
+					}
+					if key, _ := stringArg(call.Args, 3); key == "error_code" {
+						if code, ok := stringArg(call.Args, 4); ok && Has(DefaultLanguage, "error."+code) {
+							return true
+						}
+					}
+					missing = append(missing, position.String()+": "+strconv.Quote(message))
+				}
+				return true
+			})
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	assert.Empty(t, missing, "add these messages to the locale catalogs")
+}
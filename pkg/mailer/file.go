@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var unsafeFileNamePattern = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+type fileMailer struct {
+	cfg Config
+}
+
+func newFileMailer(cfg Config) (*fileMailer, error) {
+	if cfg.FileDir == "" {
+		cfg.FileDir = DefaultFileDir
+	}
+	if err := os.MkdirAll(cfg.FileDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &fileMailer{cfg: cfg}, nil
+}
+
+// Send writes the message to <dir>/<timestamp>-<key>.eml, which any mail client
+// can open.
+func (m *fileMailer) Send(ctx context.Context, message Message) error {
+	msg, err := buildMessage(m.cfg, message)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d", time.Now().UnixNano())
+	if message.IdempotencyKey != "" {
+		name += "-" + unsafeFileNamePattern.ReplaceAllString(message.IdempotencyKey, "_")
+	}
+	file, err := os.Create(filepath.Join(m.cfg.FileDir, name+".eml"))
+	if err != nil {
+		return fmt.Errorf("failed to create mail file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := msg.WriteTo(file); err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package mailer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/textproto"
+	env "pingspot/pkg/utils/env_util"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/gomail.v2"
+)
+
+type Driver string
+
+const (
+	DriverSMTP Driver = "smtp"
+	// DriverFile writes every message as an .eml file instead of sending it,
+	// for development and tests. Point MAIL_DRIVER=smtp at a local catcher such
+	// as Mailpit to inspect messages in a browser instead.
+	DriverFile Driver = "file"
+)
+
+type TLSMode string
+
+const (
+	TLSModeStartTLS TLSMode = "starttls"
+	TLSModeImplicit TLSMode = "tls"
+	TLSModeNone     TLSMode = "none"
+)
+
+const (
+	DefaultSMTPPort = 587
+	DefaultFileDir  = "tmp/mail"
+
+	legacySMTPHost = "smtp.gmail.com"
+)
+
+var ErrInvalidMessage = errors.New("invalid email message")
+
+type Config struct {
+	Driver             Driver
+	Host               string
+	Port               int
+	Username           string
+	Password           string
+	FromAddress        string
+	FromName           string
+	TLSMode            TLSMode
+	InsecureSkipVerify bool
+	FileDir            string
+}
+
+// Message is a fully rendered email. IdempotencyKey identifies the logical
+// email, so retries and duplicate enqueues of the same key are sent once.
+type Message struct {
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	To             string `json:"to"`
+	ToName         string `json:"to_name,omitempty"`
+	Subject        string `json:"subject"`
+	HTMLBody       string `json:"html_body"`
+	TextBody       string `json:"text_body,omitempty"`
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// ConfigFromEnv reads the MAIL_* variables. The older EMAIL_EMAIL and
+// EMAIL_PASSWORD variables are still honoured as credentials and sender, with
+// the Gmail relay they were used with as the default host.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Driver:             Driver(strings.ToLower(strings.TrimSpace(env.MailDriver()))),
+		Host:               env.MailHost(),
+		Port:               DefaultSMTPPort,
+		Username:           env.MailUsername(),
+		Password:           env.MailPassword(),
+		FromAddress:        env.MailFromAddress(),
+		FromName:           env.MailFromName(),
+		TLSMode:            TLSMode(strings.ToLower(strings.TrimSpace(env.MailTLS()))),
+		InsecureSkipVerify: env.MailTLSSkipVerify(),
+		FileDir:            env.MailFileDir(),
+	}
+	if port, err := strconv.Atoi(env.MailPort()); err == nil && port > 0 {
+		cfg.Port = port
+	}
+	if cfg.Username == "" && cfg.Password == "" {
+		cfg.Username = env.EmailEmail()
+		cfg.Password = env.EmailPassword()
+		if cfg.Host == "" && cfg.Username != "" {
+			cfg.Host = legacySMTPHost
+		}
+	}
+	if cfg.FromAddress == "" {
+		cfg.FromAddress = cfg.Username
+	}
+	if cfg.FromName == "" {
+		cfg.FromName = "PingSpot"
+	}
+	return cfg
+}
+
+func New(cfg Config) (Mailer, error) {
+	if cfg.Driver == "" {
+		cfg.Driver = DriverSMTP
+	}
+	if cfg.FromAddress == "" {
+		return nil, fmt.Errorf("mail sender address is not configured")
+	}
+	switch cfg.Driver {
+	case DriverSMTP:
+		return newSMTPMailer(cfg)
+	case DriverFile:
+		return newFileMailer(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Driver)
+	}
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultMailer Mailer
+	defaultErr    error
+)
+
+// Default returns the mailer configured from the environment, built once per
+// process.
+func Default() (Mailer, error) {
+	defaultOnce.Do(func() {
+		defaultMailer, defaultErr = New(ConfigFromEnv())
+	})
+	return defaultMailer, defaultErr
+}
+
+// NewIdempotencyKey derives a short, stable key from the parts that identify a
+// logical email, e.g. the purpose and the token it carries.
+func NewIdempotencyKey(scope string, parts ...any) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(hash, "%v\x00", part)
+	}
+	return scope + ":" + hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+// IsPermanent reports whether the server rejected the message outright, e.g. an
+// unknown mailbox, so retrying it cannot succeed.
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrInvalidMessage) {
+		return true
+	}
+	var protocolErr *textproto.Error
+	return errors.As(err, &protocolErr) && protocolErr.Code >= 500
+}
+
+func buildMessage(cfg Config, message Message) (*gomail.Message, error) {
+	if message.To == "" {
+		return nil, fmt.Errorf("%w: recipient is required", ErrInvalidMessage)
+	}
+	if message.HTMLBody == "" && message.TextBody == "" {
+		return nil, fmt.Errorf("%w: body is required", ErrInvalidMessage)
+	}
+
+	m := gomail.NewMessage()
+	m.SetAddressHeader("From", cfg.FromAddress, cfg.FromName)
+	if message.ToName != "" {
+		m.SetAddressHeader("To", message.To, message.ToName)
+	} else {
+		m.SetHeader("To", message.To)
+	}
+	m.SetHeader("Subject", message.Subject)
+	if message.IdempotencyKey != "" {
+		m.SetHeader("Message-ID", fmt.Sprintf("<%s@%s>", strings.ReplaceAll(message.IdempotencyKey, ":", "."), senderDomain(cfg.FromAddress)))
+	}
+
+	text := message.TextBody
+	if text == "" {
+		text = HTMLToText(message.HTMLBody)
+	}
+	m.SetBody("text/plain", text)
+	if message.HTMLBody != "" {
+		m.AddAlternative("text/html", message.HTMLBody)
+	}
+	return m, nil
+}
+
+func senderDomain(address string) string {
+	if _, domain, ok := strings.Cut(address, "@"); ok && domain != "" {
+		return domain
+	}
+	return "pingspot.local"
+}
@@ -0,0 +1,189 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLToText(t *testing.T) {
+	body := `<html><head><title>Judul</title><style>p { color: red; }</style></head>
+<body>
+	<h2>Halo sari! 👋</h2>
+	<p>Klik tombol di bawah &amp; lanjutkan.</p>
+	<a href="https://pingspot.id/verify?code=1">Verifikasi Akun</a>
+	<ul><li>Satu</li><li>Dua</li></ul>
+	<a href="https://pingspot.id">https://pingspot.id</a>
+</body></html>`
+
+	text := HTMLToText(body)
+	assert.Equal(t, "Halo sari! 👋\n\nKlik tombol di bawah & lanjutkan.\n\nVerifikasi Akun (https://pingspot.id/verify?code=1)\n\n- Satu\n- Dua\n\nhttps://pingspot.id", text)
+	assert.NotContains(t, text, "Judul")
+	assert.NotContains(t, text, "color")
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	key := NewIdempotencyKey("verification", 7, "code")
+	assert.True(t, strings.HasPrefix(key, "verification:"))
+	assert.Len(t, key, len("verification:")+32)
+	assert.Equal(t, key, NewIdempotencyKey("verification", 7, "code"))
+	assert.NotEqual(t, key, NewIdempotencyKey("verification", 7, "other"))
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	_, err := New(Config{Driver: DriverSMTP, Host: "smtp.example.com"})
+	assert.Error(t, err)
+
+	_, err = New(Config{Driver: DriverSMTP, FromAddress: "no-reply@pingspot.id"})
+	assert.Error(t, err)
+
+	_, err = New(Config{Driver: DriverSMTP, Host: "smtp.example.com", FromAddress: "no-reply@pingspot.id", TLSMode: "ssl"})
+	assert.Error(t, err)
+
+	_, err = New(Config{Driver: "carrier-pigeon", FromAddress: "no-reply@pingspot.id"})
+	assert.Error(t, err)
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	m, err := New(Config{Driver: DriverFile, FileDir: dir, FromAddress: "no-reply@pingspot.id", FromName: "PingSpot"})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), Message{
+		IdempotencyKey: "verification:abc",
+		To:             "sari@example.com",
+		ToName:         "sari",
+		Subject:        "Verifikasi Akun PingSpot",
+		HTMLBody:       "<p>Halo <b>sari</b>!</p>",
+	})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*-verification_abc.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+
+	eml := string(content)
+	assert.Contains(t, eml, "Message-ID: <verification.abc@pingspot.id>")
+	assert.Contains(t, eml, "multipart/alternative")
+	assert.Contains(t, eml, "Content-Type: text/plain")
+	assert.Contains(t, eml, "Content-Type: text/html")
+	assert.Contains(t, eml, "Halo sari!")
+
+	err = m.Send(context.Background(), Message{Subject: "tanpa penerima", HTMLBody: "<p>x</p>"})
+	assert.True(t, IsPermanent(err))
+}
+
+// fakeSMTPServer accepts one plain SMTP session and records the DATA section.
+// Recipients listed in reject are refused with a permanent 550.
+func fakeSMTPServer(t *testing.T, reject string) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"):
+				reply("250-localhost")
+				reply("250 8BITMIME")
+			case strings.HasPrefix(command, "RCPT TO") && reject != "" && strings.Contains(command, strings.ToUpper(reject)):
+				reply("550 mailbox unavailable")
+			case strings.HasPrefix(command, "DATA"):
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				received <- data.String()
+				reply("250 queued")
+			case strings.HasPrefix(command, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return listener.Addr().String(), received
+}
+
+func newTestSMTPMailer(t *testing.T, address string) Mailer {
+	host, port, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+	cfg := Config{Driver: DriverSMTP, Host: host, TLSMode: TLSModeNone, FromAddress: "no-reply@pingspot.id"}
+	cfg.Port, err = strconv.Atoi(port)
+	require.NoError(t, err)
+	m, err := New(cfg)
+	require.NoError(t, err)
+	return m
+}
+
+func TestSMTPMailer(t *testing.T) {
+	t.Run("sends multipart message without auth", func(t *testing.T) {
+		address, received := fakeSMTPServer(t, "")
+		m := newTestSMTPMailer(t, address)
+
+		err := m.Send(context.Background(), Message{To: "sari@example.com", Subject: "Halo", HTMLBody: "<p>Halo sari!</p>"})
+		require.NoError(t, err)
+
+		data := <-received
+		assert.Contains(t, data, "Subject: Halo")
+		assert.Contains(t, data, "Content-Type: text/plain")
+		assert.Contains(t, data, "Content-Type: text/html")
+	})
+
+	t.Run("rejected recipient is permanent", func(t *testing.T) {
+		address, _ := fakeSMTPServer(t, "ghost@example.com")
+		m := newTestSMTPMailer(t, address)
+
+		err := m.Send(context.Background(), Message{To: "ghost@example.com", Subject: "Halo", HTMLBody: "<p>Halo</p>"})
+		require.Error(t, err)
+		assert.True(t, IsPermanent(err))
+	})
+
+	t.Run("starttls is required when configured", func(t *testing.T) {
+		address, _ := fakeSMTPServer(t, "")
+		host, port, _ := net.SplitHostPort(address)
+		cfg := Config{Driver: DriverSMTP, Host: host, FromAddress: "no-reply@pingspot.id"}
+		cfg.Port, _ = strconv.Atoi(port)
+		m, err := New(cfg)
+		require.NoError(t, err)
+
+		err = m.Send(context.Background(), Message{To: "sari@example.com", Subject: "Halo", HTMLBody: "<p>Halo</p>"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "STARTTLS")
+		assert.False(t, IsPermanent(err))
+	})
+}
@@ -0,0 +1,106 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+const smtpTimeout = 30 * time.Second
+
+type smtpMailer struct {
+	cfg Config
+}
+
+func newSMTPMailer(cfg Config) (*smtpMailer, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("mail host is not configured")
+	}
+	if cfg.Port <= 0 {
+		cfg.Port = DefaultSMTPPort
+	}
+	switch cfg.TLSMode {
+	case "":
+		cfg.TLSMode = TLSModeStartTLS
+	case TLSModeStartTLS, TLSModeImplicit, TLSModeNone:
+	default:
+		return nil, fmt.Errorf("unsupported mail TLS mode: %s", cfg.TLSMode)
+	}
+	return &smtpMailer{cfg: cfg}, nil
+}
+
+// Send speaks SMTP directly so the TLS mode is enforced: STARTTLS is required
+// rather than opportunistic, and credentials are only sent when a username is
+// configured, which lets a local catcher run without auth.
+func (m *smtpMailer) Send(ctx context.Context, message Message) error {
+	msg, err := buildMessage(m.cfg, message)
+	if err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	address := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	tlsConfig := &tls.Config{ServerName: m.cfg.Host, InsecureSkipVerify: m.cfg.InsecureSkipVerify}
+
+	dialer := &net.Dialer{Deadline: deadline}
+	var conn net.Conn
+	if m.cfg.TLSMode == TLSModeImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to mail server: %w", err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set mail server deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if m.cfg.TLSMode == TLSModeStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("mail server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate with mail server: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.cfg.FromAddress); err != nil {
+		return fmt.Errorf("mail server rejected sender: %w", err)
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return fmt.Errorf("mail server rejected recipient: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message data: %w", err)
+	}
+	if _, err := msg.WriteTo(writer); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("mail server rejected message: %w", err)
+	}
+	return client.Quit()
+}
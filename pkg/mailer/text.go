@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	hiddenBlockPattern = regexp.MustCompile(`(?is)<(head|style|script)\b.*?</(head|style|script)>`)
+	linkPattern        = regexp.MustCompile(`(?is)<a\b[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	listItemPattern    = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	lineBreakPattern   = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|tr|ul|table)>`)
+	tagPattern         = regexp.MustCompile(`(?s)<[^>]*>`)
+	spacePattern       = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLinesPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToText derives the plain-text alternative of an HTML email. Links keep
+// their target so they stay usable in text-only clients.
+func HTMLToText(body string) string {
+	text := hiddenBlockPattern.ReplaceAllString(body, "")
+	text = linkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		href := strings.TrimSpace(match[1])
+		label := strings.TrimSpace(tagPattern.ReplaceAllString(match[2], ""))
+		if label == "" || label == href {
+			return href
+		}
+		return label + " (" + href + ")"
+	})
+	text = listItemPattern.ReplaceAllString(text, "\n- ")
+	text = lineBreakPattern.ReplaceAllString(text, "\n")
+	text = tagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacePattern.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+	text = blankLinesPattern.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
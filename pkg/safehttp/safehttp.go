@@ -0,0 +1,122 @@
+package safehttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	env "pingspot/pkg/utils/env_util"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var ErrBlockedAddress = errors.New("destination address is not allowed")
+
+var blockedNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+	mustParseCIDR("64:ff9b::/96"),
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// IsBlockedIP reports whether ip is loopback, private, link-local (which
+// includes the 169.254.169.254 cloud metadata endpoint), unspecified,
+// multicast or another range that never belongs to a public service.
+func IsBlockedIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowPrivateNetworks lets local development and tests reach services on
+// loopback or a private network. It is read on every dial so it follows the
+// environment loaded at startup.
+func allowPrivateNetworks() bool {
+	return env.OutboundAllowPrivateNetworks()
+}
+
+// CheckURL rejects URLs that are not http(s) or that name a blocked address
+// literally, so obviously internal targets are refused when they are saved.
+// Hostnames are only checked when the client connects.
+func CheckURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("unsupported url: %s", rawURL)
+	}
+	if allowPrivateNetworks() {
+		return nil
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrBlockedAddress
+	}
+	if ip := net.ParseIP(host); ip != nil && IsBlockedIP(ip) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+// control runs after DNS resolution, on the address actually being connected
+// to, so a hostname that resolves or rebinds to an internal IP is refused too.
+func control(network, address string, _ syscall.RawConn) error {
+	if allowPrivateNetworks() {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || IsBlockedIP(ip) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	return nil
+}
+
+// NewClient returns an HTTP client for user-supplied URLs. It refuses to
+// connect to internal addresses, ignores proxy settings so the check applies
+// to the real destination, and does not follow redirects, so a public URL
+// cannot bounce the request to an internal one.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: control,
+	}
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
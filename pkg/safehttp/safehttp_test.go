@@ -0,0 +1,69 @@
+package safehttp
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBlockedIP(t *testing.T) {
+	blocked := []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "100.64.0.1", "::1", "fe80::1", "fc00::1", "::ffff:127.0.0.1"}
+	for _, ip := range blocked {
+		assert.True(t, IsBlockedIP(net.ParseIP(ip)), ip)
+	}
+	allowed := []string{"8.8.8.8", "142.250.4.100", "2001:4860:4860::8888"}
+	for _, ip := range allowed {
+		assert.False(t, IsBlockedIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	t.Setenv("OUTBOUND_ALLOW_PRIVATE_NETWORKS", "")
+
+	assert.NoError(t, CheckURL("https://example.com/hook"))
+	assert.Error(t, CheckURL("ftp://example.com/hook"))
+	assert.Error(t, CheckURL("https:///hook"))
+	assert.ErrorIs(t, CheckURL("http://127.0.0.1:8080/hook"), ErrBlockedAddress)
+	assert.ErrorIs(t, CheckURL("http://169.254.169.254/latest/meta-data"), ErrBlockedAddress)
+	assert.ErrorIs(t, CheckURL("http://[::1]/hook"), ErrBlockedAddress)
+	assert.ErrorIs(t, CheckURL("http://localhost/hook"), ErrBlockedAddress)
+}
+
+func TestClientBlocksInternalDestinations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Run("refuses loopback at connect time", func(t *testing.T) {
+		t.Setenv("OUTBOUND_ALLOW_PRIVATE_NETWORKS", "")
+		_, err := NewClient(time.Second).Get(server.URL)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrBlockedAddress))
+	})
+
+	t.Run("allows loopback behind the env flag", func(t *testing.T) {
+		t.Setenv("OUTBOUND_ALLOW_PRIVATE_NETWORKS", "true")
+		resp, err := NewClient(time.Second).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("does not follow redirects", func(t *testing.T) {
+		t.Setenv("OUTBOUND_ALLOW_PRIVATE_NETWORKS", "true")
+		redirect := httptest.NewServer(http.RedirectHandler(server.URL, http.StatusFound))
+		defer redirect.Close()
+
+		resp, err := NewClient(time.Second).Get(redirect.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+	})
+}
@@ -2,11 +2,15 @@ package context_util
 
 import (
 	"context"
+	"pingspot/pkg/i18n"
 )
 
 type contextKey string
 
-const requestIDKey contextKey = "request_id"
+const (
+	requestIDKey contextKey = "request_id"
+	languageKey  contextKey = "language"
+)
 
 func SetRequestIDInContext(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, requestIDKey, requestID)
@@ -18,3 +22,14 @@ func GetRequestID(ctx context.Context) string {
 	}
 	return ""
 }
+
+func SetLanguageInContext(ctx context.Context, language i18n.Language) context.Context {
+	return context.WithValue(ctx, languageKey, language)
+}
+
+func GetLanguage(ctx context.Context) i18n.Language {
+	if language, ok := ctx.Value(languageKey).(i18n.Language); ok {
+		return language
+	}
+	return i18n.DefaultLanguage
+}
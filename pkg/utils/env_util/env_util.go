@@ -40,4 +40,33 @@ func GlobalRateLimiterWindowSeconds() string {
 func RedisUsername() string { return os.Getenv("REDIS_USERNAME") }
 func RedisPassword() string { return os.Getenv("REDIS_PASSWORD") }
 func RedisTLS() bool { return os.Getenv("REDIS_TLS") == "true" }
-func AllowedOrigins() string { return os.Getenv("ALLOWED_ORIGINS") }
\ No newline at end of file
+func AllowedOrigins() string { return os.Getenv("ALLOWED_ORIGINS") }
+func SurgeWindowMinutes() string { return os.Getenv("SURGE_WINDOW_MINUTES") }
+func SurgeRadiusMeters() string { return os.Getenv("SURGE_RADIUS_METERS") }
+func SurgeMinReports() string { return os.Getenv("SURGE_MIN_REPORTS") }
+func SurgeBaselineDays() string { return os.Getenv("SURGE_BASELINE_DAYS") }
+func SurgeMultiplier() string { return os.Getenv("SURGE_MULTIPLIER") }
+func SurgeNotifySubscribers() bool { return os.Getenv("SURGE_NOTIFY_SUBSCRIBERS") == "true" }
+func ReportDraftTTLDays() string { return os.Getenv("REPORT_DRAFT_TTL_DAYS") }
+func AnonymousReportTypes() string { return os.Getenv("ANONYMOUS_REPORT_TYPES") }
+func PublicLocationFuzzMeters() string { return os.Getenv("PUBLIC_LOCATION_FUZZ_METERS") }
+func ServerURL() string { return os.Getenv("SERVER_URL") }
+func VAPIDPublicKey() string { return os.Getenv("VAPID_PUBLIC_KEY") }
+func VAPIDPrivateKey() string { return os.Getenv("VAPID_PRIVATE_KEY") }
+func VAPIDSubject() string { return os.Getenv("VAPID_SUBJECT") }
+func SMSProviderURL() string { return os.Getenv("SMS_PROVIDER_URL") }
+func SMSProviderAPIKey() string { return os.Getenv("SMS_PROVIDER_API_KEY") }
+func SMSSenderID() string { return os.Getenv("SMS_SENDER_ID") }
+func MailDriver() string { return os.Getenv("MAIL_DRIVER") }
+func MailHost() string { return os.Getenv("MAIL_HOST") }
+func MailPort() string { return os.Getenv("MAIL_PORT") }
+func MailUsername() string { return os.Getenv("MAIL_USERNAME") }
+func MailPassword() string { return os.Getenv("MAIL_PASSWORD") }
+func MailFromAddress() string { return os.Getenv("MAIL_FROM_ADDRESS") }
+func MailFromName() string { return os.Getenv("MAIL_FROM_NAME") }
+func MailTLS() string { return os.Getenv("MAIL_TLS") }
+func MailTLSSkipVerify() bool { return os.Getenv("MAIL_TLS_SKIP_VERIFY") == "true" }
+func MailFileDir() string { return os.Getenv("MAIL_FILE_DIR") }
+func OutboundAllowPrivateNetworks() bool { return os.Getenv("OUTBOUND_ALLOW_PRIVATE_NETWORKS") == "true" }
+func DigestUnsubscribeSecret() string { return os.Getenv("DIGEST_UNSUBSCRIBE_SECRET") }
+func TwoFactorEncryptionKey() string { return os.Getenv("TWO_FACTOR_ENCRYPTION_KEY") }
@@ -2,19 +2,20 @@ package main_util
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"net/http"
 	"os"
 	"path/filepath"
-	env "pingspot/pkg/utils/env_util"
+	"pingspot/pkg/i18n"
+	"pingspot/pkg/mailer"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"gopkg.in/gomail.v2"
 )
 
 func GetClientIP(c *fiber.Ctx) string {
@@ -84,45 +85,56 @@ func GetKeyPath(filename string) string {
 type EmailType string
 
 const (
-	EmailTypeVerification     EmailType = "verification"
-	EmailTypePasswordReset    EmailType = "password_reset"
-	EmailTypeProgressReminder EmailType = "progress_reminder"
+	EmailTypeVerification       EmailType = "verification"
+	EmailTypePasswordReset      EmailType = "password_reset"
+	EmailTypeProgressReminder   EmailType = "progress_reminder"
+	EmailTypeNotificationDigest EmailType = "notification_digest"
+	EmailTypeNotification       EmailType = "notification"
 )
 
 type EmailData struct {
-	To            string
-	Subject       string
-	RecipientName string
-	EmailType     EmailType
-	BodyTempate   string
-	TemplateData  map[string]any
+	To             string
+	Subject        string
+	RecipientName  string
+	EmailType      EmailType
+	BodyTempate    string
+	TemplateData   map[string]any
+	Language       i18n.Language
+	IdempotencyKey string
 }
 
-func SendEmail(data EmailData) error {
+// BuildEmail renders data into a message for the mailer, adding a plain-text
+// alternative derived from the HTML body.
+func BuildEmail(data EmailData) (mailer.Message, error) {
 	if data.To == "" || data.RecipientName == "" {
-		return fmt.Errorf("recipient email and name cannot be empty")
-	}
-
-	email := env.EmailEmail()
-	password := env.EmailPassword()
-
-	if email == "" || password == "" {
-		return fmt.Errorf("email credentials not configured")
+		return mailer.Message{}, fmt.Errorf("recipient email and name cannot be empty")
 	}
-
-	m := gomail.NewMessage()
-	m.SetHeader("From", email)
-	m.SetHeader("To", data.To)
-	m.SetHeader("Subject", data.Subject)
-
 	body, err := RenderEmailTemplate(data)
+	if err != nil {
+		return mailer.Message{}, fmt.Errorf("failed to render email template: %w", err)
+	}
+	return mailer.Message{
+		IdempotencyKey: data.IdempotencyKey,
+		To:             data.To,
+		ToName:         data.RecipientName,
+		Subject:        data.Subject,
+		HTMLBody:       body,
+		TextBody:       mailer.HTMLToText(body),
+	}, nil
+}
 
+// SendEmail sends right away through the configured mailer. Callers without
+// their own retry handling should enqueue the message instead.
+func SendEmail(data EmailData) error {
+	message, err := BuildEmail(data)
+	if err != nil {
+		return err
+	}
+	m, err := mailer.Default()
 	if err != nil {
-		return fmt.Errorf("failed to render email template: %w", err)
+		return fmt.Errorf("mailer is not configured: %w", err)
 	}
-	m.SetBody("text/html", body)
-	d := gomail.NewDialer("smtp.gmail.com", 587, email, password)
-	if err := d.DialAndSend(m); err != nil {
+	if err := m.Send(context.Background(), message); err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 	return nil
@@ -187,11 +199,51 @@ func RenderEmailTemplate(data EmailData) (string, error) {
 			DaysRemaining: daysRemaining,
 		}
 
+	case EmailTypeNotificationDigest:
+		unsubscribeLink, ok := data.TemplateData["UnsubscribeLink"].(string)
+		if !ok || unsubscribeLink == "" {
+			return "", fmt.Errorf("unsubscribe link is required for notification digest email")
+		}
+		digest, ok := data.TemplateData["Digest"]
+		if !ok || digest == nil {
+			return "", fmt.Errorf("digest content is required for notification digest email")
+		}
+		templateHTML = data.BodyTempate
+		templateData = struct {
+			UserName        string
+			Digest          any
+			UnsubscribeLink string
+		}{
+			UserName:        data.RecipientName,
+			Digest:          digest,
+			UnsubscribeLink: unsubscribeLink,
+		}
+
+	case EmailTypeNotification:
+		title, ok := data.TemplateData["Title"].(string)
+		if !ok || title == "" {
+			return "", fmt.Errorf("title is required for notification email")
+		}
+		description, _ := data.TemplateData["Description"].(string)
+		link, _ := data.TemplateData["Link"].(string)
+		templateHTML = data.BodyTempate
+		templateData = struct {
+			UserName    string
+			Title       string
+			Description string
+			Link        string
+		}{
+			UserName:    data.RecipientName,
+			Title:       title,
+			Description: description,
+			Link:        link,
+		}
+
 	default:
 		return "", fmt.Errorf("unsupported email type: %s", data.EmailType)
 	}
 
-	tmpl, err := template.New(string(data.EmailType)).Parse(templateHTML)
+	tmpl, err := template.New(string(data.EmailType)).Funcs(emailTemplateFuncs(data.Language)).Parse(templateHTML)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -204,6 +256,26 @@ func RenderEmailTemplate(data EmailData) (string, error) {
 	return buf.String(), nil
 }
 
+// emailTemplateFuncs exposes the translation catalog to email templates, e.g.
+// {{t "email.common.greeting" "name" .UserName}}.
+func emailTemplateFuncs(language i18n.Language) template.FuncMap {
+	if language == "" {
+		language = i18n.DefaultLanguage
+	}
+	return template.FuncMap{
+		"t": func(key string, args ...any) string {
+			params := make(map[string]string, len(args)/2)
+			for i := 0; i+1 < len(args); i += 2 {
+				params[fmt.Sprint(args[i])] = fmt.Sprint(args[i+1])
+			}
+			return i18n.T(language, key, params)
+		},
+		"lang": func() string {
+			return string(language)
+		},
+	}
+}
+
 func StrPtrOrNil(s string) *string {
 	if s == "" {
 		return nil
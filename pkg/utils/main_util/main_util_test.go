@@ -261,6 +261,71 @@ func TestRenderEmailTemplate(t *testing.T) {
 		assert.Contains(t, html, "Test User")
 		assert.Contains(t, html, "https://example.com/reset?token=456")
 	})
+
+	t.Run("should return error for digest email without unsubscribe link", func(t *testing.T) {
+		data := EmailData{
+			To:            "test@example.com",
+			RecipientName: "Test User",
+			EmailType:     EmailTypeNotificationDigest,
+			TemplateData:  map[string]any{"Digest": struct{}{}},
+		}
+
+		_, err := RenderEmailTemplate(data)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsubscribe link is required")
+	})
+
+	t.Run("should render notification digest email template", func(t *testing.T) {
+		templateHTML := "<html><body>Hello {{.UserName}}, {{.Digest.UnreadCount}} unread. <a href=\"{{.UnsubscribeLink}}\">unsubscribe</a></body></html>"
+		data := EmailData{
+			To:            "test@example.com",
+			RecipientName: "Test User",
+			EmailType:     EmailTypeNotificationDigest,
+			BodyTempate:   templateHTML,
+			TemplateData: map[string]any{
+				"Digest":          struct{ UnreadCount int }{UnreadCount: 7},
+				"UnsubscribeLink": "https://example.com/unsubscribe?token=789",
+			},
+		}
+
+		html, err := RenderEmailTemplate(data)
+		require.NoError(t, err)
+		assert.Contains(t, html, "7 unread")
+		assert.Contains(t, html, "https://example.com/unsubscribe?token=789")
+	})
+
+	t.Run("should return error for notification email without title", func(t *testing.T) {
+		data := EmailData{
+			To:            "test@example.com",
+			RecipientName: "Test User",
+			EmailType:     EmailTypeNotification,
+			TemplateData:  map[string]any{"Description": "Laporan Anda diperbarui"},
+		}
+
+		_, err := RenderEmailTemplate(data)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "title is required")
+	})
+
+	t.Run("should render notification email template", func(t *testing.T) {
+		templateHTML := "<html><body>Hello {{.UserName}}, {{.Title}}: {{.Description}} <a href=\"{{.Link}}\">open</a></body></html>"
+		data := EmailData{
+			To:            "test@example.com",
+			RecipientName: "Test User",
+			EmailType:     EmailTypeNotification,
+			BodyTempate:   templateHTML,
+			TemplateData: map[string]any{
+				"Title":       "Status laporan berubah",
+				"Description": "Laporan Anda sedang ditangani",
+				"Link":        "https://example.com/main/report/1",
+			},
+		}
+
+		html, err := RenderEmailTemplate(data)
+		require.NoError(t, err)
+		assert.Contains(t, html, "Status laporan berubah: Laporan Anda sedang ditangani")
+		assert.Contains(t, html, "https://example.com/main/report/1")
+	})
 }
 
 // Benchmark tests
@@ -1,6 +1,10 @@
 package response_util
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"pingspot/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 func ResponseSuccess(c *fiber.Ctx, status int, message string, key string, data any) error {
 	if key == "" {
@@ -8,7 +12,7 @@ func ResponseSuccess(c *fiber.Ctx, status int, message string, key string, data
 	}
 	response := fiber.Map{
 		"success": true,
-		"message": message,
+		"message": i18n.Localize(getLanguage(c), message, ""),
 		key:      data,
 	}
 	return c.Status(status).JSON(response)
@@ -18,10 +22,43 @@ func ResponseError(c *fiber.Ctx, status int, message string, key string, data an
 	if key == "" {
 		key = "errors"
 	}
+	language := getLanguage(c)
+	code := ""
+	if key == "error_code" {
+		code, _ = data.(string)
+	}
 	response := fiber.Map{
 		"success": false,
-		"message": message,
-		key:      data,
+		"message": i18n.Localize(language, message, code),
+		key:      localizeErrorData(language, key, data),
 	}
 	return c.Status(status).JSON(response)
-}
\ No newline at end of file
+}
+
+func getLanguage(c *fiber.Ctx) i18n.Language {
+	if language, ok := c.Locals("language").(i18n.Language); ok {
+		return language
+	}
+	return i18n.DefaultLanguage
+}
+
+// localizeErrorData translates the human readable parts of an error payload:
+// a plain detail message or a map of validation messages. Error codes are left
+// untouched.
+func localizeErrorData(language i18n.Language, key string, data any) any {
+	if language == i18n.DefaultLanguage || key == "error_code" {
+		return data
+	}
+	switch value := data.(type) {
+	case string:
+		return i18n.Localize(language, value, "")
+	case map[string]string:
+		localized := make(map[string]string, len(value))
+		for field, message := range value {
+			localized[field] = i18n.Localize(language, message, "")
+		}
+		return localized
+	default:
+		return data
+	}
+}
@@ -224,7 +224,7 @@ func LoadPublicKey(path string) (*rsa.PublicKey, error) {
 	return publicKey, nil
 }
 
-func GenerateAccessToken(userID, sessionID uint, email, username, fullName string) string {
+func GenerateAccessToken(userID, sessionID uint, email, username, fullName, language string) string {
 	privateKeyPath := mainutil.GetKeyPath("private.pem")
 	privateKey, err := LoadPrivateKey(privateKeyPath)
 	if err != nil {
@@ -241,6 +241,9 @@ func GenerateAccessToken(userID, sessionID uint, email, username, fullName strin
 		"iat":        time.Now().Unix(),
 		"token_type": "access",
 	}
+	if language != "" {
+		claims["language"] = language
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
 	signedToken, err := token.SignedString(privateKey)
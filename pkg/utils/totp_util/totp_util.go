@@ -0,0 +1,170 @@
+package totp_util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Codes follow RFC 6238 with the parameters authenticator apps assume when the
+// otpauth URI does not say otherwise: SHA-1, 6 digits and a 30 second period.
+const (
+	Digits     = 6
+	Period     = 30
+	SkewSteps  = 1
+	secretSize = 20
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// encryptedSecretPrefix marks a secret sealed by EncryptSecret, so stored
+// values can be told apart from plaintext secrets written before encryption.
+const encryptedSecretPrefix = "v1:"
+
+// ParseEncryptionKey decodes a base64 encoded AES-256 key.
+func ParseEncryptionKey(value string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid encryption key: expected 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+func IsEncryptedSecret(value string) bool {
+	return strings.HasPrefix(value, encryptedSecretPrefix)
+}
+
+// EncryptSecret seals a secret with AES-GCM under key. The random nonce is
+// stored in front of the ciphertext.
+func EncryptSecret(secret string, key []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return encryptedSecretPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func DecryptSecret(value string, key []byte) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encryptedSecretPrefix)
+	if !ok {
+		return "", errors.New("secret is not encrypted")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted secret: %w", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("invalid encrypted secret: too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	secret, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return string(secret), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return secretEncoding.EncodeToString(secret), nil
+}
+
+// BuildURI returns the otpauth:// URI that authenticator apps import, usually
+// rendered as a QR code by the client.
+func BuildURI(issuer, accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", Digits))
+	query.Set("period", fmt.Sprintf("%d", Period))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, query.Encode())
+}
+
+func GetTimeStep(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return generateCode(key, GetTimeStep(t)), nil
+}
+
+// ValidateCode accepts codes from the current step and SkewSteps either side
+// to tolerate clock drift. It returns the matched step so callers can reject a
+// code that was already used.
+func ValidateCode(secret, code string, t time.Time) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+	current := GetTimeStep(t)
+	for step := current - SkewSteps; step <= current+SkewSteps; step++ {
+		if subtle.ConstantTimeCompare([]byte(generateCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret: %w", err)
+	}
+	return key, nil
+}
+
+func generateCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%1000000)
+}
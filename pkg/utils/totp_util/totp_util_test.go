@@ -0,0 +1,110 @@
+package totp_util
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA-1 test key from RFC 6238 appendix B.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, expected := range cases {
+		code, err := GenerateCode(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	step, ok := ValidateCode(rfcSecret, "081804", now)
+	assert.True(t, ok)
+	assert.Equal(t, GetTimeStep(now), step)
+
+	previous, err := GenerateCode(rfcSecret, now.Add(-Period*time.Second))
+	require.NoError(t, err)
+	step, ok = ValidateCode(rfcSecret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, GetTimeStep(now)-1, step)
+
+	stale, err := GenerateCode(rfcSecret, now.Add(-3*Period*time.Second))
+	require.NoError(t, err)
+	_, ok = ValidateCode(rfcSecret, stale, now)
+	assert.False(t, ok)
+
+	_, ok = ValidateCode(rfcSecret, "12345", now)
+	assert.False(t, ok)
+	_, ok = ValidateCode("not base32!", "081804", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	code, err := GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+	_, ok := ValidateCode(secret, code, time.Now())
+	assert.True(t, ok)
+}
+
+func TestEncryptSecret(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+
+	encrypted, err := EncryptSecret("JBSWY3DPEHPK3PXP", key)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedSecret(encrypted))
+	assert.NotContains(t, encrypted, "JBSWY3DPEHPK3PXP")
+
+	other, err := EncryptSecret("JBSWY3DPEHPK3PXP", key)
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, other)
+
+	secret, err := DecryptSecret(encrypted, key)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", secret)
+
+	_, err = DecryptSecret(encrypted, bytes.Repeat([]byte{8}, 32))
+	assert.Error(t, err)
+	_, err = DecryptSecret("JBSWY3DPEHPK3PXP", key)
+	assert.Error(t, err)
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	key, err := ParseEncryptionKey(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	_, err = ParseEncryptionKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+	_, err = ParseEncryptionKey("")
+	assert.Error(t, err)
+}
+
+func TestBuildURI(t *testing.T) {
+	uri := BuildURI("PingSpot", "sari@example.com", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/PingSpot:sari@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=PingSpot")
+	assert.Contains(t, uri, "digits=6")
+}
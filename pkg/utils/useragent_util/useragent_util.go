@@ -0,0 +1,115 @@
+package useragent_util
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+	DeviceUnknown = "unknown"
+
+	Unknown = "Unknown"
+)
+
+type DeviceInfo struct {
+	Browser        string
+	BrowserVersion string
+	OS             string
+	OSVersion      string
+	DeviceType     string
+}
+
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// Browsers that embed another engine's token must come before it: Edge and
+// Opera also send "Chrome", and Chrome also sends "Safari".
+var browserPatterns = []pattern{
+	{"Edge", regexp.MustCompile(`(?:Edg|EdgA|EdgiOS|Edge)/([\d.]+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/([\d.]+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/([\d.]+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/([\d.]+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/([\d.]+)`)},
+	{"Safari", regexp.MustCompile(`Version/([\d.]+).*Safari/`)},
+	{"Internet Explorer", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)([\d.]+)`)},
+	{"PingSpot App", regexp.MustCompile(`(?i)pingspot/([\d.]+)`)},
+	{"Postman", regexp.MustCompile(`PostmanRuntime/([\d.]+)`)},
+	{"curl", regexp.MustCompile(`curl/([\d.]+)`)},
+}
+
+var osPatterns = []pattern{
+	{"iOS", regexp.MustCompile(`(?:iPhone|iPad|iPod).*?OS ([\d_]+)`)},
+	{"Android", regexp.MustCompile(`Android ([\d.]+)`)},
+	{"Windows", regexp.MustCompile(`Windows NT ([\d.]+)`)},
+	{"macOS", regexp.MustCompile(`Mac OS X ([\d_.]+)`)},
+	{"Chrome OS", regexp.MustCompile(`CrOS \S+ ([\d.]+)`)},
+	{"Linux", regexp.MustCompile(`Linux()`)},
+}
+
+var (
+	botPattern    = regexp.MustCompile(`(?i)bot|crawler|spider|slurp|curl|wget|postman`)
+	tabletPattern = regexp.MustCompile(`(?i)iPad|Tablet|Kindle|Silk|PlayBook`)
+	mobilePattern = regexp.MustCompile(`(?i)Mobi|iPhone|iPod|Windows Phone`)
+)
+
+var windowsVersions = map[string]string{
+	"10.0": "10",
+	"6.3":  "8.1",
+	"6.2":  "8",
+	"6.1":  "7",
+}
+
+// Parse extracts a best-effort description of the client from a User-Agent
+// header. It is meant for showing sessions to their owner, not for feature
+// detection, so unrecognised parts are reported as Unknown.
+func Parse(userAgent string) DeviceInfo {
+	info := DeviceInfo{Browser: Unknown, OS: Unknown, DeviceType: DeviceUnknown}
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		return info
+	}
+
+	for _, p := range browserPatterns {
+		if match := p.re.FindStringSubmatch(userAgent); match != nil {
+			info.Browser = p.name
+			info.BrowserVersion = majorVersion(match[1])
+			break
+		}
+	}
+
+	for _, p := range osPatterns {
+		if match := p.re.FindStringSubmatch(userAgent); match != nil {
+			info.OS = p.name
+			info.OSVersion = strings.ReplaceAll(match[1], "_", ".")
+			break
+		}
+	}
+	if info.OS == "Windows" {
+		if version, ok := windowsVersions[info.OSVersion]; ok {
+			info.OSVersion = version
+		}
+	}
+
+	switch {
+	case botPattern.MatchString(userAgent):
+		info.DeviceType = DeviceBot
+	case tabletPattern.MatchString(userAgent), info.OS == "Android" && !strings.Contains(userAgent, "Mobile"):
+		info.DeviceType = DeviceTablet
+	case mobilePattern.MatchString(userAgent):
+		info.DeviceType = DeviceMobile
+	case info.OS == "Windows", info.OS == "macOS", info.OS == "Linux", info.OS == "Chrome OS":
+		info.DeviceType = DeviceDesktop
+	}
+	return info
+}
+
+func majorVersion(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
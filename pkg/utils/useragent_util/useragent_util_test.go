@@ -0,0 +1,43 @@
+package useragent_util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]DeviceInfo{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.130 Safari/537.36": {
+			Browser: "Chrome", BrowserVersion: "120", OS: "Windows", OSVersion: "10", DeviceType: DeviceDesktop,
+		},
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91": {
+			Browser: "Edge", BrowserVersion: "120", OS: "Windows", OSVersion: "10", DeviceType: DeviceDesktop,
+		},
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Safari/605.1.15": {
+			Browser: "Safari", BrowserVersion: "17", OS: "macOS", OSVersion: "10.15.7", DeviceType: DeviceDesktop,
+		},
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1": {
+			Browser: "Safari", BrowserVersion: "17", OS: "iOS", OSVersion: "17.2", DeviceType: DeviceMobile,
+		},
+		"Mozilla/5.0 (Linux; Android 14; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36": {
+			Browser: "Samsung Internet", BrowserVersion: "23", OS: "Android", OSVersion: "14", DeviceType: DeviceMobile,
+		},
+		"Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36": {
+			Browser: "Chrome", BrowserVersion: "120", OS: "Android", OSVersion: "13", DeviceType: DeviceTablet,
+		},
+		"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0": {
+			Browser: "Firefox", BrowserVersion: "121", OS: "Linux", DeviceType: DeviceDesktop,
+		},
+		"curl/8.4.0": {
+			Browser: "curl", BrowserVersion: "8", OS: Unknown, DeviceType: DeviceBot,
+		},
+		"": {
+			Browser: Unknown, OS: Unknown, DeviceType: DeviceUnknown,
+		},
+	}
+
+	for userAgent, expected := range cases {
+		assert.Equal(t, expected, Parse(userAgent), userAgent)
+	}
+}